@@ -0,0 +1,185 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package algorithm
+
+import (
+	"errors"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrInvalidCostMatrix is returned by Hungarian if the rows of cost matrix have different lengths.
+var ErrInvalidCostMatrix = errors.New("algorithm: rows of cost matrix should have the same length")
+
+// Hungarian solves the assignment problem by Hungarian algorithm in O(n^2*m): costs[i][j] is the cost of
+// assigning row i (e.g. worker) to column j (e.g. task), it finds the assignment with minimum total cost,
+// negate the costs to find the maximum. The matrix could be rectangular, result[i] is the column assigned
+// to row i, and it's -1 if there are more rows than columns and row i is not assigned.
+func Hungarian[T constraints.Signed | constraints.Float](costs [][]T) ([]int, T, error) {
+	rows := len(costs)
+	if rows == 0 {
+		return []int{}, 0, nil
+	}
+	cols := len(costs[0])
+	for _, row := range costs {
+		if len(row) != cols {
+			return nil, 0, ErrInvalidCostMatrix
+		}
+	}
+
+	result := make([]int, rows)
+	for i := range result {
+		result[i] = -1
+	}
+	if cols == 0 {
+		return result, 0, nil
+	}
+
+	// the algorithm requires rows <= cols, so solve the transposed matrix otherwise
+	var total T
+	if rows <= cols {
+		for i, j := range hungarian(rows, cols, func(i, j int) T { return costs[i][j] }) {
+			result[i] = j
+			total += costs[i][j]
+		}
+	} else {
+		for j, i := range hungarian(cols, rows, func(j, i int) T { return costs[i][j] }) {
+			result[i] = j
+			total += costs[i][j]
+		}
+	}
+
+	return result, total, nil
+}
+
+// hungarian assigns every row of n x m (n <= m) matrix a column by the potentials method,
+// see https://cp-algorithms.com/graph/hungarian-algorithm.html
+func hungarian[T constraints.Signed | constraints.Float](n, m int, cost func(i, j int) T) []int {
+	// 1-indexed, u and v are the potentials of rows and columns, p[j] is the row assigned to column j,
+	// and way[j] is the previous column in the augmenting path
+	u := make([]T, n+1)
+	v := make([]T, m+1)
+	p := make([]int, m+1)
+	way := make([]int, m+1)
+
+	minv := make([]T, m+1)
+	used := make([]bool, m+1)
+	// minv[j] is infinite if it's not set
+	set := make([]bool, m+1)
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		for j := range used {
+			used[j] = false
+			set[j] = false
+		}
+
+		for p[j0] != 0 {
+			used[j0] = true
+			i0 := p[j0]
+			j1 := -1
+			var delta T
+
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				cur := cost(i0-1, j-1) - u[i0] - v[j]
+				if !set[j] || cur < minv[j] {
+					minv[j], set[j] = cur, true
+					way[j] = j0
+				}
+				if j1 < 0 || minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+
+		// augment along the path
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	result := make([]int, n)
+	for j := 1; j <= m; j++ {
+		if p[j] != 0 {
+			result[p[j]-1] = j - 1
+		}
+	}
+
+	return result
+}
+
+// StableMatching finds the stable matching by Gale-Shapley algorithm: proposers propose to receivers in
+// the order of their preferences, and receivers keep the most preferred proposal. proposerPrefs and
+// receiverPrefs are the preference lists in descending order, and a pair not in both lists is not acceptable,
+// so some of them may be unmatched. The matching is optimal for proposers, it returns the receiver matched to
+// each proposer.
+func StableMatching[P comparable, R comparable](proposerPrefs map[P][]R, receiverPrefs map[R][]P) map[P]R {
+	rank := make(map[R]map[P]int, len(receiverPrefs))
+	for r, prefs := range receiverPrefs {
+		rank[r] = make(map[P]int, len(prefs))
+		for i, p := range prefs {
+			if _, ok := rank[r][p]; !ok {
+				rank[r][p] = i
+			}
+		}
+	}
+
+	free := make([]P, 0, len(proposerPrefs))
+	for p := range proposerPrefs {
+		free = append(free, p)
+	}
+
+	next := make(map[P]int, len(proposerPrefs))
+	engaged := make(map[R]P, len(receiverPrefs))
+
+	for len(free) > 0 {
+		p := free[len(free)-1]
+		free = free[:len(free)-1]
+
+		prefs := proposerPrefs[p]
+		for next[p] < len(prefs) {
+			r := prefs[next[p]]
+			next[p]++
+
+			pRank, ok := rank[r][p]
+			if !ok {
+				continue
+			}
+
+			current, ok := engaged[r]
+			if !ok {
+				engaged[r] = p
+				break
+			}
+			if pRank < rank[r][current] {
+				engaged[r] = p
+				free = append(free, current)
+				break
+			}
+		}
+	}
+
+	result := make(map[P]R, len(engaged))
+	for r, p := range engaged {
+		result[p] = r
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+package algorithm
+
+import "fmt"
+
+func ExampleHungarian() {
+	// costs[i][j] is the cost of worker i doing task j
+	costs := [][]int{
+		{9, 2, 7},
+		{6, 4, 3},
+		{5, 8, 1},
+	}
+
+	assignment, total, err := Hungarian(costs)
+
+	fmt.Println(assignment)
+	fmt.Println(total)
+	fmt.Println(err)
+
+	// Output:
+	// [1 0 2]
+	// 9
+	// <nil>
+}
+
+func ExampleStableMatching() {
+	proposers := map[string][]string{
+		"A": {"X", "Y"},
+		"B": {"X", "Y"},
+	}
+	receivers := map[string][]string{
+		"X": {"B", "A"},
+		"Y": {"A", "B"},
+	}
+
+	result := StableMatching(proposers, receivers)
+
+	fmt.Println(result["A"], result["B"])
+
+	// Output:
+	// Y X
+}
@@ -0,0 +1,213 @@
+package algorithm
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestHungarian(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHungarian")
+
+	costs := [][]int{
+		{9, 2, 7, 8},
+		{6, 4, 3, 7},
+		{5, 8, 1, 8},
+		{7, 6, 9, 4},
+	}
+	assignment, total, err := Hungarian(costs)
+	assert.IsNil(err)
+	assert.Equal([]int{1, 0, 2, 3}, assignment)
+	assert.Equal(13, total)
+
+	// more columns than rows
+	floatAssignment, floatTotal, err := Hungarian([][]float64{
+		{1.5, 2, 0.5},
+		{1, 3, 0.5},
+	})
+	assert.IsNil(err)
+	assert.Equal([]int{2, 0}, floatAssignment)
+	assert.Equal(1.5, floatTotal)
+
+	// more rows than columns
+	assignment, total, err = Hungarian([][]int{
+		{4, 1},
+		{2, 9},
+		{3, 3},
+	})
+	assert.IsNil(err)
+	assert.Equal([]int{1, 0, -1}, assignment)
+	assert.Equal(3, total)
+
+	// negative costs to maximize
+	assignment, total, err = Hungarian([][]int{
+		{-3, -1},
+		{-2, -4},
+	})
+	assert.IsNil(err)
+	assert.Equal([]int{0, 1}, assignment)
+	assert.Equal(-7, total)
+
+	assignment, total, err = Hungarian([][]int{})
+	assert.IsNil(err)
+	assert.Equal([]int{}, assignment)
+	assert.Equal(0, total)
+
+	assignment, _, err = Hungarian([][]int{{}, {}})
+	assert.IsNil(err)
+	assert.Equal([]int{-1, -1}, assignment)
+
+	_, _, err = Hungarian([][]int{{1, 2}, {3}})
+	assert.Equal(ErrInvalidCostMatrix, err)
+}
+
+func TestHungarian_BruteForce(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHungarian_BruteForce")
+
+	r := rand.New(rand.NewSource(7))
+	for round := 0; round < 200; round++ {
+		rows, cols := 1+r.Intn(5), 1+r.Intn(5)
+		costs := make([][]int, rows)
+		for i := range costs {
+			costs[i] = make([]int, cols)
+			for j := range costs[i] {
+				costs[i][j] = r.Intn(41) - 20
+			}
+		}
+
+		assignment, total, err := Hungarian(costs)
+		assert.IsNil(err)
+
+		// the assignment is valid and its cost is total
+		usedCols := map[int]bool{}
+		sum, assigned := 0, 0
+		for i, j := range assignment {
+			if j < 0 {
+				continue
+			}
+			assert.Equal(false, usedCols[j])
+			usedCols[j] = true
+			sum += costs[i][j]
+			assigned++
+		}
+		assert.Equal(total, sum)
+		assert.Equal(minInt(rows, cols), assigned)
+		assert.Equal(bruteForceAssignment(costs, 0, map[int]bool{}, minInt(rows, cols)), total)
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// bruteForceAssignment returns the min cost to assign k more rows from row i.
+func bruteForceAssignment(costs [][]int, i int, used map[int]bool, k int) int {
+	if k == 0 {
+		return 0
+	}
+	if len(costs)-i < k {
+		return 1 << 30
+	}
+
+	// skip row i
+	best := bruteForceAssignment(costs, i+1, used, k)
+	for j := range costs[i] {
+		if used[j] {
+			continue
+		}
+		used[j] = true
+		if c := costs[i][j] + bruteForceAssignment(costs, i+1, used, k-1); c < best {
+			best = c
+		}
+		used[j] = false
+	}
+	return best
+}
+
+func TestStableMatching(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStableMatching")
+
+	men := map[string][]string{
+		"A": {"X", "Y", "Z"},
+		"B": {"Y", "X", "Z"},
+		"C": {"X", "Y", "Z"},
+	}
+	women := map[string][]string{
+		"X": {"B", "A", "C"},
+		"Y": {"A", "B", "C"},
+		"Z": {"A", "B", "C"},
+	}
+
+	assert.Equal(map[string]string{"A": "X", "B": "Y", "C": "Z"}, StableMatching(men, women))
+	// receivers proposing gets the optimal matching for them
+	assert.Equal(map[string]string{"X": "B", "Y": "A", "Z": "C"}, StableMatching(women, men))
+
+	// unacceptable pairs are not matched
+	workers := map[int][]string{
+		1: {"build"},
+		2: {"build", "test"},
+		3: {"deploy"},
+	}
+	tasks := map[string][]int{
+		"build": {2, 1},
+		"test":  {2},
+	}
+	assert.Equal(map[int]string{2: "build"}, StableMatching(workers, tasks))
+
+	assert.Equal(map[int]string{}, StableMatching(map[int][]string{}, tasks))
+}
+
+func TestStableMatching_Random(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStableMatching_Random")
+
+	r := rand.New(rand.NewSource(3))
+	for round := 0; round < 100; round++ {
+		n := 1 + r.Intn(8)
+		proposers := map[int][]int{}
+		receivers := map[int][]int{}
+		for i := 0; i < n; i++ {
+			proposers[i] = r.Perm(n)
+			receivers[i] = r.Perm(n)
+		}
+
+		matching := StableMatching(proposers, receivers)
+		assert.Equal(n, len(matching))
+
+		partner := map[int]int{}
+		for p, rcv := range matching {
+			partner[rcv] = p
+		}
+		assert.Equal(n, len(partner))
+
+		rank := func(prefs []int, x int) int {
+			for i, v := range prefs {
+				if v == x {
+					return i
+				}
+			}
+			return len(prefs)
+		}
+
+		// no blocking pair: p and rcv prefer each other to their partners
+		for p, prefs := range proposers {
+			for _, rcv := range prefs {
+				if rcv == matching[p] {
+					break
+				}
+				assert.Equal(false, rank(receivers[rcv], p) < rank(receivers[rcv], partner[rcv]))
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package algorithm
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// rollingHashBase is the odd base of the polynomial, the hash is modulo 2^64.
+const rollingHashBase uint64 = 0x100000001b3
+
+// RollingHash is the Rabin-Karp rolling hash of the last window bytes, the hash of the next window is
+// calculated from the previous one in O(1) when a byte is rolled in (thread unsafe).
+type RollingHash struct {
+	window int
+	// pow is base^window, used to remove the oldest byte
+	pow    uint64
+	hash   uint64
+	buf    []byte
+	pos    int
+	filled bool
+}
+
+// NewRollingHash creates a RollingHash pointer instance with window size, window should be positive.
+func NewRollingHash(window int) *RollingHash {
+	if window <= 0 {
+		panic("algorithm: window of rolling hash should be positive")
+	}
+
+	var pow uint64 = 1
+	for i := 0; i < window; i++ {
+		pow *= rollingHashBase
+	}
+
+	return &RollingHash{
+		window: window,
+		pow:    pow,
+		buf:    make([]byte, window),
+	}
+}
+
+// Roll rolls b into the window, the oldest byte is rolled out if the window is full, and returns the new hash.
+func (h *RollingHash) Roll(b byte) uint64 {
+	h.hash = h.hash*rollingHashBase + uint64(b)
+	if h.filled {
+		h.hash -= uint64(h.buf[h.pos]) * h.pow
+	}
+
+	h.buf[h.pos] = b
+	h.pos++
+	if h.pos == h.window {
+		h.pos = 0
+		h.filled = true
+	}
+
+	return h.hash
+}
+
+// Write rolls all the bytes of p into the window, it never returns error.
+func (h *RollingHash) Write(p []byte) (int, error) {
+	for _, b := range p {
+		h.Roll(b)
+	}
+
+	return len(p), nil
+}
+
+// Sum64 returns the hash of the bytes in the window, bytes before the window don't affect it.
+func (h *RollingHash) Sum64() uint64 {
+	return h.hash
+}
+
+// Reset clears the window.
+func (h *RollingHash) Reset() {
+	h.hash = 0
+	h.pos = 0
+	h.filled = false
+}
+
+const (
+	// chunkerWindow is the window size of rolling hash to find chunk boundary
+	chunkerWindow = 64
+	// chunkerPattern is compared with the top bits of hash, it's not 0 so that a run of zero bytes
+	// doesn't match at every min size
+	chunkerPattern uint64 = 0x9e3779b97f4a7c15
+
+	defaultMinChunkSize = 2 << 10
+	defaultAvgChunkSize = 8 << 10
+	defaultMaxChunkSize = 64 << 10
+)
+
+// ErrInvalidChunkSize is returned by NewChunker if the chunk sizes are invalid.
+var ErrInvalidChunkSize = errors.New("algorithm: chunk sizes should be 64 <= min <= avg <= max")
+
+// Chunk is a piece of data split by Chunker.
+type Chunk struct {
+	// Offset is the offset of chunk in the reader
+	Offset int64
+	// Data is the content of chunk, it's owned by caller
+	Data []byte
+}
+
+// ChunkerOption is the option of Chunker.
+type ChunkerOption func(*chunkerConfig)
+
+type chunkerConfig struct {
+	minSize int
+	avgSize int
+	maxSize int
+}
+
+// WithChunkSizes sets the min, average and max size of chunks, default is 2 KiB, 8 KiB and 64 KiB.
+// The average is rounded to the nearest power of 2, and the actual average is about min plus it.
+func WithChunkSizes(min, avg, max int) ChunkerOption {
+	return func(c *chunkerConfig) {
+		c.minSize = min
+		c.avgSize = avg
+		c.maxSize = max
+	}
+}
+
+// Chunker splits data from reader into content-defined chunks (CDC): the chunk boundary is where the rolling
+// hash of the last 64 bytes matches a pattern, so inserting or removing bytes only changes the chunks around,
+// which is used by deduplication and backup tools (thread unsafe).
+type Chunker struct {
+	reader  *bufio.Reader
+	hash    *RollingHash
+	config  chunkerConfig
+	shift   uint
+	target  uint64
+	offset  int64
+	lastErr error
+}
+
+// NewChunker creates a Chunker pointer instance to split data from r.
+func NewChunker(r io.Reader, opts ...ChunkerOption) (*Chunker, error) {
+	config := chunkerConfig{
+		minSize: defaultMinChunkSize,
+		avgSize: defaultAvgChunkSize,
+		maxSize: defaultMaxChunkSize,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.minSize < chunkerWindow || config.avgSize < config.minSize || config.maxSize < config.avgSize {
+		return nil, ErrInvalidChunkSize
+	}
+
+	// the boundary is where the top log2(avg) bits of hash match the pattern, so the probability is 1/avg
+	bitsOfAvg := uint(bits.Len(uint(config.avgSize)) - 1)
+	if config.avgSize&(config.avgSize-1) != 0 && config.avgSize >= 3<<(bitsOfAvg-1) {
+		bitsOfAvg++
+	}
+
+	return &Chunker{
+		reader: bufio.NewReaderSize(r, config.maxSize),
+		hash:   NewRollingHash(chunkerWindow),
+		config: config,
+		shift:  64 - bitsOfAvg,
+		target: chunkerPattern >> (64 - bitsOfAvg),
+	}, nil
+}
+
+// Next returns the next chunk, and io.EOF if there is no more data. The chunks are at most max size,
+// and at least min size except the last one.
+func (c *Chunker) Next() (Chunk, error) {
+	if c.lastErr != nil {
+		return Chunk{}, c.lastErr
+	}
+
+	data := make([]byte, 0, c.config.avgSize)
+	c.hash.Reset()
+
+	for len(data) < c.config.maxSize {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			c.lastErr = err
+			if err == io.EOF && len(data) > 0 {
+				break
+			}
+			return Chunk{}, err
+		}
+
+		data = append(data, b)
+		h := c.hash.Roll(b)
+		if len(data) >= c.config.minSize && h>>c.shift == c.target {
+			break
+		}
+	}
+
+	chunk := Chunk{Offset: c.offset, Data: data}
+	c.offset += int64(len(data))
+
+	return chunk, nil
+}
@@ -0,0 +1,48 @@
+package algorithm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+func ExampleRollingHash() {
+	h1 := NewRollingHash(3)
+	h1.Write([]byte("hello"))
+
+	h2 := NewRollingHash(3)
+	h2.Write([]byte("jello"))
+
+	// both windows are "llo"
+	fmt.Println(h1.Sum64() == h2.Sum64())
+
+	h2.Roll('!')
+	fmt.Println(h1.Sum64() == h2.Sum64())
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleChunker() {
+	data := bytes.Repeat([]byte{0}, 1000)
+
+	chunker, err := NewChunker(bytes.NewReader(data), WithChunkSizes(64, 128, 300))
+	if err != nil {
+		return
+	}
+
+	for {
+		chunk, err := chunker.Next()
+		if err == io.EOF {
+			break
+		}
+		fmt.Println(chunk.Offset, len(chunk.Data))
+	}
+
+	// Output:
+	// 0 300
+	// 300 300
+	// 600 300
+	// 900 100
+}
@@ -0,0 +1,195 @@
+package algorithm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestRollingHash(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRollingHash")
+
+	data := []byte("the quick brown fox jumps over the lazy dog")
+	window := 5
+
+	rolling := NewRollingHash(window)
+	for i, b := range data {
+		h := rolling.Roll(b)
+		if i+1 < window {
+			continue
+		}
+
+		fresh := NewRollingHash(window)
+		fresh.Write(data[i+1-window : i+1])
+		assert.Equal(fresh.Sum64(), h)
+		assert.Equal(h, rolling.Sum64())
+	}
+
+	// the same window has the same hash
+	h1 := NewRollingHash(3)
+	h1.Write([]byte("abcxyz"))
+	h2 := NewRollingHash(3)
+	h2.Write([]byte("123456789xyz"))
+	assert.Equal(h1.Sum64(), h2.Sum64())
+
+	h2.Reset()
+	assert.Equal(uint64(0), h2.Sum64())
+	h2.Write([]byte("xyz"))
+	assert.Equal(h1.Sum64(), h2.Sum64())
+
+	n, err := h2.Write([]byte("ab"))
+	assert.Equal(2, n)
+	assert.IsNil(err)
+	assert.NotEqual(h1.Sum64(), h2.Sum64())
+}
+
+func TestRabinKarpSearchByRollingHash(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRabinKarpSearchByRollingHash")
+
+	text := []byte("abracadabra")
+	pattern := []byte("abra")
+
+	target := NewRollingHash(len(pattern))
+	target.Write(pattern)
+
+	rolling := NewRollingHash(len(pattern))
+	found := []int{}
+	for i, b := range text {
+		if rolling.Roll(b) == target.Sum64() && i+1 >= len(pattern) {
+			start := i + 1 - len(pattern)
+			if bytes.Equal(text[start:i+1], pattern) {
+				found = append(found, start)
+			}
+		}
+	}
+
+	assert.Equal([]int{0, 7}, found)
+}
+
+func chunkAll(t *testing.T, data []byte, opts ...ChunkerOption) []Chunk {
+	chunker, err := NewChunker(bytes.NewReader(data), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := []Chunk{}
+	for {
+		chunk, err := chunker.Next()
+		if err == io.EOF {
+			return chunks
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks = append(chunks, chunk)
+	}
+}
+
+func TestChunker(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestChunker")
+
+	data := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	chunks := chunkAll(t, data, WithChunkSizes(512, 2048, 8192))
+
+	joined := []byte{}
+	var offset int64
+	for i, chunk := range chunks {
+		assert.Equal(offset, chunk.Offset)
+		assert.Equal(true, len(chunk.Data) <= 8192)
+		if i < len(chunks)-1 {
+			assert.Equal(true, len(chunk.Data) >= 512)
+		}
+		joined = append(joined, chunk.Data...)
+		offset += int64(len(chunk.Data))
+	}
+	assert.Equal(data, joined)
+
+	// the average is about min + avg
+	avg := len(data) / len(chunks)
+	assert.Equal(true, avg > 1500 && avg < 4000)
+
+	// deterministic
+	assert.Equal(chunks, chunkAll(t, data, WithChunkSizes(512, 2048, 8192)))
+}
+
+func TestChunker_Shift(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestChunker_Shift")
+
+	data := make([]byte, 1<<19)
+	rand.New(rand.NewSource(2)).Read(data)
+
+	// insert bytes in the middle, only the chunks around are changed
+	modified := append([]byte{}, data[:len(data)/2]...)
+	modified = append(modified, []byte("inserted bytes")...)
+	modified = append(modified, data[len(data)/2:]...)
+
+	seen := map[string]bool{}
+	original := chunkAll(t, data, WithChunkSizes(256, 1024, 4096))
+	for _, chunk := range original {
+		seen[string(chunk.Data)] = true
+	}
+
+	changed := 0
+	for _, chunk := range chunkAll(t, modified, WithChunkSizes(256, 1024, 4096)) {
+		if !seen[string(chunk.Data)] {
+			changed++
+		}
+	}
+
+	assert.Equal(true, len(original) > 100)
+	assert.Equal(true, changed <= 3)
+}
+
+func TestChunker_Edge(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestChunker_Edge")
+
+	assert.Equal([]Chunk{}, chunkAll(t, []byte{}))
+
+	small := []byte("small data")
+	assert.Equal([]Chunk{{Offset: 0, Data: small}}, chunkAll(t, small))
+
+	// zero bytes never match the pattern, so the chunks are max size
+	zeros := make([]byte, 1000)
+	chunks := chunkAll(t, zeros, WithChunkSizes(64, 128, 300))
+	assert.Equal(4, len(chunks))
+	assert.Equal(300, len(chunks[0].Data))
+	assert.Equal(100, len(chunks[3].Data))
+
+	for _, sizes := range [][3]int{{32, 128, 256}, {256, 128, 512}, {64, 512, 256}} {
+		_, err := NewChunker(bytes.NewReader(zeros), WithChunkSizes(sizes[0], sizes[1], sizes[2]))
+		assert.Equal(ErrInvalidChunkSize, err)
+	}
+
+	// the read error is returned
+	readErr := errors.New("read failed")
+	chunker, err := NewChunker(io.MultiReader(bytes.NewReader(zeros), &errReader{readErr}))
+	assert.IsNil(err)
+	_, err = chunker.Next()
+	assert.Equal(readErr, err)
+	_, err = chunker.Next()
+	assert.Equal(readErr, err)
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,331 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package algorithm
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Edge is a weighted edge to node To.
+type Edge[N comparable] struct {
+	To     N
+	Weight float64
+}
+
+// Graph is a weighted graph which provides the outgoing edges of a node, the nodes could be stored
+// like DirectedGraph or generated on the fly like the states of a puzzle, see GraphFunc.
+type Graph[N comparable] interface {
+	Neighbors(node N) []Edge[N]
+}
+
+// GraphFunc is an adapter to use a function as Graph.
+type GraphFunc[N comparable] func(node N) []Edge[N]
+
+// Neighbors calls f(node).
+func (f GraphFunc[N]) Neighbors(node N) []Edge[N] {
+	return f(node)
+}
+
+// DirectedGraph is a weighted directed graph stored by adjacency list (thread unsafe).
+type DirectedGraph[N comparable] struct {
+	nodes []N
+	edges map[N][]Edge[N]
+}
+
+// NewDirectedGraph creates a DirectedGraph pointer instance.
+func NewDirectedGraph[N comparable]() *DirectedGraph[N] {
+	return &DirectedGraph[N]{
+		nodes: []N{},
+		edges: map[N][]Edge[N]{},
+	}
+}
+
+// AddNode adds node to the graph if it doesn't exist.
+func (g *DirectedGraph[N]) AddNode(node N) {
+	if _, ok := g.edges[node]; !ok {
+		g.nodes = append(g.nodes, node)
+		g.edges[node] = []Edge[N]{}
+	}
+}
+
+// AddEdge adds the edge from -> to with weight, the nodes are added if they don't exist.
+func (g *DirectedGraph[N]) AddEdge(from, to N, weight float64) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.edges[from] = append(g.edges[from], Edge[N]{To: to, Weight: weight})
+}
+
+// AddUndirectedEdge adds the edges in both directions between a and b with weight.
+func (g *DirectedGraph[N]) AddUndirectedEdge(a, b N, weight float64) {
+	g.AddEdge(a, b, weight)
+	if a != b {
+		g.AddEdge(b, a, weight)
+	}
+}
+
+// Nodes returns all the nodes of the graph in the order they are added.
+func (g *DirectedGraph[N]) Nodes() []N {
+	result := make([]N, len(g.nodes))
+	copy(result, g.nodes)
+
+	return result
+}
+
+// Neighbors returns the outgoing edges of node.
+func (g *DirectedGraph[N]) Neighbors(node N) []Edge[N] {
+	return g.edges[node]
+}
+
+// Reverse returns a new graph with all the edges reversed, e.g. for the backward search of
+// BidirectionalDijkstra.
+func (g *DirectedGraph[N]) Reverse() *DirectedGraph[N] {
+	reversed := NewDirectedGraph[N]()
+	for _, node := range g.nodes {
+		reversed.AddNode(node)
+	}
+	for _, from := range g.nodes {
+		for _, e := range g.edges[from] {
+			reversed.AddEdge(e.To, from, e.Weight)
+		}
+	}
+
+	return reversed
+}
+
+// AStar finds the shortest path from start to goal by A* search, and returns the path including start
+// and goal, the total weight of it, and whether goal is reachable. The heuristic estimates the cost from
+// a node to goal, it should never overestimate to get the shortest path, and nil heuristic makes it
+// Dijkstra's algorithm. The weights of edges should not be negative.
+func AStar[N comparable](graph Graph[N], start, goal N, heuristic func(node N) float64) ([]N, float64, bool) {
+	if heuristic == nil {
+		heuristic = func(N) float64 { return 0 }
+	}
+
+	cost := map[N]float64{start: 0}
+	prev := map[N]N{}
+
+	pq := &nodeQueue[N]{}
+	heap.Push(pq, nodeItem[N]{node: start, cost: 0, priority: heuristic(start)})
+
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(nodeItem[N])
+		// stale item, the node has been reached with lower cost
+		if item.cost > cost[item.node] {
+			continue
+		}
+		if item.node == goal {
+			return buildPath(prev, start, goal), item.cost, true
+		}
+
+		for _, e := range graph.Neighbors(item.node) {
+			c := item.cost + e.Weight
+			if old, ok := cost[e.To]; ok && c >= old {
+				continue
+			}
+			cost[e.To] = c
+			prev[e.To] = item.node
+			heap.Push(pq, nodeItem[N]{node: e.To, cost: c, priority: c + heuristic(e.To)})
+		}
+	}
+
+	return nil, 0, false
+}
+
+// BidirectionalDijkstra finds the shortest path from start to goal by searching from both ends, which
+// visits much fewer nodes than Dijkstra's algorithm on large graphs. The reverse graph has all the edges
+// of graph reversed, it could be nil if graph is undirected. It returns the path including start and goal,
+// the total weight of it, and whether goal is reachable. The weights of edges should not be negative.
+func BidirectionalDijkstra[N comparable](graph, reverse Graph[N], start, goal N) ([]N, float64, bool) {
+	if reverse == nil {
+		reverse = graph
+	}
+	if start == goal {
+		return []N{start}, 0, true
+	}
+
+	forward := newDijkstraSide(graph, start)
+	backward := newDijkstraSide(reverse, goal)
+
+	best := math.Inf(1)
+	var meet N
+	found := false
+
+	for forward.pq.Len() > 0 && backward.pq.Len() > 0 {
+		// no path through the unsettled nodes could be shorter than best
+		if forward.pq.peek().cost+backward.pq.peek().cost >= best {
+			break
+		}
+
+		side, other := forward, backward
+		if backward.pq.peek().cost < forward.pq.peek().cost {
+			side, other = backward, forward
+		}
+
+		item := heap.Pop(side.pq).(nodeItem[N])
+		if item.cost > side.cost[item.node] {
+			continue
+		}
+
+		for _, e := range side.graph.Neighbors(item.node) {
+			c := item.cost + e.Weight
+			if old, ok := side.cost[e.To]; ok && c >= old {
+				continue
+			}
+			side.cost[e.To] = c
+			side.prev[e.To] = item.node
+			heap.Push(side.pq, nodeItem[N]{node: e.To, cost: c, priority: c})
+
+			if oc, ok := other.cost[e.To]; ok && c+oc < best {
+				best, meet, found = c+oc, e.To, true
+			}
+		}
+	}
+
+	if !found {
+		return nil, 0, false
+	}
+
+	return joinPath(forward.prev, backward.prev, start, meet, goal), best, true
+}
+
+// BidirectionalBFS finds the path with fewest edges from start to goal by breadth first search from both
+// ends, the weights of edges are ignored. The reverse graph has all the edges of graph reversed, it could be
+// nil if graph is undirected. It returns the path including start and goal, the count of edges in it, and
+// whether goal is reachable.
+func BidirectionalBFS[N comparable](graph, reverse Graph[N], start, goal N) ([]N, int, bool) {
+	if reverse == nil {
+		reverse = graph
+	}
+	if start == goal {
+		return []N{start}, 0, true
+	}
+
+	forward := newBFSSide(graph, start)
+	backward := newBFSSide(reverse, goal)
+
+	for len(forward.frontier) > 0 && len(backward.frontier) > 0 {
+		// expand the smaller frontier
+		side, other := forward, backward
+		if len(backward.frontier) < len(forward.frontier) {
+			side, other = backward, forward
+		}
+
+		// the whole level is expanded, so the shortest meeting in it is found
+		best := -1
+		var meet N
+		next := []N{}
+		for _, node := range side.frontier {
+			for _, e := range side.graph.Neighbors(node) {
+				if _, ok := side.depth[e.To]; ok {
+					continue
+				}
+				side.depth[e.To] = side.depth[node] + 1
+				side.prev[e.To] = node
+				next = append(next, e.To)
+
+				if od, ok := other.depth[e.To]; ok && (best < 0 || side.depth[e.To]+od < best) {
+					best, meet = side.depth[e.To]+od, e.To
+				}
+			}
+		}
+		side.frontier = next
+
+		if best >= 0 {
+			return joinPath(forward.prev, backward.prev, start, meet, goal), best, true
+		}
+	}
+
+	return nil, 0, false
+}
+
+type dijkstraSide[N comparable] struct {
+	graph Graph[N]
+	cost  map[N]float64
+	prev  map[N]N
+	pq    *nodeQueue[N]
+}
+
+func newDijkstraSide[N comparable](graph Graph[N], source N) *dijkstraSide[N] {
+	pq := &nodeQueue[N]{}
+	heap.Push(pq, nodeItem[N]{node: source})
+
+	return &dijkstraSide[N]{
+		graph: graph,
+		cost:  map[N]float64{source: 0},
+		prev:  map[N]N{},
+		pq:    pq,
+	}
+}
+
+type bfsSide[N comparable] struct {
+	graph    Graph[N]
+	depth    map[N]int
+	prev     map[N]N
+	frontier []N
+}
+
+func newBFSSide[N comparable](graph Graph[N], source N) *bfsSide[N] {
+	return &bfsSide[N]{
+		graph:    graph,
+		depth:    map[N]int{source: 0},
+		prev:     map[N]N{},
+		frontier: []N{source},
+	}
+}
+
+// buildPath returns the path from start to end by following prev.
+func buildPath[N comparable](prev map[N]N, start, end N) []N {
+	path := []N{end}
+	for node := end; node != start; {
+		node = prev[node]
+		path = append(path, node)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// joinPath joins the forward path from start to meet and the backward path from meet to goal.
+func joinPath[N comparable](forwardPrev, backwardPrev map[N]N, start, meet, goal N) []N {
+	path := buildPath(forwardPrev, start, meet)
+	for node := meet; node != goal; {
+		node = backwardPrev[node]
+		path = append(path, node)
+	}
+
+	return path
+}
+
+type nodeItem[N comparable] struct {
+	node     N
+	cost     float64
+	priority float64
+}
+
+// nodeQueue is a min heap of nodeItem by priority, it implements heap.Interface.
+type nodeQueue[N comparable] []nodeItem[N]
+
+func (q nodeQueue[N]) Len() int           { return len(q) }
+func (q nodeQueue[N]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q nodeQueue[N]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *nodeQueue[N]) Push(x any) {
+	*q = append(*q, x.(nodeItem[N]))
+}
+
+func (q *nodeQueue[N]) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
+
+func (q nodeQueue[N]) peek() nodeItem[N] {
+	return q[0]
+}
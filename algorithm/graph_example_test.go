@@ -0,0 +1,85 @@
+package algorithm
+
+import "fmt"
+
+func ExampleAStar() {
+	g := NewDirectedGraph[string]()
+	g.AddUndirectedEdge("A", "B", 1)
+	g.AddUndirectedEdge("B", "C", 2)
+	g.AddUndirectedEdge("A", "C", 5)
+	g.AddUndirectedEdge("C", "D", 1)
+
+	// estimated cost to D
+	estimate := map[string]float64{"A": 3, "B": 2, "C": 1, "D": 0}
+	heuristic := func(node string) float64 {
+		return estimate[node]
+	}
+
+	path, cost, ok := AStar[string](g, "A", "D", heuristic)
+
+	fmt.Println(path)
+	fmt.Println(cost)
+	fmt.Println(ok)
+
+	// Output:
+	// [A B C D]
+	// 4
+	// true
+}
+
+func ExampleBidirectionalDijkstra() {
+	g := NewDirectedGraph[string]()
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", 2)
+	g.AddEdge("A", "C", 5)
+	g.AddEdge("C", "D", 1)
+
+	path, cost, ok := BidirectionalDijkstra[string](g, g.Reverse(), "A", "D")
+
+	fmt.Println(path)
+	fmt.Println(cost)
+	fmt.Println(ok)
+
+	// Output:
+	// [A B C D]
+	// 4
+	// true
+}
+
+func ExampleBidirectionalBFS() {
+	g := NewDirectedGraph[string]()
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", 2)
+	g.AddEdge("A", "C", 5)
+	g.AddEdge("C", "D", 1)
+
+	path, hops, ok := BidirectionalBFS[string](g, g.Reverse(), "A", "D")
+
+	fmt.Println(path)
+	fmt.Println(hops)
+	fmt.Println(ok)
+
+	// Output:
+	// [A C D]
+	// 2
+	// true
+}
+
+func ExampleTopoSortLevels() {
+	g := NewDirectedGraph[string]()
+	g.AddEdge("fetch", "build", 0)
+	g.AddEdge("fetch", "test", 0)
+	g.AddEdge("build", "deploy", 0)
+	g.AddEdge("test", "deploy", 0)
+
+	levels, err := TopoSortLevels(g)
+	fmt.Println(levels, err)
+
+	g.AddEdge("deploy", "fetch", 0)
+	_, err = TopoSortLevels(g)
+	fmt.Println(err)
+
+	// Output:
+	// [[fetch] [build test] [deploy]] <nil>
+	// algorithm: graph has cycle: fetch -> test -> deploy -> fetch
+}
@@ -0,0 +1,226 @@
+package algorithm
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type gridPoint struct {
+	x, y int
+}
+
+// gridGraph is a 4-connected grid of width x height, '#' in walls are blocked.
+func gridGraph(walls []string) GraphFunc[gridPoint] {
+	return func(p gridPoint) []Edge[gridPoint] {
+		edges := []Edge[gridPoint]{}
+		for _, d := range []gridPoint{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			q := gridPoint{p.x + d.x, p.y + d.y}
+			if q.y < 0 || q.y >= len(walls) || q.x < 0 || q.x >= len(walls[q.y]) || walls[q.y][q.x] == '#' {
+				continue
+			}
+			edges = append(edges, Edge[gridPoint]{To: q, Weight: 1})
+		}
+		return edges
+	}
+}
+
+func TestDirectedGraph(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDirectedGraph")
+
+	g := NewDirectedGraph[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 2)
+	g.AddUndirectedEdge("c", "d", 3)
+	g.AddNode("e")
+	g.AddNode("a")
+
+	assert.Equal([]string{"a", "b", "c", "d", "e"}, g.Nodes())
+	assert.Equal([]Edge[string]{{"b", 1}, {"c", 2}}, g.Neighbors("a"))
+	assert.Equal([]Edge[string]{{"c", 3}}, g.Neighbors("d"))
+	assert.Equal([]Edge[string]{}, g.Neighbors("e"))
+
+	r := g.Reverse()
+	assert.Equal([]string{"a", "b", "c", "d", "e"}, r.Nodes())
+	assert.Equal([]Edge[string]{}, r.Neighbors("a"))
+	assert.Equal([]Edge[string]{{"a", 2}, {"d", 3}}, r.Neighbors("c"))
+}
+
+func TestAStar(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAStar")
+
+	walls := []string{
+		".....",
+		".###.",
+		"...#.",
+		"##.#.",
+		".....",
+	}
+	graph := gridGraph(walls)
+	start, goal := gridPoint{0, 0}, gridPoint{0, 4}
+	manhattan := func(p gridPoint) float64 {
+		return math.Abs(float64(p.x-goal.x)) + math.Abs(float64(p.y-goal.y))
+	}
+
+	path, cost, ok := AStar[gridPoint](graph, start, goal, manhattan)
+	assert.Equal(true, ok)
+	assert.Equal(8.0, cost)
+	assert.Equal(9, len(path))
+	assert.Equal(start, path[0])
+	assert.Equal(goal, path[len(path)-1])
+
+	_, dijkstraCost, _ := AStar[gridPoint](graph, start, goal, nil)
+	assert.Equal(cost, dijkstraCost)
+
+	path, cost, ok = AStar[gridPoint](graph, start, start, manhattan)
+	assert.Equal(true, ok)
+	assert.Equal(0.0, cost)
+	assert.Equal([]gridPoint{start}, path)
+
+	_, _, ok = AStar[gridPoint](gridGraph([]string{".#."}), gridPoint{0, 0}, gridPoint{2, 0}, nil)
+	assert.Equal(false, ok)
+}
+
+func TestBidirectionalSearch(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBidirectionalSearch")
+
+	g := NewDirectedGraph[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("c", "d", 1)
+	g.AddEdge("a", "d", 10)
+	g.AddEdge("d", "a", 1)
+
+	path, cost, ok := BidirectionalDijkstra[string](g, g.Reverse(), "a", "d")
+	assert.Equal(true, ok)
+	assert.Equal(3.0, cost)
+	assert.Equal([]string{"a", "b", "c", "d"}, path)
+
+	hopPath, hops, ok := BidirectionalBFS[string](g, g.Reverse(), "a", "d")
+	assert.Equal(true, ok)
+	assert.Equal(1, hops)
+	assert.Equal([]string{"a", "d"}, hopPath)
+
+	path, cost, ok = BidirectionalDijkstra[string](g, g.Reverse(), "d", "c")
+	assert.Equal(true, ok)
+	assert.Equal(3.0, cost)
+	assert.Equal([]string{"d", "a", "b", "c"}, path)
+
+	g.AddNode("e")
+	_, _, ok = BidirectionalDijkstra[string](g, g.Reverse(), "a", "e")
+	assert.Equal(false, ok)
+	_, _, ok = BidirectionalBFS[string](g, g.Reverse(), "a", "e")
+	assert.Equal(false, ok)
+
+	// undirected grid
+	graph := gridGraph([]string{"....", ".##.", "...."})
+	gridPath, hops, ok := BidirectionalBFS[gridPoint](graph, nil, gridPoint{0, 0}, gridPoint{3, 2})
+	assert.Equal(true, ok)
+	assert.Equal(5, hops)
+	assert.Equal(6, len(gridPath))
+}
+
+func TestShortestPathRandomGraph(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestShortestPathRandomGraph")
+
+	r := rand.New(rand.NewSource(42))
+	for round := 0; round < 50; round++ {
+		n := 2 + r.Intn(20)
+		g := NewDirectedGraph[int]()
+		for i := 0; i < n; i++ {
+			g.AddNode(i)
+		}
+		for i := 0; i < n*2; i++ {
+			g.AddEdge(r.Intn(n), r.Intn(n), float64(r.Intn(10)))
+		}
+		reverse := g.Reverse()
+
+		dist, hops := floydWarshall(g, n)
+		for s := 0; s < n; s++ {
+			for e := 0; e < n; e++ {
+				path1, cost1, ok1 := AStar[int](g, s, e, nil)
+				path2, cost2, ok2 := BidirectionalDijkstra[int](g, reverse, s, e)
+				path3, hop3, ok3 := BidirectionalBFS[int](g, reverse, s, e)
+
+				reachable := !math.IsInf(dist[s][e], 1)
+				assert.Equal(reachable, ok1)
+				assert.Equal(reachable, ok2)
+				assert.Equal(reachable, ok3)
+				if !reachable {
+					continue
+				}
+
+				assert.Equal(dist[s][e], cost1)
+				assert.Equal(dist[s][e], cost2)
+				assert.Equal(hops[s][e], hop3)
+				assert.Equal(cost1, pathWeight(g, path1, s, e))
+				assert.Equal(cost2, pathWeight(g, path2, s, e))
+				assert.Equal(hop3, len(path3)-1)
+				assert.Equal(false, math.IsNaN(pathWeight(g, path3, s, e)))
+			}
+		}
+	}
+}
+
+func floydWarshall(g *DirectedGraph[int], n int) ([][]float64, [][]int) {
+	dist := make([][]float64, n)
+	hops := make([][]int, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		hops[i] = make([]int, n)
+		for j := range dist[i] {
+			dist[i][j] = math.Inf(1)
+			hops[i][j] = math.MaxInt32
+		}
+		dist[i][i] = 0
+		hops[i][i] = 0
+		for _, e := range g.Neighbors(i) {
+			dist[i][e.To] = math.Min(dist[i][e.To], e.Weight)
+			if i != e.To {
+				hops[i][e.To] = 1
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				dist[i][j] = math.Min(dist[i][j], dist[i][k]+dist[k][j])
+				if hops[i][k]+hops[k][j] < hops[i][j] {
+					hops[i][j] = hops[i][k] + hops[k][j]
+				}
+			}
+		}
+	}
+	return dist, hops
+}
+
+// pathWeight returns the min weight of the path, or NaN if it's not a valid path from s to e.
+func pathWeight(g *DirectedGraph[int], path []int, s, e int) float64 {
+	if len(path) == 0 || path[0] != s || path[len(path)-1] != e {
+		return math.NaN()
+	}
+	total := 0.0
+	for i := 0; i+1 < len(path); i++ {
+		w := math.Inf(1)
+		for _, edge := range g.Neighbors(path[i]) {
+			if edge.To == path[i+1] {
+				w = math.Min(w, edge.Weight)
+			}
+		}
+		if math.IsInf(w, 1) {
+			return math.NaN()
+		}
+		total += w
+	}
+	return total
+}
@@ -1,5 +1,19 @@
 package algorithm
 
+// Cache is the interface of key-value caches with limited capacity, the entries are evicted by their policies.
+type Cache[K comparable, V any] interface {
+	// Get returns the value of key, and if it's found.
+	Get(key K) (V, bool)
+	// Put sets the value of key, an entry may be evicted if the cache is full.
+	Put(key K, value V)
+	// Delete deletes key, and returns if it's found.
+	Delete(key K) bool
+	// Len returns the count of entries.
+	Len() int
+}
+
+var _ Cache[int, int] = (*LRUCache[int, int])(nil)
+
 type lruNode[K comparable, V any] struct {
 	key   K
 	value V
@@ -0,0 +1,295 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package algorithm
+
+import (
+	"container/list"
+	"fmt"
+	"hash/maphash"
+	"math/bits"
+)
+
+var _ Cache[int, int] = (*TinyLFUCache[int, int])(nil)
+
+type tinyLFUSegment uint8
+
+const (
+	segmentWindow tinyLFUSegment = iota
+	segmentProbation
+	segmentProtected
+)
+
+type tinyLFUEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	segment tinyLFUSegment
+}
+
+// TinyLFUCache is a W-TinyLFU cache (thread unsafe): new entries enter a small window LRU, and an entry evicted
+// from the window is admitted into the main segmented LRU only if it's accessed more frequently than the entry
+// it would evict there. The frequencies are estimated by a count-min sketch which is aged periodically, so the
+// entries accessed once (e.g. by a scan) can't flush the popular ones, which is poor for plain LRU.
+// See https://arxiv.org/abs/1512.00727
+type TinyLFUCache[K comparable, V any] struct {
+	cache  map[K]*list.Element
+	sketch *countMinSketch
+
+	// the front of lists is the most recently used
+	window    *list.List
+	probation *list.List
+	protected *list.List
+
+	windowCapacity    int
+	mainCapacity      int
+	protectedCapacity int
+}
+
+// NewTinyLFUCache creates a TinyLFUCache pointer instance, 1% of capacity is the window, and 80% of the rest is
+// the protected segment of main LRU. capacity is at least 1.
+func NewTinyLFUCache[K comparable, V any](capacity int) *TinyLFUCache[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	windowCapacity := capacity / 100
+	if windowCapacity < 1 {
+		windowCapacity = 1
+	}
+	mainCapacity := capacity - windowCapacity
+
+	return &TinyLFUCache[K, V]{
+		cache:             make(map[K]*list.Element, capacity),
+		sketch:            newCountMinSketch(capacity),
+		window:            list.New(),
+		probation:         list.New(),
+		protected:         list.New(),
+		windowCapacity:    windowCapacity,
+		mainCapacity:      mainCapacity,
+		protectedCapacity: mainCapacity * 8 / 10,
+	}
+}
+
+// Get value of key from the cache.
+func (c *TinyLFUCache[K, V]) Get(key K) (V, bool) {
+	c.sketch.increment(hashCacheKey(key))
+
+	elem, ok := c.cache[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.access(elem)
+
+	return elem.Value.(*tinyLFUEntry[K, V]).value, true
+}
+
+// Put value of key into the cache.
+func (c *TinyLFUCache[K, V]) Put(key K, value V) {
+	hash := hashCacheKey(key)
+	c.sketch.increment(hash)
+
+	if elem, ok := c.cache[key]; ok {
+		elem.Value.(*tinyLFUEntry[K, V]).value = value
+		c.access(elem)
+		return
+	}
+
+	c.cache[key] = c.window.PushFront(&tinyLFUEntry[K, V]{key: key, value: value, segment: segmentWindow})
+	if c.window.Len() > c.windowCapacity {
+		c.admit(c.window.Back())
+	}
+}
+
+// Delete item from the cache.
+func (c *TinyLFUCache[K, V]) Delete(key K) bool {
+	elem, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+
+	c.remove(elem)
+	return true
+}
+
+// Len returns the number of items in the cache.
+func (c *TinyLFUCache[K, V]) Len() int {
+	return len(c.cache)
+}
+
+func (c *TinyLFUCache[K, V]) segment(s tinyLFUSegment) *list.List {
+	switch s {
+	case segmentWindow:
+		return c.window
+	case segmentProbation:
+		return c.probation
+	default:
+		return c.protected
+	}
+}
+
+func (c *TinyLFUCache[K, V]) remove(elem *list.Element) {
+	entry := elem.Value.(*tinyLFUEntry[K, V])
+	c.segment(entry.segment).Remove(elem)
+	delete(c.cache, entry.key)
+}
+
+// access moves the entry to the front of its segment, and promotes the entry in probation to protected.
+func (c *TinyLFUCache[K, V]) access(elem *list.Element) {
+	entry := elem.Value.(*tinyLFUEntry[K, V])
+	if entry.segment != segmentProbation {
+		c.segment(entry.segment).MoveToFront(elem)
+		return
+	}
+
+	c.probation.Remove(elem)
+	entry.segment = segmentProtected
+	c.cache[entry.key] = c.protected.PushFront(entry)
+
+	// demote the least recently used entry of protected to probation
+	if c.protected.Len() > c.protectedCapacity {
+		demoted := c.protected.Remove(c.protected.Back()).(*tinyLFUEntry[K, V])
+		demoted.segment = segmentProbation
+		c.cache[demoted.key] = c.probation.PushFront(demoted)
+	}
+}
+
+// admit moves the candidate evicted from window into main if it wins the victim of main, otherwise it's evicted.
+func (c *TinyLFUCache[K, V]) admit(candidate *list.Element) {
+	entry := candidate.Value.(*tinyLFUEntry[K, V])
+
+	if c.probation.Len()+c.protected.Len() >= c.mainCapacity {
+		victim := c.probation.Back()
+		if victim == nil {
+			victim = c.protected.Back()
+		}
+		if victim == nil {
+			c.remove(candidate)
+			return
+		}
+
+		victimKey := victim.Value.(*tinyLFUEntry[K, V]).key
+		if c.sketch.estimate(hashCacheKey(entry.key)) <= c.sketch.estimate(hashCacheKey(victimKey)) {
+			c.remove(candidate)
+			return
+		}
+		c.remove(victim)
+	}
+
+	c.window.Remove(candidate)
+	entry.segment = segmentProbation
+	c.cache[entry.key] = c.probation.PushFront(entry)
+}
+
+var cacheKeySeed = maphash.MakeSeed()
+
+// hashCacheKey hashes key for the sketch, the common key types are hashed directly, and the others by their
+// printed values, which is slower but enough for estimating frequency.
+func hashCacheKey[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		var h maphash.Hash
+		h.SetSeed(cacheKeySeed)
+		h.WriteString(k)
+		return h.Sum64()
+	case int:
+		return mix64(uint64(k))
+	case int64:
+		return mix64(uint64(k))
+	case int32:
+		return mix64(uint64(k))
+	case uint:
+		return mix64(uint64(k))
+	case uint64:
+		return mix64(k)
+	case uint32:
+		return mix64(uint64(k))
+	}
+
+	return hashPrinted(key)
+}
+
+func hashPrinted(key any) uint64 {
+	var h maphash.Hash
+	h.SetSeed(cacheKeySeed)
+	fmt.Fprintf(&h, "%#v", key)
+
+	return h.Sum64()
+}
+
+// mix64 is the finalizer of splitmix64, which spreads the bits of x.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+const (
+	sketchDepth      = 4
+	sketchMaxCounter = 15
+)
+
+// countMinSketch estimates the frequencies of keys with 4-bit counters, the counters are halved after
+// 10 * width increments, so the old frequencies fade out.
+type countMinSketch struct {
+	rows      [sketchDepth][]uint8
+	mask      uint64
+	additions int
+	resetAt   int
+}
+
+func newCountMinSketch(capacity int) *countMinSketch {
+	width := 16
+	if capacity > width {
+		width = 1 << bits.Len(uint(capacity-1))
+	}
+
+	s := &countMinSketch{
+		mask:    uint64(width - 1),
+		resetAt: 10 * width,
+	}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+
+	return s
+}
+
+// index returns the index of hash in row i by double hashing.
+func (s *countMinSketch) index(hash uint64, i int) uint64 {
+	h1, h2 := hash&0xffffffff, hash>>32|1
+	return (h1 + uint64(i)*h2) & s.mask
+}
+
+func (s *countMinSketch) increment(hash uint64) {
+	for i := range s.rows {
+		if idx := s.index(hash, i); s.rows[i][idx] < sketchMaxCounter {
+			s.rows[i][idx]++
+		}
+	}
+
+	s.additions++
+	if s.additions >= s.resetAt {
+		for i := range s.rows {
+			for j := range s.rows[i] {
+				s.rows[i][j] >>= 1
+			}
+		}
+		s.additions /= 2
+	}
+}
+
+func (s *countMinSketch) estimate(hash uint64) uint8 {
+	result := uint8(sketchMaxCounter)
+	for i := range s.rows {
+		if v := s.rows[i][s.index(hash, i)]; v < result {
+			result = v
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,23 @@
+package algorithm
+
+import "fmt"
+
+func ExampleTinyLFUCache() {
+	var cache Cache[int, string] = NewTinyLFUCache[int, string](100)
+
+	cache.Put(1, "a")
+	cache.Put(2, "b")
+
+	v, ok := cache.Get(1)
+	fmt.Println(v, ok)
+
+	_, ok = cache.Get(3)
+	fmt.Println(ok)
+
+	fmt.Println(cache.Len())
+
+	// Output:
+	// a true
+	// false
+	// 2
+}
@@ -0,0 +1,164 @@
+package algorithm
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestTinyLFUCache(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTinyLFUCache")
+
+	cache := NewTinyLFUCache[string, int](3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	assert.Equal(3, cache.Len())
+
+	v, ok := cache.Get("a")
+	assert.Equal(true, ok)
+	assert.Equal(1, v)
+
+	cache.Put("a", 10)
+	v, _ = cache.Get("a")
+	assert.Equal(10, v)
+
+	assert.Equal(true, cache.Delete("b"))
+	assert.Equal(false, cache.Delete("b"))
+	_, ok = cache.Get("b")
+	assert.Equal(false, ok)
+	assert.Equal(2, cache.Len())
+}
+
+func TestTinyLFUCache_Capacity(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTinyLFUCache_Capacity")
+
+	for _, capacity := range []int{0, 1, 2, 10, 1000} {
+		cache := NewTinyLFUCache[int, int](capacity)
+		r := rand.New(rand.NewSource(1))
+
+		for i := 0; i < 20000; i++ {
+			k := r.Intn(3000)
+			switch r.Intn(10) {
+			case 0:
+				cache.Delete(k)
+			case 1, 2, 3:
+				cache.Put(k, k*2)
+			default:
+				if v, ok := cache.Get(k); ok {
+					assert.Equal(k*2, v)
+				}
+			}
+
+			if capacity > 0 {
+				assert.Equal(true, cache.Len() <= capacity)
+			} else {
+				assert.Equal(true, cache.Len() <= 1)
+			}
+		}
+
+		assert.Equal(cache.Len(), cache.window.Len()+cache.probation.Len()+cache.protected.Len())
+	}
+}
+
+func TestTinyLFUCache_Admission(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTinyLFUCache_Admission")
+
+	cache := NewTinyLFUCache[string, int](100)
+
+	// the hot entries are accessed many times
+	for i := 0; i < 10; i++ {
+		for _, k := range []string{"x", "y", "z"} {
+			cache.Put(k, i)
+			cache.Get(k)
+		}
+	}
+
+	// a long scan of entries accessed once doesn't evict them
+	for i := 0; i < 1000; i++ {
+		cache.Put(string(rune('a'+i%26))+string(rune(i)), i)
+	}
+
+	for _, k := range []string{"x", "y", "z"} {
+		_, ok := cache.Get(k)
+		assert.Equal(true, ok)
+	}
+}
+
+// scanWorkload returns a zipf distributed workload mixed with sequential scans of keys never seen before.
+func scanWorkload(n int) []int {
+	r := rand.New(rand.NewSource(42))
+	zipf := rand.NewZipf(r, 1.1, 1, 100000)
+
+	keys := make([]int, 0, n)
+	scan := 1 << 30
+	for len(keys) < n {
+		if r.Intn(100) < 2 {
+			// a scan of 200 keys
+			for i := 0; i < 200 && len(keys) < n; i++ {
+				keys = append(keys, scan)
+				scan++
+			}
+			continue
+		}
+		keys = append(keys, int(zipf.Uint64()))
+	}
+
+	return keys
+}
+
+func hitRate(cache Cache[int, int], keys []int) float64 {
+	hits := 0
+	for _, k := range keys {
+		if _, ok := cache.Get(k); ok {
+			hits++
+		} else {
+			cache.Put(k, k)
+		}
+	}
+
+	return float64(hits) / float64(len(keys))
+}
+
+func TestTinyLFUCache_HitRate(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTinyLFUCache_HitRate")
+
+	keys := scanWorkload(200000)
+
+	lru := hitRate(NewLRUCache[int, int](500), keys)
+	tinyLFU := hitRate(NewTinyLFUCache[int, int](500), keys)
+
+	assert.Equal(true, tinyLFU > lru)
+}
+
+func benchmarkCache(b *testing.B, cache Cache[int, int]) {
+	keys := scanWorkload(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	hits := 0
+	for i := 0; i < b.N; i++ {
+		k := keys[i%len(keys)]
+		if _, ok := cache.Get(k); ok {
+			hits++
+		} else {
+			cache.Put(k, k)
+		}
+	}
+
+	b.ReportMetric(float64(hits)/float64(b.N)*100, "hit%")
+}
+
+func BenchmarkLRUCache(b *testing.B) {
+	benchmarkCache(b, NewLRUCache[int, int](1000))
+}
+
+func BenchmarkTinyLFUCache(b *testing.B) {
+	benchmarkCache(b, NewTinyLFUCache[int, int](1000))
+}
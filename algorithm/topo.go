@@ -0,0 +1,146 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrCycle is returned by TopoSortLevels if the graph has cycle, the returned error is a *CycleError,
+// use errors.Is to check it.
+var ErrCycle = errors.New("algorithm: graph has cycle")
+
+// CycleError records a cycle of the graph, the first node is not repeated at the end.
+type CycleError[N comparable] struct {
+	Cycle []N
+}
+
+// Error implements the error interface.
+func (e *CycleError[N]) Error() string {
+	nodes := make([]string, 0, len(e.Cycle)+1)
+	for _, node := range e.Cycle {
+		nodes = append(nodes, fmt.Sprint(node))
+	}
+	if len(e.Cycle) > 0 {
+		nodes = append(nodes, fmt.Sprint(e.Cycle[0]))
+	}
+
+	return "algorithm: graph has cycle: " + strings.Join(nodes, " -> ")
+}
+
+// Is reports whether target is ErrCycle.
+func (e *CycleError[N]) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// TopoSortLevels sorts the nodes of graph topologically by Kahn's algorithm, an edge a -> b means a
+// should be before b, e.g. a is the dependency of b. The nodes are grouped into levels, the nodes in a
+// level only depend on the nodes in previous levels, so they could run in parallel. The nodes in a level
+// are in the order they are added to graph. If the graph has cycle, it returns a *CycleError with one
+// of the cycles.
+func TopoSortLevels[N comparable](graph *DirectedGraph[N]) ([][]N, error) {
+	nodes := graph.Nodes()
+
+	indegree := make(map[N]int, len(nodes))
+	for _, node := range nodes {
+		for _, e := range graph.Neighbors(node) {
+			indegree[e.To]++
+		}
+	}
+
+	level := []N{}
+	for _, node := range nodes {
+		if indegree[node] == 0 {
+			level = append(level, node)
+		}
+	}
+
+	levels := [][]N{}
+	sorted := 0
+	for len(level) > 0 {
+		levels = append(levels, level)
+		sorted += len(level)
+
+		next := []N{}
+		for _, node := range level {
+			for _, e := range graph.Neighbors(node) {
+				indegree[e.To]--
+				if indegree[e.To] == 0 {
+					next = append(next, e.To)
+				}
+			}
+		}
+		level = next
+	}
+
+	if sorted < len(nodes) {
+		return nil, &CycleError[N]{Cycle: findCycle(graph, nodes, indegree)}
+	}
+
+	// the nodes in next level are in the order of their last dependency, sort them by the order of graph
+	order := make(map[N]int, len(nodes))
+	for i, node := range nodes {
+		order[node] = i
+	}
+	for i := 1; i < len(levels); i++ {
+		level := levels[i]
+		sort.Slice(level, func(i, j int) bool {
+			return order[level[i]] < order[level[j]]
+		})
+	}
+
+	return levels, nil
+}
+
+// findCycle finds a cycle in the nodes which are not sorted, i.e. indegree is still positive. Every such
+// node has an unsorted predecessor, so walking back along them must meet a visited node.
+func findCycle[N comparable](graph *DirectedGraph[N], nodes []N, indegree map[N]int) []N {
+	predecessor := map[N]N{}
+	var start N
+	for _, node := range nodes {
+		if indegree[node] == 0 {
+			continue
+		}
+		start = node
+		for _, e := range graph.Neighbors(node) {
+			if indegree[e.To] > 0 {
+				predecessor[e.To] = node
+			}
+		}
+	}
+
+	visited := map[N]int{}
+	path := []N{}
+	node := start
+	for {
+		if i, ok := visited[node]; ok {
+			path = path[i:]
+			break
+		}
+		visited[node] = len(path)
+		path = append(path, node)
+		node = predecessor[node]
+	}
+
+	// the path is backward, reverse it and begin the cycle with the earliest added node
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	order := make(map[N]int, len(nodes))
+	for i, node := range nodes {
+		order[node] = i
+	}
+	first := 0
+	for i, node := range path {
+		if order[node] < order[path[first]] {
+			first = i
+		}
+	}
+
+	return append(path[first:], path[:first]...)
+}
@@ -0,0 +1,75 @@
+package algorithm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestTopoSortLevels(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTopoSortLevels")
+
+	g := NewDirectedGraph[string]()
+	g.AddNode("lint")
+	g.AddEdge("fetch", "build", 0)
+	g.AddEdge("fetch", "test", 0)
+	g.AddEdge("build", "deploy", 0)
+	g.AddEdge("lint", "deploy", 0)
+	g.AddEdge("test", "deploy", 0)
+	g.AddEdge("build", "package", 0)
+	g.AddNode("docs")
+
+	levels, err := TopoSortLevels(g)
+	assert.IsNil(err)
+	assert.Equal([][]string{
+		{"lint", "fetch", "docs"},
+		{"build", "test"},
+		{"deploy", "package"},
+	}, levels)
+
+	// the levels are in the order of graph, not the order of sorting
+	g2 := NewDirectedGraph[int]()
+	for i := 1; i <= 4; i++ {
+		g2.AddNode(i)
+	}
+	g2.AddEdge(4, 1, 0)
+	g2.AddEdge(3, 2, 0)
+	levels2, err := TopoSortLevels(g2)
+	assert.IsNil(err)
+	assert.Equal([][]int{{3, 4}, {1, 2}}, levels2)
+
+	levels3, err := TopoSortLevels(NewDirectedGraph[int]())
+	assert.IsNil(err)
+	assert.Equal([][]int{}, levels3)
+}
+
+func TestTopoSortLevels_Cycle(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTopoSortLevels_Cycle")
+
+	g := NewDirectedGraph[string]()
+	g.AddEdge("a", "b", 0)
+	g.AddEdge("b", "c", 0)
+	g.AddEdge("c", "d", 0)
+	g.AddEdge("d", "b", 0)
+	g.AddEdge("d", "e", 0)
+
+	levels, err := TopoSortLevels(g)
+	assert.Equal(true, levels == nil)
+	assert.Equal(true, errors.Is(err, ErrCycle))
+	assert.Equal("algorithm: graph has cycle: b -> c -> d -> b", err.Error())
+
+	var cycleErr *CycleError[string]
+	assert.Equal(true, errors.As(err, &cycleErr))
+	assert.Equal([]string{"b", "c", "d"}, cycleErr.Cycle)
+
+	self := NewDirectedGraph[int]()
+	self.AddEdge(1, 2, 0)
+	self.AddEdge(2, 2, 0)
+	_, err = TopoSortLevels(self)
+	assert.Equal("algorithm: graph has cycle: 2 -> 2", err.Error())
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package atomicx implements some type-safe atomic values and counters.
+package atomicx
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// box wraps values stored in atomic.Value, so values of interface type (even nil) are always
+// stored with the same concrete type.
+type box[T any] struct {
+	v T
+}
+
+// Value provides type-safe atomic load and store of a value of type T.
+// The zero Value is ready to use and holds the zero value of T.
+type Value[T any] struct {
+	v atomic.Value
+}
+
+// NewValue creates a Value with initial value.
+func NewValue[T any](initial T) *Value[T] {
+	v := &Value[T]{}
+	v.Store(initial)
+	return v
+}
+
+// Load returns the current value.
+func (v *Value[T]) Load() T {
+	if b, ok := v.v.Load().(box[T]); ok {
+		return b.v
+	}
+
+	var zero T
+	return zero
+}
+
+// Store sets the value to val.
+func (v *Value[T]) Store(val T) {
+	v.v.Store(box[T]{v: val})
+}
+
+// Swap stores new value and returns the old value.
+func (v *Value[T]) Swap(new T) (old T) {
+	if b, ok := v.v.Swap(box[T]{v: new}).(box[T]); ok {
+		return b.v
+	}
+
+	return old
+}
+
+// CompareAndSwap stores new value if the current value is equal to old, and reports whether it's swapped.
+// It panics if T is not comparable.
+func (v *Value[T]) CompareAndSwap(old, new T) bool {
+	if v.v.CompareAndSwap(box[T]{v: old}, box[T]{v: new}) {
+		return true
+	}
+
+	// the Value is never stored, it holds the zero value of T.
+	var zero T
+	if any(old) == any(zero) {
+		return v.v.CompareAndSwap(nil, box[T]{v: new})
+	}
+
+	return false
+}
+
+// Float64 is an atomic float64. The zero Float64 is ready to use and holds 0.
+type Float64 struct {
+	bits uint64
+}
+
+// NewFloat64 creates a Float64 with initial value.
+func NewFloat64(initial float64) *Float64 {
+	return &Float64{bits: math.Float64bits(initial)}
+}
+
+// Load returns the current value.
+func (f *Float64) Load() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&f.bits))
+}
+
+// Store sets the value to val.
+func (f *Float64) Store(val float64) {
+	atomic.StoreUint64(&f.bits, math.Float64bits(val))
+}
+
+// Swap stores new value and returns the old value.
+func (f *Float64) Swap(new float64) (old float64) {
+	return math.Float64frombits(atomic.SwapUint64(&f.bits, math.Float64bits(new)))
+}
+
+// CompareAndSwap stores new value if the current value is equal to old, and reports whether it's swapped.
+// Values are compared by their bits, so NaN could be swapped and 0 is not equal to -0.
+func (f *Float64) CompareAndSwap(old, new float64) bool {
+	return atomic.CompareAndSwapUint64(&f.bits, math.Float64bits(old), math.Float64bits(new))
+}
+
+// Add adds delta to the value and returns the new value.
+func (f *Float64) Add(delta float64) float64 {
+	for {
+		oldBits := atomic.LoadUint64(&f.bits)
+		newVal := math.Float64frombits(oldBits) + delta
+		if atomic.CompareAndSwapUint64(&f.bits, oldBits, math.Float64bits(newVal)) {
+			return newVal
+		}
+	}
+}
+
+// cacheLinePad prevents false sharing between counter stripes.
+type cacheLinePad [64 - 8]byte
+
+type stripe struct {
+	n int64
+	_ cacheLinePad
+}
+
+// Counter is a striped int64 counter for high-contention counting.
+// Goroutines add to different stripes to reduce contention, Sum adds up all the stripes.
+// The zero Counter is ready to use and has runtime.GOMAXPROCS(0) stripes.
+type Counter struct {
+	once    sync.Once
+	stripes []stripe
+	next    uint32
+	indexes sync.Pool
+}
+
+// NewCounter creates a Counter with given count of stripes, if stripes <= 0, runtime.GOMAXPROCS(0) is used.
+func NewCounter(stripes int) *Counter {
+	c := &Counter{}
+	c.once.Do(func() { c.init(stripes) })
+
+	return c
+}
+
+func (c *Counter) init(stripes int) {
+	if stripes <= 0 {
+		stripes = runtime.GOMAXPROCS(0)
+	}
+
+	c.stripes = make([]stripe, stripes)
+
+	// sync.Pool caches values per P, so goroutines running on different Ps mostly get different stripes.
+	c.indexes.New = func() any {
+		i := (atomic.AddUint32(&c.next, 1) - 1) % uint32(len(c.stripes))
+		return &i
+	}
+}
+
+// lazyInit initializes the zero Counter.
+func (c *Counter) lazyInit() {
+	c.once.Do(func() { c.init(0) })
+}
+
+// Add adds delta to the counter.
+func (c *Counter) Add(delta int64) {
+	c.lazyInit()
+
+	i := c.indexes.Get().(*uint32)
+	atomic.AddInt64(&c.stripes[*i].n, delta)
+	c.indexes.Put(i)
+}
+
+// Inc adds 1 to the counter.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Dec subtracts 1 from the counter.
+func (c *Counter) Dec() {
+	c.Add(-1)
+}
+
+// Sum returns the sum of all stripes. It's not an atomic snapshot if there are concurrent updates.
+func (c *Counter) Sum() int64 {
+	c.lazyInit()
+
+	var sum int64
+	for i := range c.stripes {
+		sum += atomic.LoadInt64(&c.stripes[i].n)
+	}
+
+	return sum
+}
+
+// Reset sets the counter to 0.
+func (c *Counter) Reset() {
+	c.lazyInit()
+
+	for i := range c.stripes {
+		atomic.StoreInt64(&c.stripes[i].n, 0)
+	}
+}
@@ -0,0 +1,148 @@
+package atomicx
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestValue")
+
+	var v Value[string]
+	assert.Equal("", v.Load())
+
+	v.Store("a")
+	assert.Equal("a", v.Load())
+
+	old := v.Swap("b")
+	assert.Equal("a", old)
+	assert.Equal("b", v.Load())
+
+	assert.Equal(false, v.CompareAndSwap("a", "c"))
+	assert.Equal(true, v.CompareAndSwap("b", "c"))
+	assert.Equal("c", v.Load())
+
+	var empty Value[int]
+	assert.Equal(0, empty.Swap(1))
+
+	var zero Value[int]
+	assert.Equal(false, zero.CompareAndSwap(1, 2))
+	assert.Equal(true, zero.CompareAndSwap(0, 2))
+	assert.Equal(2, zero.Load())
+
+	ev := NewValue[error](nil)
+	assert.IsNil(ev.Load())
+	ev.Store(nil)
+	assert.IsNil(ev.Load())
+}
+
+func TestValue_Concurrent(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestValue_Concurrent")
+
+	v := NewValue(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				cur := v.Load()
+				if v.CompareAndSwap(cur, cur+1) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(50, v.Load())
+}
+
+func TestFloat64(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestFloat64")
+
+	var f Float64
+	assert.Equal(0.0, f.Load())
+
+	f.Store(1.5)
+	assert.Equal(1.5, f.Load())
+	assert.Equal(2.0, f.Add(0.5))
+	assert.Equal(2.0, f.Swap(3.0))
+	assert.Equal(false, f.CompareAndSwap(2.0, 4.0))
+	assert.Equal(true, f.CompareAndSwap(3.0, 4.0))
+	assert.Equal(4.0, f.Load())
+
+	f2 := NewFloat64(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f2.Add(0.5)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(50.0, f2.Load())
+}
+
+func TestCounter(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestCounter")
+
+	c := NewCounter(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Inc()
+			}
+			c.Add(5)
+			c.Dec()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(int64(10400), c.Sum())
+
+	c.Reset()
+	assert.Equal(int64(0), c.Sum())
+
+	c2 := NewCounter(4)
+	c2.Add(-3)
+	assert.Equal(int64(-3), c2.Sum())
+}
+
+func TestCounterZeroValue(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestCounterZeroValue")
+
+	var c Counter
+	assert.Equal(int64(0), c.Sum())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Inc()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(int64(10), c.Sum())
+
+	var c2 Counter
+	c2.Reset()
+	c2.Add(2)
+	assert.Equal(int64(2), c2.Sum())
+}
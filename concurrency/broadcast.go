@@ -0,0 +1,185 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"sync"
+)
+
+// SlowConsumerPolicy decides what Broadcaster does when the buffer of a subscriber is full.
+type SlowConsumerPolicy int
+
+const (
+	// PolicyBlock makes Publish wait until the subscriber has room for the value.
+	PolicyBlock SlowConsumerPolicy = iota
+	// PolicyDropOldest discards the oldest buffered value to make room for the new one.
+	PolicyDropOldest
+	// PolicyDisconnect unsubscribes the subscriber and closes its channel.
+	PolicyDisconnect
+)
+
+// Broadcaster fans out every published value to all current subscribers,
+// each subscriber has its own buffer and slow consumer policy.
+type Broadcaster[T any] struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription[T]]struct{}
+	closed      bool
+}
+
+// Subscription is a subscriber of Broadcaster, read published values from C().
+type Subscription[T any] struct {
+	broadcaster *Broadcaster[T]
+	policy      SlowConsumerPolicy
+	ch          chan T
+	done        chan struct{}
+	mu          sync.Mutex
+	once        sync.Once
+}
+
+// NewBroadcaster creates a Broadcaster instance.
+func NewBroadcaster[T any]() *Broadcaster[T] {
+	return &Broadcaster[T]{
+		subscribers: make(map[*Subscription[T]]struct{}),
+	}
+}
+
+// Subscribe adds a subscriber with given buffer size and slow consumer policy.
+// Subscribing a closed Broadcaster returns a subscription whose channel is already closed.
+func (b *Broadcaster[T]) Subscribe(bufferSize int, policy SlowConsumerPolicy) *Subscription[T] {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+
+	s := &Subscription[T]{
+		broadcaster: b,
+		policy:      policy,
+		ch:          make(chan T, bufferSize),
+		done:        make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		s.close()
+		return s
+	}
+
+	b.subscribers[s] = struct{}{}
+
+	return s
+}
+
+// Publish sends value to all current subscribers, it applies the policy of every subscriber
+// whose buffer is full. Publish after Close does nothing.
+func (b *Broadcaster[T]) Publish(value T) {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return
+	}
+	subscribers := make([]*Subscription[T], 0, len(b.subscribers))
+	for s := range b.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	b.mu.RUnlock()
+
+	for _, s := range subscribers {
+		if !s.send(value) {
+			s.Unsubscribe()
+		}
+	}
+}
+
+// SubscriberCount returns the count of current subscribers.
+func (b *Broadcaster[T]) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers)
+}
+
+// Close unsubscribes all subscribers and closes their channels.
+func (b *Broadcaster[T]) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	subscribers := b.subscribers
+	b.subscribers = make(map[*Subscription[T]]struct{})
+	b.mu.Unlock()
+
+	for s := range subscribers {
+		s.close()
+	}
+}
+
+// C returns the channel to receive published values, it's closed after unsubscribing.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Unsubscribe removes the subscriber from its Broadcaster and closes its channel.
+func (s *Subscription[T]) Unsubscribe() {
+	s.broadcaster.mu.Lock()
+	delete(s.broadcaster.subscribers, s)
+	s.broadcaster.mu.Unlock()
+
+	s.close()
+}
+
+// send delivers value according to the policy, returns false if the subscriber should be disconnected.
+func (s *Subscription[T]) send(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return true
+	default:
+	}
+
+	switch s.policy {
+	case PolicyDropOldest:
+		for {
+			select {
+			case s.ch <- value:
+				return true
+			default:
+			}
+			if cap(s.ch) == 0 {
+				return true
+			}
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	case PolicyDisconnect:
+		select {
+		case s.ch <- value:
+			return true
+		default:
+			return false
+		}
+	default:
+		select {
+		case s.ch <- value:
+		case <-s.done:
+		}
+		return true
+	}
+}
+
+func (s *Subscription[T]) close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
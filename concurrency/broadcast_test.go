@@ -0,0 +1,129 @@
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestBroadcaster_Publish(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBroadcaster_Publish")
+
+	b := NewBroadcaster[int]()
+	s1 := b.Subscribe(3, PolicyBlock)
+	s2 := b.Subscribe(3, PolicyBlock)
+
+	assert.Equal(2, b.SubscriberCount())
+
+	b.Publish(1)
+	b.Publish(2)
+
+	assert.Equal(1, <-s1.C())
+	assert.Equal(2, <-s1.C())
+	assert.Equal(1, <-s2.C())
+	assert.Equal(2, <-s2.C())
+
+	s1.Unsubscribe()
+	assert.Equal(1, b.SubscriberCount())
+
+	_, ok := <-s1.C()
+	assert.Equal(false, ok)
+
+	b.Publish(3)
+	assert.Equal(3, <-s2.C())
+}
+
+func TestBroadcaster_PolicyBlock(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBroadcaster_PolicyBlock")
+
+	b := NewBroadcaster[int]()
+	s := b.Subscribe(1, PolicyBlock)
+
+	b.Publish(1)
+
+	published := make(chan struct{})
+	go func() {
+		b.Publish(2)
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		t.Fatal("publish should block when buffer is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	assert.Equal(1, <-s.C())
+	<-published
+	assert.Equal(2, <-s.C())
+
+	// unsubscribing unblocks the pending publish
+	b.Publish(3)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		s.Unsubscribe()
+	}()
+	b.Publish(4)
+}
+
+func TestBroadcaster_PolicyDropOldest(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBroadcaster_PolicyDropOldest")
+
+	b := NewBroadcaster[int]()
+	s := b.Subscribe(2, PolicyDropOldest)
+
+	for i := 1; i <= 5; i++ {
+		b.Publish(i)
+	}
+
+	assert.Equal(4, <-s.C())
+	assert.Equal(5, <-s.C())
+	assert.Equal(1, b.SubscriberCount())
+}
+
+func TestBroadcaster_PolicyDisconnect(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBroadcaster_PolicyDisconnect")
+
+	b := NewBroadcaster[int]()
+	slow := b.Subscribe(1, PolicyDisconnect)
+	fast := b.Subscribe(3, PolicyDisconnect)
+
+	b.Publish(1)
+	b.Publish(2)
+
+	assert.Equal(1, b.SubscriberCount())
+
+	assert.Equal(1, <-slow.C())
+	_, ok := <-slow.C()
+	assert.Equal(false, ok)
+
+	assert.Equal(1, <-fast.C())
+	assert.Equal(2, <-fast.C())
+}
+
+func TestBroadcaster_Close(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestBroadcaster_Close")
+
+	b := NewBroadcaster[string]()
+	s := b.Subscribe(1, PolicyBlock)
+
+	b.Close()
+	b.Close()
+	b.Publish("a")
+
+	_, ok := <-s.C()
+	assert.Equal(false, ok)
+	assert.Equal(0, b.SubscriberCount())
+
+	s2 := b.Subscribe(1, PolicyBlock)
+	_, ok = <-s2.C()
+	assert.Equal(false, ok)
+
+	s.Unsubscribe()
+}
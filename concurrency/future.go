@@ -0,0 +1,186 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+var (
+	// ErrFuturePanic is the error of Future if its function panics.
+	ErrFuturePanic = errors.New("concurrency: future function panicked")
+	// ErrNoFutures is the error of WhenAny without futures.
+	ErrNoFutures = errors.New("concurrency: no futures")
+)
+
+// Future is the result of an asynchronous call, which is available after the call returns. Unlike the promise
+// package, getting the result could be canceled by context.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Async calls fn in a new goroutine and returns the Future of its result. If fn panics, the error of Future is
+// ErrFuturePanic.
+func Async[T any](fn func() (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+
+	go func() {
+		defer close(f.done)
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = fmt.Errorf("%w: %v", ErrFuturePanic, r)
+			}
+		}()
+
+		f.value, f.err = fn()
+	}()
+
+	return f
+}
+
+// Completed returns a done Future with value and err, eg: for the cached results.
+func Completed[T any](value T, err error) *Future[T] {
+	f := &Future[T]{done: make(chan struct{}), value: value, err: err}
+	close(f.done)
+
+	return f
+}
+
+// Get waits for the result, it returns the error of ctx if ctx is done before the call returns, and the call
+// keeps running.
+func (f *Future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+	}
+
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// GetWithTimeout waits for the result at most timeout, it returns context.DeadlineExceeded if it times out.
+func (f *Future[T]) GetWithTimeout(timeout time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return f.Get(ctx)
+}
+
+// Done returns a channel that's closed when the call returns.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// IsDone reports whether the call has returned.
+func (f *Future[T]) IsDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Then returns a Future of calling fn with the value of f after f succeeds, the error of f is passed through
+// without calling fn. See the function Then for converting to another type.
+func (f *Future[T]) Then(fn func(value T) (T, error)) *Future[T] {
+	return Then(f, fn)
+}
+
+// Catch returns a Future of calling fn with the error of f after f fails, fn could recover from the error by
+// returning a value and nil error. The value of f is passed through without calling fn.
+func (f *Future[T]) Catch(fn func(err error) (T, error)) *Future[T] {
+	return Async(func() (T, error) {
+		<-f.done
+		if f.err != nil {
+			return fn(f.err)
+		}
+		return f.value, nil
+	})
+}
+
+// Then returns a Future of calling fn with the value of f after f succeeds, the error of f is passed through
+// without calling fn.
+func Then[T any, R any](f *Future[T], fn func(value T) (R, error)) *Future[R] {
+	return Async(func() (R, error) {
+		<-f.done
+		if f.err != nil {
+			var zero R
+			return zero, f.err
+		}
+		return fn(f.value)
+	})
+}
+
+// WhenAll returns a Future of the values of all the futures in order, it fails with the first error of futures
+// without waiting for the others.
+func WhenAll[T any](futures ...*Future[T]) *Future[[]T] {
+	return Async(func() ([]T, error) {
+		errCh := make(chan error, len(futures))
+		for _, f := range futures {
+			f := f
+			go func() {
+				<-f.done
+				errCh <- f.err
+			}()
+		}
+
+		for range futures {
+			if err := <-errCh; err != nil {
+				return nil, err
+			}
+		}
+
+		values := make([]T, len(futures))
+		for i, f := range futures {
+			values[i] = f.value
+		}
+
+		return values, nil
+	})
+}
+
+// WhenAny returns a Future of the value of the first succeeded future, it fails with all the errors joined if
+// all the futures fail, or ErrNoFutures if there are no futures.
+func WhenAny[T any](futures ...*Future[T]) *Future[T] {
+	return Async(func() (T, error) {
+		var zero T
+		if len(futures) == 0 {
+			return zero, ErrNoFutures
+		}
+
+		doneCh := make(chan int, len(futures))
+		for i, f := range futures {
+			i, f := i, f
+			go func() {
+				<-f.done
+				doneCh <- i
+			}()
+		}
+
+		errs := make([]error, len(futures))
+		for range futures {
+			i := <-doneCh
+			if futures[i].err == nil {
+				return futures[i].value, nil
+			}
+			errs[i] = futures[i].err
+		}
+
+		return zero, internal.JoinError(errs...)
+	})
+}
@@ -0,0 +1,60 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func ExampleAsync() {
+	f := Async(func() (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 42, nil
+	})
+
+	value, err := f.GetWithTimeout(time.Second)
+
+	fmt.Println(value)
+	fmt.Println(err)
+
+	// Output:
+	// 42
+	// <nil>
+}
+
+func ExampleThen() {
+	f := Async(func() (int, error) {
+		return 0, fmt.Errorf("not found")
+	}).Catch(func(err error) (int, error) {
+		return 404, nil
+	})
+
+	value, err := Then(f, func(code int) (string, error) {
+		return "status " + strconv.Itoa(code), nil
+	}).Get(context.Background())
+
+	fmt.Println(value)
+	fmt.Println(err)
+
+	// Output:
+	// status 404
+	// <nil>
+}
+
+func ExampleWhenAll() {
+	futures := []*Future[int]{
+		Async(func() (int, error) { return 1, nil }),
+		Async(func() (int, error) { return 2, nil }),
+		Async(func() (int, error) { return 3, nil }),
+	}
+
+	values, err := WhenAll(futures...).Get(context.Background())
+
+	fmt.Println(values)
+	fmt.Println(err)
+
+	// Output:
+	// [1 2 3]
+	// <nil>
+}
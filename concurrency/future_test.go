@@ -0,0 +1,179 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestAsync(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestAsync")
+
+	release := make(chan struct{})
+	f := Async(func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	assert.Equal(false, f.IsDone())
+
+	close(release)
+	v, err := f.Get(context.Background())
+	assert.IsNil(err)
+	assert.Equal(1, v)
+	assert.Equal(true, f.IsDone())
+
+	// get again
+	v, err = f.Get(context.Background())
+	assert.IsNil(err)
+	assert.Equal(1, v)
+
+	f2 := Async(func() (string, error) {
+		return "", errors.New("failed")
+	})
+	<-f2.Done()
+	_, err = f2.Get(context.Background())
+	assert.Equal("failed", err.Error())
+}
+
+func TestAsync_Panic(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestAsync_Panic")
+
+	f := Async(func() (int, error) {
+		panic("boom")
+	})
+
+	_, err := f.Get(context.Background())
+	assert.Equal(true, errors.Is(err, ErrFuturePanic))
+	assert.Equal(true, f.IsDone())
+}
+
+func TestFuture_GetCanceled(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestFuture_GetCanceled")
+
+	release := make(chan struct{})
+	defer close(release)
+
+	f := Async(func() (int, error) {
+		<-release
+		return 1, nil
+	})
+
+	_, err := f.GetWithTimeout(10 * time.Millisecond)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = f.Get(ctx)
+	assert.Equal(context.Canceled, err)
+
+	// the done future returns the result even if ctx is done
+	v, err := Completed(2, nil).Get(ctx)
+	assert.IsNil(err)
+	assert.Equal(2, v)
+}
+
+func TestFuture_ThenCatch(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestFuture_ThenCatch")
+
+	f := Async(func() (int, error) {
+		return 2, nil
+	}).Then(func(v int) (int, error) {
+		return v * 10, nil
+	})
+
+	s, err := Then(f, func(v int) (string, error) {
+		return strconv.Itoa(v), nil
+	}).Get(context.Background())
+	assert.IsNil(err)
+	assert.Equal("20", s)
+
+	// the error skips Then and is handled by Catch
+	called := false
+	v, err := Async(func() (int, error) {
+		return 0, errors.New("failed")
+	}).Then(func(v int) (int, error) {
+		called = true
+		return v, nil
+	}).Catch(func(err error) (int, error) {
+		return -1, nil
+	}).Get(context.Background())
+	assert.IsNil(err)
+	assert.Equal(-1, v)
+	assert.Equal(false, called)
+
+	// Catch is skipped without error
+	v, err = Completed(1, nil).Catch(func(err error) (int, error) {
+		return -1, nil
+	}).Get(context.Background())
+	assert.IsNil(err)
+	assert.Equal(1, v)
+
+	_, err = Completed(1, nil).Then(func(v int) (int, error) {
+		return 0, errors.New("then failed")
+	}).Get(context.Background())
+	assert.Equal("then failed", err.Error())
+}
+
+func TestWhenAll(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestWhenAll")
+
+	futures := make([]*Future[int], 5)
+	for i := range futures {
+		i := i
+		futures[i] = Async(func() (int, error) {
+			time.Sleep(time.Duration(5-i) * time.Millisecond)
+			return i, nil
+		})
+	}
+
+	values, err := WhenAll(futures...).Get(context.Background())
+	assert.IsNil(err)
+	assert.Equal([]int{0, 1, 2, 3, 4}, values)
+
+	values, err = WhenAll[int]().Get(context.Background())
+	assert.IsNil(err)
+	assert.Equal([]int{}, values)
+
+	// fail fast
+	release := make(chan struct{})
+	defer close(release)
+	slow := Async(func() (int, error) {
+		<-release
+		return 0, nil
+	})
+	_, err = WhenAll(slow, Completed(0, errors.New("failed"))).GetWithTimeout(time.Second)
+	assert.Equal("failed", err.Error())
+}
+
+func TestWhenAny(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestWhenAny")
+
+	release := make(chan struct{})
+	defer close(release)
+	slow := Async(func() (int, error) {
+		<-release
+		return 0, nil
+	})
+
+	v, err := WhenAny(slow, Completed(0, errors.New("failed")), Completed(2, nil)).GetWithTimeout(time.Second)
+	assert.IsNil(err)
+	assert.Equal(2, v)
+
+	err1, err2 := errors.New("a"), errors.New("b")
+	_, err = WhenAny(Completed(0, err1), Completed(0, err2)).Get(context.Background())
+	assert.Equal(true, errors.Is(err, err1))
+	assert.Equal(true, errors.Is(err, err2))
+
+	_, err = WhenAny[int]().Get(context.Background())
+	assert.Equal(ErrNoFutures, err)
+}
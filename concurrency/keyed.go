@@ -0,0 +1,209 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSingleFlightPanic is returned to the duplicate callers of Do if the shared call panics.
+var ErrSingleFlightPanic = errors.New("concurrency: singleflight call panicked")
+
+// KeyedLock is a set of mutexes by key, the goroutines locking the same key are mutually exclusive, and different
+// keys don't block each other. The mutex of a key is removed when it's unlocked and no one is waiting for it, so
+// the keys could be unbounded, eg: the user ids or cache keys. The zero value is ready to use.
+type KeyedLock[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*keyedMutex
+}
+
+// keyedMutex is the mutex of a key, refs counts the goroutines holding or waiting for it.
+type keyedMutex struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewKeyedLock creates a KeyedLock pointer instance.
+func NewKeyedLock[K comparable]() *KeyedLock[K] {
+	return &KeyedLock[K]{locks: make(map[K]*keyedMutex)}
+}
+
+// Lock locks the key, it blocks until the key is available.
+func (kl *KeyedLock[K]) Lock(key K) {
+	kl.mu.Lock()
+	if kl.locks == nil {
+		kl.locks = make(map[K]*keyedMutex)
+	}
+	m, ok := kl.locks[key]
+	if !ok {
+		m = &keyedMutex{}
+		kl.locks[key] = m
+	}
+	m.refs++
+	kl.mu.Unlock()
+
+	m.mu.Lock()
+}
+
+// TryLock tries to lock the key without blocking, and reports whether it succeeds.
+func (kl *KeyedLock[K]) TryLock(key K) bool {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+
+	if kl.locks == nil {
+		kl.locks = make(map[K]*keyedMutex)
+	}
+	if _, ok := kl.locks[key]; ok {
+		return false
+	}
+
+	m := &keyedMutex{refs: 1}
+	m.mu.Lock()
+	kl.locks[key] = m
+
+	return true
+}
+
+// Unlock unlocks the key, it panics if the key is not locked like sync.Mutex.
+func (kl *KeyedLock[K]) Unlock(key K) {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+
+	m, ok := kl.locks[key]
+	if !ok {
+		panic(fmt.Sprintf("concurrency: unlock of unlocked key %v", key))
+	}
+
+	m.refs--
+	if m.refs == 0 {
+		delete(kl.locks, key)
+	}
+	m.mu.Unlock()
+}
+
+// WithLock calls fn with the key locked.
+func (kl *KeyedLock[K]) WithLock(key K, fn func()) {
+	kl.Lock(key)
+	defer kl.Unlock(key)
+
+	fn()
+}
+
+// Len returns the number of keys which are locked or waited for.
+func (kl *KeyedLock[K]) Len() int {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+
+	return len(kl.locks)
+}
+
+// SingleFlight deduplicates the concurrent calls by key: while a call of a key is running, the other calls of the
+// same key wait for it and share its result instead of calling their functions, eg: to avoid the cache stampede
+// when many requests miss the same key. The zero value is ready to use.
+type SingleFlight[K comparable, V any] struct {
+	group flightGroup
+}
+
+// NewSingleFlight creates a SingleFlight pointer instance.
+func NewSingleFlight[K comparable, V any]() *SingleFlight[K, V] {
+	return &SingleFlight[K, V]{}
+}
+
+// Do calls fn and returns its result, if a call of key is running, it waits for the call and returns the same
+// result. The result is not cached, the calls after finished call fn again. If fn panics, the panic is
+// propagated in the goroutine calling fn, and the duplicate callers get ErrSingleFlightPanic.
+func (sf *SingleFlight[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	return doFlight(&sf.group, key, fn)
+}
+
+// Forget makes the next call of key call fn, instead of waiting for the running call.
+func (sf *SingleFlight[K, V]) Forget(key K) {
+	sf.group.forget(key)
+}
+
+// defaultFlightGroup is the flightGroup of Do.
+var defaultFlightGroup flightGroup
+
+// flightKey distinguishes the keys of Do with different types of results.
+type flightKey[K comparable, V any] struct {
+	key K
+}
+
+// Do deduplicates the concurrent calls by key like SingleFlight.Do with a package-level group, the keys with
+// different types of results are different.
+func Do[K comparable, V any](key K, fn func() (V, error)) (V, error) {
+	return doFlight(&defaultFlightGroup, flightKey[K, V]{key: key}, fn)
+}
+
+// flightGroup is the untyped implementation of SingleFlight.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[any]*flightCall
+}
+
+type flightCall struct {
+	wg       sync.WaitGroup
+	value    any
+	err      error
+	panicked bool
+}
+
+func doFlight[V any](g *flightGroup, key any, fn func() (V, error)) (V, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[any]*flightCall)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+
+		v, _ := c.value.(V)
+		return v, c.err
+	}
+
+	c := &flightCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.panicked = true
+	defer func() {
+		if !c.panicked {
+			g.done(key, c)
+			return
+		}
+
+		// r is nil if fn calls runtime.Goexit
+		r := recover()
+		c.err = fmt.Errorf("%w: %v", ErrSingleFlightPanic, r)
+		g.done(key, c)
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	v, err := fn()
+	c.value, c.err, c.panicked = v, err, false
+
+	return v, err
+}
+
+// done removes the finished call and wakes up the waiters.
+func (g *flightGroup) done(key any, c *flightCall) {
+	g.mu.Lock()
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+
+	c.wg.Done()
+}
+
+func (g *flightGroup) forget(key any) {
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+}
@@ -0,0 +1,51 @@
+package concurrency
+
+import (
+	"fmt"
+	"sync"
+)
+
+func ExampleKeyedLock() {
+	kl := NewKeyedLock[string]()
+	balances := map[string]int{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			kl.Lock("alice")
+			defer kl.Unlock("alice")
+
+			mu.Lock()
+			balances["alice"] += 10
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	fmt.Println(balances["alice"])
+	fmt.Println(kl.Len())
+
+	// Output:
+	// 100
+	// 0
+}
+
+func ExampleSingleFlight() {
+	sf := NewSingleFlight[string, string]()
+
+	value, err := sf.Do("user:1", func() (string, error) {
+		// load from database once for the concurrent calls
+		return "alice", nil
+	})
+
+	fmt.Println(value)
+	fmt.Println(err)
+
+	// Output:
+	// alice
+	// <nil>
+}
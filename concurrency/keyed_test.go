@@ -0,0 +1,233 @@
+package concurrency
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestKeyedLock(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestKeyedLock")
+
+	kl := NewKeyedLock[string]()
+
+	var wg sync.WaitGroup
+	counters := map[string]int{}
+	var mu sync.Mutex
+
+	for i := 0; i < 100; i++ {
+		key := []string{"a", "b"}[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kl.WithLock(key, func() {
+				mu.Lock()
+				counters[key]++
+				mu.Unlock()
+				time.Sleep(time.Microsecond)
+			})
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(50, counters["a"])
+	assert.Equal(50, counters["b"])
+	// the mutexes are removed
+	assert.Equal(0, kl.Len())
+}
+
+func TestKeyedLock_Exclusive(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestKeyedLock_Exclusive")
+
+	var kl KeyedLock[int]
+
+	kl.Lock(1)
+	assert.Equal(false, kl.TryLock(1))
+	// different keys don't block
+	assert.Equal(true, kl.TryLock(2))
+	assert.Equal(2, kl.Len())
+
+	locked := make(chan struct{})
+	go func() {
+		kl.Lock(1)
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("locked key is acquired")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	kl.Unlock(1)
+	<-locked
+	assert.Equal(2, kl.Len())
+
+	kl.Unlock(1)
+	kl.Unlock(2)
+	assert.Equal(0, kl.Len())
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	kl.Unlock(3)
+}
+
+func TestSingleFlight(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSingleFlight")
+
+	sf := NewSingleFlight[string, int]()
+
+	var calls int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := sf.Do("key", func() (int, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return 42, nil
+			})
+			assert.IsNil(err)
+			results[i] = v
+		}(i)
+	}
+
+	// wait for the first call to start, and the others to wait for it
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+	for _, v := range results {
+		assert.Equal(42, v)
+	}
+
+	// the result is not cached
+	v, err := sf.Do("key", func() (int, error) {
+		return 0, errors.New("failed")
+	})
+	assert.Equal(0, v)
+	assert.Equal("failed", err.Error())
+}
+
+func TestSingleFlight_Forget(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSingleFlight_Forget")
+
+	var sf SingleFlight[int, string]
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		v, _ := sf.Do(1, func() (string, error) {
+			close(started)
+			<-release
+			return "first", nil
+		})
+		assert.Equal("first", v)
+	}()
+
+	<-started
+	sf.Forget(1)
+
+	v, err := sf.Do(1, func() (string, error) {
+		return "second", nil
+	})
+	assert.IsNil(err)
+	assert.Equal("second", v)
+
+	close(release)
+	<-done
+}
+
+func TestSingleFlight_Panic(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSingleFlight_Panic")
+
+	var sf SingleFlight[int, int]
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	waiterErr := make(chan error)
+
+	go func() {
+		defer func() {
+			assert.Equal("boom", recover())
+		}()
+		sf.Do(1, func() (int, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+
+	<-started
+	go func() {
+		_, err := sf.Do(1, func() (int, error) {
+			return 1, nil
+		})
+		waiterErr <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	err := <-waiterErr
+	assert.Equal(true, errors.Is(err, ErrSingleFlightPanic))
+
+	// the group is usable after panic
+	v, err := sf.Do(1, func() (int, error) {
+		return 2, nil
+	})
+	assert.IsNil(err)
+	assert.Equal(2, v)
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestDo")
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		v, _ := Do("TestDo", func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+		assert.Equal(1, v)
+	}()
+
+	<-started
+	// the same key with different type of result is not shared
+	s, err := Do("TestDo", func() (string, error) {
+		return "a", nil
+	})
+	assert.IsNil(err)
+	assert.Equal("a", s)
+
+	close(release)
+	wg.Wait()
+}
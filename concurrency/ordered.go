@@ -0,0 +1,195 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrExecutorClosed is returned by Submit if the OrderedExecutor is closed.
+var ErrExecutorClosed = errors.New("concurrency: ordered executor is closed")
+
+// orderedJob is an item submitted to OrderedExecutor with its sequence number.
+type orderedJob[T any] struct {
+	ctx  context.Context
+	seq  uint64
+	item T
+}
+
+// orderedResult is the result of an orderedJob waiting to be consumed.
+type orderedResult[T any, R any] struct {
+	item   T
+	result R
+	err    error
+}
+
+// OrderedExecutor processes the submitted items by a group of workers concurrently, but the results are passed to
+// the consumer one by one strictly in the order of submission, e.g. process the ordered event logs in parallel.
+// At most window items are processed or waiting for the earlier ones to be consumed, so the memory to reorder the
+// results is bounded, and Submit blocks if the window is full.
+type OrderedExecutor[T any, R any] struct {
+	process func(ctx context.Context, item T) (R, error)
+	consume func(item T, result R, err error)
+
+	// tokens limits the items in window
+	tokens chan struct{}
+	jobs   chan orderedJob[T]
+	wg     sync.WaitGroup
+
+	submitMu sync.Mutex
+	seq      uint64
+	closed   bool
+
+	resultMu   sync.Mutex
+	pending    map[uint64]orderedResult[T, R]
+	next       uint64
+	delivering bool
+	// consumeErr is the first panic of consume
+	consumeErr error
+}
+
+// NewOrderedExecutor creates an OrderedExecutor pointer instance, which processes the items by process with workers
+// goroutines, and passes the results to consume in order, consume is never called concurrently. workers and window
+// are at least 1, and window is at least workers, otherwise some workers are always idle.
+func NewOrderedExecutor[T any, R any](workers, window int, process func(ctx context.Context, item T) (R, error),
+	consume func(item T, result R, err error)) *OrderedExecutor[T, R] {
+	if workers < 1 {
+		workers = 1
+	}
+	if window < workers {
+		window = workers
+	}
+
+	e := &OrderedExecutor[T, R]{
+		process: process,
+		consume: consume,
+		tokens:  make(chan struct{}, window),
+		jobs:    make(chan orderedJob[T], window),
+		pending: make(map[uint64]orderedResult[T, R], window),
+	}
+
+	e.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go e.work()
+	}
+
+	return e
+}
+
+// Submit adds the item to be processed, ctx is passed to process. It blocks until there is room in the window,
+// and returns ctx.Err() if ctx is done before that, or ErrExecutorClosed if the executor is closed.
+func (e *OrderedExecutor[T, R]) Submit(ctx context.Context, item T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case e.tokens <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	e.submitMu.Lock()
+	defer e.submitMu.Unlock()
+
+	if e.closed {
+		<-e.tokens
+		return ErrExecutorClosed
+	}
+
+	// jobs never blocks, since its capacity is the size of window
+	e.jobs <- orderedJob[T]{ctx: ctx, seq: e.seq, item: item}
+	e.seq++
+
+	return nil
+}
+
+// Close stops accepting new items, and waits for the submitted items to be processed and consumed. A panic of
+// consume doesn't stop the delivery of the following results, and Close returns the first one as error.
+func (e *OrderedExecutor[T, R]) Close() error {
+	e.submitMu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.jobs)
+	}
+	e.submitMu.Unlock()
+
+	e.wg.Wait()
+
+	e.resultMu.Lock()
+	defer e.resultMu.Unlock()
+
+	return e.consumeErr
+}
+
+func (e *OrderedExecutor[T, R]) work() {
+	defer e.wg.Done()
+
+	for job := range e.jobs {
+		result, err := e.run(job)
+		e.deliver(job.seq, orderedResult[T, R]{item: job.item, result: result, err: err})
+	}
+}
+
+// run processes the job, and converts its panic into error.
+func (e *OrderedExecutor[T, R]) run(job orderedJob[T]) (result R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("concurrency: process panicked: %v", r)
+		}
+	}()
+
+	if err := job.ctx.Err(); err != nil {
+		return result, err
+	}
+
+	return e.process(job.ctx, job.item)
+}
+
+// deliver adds the result of seq, and consumes the results in order until a result is missing. Only one goroutine
+// consumes at a time, the others just leave their results to it.
+func (e *OrderedExecutor[T, R]) deliver(seq uint64, result orderedResult[T, R]) {
+	e.resultMu.Lock()
+	e.pending[seq] = result
+	if e.delivering {
+		e.resultMu.Unlock()
+		return
+	}
+	e.delivering = true
+
+	for {
+		r, ok := e.pending[e.next]
+		if !ok {
+			e.delivering = false
+			e.resultMu.Unlock()
+			return
+		}
+		delete(e.pending, e.next)
+		e.next++
+		e.resultMu.Unlock()
+
+		e.callConsume(r)
+		<-e.tokens
+
+		e.resultMu.Lock()
+	}
+}
+
+// callConsume calls consume, and records its panic so the delivering goroutine goes on.
+func (e *OrderedExecutor[T, R]) callConsume(r orderedResult[T, R]) {
+	defer func() {
+		if p := recover(); p != nil {
+			e.resultMu.Lock()
+			if e.consumeErr == nil {
+				e.consumeErr = fmt.Errorf("concurrency: consume panicked: %v", p)
+			}
+			e.resultMu.Unlock()
+		}
+	}()
+
+	e.consume(r.item, r.result, r.err)
+}
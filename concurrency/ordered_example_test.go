@@ -0,0 +1,28 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func ExampleOrderedExecutor() {
+	executor := NewOrderedExecutor(3, 6, func(ctx context.Context, n int) (string, error) {
+		// the smaller numbers take longer
+		time.Sleep(time.Duration(5-n) * time.Millisecond)
+		return fmt.Sprintf("%d*%d=%d", n, n, n*n), nil
+	}, func(n int, result string, err error) {
+		fmt.Println(result)
+	})
+
+	for n := 1; n <= 4; n++ {
+		executor.Submit(context.Background(), n)
+	}
+	executor.Close()
+
+	// Output:
+	// 1*1=1
+	// 2*2=4
+	// 3*3=9
+	// 4*4=16
+}
@@ -0,0 +1,138 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestOrderedExecutor(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedExecutor")
+
+	var consumed []int
+	var consuming int32
+	executor := NewOrderedExecutor(8, 16, func(ctx context.Context, item int) (int, error) {
+		// the later items may finish earlier
+		time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
+		return item * 2, nil
+	}, func(item int, result int, err error) {
+		if atomic.AddInt32(&consuming, 1) != 1 {
+			t.Error("consume is called concurrently")
+		}
+		assert.IsNil(err)
+		assert.Equal(item*2, result)
+		consumed = append(consumed, item)
+		atomic.AddInt32(&consuming, -1)
+	})
+
+	expected := make([]int, 300)
+	for i := range expected {
+		expected[i] = i
+		assert.IsNil(executor.Submit(context.Background(), i))
+	}
+	executor.Close()
+
+	assert.Equal(expected, consumed)
+	assert.Equal(ErrExecutorClosed, executor.Submit(context.Background(), 1))
+}
+
+func TestOrderedExecutor_Window(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedExecutor_Window")
+
+	// the first item is slow, so the others wait for it in window
+	release := make(chan struct{})
+	var processed int32
+	executor := NewOrderedExecutor(4, 4, func(ctx context.Context, item int) (int, error) {
+		if item == 0 {
+			<-release
+		}
+		atomic.AddInt32(&processed, 1)
+		return item, nil
+	}, func(item int, result int, err error) {})
+
+	for i := 0; i < 4; i++ {
+		assert.IsNil(executor.Submit(context.Background(), i))
+	}
+
+	// the window is full
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, executor.Submit(ctx, 4))
+	assert.Equal(int32(3), atomic.LoadInt32(&processed))
+
+	close(release)
+	assert.IsNil(executor.Submit(context.Background(), 5))
+	executor.Close()
+	assert.Equal(int32(5), atomic.LoadInt32(&processed))
+}
+
+func TestOrderedExecutor_Errors(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedExecutor_Errors")
+
+	errOdd := errors.New("odd")
+	var errs []error
+	executor := NewOrderedExecutor(2, 0, func(ctx context.Context, item int) (int, error) {
+		switch {
+		case item == 4:
+			panic("boom")
+		case item%2 == 1:
+			return 0, errOdd
+		}
+		return item, nil
+	}, func(item int, result int, err error) {
+		errs = append(errs, err)
+	})
+
+	for i := 0; i < 5; i++ {
+		assert.IsNil(executor.Submit(context.Background(), i))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(context.Canceled, executor.Submit(ctx, 5))
+
+	assert.IsNil(executor.Close())
+
+	assert.Equal(5, len(errs))
+	assert.IsNil(errs[0])
+	assert.Equal(errOdd, errs[1])
+	assert.IsNil(errs[2])
+	assert.Equal(errOdd, errs[3])
+	assert.Equal("concurrency: process panicked: boom", errs[4].Error())
+}
+
+func TestOrderedExecutor_ConsumePanic(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedExecutor_ConsumePanic")
+
+	var consumed []int
+	executor := NewOrderedExecutor(2, 2, func(ctx context.Context, item int) (int, error) {
+		return item, nil
+	}, func(item int, result int, err error) {
+		if item == 1 {
+			panic("boom")
+		}
+		consumed = append(consumed, result)
+	})
+
+	for i := 0; i < 5; i++ {
+		assert.IsNil(executor.Submit(context.Background(), i))
+	}
+
+	err := executor.Close()
+	assert.IsNotNil(err)
+	assert.Equal("concurrency: consume panicked: boom", err.Error())
+	assert.Equal([]int{0, 2, 3, 4}, consumed)
+}
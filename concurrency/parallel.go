@@ -0,0 +1,197 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal/parallel"
+)
+
+// ParallelConfig is config for ForEach and Map.
+type ParallelConfig struct {
+	collectAll  bool
+	taskTimeout time.Duration
+}
+
+// ParallelOption is for adding parallel config.
+type ParallelOption func(*ParallelConfig)
+
+// WithCollectAll makes ForEach and Map keep processing the remaining items when some of them fail,
+// all the errors are returned together as *MultiError.
+func WithCollectAll() ParallelOption {
+	return func(pc *ParallelConfig) {
+		pc.collectAll = true
+	}
+}
+
+// WithTaskTimeout sets the timeout of every call of fn, the context passed to fn is done after d, so fn should
+// return once the context is done.
+func WithTaskTimeout(d time.Duration) ParallelOption {
+	return func(pc *ParallelConfig) {
+		pc.taskTimeout = d
+	}
+}
+
+// call calls fn with the task timeout.
+func (pc *ParallelConfig) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	if pc.taskTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pc.taskTimeout)
+		defer cancel()
+	}
+
+	return fn(ctx)
+}
+
+// MultiError holds the errors returned by functions run in parallel, ordered by item index.
+type MultiError struct {
+	Errors []error
+}
+
+// Error implements the error interface.
+func (me *MultiError) Error() string {
+	if len(me.Errors) == 1 {
+		return me.Errors[0].Error()
+	}
+
+	msgs := make([]string, len(me.Errors))
+	for i, err := range me.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the wrapped errors, it's used by errors.Is and errors.As since Go 1.20.
+func (me *MultiError) Unwrap() []error {
+	return me.Errors
+}
+
+// Is reports whether any of the errors matches target, so errors.Is works before Go 1.20.
+func (me *MultiError) Is(target error) bool {
+	for _, err := range me.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first of the errors that matches target, so errors.As works before Go 1.20.
+func (me *MultiError) As(target any) bool {
+	for _, err := range me.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ForEach calls fn for every item of items, at most limit goroutines run at the same time.
+// If limit <= 0, runtime.NumCPU() is used.
+// By default, ForEach stops scheduling new items and cancels the context passed to fn on the first error,
+// and returns that error. Use WithCollectAll to process all items and get every error as *MultiError.
+func ForEach[T any](ctx context.Context, items []T, limit int,
+	fn func(ctx context.Context, index int, item T) error, opts ...ParallelOption) error {
+	return runParallel(ctx, len(items), limit, func(ctx context.Context, i int) error {
+		return fn(ctx, i, items[i])
+	}, opts...)
+}
+
+// Map calls fn for every item of items concurrently and returns the results in the order of items,
+// at most limit goroutines run at the same time. If limit <= 0, runtime.NumCPU() is used.
+// Errors are handled in the same way as ForEach, the returned slice always has the same length of items
+// and the results of failed or skipped items are zero values.
+func Map[T any, R any](ctx context.Context, items []T, limit int,
+	fn func(ctx context.Context, index int, item T) (R, error), opts ...ParallelOption) ([]R, error) {
+	result := make([]R, len(items))
+
+	err := runParallel(ctx, len(items), limit, func(ctx context.Context, i int) error {
+		r, err := fn(ctx, i, items[i])
+		if err != nil {
+			return err
+		}
+		result[i] = r
+		return nil
+	}, opts...)
+
+	return result, err
+}
+
+// runParallel runs fn for index in [0, n) with a bounded count of goroutines.
+func runParallel(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error,
+	opts ...ParallelOption) error {
+	config := &ParallelConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if n == 0 {
+		return ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = runtime.NumCPU()
+	}
+	if limit > n {
+		limit = n
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		errs     = make([]error, n)
+	)
+
+	ctxErr := parallel.Dispatch(ctx, n, limit, func(i int) {
+		err := config.call(ctx, func(ctx context.Context) error {
+			return fn(ctx, i)
+		})
+		if err == nil {
+			return
+		}
+		if config.collectAll {
+			errs[i] = err
+			return
+		}
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+		mu.Unlock()
+	})
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if config.collectAll {
+		var collected []error
+		for _, err := range errs {
+			if err != nil {
+				collected = append(collected, err)
+			}
+		}
+		if ctxErr != nil {
+			collected = append(collected, ctxErr)
+		}
+		if len(collected) > 0 {
+			return &MultiError{Errors: collected}
+		}
+	}
+
+	return ctxErr
+}
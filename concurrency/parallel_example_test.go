@@ -0,0 +1,39 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func ExampleForEach() {
+	items := []string{"a", "b", "c"}
+	result := make([]string, len(items))
+
+	err := ForEach(context.Background(), items, 2, func(ctx context.Context, index int, item string) error {
+		result[index] = strings.ToUpper(item)
+		return nil
+	})
+
+	fmt.Println(result)
+	fmt.Println(err)
+
+	// Output:
+	// [A B C]
+	// <nil>
+}
+
+func ExampleMap() {
+	items := []int{1, 2, 3}
+
+	result, err := Map(context.Background(), items, 2, func(ctx context.Context, index int, item int) (int, error) {
+		return item * 10, nil
+	})
+
+	fmt.Println(result)
+	fmt.Println(err)
+
+	// Output:
+	// [10 20 30]
+	// <nil>
+}
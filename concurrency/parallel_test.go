@@ -0,0 +1,163 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestForEach")
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	var sum int64
+	var running, maxRunning int32
+
+	err := ForEach(context.Background(), items, 3, func(ctx context.Context, index int, item int) error {
+		cur := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&maxRunning)
+			if cur <= old || atomic.CompareAndSwapInt32(&maxRunning, old, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		atomic.AddInt64(&sum, int64(item))
+		return nil
+	})
+
+	assert.IsNil(err)
+	assert.Equal(int64(55), sum)
+	assert.GreaterOrEqual(int32(3), atomic.LoadInt32(&maxRunning))
+
+	assert.IsNil(ForEach(context.Background(), []int{}, 3, func(ctx context.Context, index int, item int) error {
+		return errors.New("should not be called")
+	}))
+}
+
+func TestForEach_StopOnFirstError(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestForEach_StopOnFirstError")
+
+	items := make([]int, 100)
+	errFailed := errors.New("failed")
+
+	var called int32
+	err := ForEach(context.Background(), items, 1, func(ctx context.Context, index int, item int) error {
+		atomic.AddInt32(&called, 1)
+		if index == 2 {
+			return errFailed
+		}
+		return nil
+	})
+
+	assert.Equal(errFailed, err)
+	assert.Greater(int32(100), atomic.LoadInt32(&called))
+}
+
+func TestForEach_CollectAll(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestForEach_CollectAll")
+
+	items := []int{1, 2, 3, 4, 5, 6}
+	errOdd := errors.New("odd")
+
+	var called int32
+	err := ForEach(context.Background(), items, 2, func(ctx context.Context, index int, item int) error {
+		atomic.AddInt32(&called, 1)
+		if item%2 == 1 {
+			return fmt.Errorf("item %d: %w", item, errOdd)
+		}
+		return nil
+	}, WithCollectAll())
+
+	assert.Equal(int32(6), atomic.LoadInt32(&called))
+
+	var multiErr *MultiError
+	assert.Equal(true, errors.As(err, &multiErr))
+	assert.Equal(3, len(multiErr.Errors))
+	assert.Equal("item 1: odd; item 3: odd; item 5: odd", err.Error())
+	assert.Equal(true, errors.Is(multiErr.Errors[0], errOdd))
+
+	// the errors are inspected by the Is and As methods
+	assert.Equal(true, multiErr.Is(errOdd))
+	assert.Equal(false, multiErr.Is(context.Canceled))
+
+	var target *testItemError
+	assert.Equal(false, multiErr.As(&target))
+
+	multiErr.Errors = append(multiErr.Errors, &testItemError{item: 7})
+	assert.Equal(true, multiErr.As(&target))
+	assert.Equal(7, target.item)
+}
+
+type testItemError struct {
+	item int
+}
+
+func (e *testItemError) Error() string {
+	return fmt.Sprintf("item %d failed", e.item)
+}
+
+func TestForEach_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestForEach_ContextCanceled")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ForEach(ctx, []int{1, 2, 3}, 1, func(ctx context.Context, index int, item int) error {
+		return nil
+	})
+	assert.Equal(context.Canceled, err)
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestMap")
+
+	items := []int{1, 2, 3, 4, 5}
+
+	result, err := Map(context.Background(), items, 2, func(ctx context.Context, index int, item int) (string, error) {
+		return fmt.Sprintf("%d-%d", index, item*item), nil
+	})
+
+	assert.IsNil(err)
+	assert.Equal([]string{"0-1", "1-4", "2-9", "3-16", "4-25"}, result)
+
+	result, err = Map(context.Background(), items, 0, func(ctx context.Context, index int, item int) (string, error) {
+		if item == 3 {
+			return "", errors.New("bad item")
+		}
+		return fmt.Sprint(item), nil
+	}, WithCollectAll())
+
+	assert.Equal("bad item", err.Error())
+	assert.Equal([]string{"1", "2", "", "4", "5"}, result)
+}
+
+func TestMap_TaskTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMap_TaskTimeout")
+
+	result, err := Map(context.Background(), []int{1, 2, 3}, 3, func(ctx context.Context, _ int, item int) (int, error) {
+		if item == 2 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return item, nil
+	}, WithTaskTimeout(10*time.Millisecond), WithCollectAll())
+
+	assert.Equal([]int{1, 0, 3}, result)
+	assert.Equal(true, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Pipeline connects the stages of channel processing, the stages share the context of pipeline and report their
+// errors to it. It accepts the ParallelOption: by default, the first error cancels all the stages like ForEach,
+// WithCollectAll makes the failed items dropped and the others keep flowing, and WithTaskTimeout sets the timeout
+// of every call of the stage functions.
+type Pipeline struct {
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+	config ParallelConfig
+
+	// wg counts the goroutines of all the stages
+	wg sync.WaitGroup
+
+	mu       sync.Mutex
+	firstErr error
+	errs     []error
+}
+
+// NewPipeline creates a Pipeline pointer instance, all the stages are stopped when ctx is done.
+func NewPipeline(ctx context.Context, opts ...ParallelOption) *Pipeline {
+	p := &Pipeline{parent: ctx}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	for _, opt := range opts {
+		opt(&p.config)
+	}
+
+	return p
+}
+
+// Context returns the context of pipeline, which is done when the pipeline is stopped, eg: it could be passed to
+// Channel.Generate as the source of pipeline.
+func (p *Pipeline) Context() context.Context {
+	return p.ctx
+}
+
+// Stop stops all the stages gracefully: the stages stop receiving items, the running calls of stage functions are
+// finished with a done context, and the output channels are closed.
+func (p *Pipeline) Stop() {
+	p.cancel()
+}
+
+// Wait waits for all the stages to finish, and returns the first error, or *MultiError of all the errors with
+// WithCollectAll, or the error of ctx passed to NewPipeline if it's done. The output of the last stage should be
+// drained, or the pipeline be stopped before Wait, otherwise Wait blocks forever.
+func (p *Pipeline) Wait() error {
+	p.wg.Wait()
+	p.cancel()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.firstErr != nil {
+		return p.firstErr
+	}
+
+	errs := p.errs
+	if err := p.parent.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	switch {
+	case len(errs) == 0:
+		return nil
+	case p.config.collectAll:
+		return &MultiError{Errors: errs}
+	}
+
+	return errs[0]
+}
+
+func (p *Pipeline) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.config.collectAll {
+		p.errs = append(p.errs, err)
+		return
+	}
+
+	if p.firstErr == nil {
+		p.firstErr = err
+		p.cancel()
+	}
+}
+
+// Stage processes the items from in by fn with workers goroutines concurrently, and sends the results to the
+// returned channel, which is closed after in is closed and all the items are processed, or the pipeline is
+// stopped. The results are in the order of completion. The error of fn is reported to the pipeline, and the item
+// is dropped. If workers <= 0, runtime.NumCPU() is used.
+func Stage[I any, O any](p *Pipeline, in <-chan I, workers int, fn func(ctx context.Context, item I) (O, error)) <-chan O {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	out := make(chan O)
+	ctx := p.ctx
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	p.wg.Add(workers + 1)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer p.wg.Done()
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				var item I
+				var ok bool
+
+				select {
+				case item, ok = <-in:
+					if !ok {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+
+				var result O
+				err := p.config.call(ctx, func(ctx context.Context) error {
+					var err error
+					result, err = fn(ctx, item)
+					return err
+				})
+				if err != nil {
+					// the errors caused by stopping the pipeline are not reported
+					if ctx.Err() == nil {
+						p.fail(err)
+					}
+					continue
+				}
+
+				select {
+				case out <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer p.wg.Done()
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
@@ -0,0 +1,46 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+func ExampleStage() {
+	p := NewPipeline(context.Background())
+
+	lines := NewChannel[string]().Generate(p.Context(), "1", "2", "x", "4")
+	nums := Stage(p, lines, 2, func(ctx context.Context, line string) (int, error) {
+		return strconv.Atoi(line)
+	})
+	squares := Stage(p, nums, 2, func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+
+	for range squares {
+	}
+
+	fmt.Println(p.Wait())
+
+	// Output:
+	// strconv.Atoi: parsing "x": invalid syntax
+}
+
+func ExamplePipeline() {
+	p := NewPipeline(context.Background(), WithCollectAll())
+
+	nums := NewChannel[int]().Generate(p.Context(), 1, 2, 3)
+	squares := Stage(p, nums, 2, func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+
+	sum := 0
+	for n := range squares {
+		sum += n
+	}
+
+	fmt.Println(sum, p.Wait())
+
+	// Output:
+	// 14 <nil>
+}
@@ -0,0 +1,169 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPipeline")
+
+	p := NewPipeline(context.Background())
+
+	nums := NewChannel[int]().Generate(p.Context(), 1, 2, 3, 4, 5)
+	squares := Stage(p, nums, 3, func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+	strs := Stage(p, squares, 2, func(ctx context.Context, n int) (string, error) {
+		return fmt.Sprintf("%02d", n), nil
+	})
+
+	var result []string
+	for s := range strs {
+		result = append(result, s)
+	}
+	sort.Strings(result)
+
+	assert.IsNil(p.Wait())
+	assert.Equal([]string{"01", "04", "09", "16", "25"}, result)
+}
+
+func TestPipeline_FirstError(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPipeline_FirstError")
+
+	errBad := errors.New("bad item")
+	p := NewPipeline(context.Background())
+
+	var processed int32
+	nums := NewChannel[int]().Repeat(p.Context(), 1, 2, 3)
+	out := Stage(p, nums, 2, func(ctx context.Context, n int) (int, error) {
+		if atomic.AddInt32(&processed, 1) == 10 {
+			return 0, errBad
+		}
+		return n, nil
+	})
+
+	count := 0
+	for range out {
+		count++
+	}
+
+	assert.Equal(errBad, p.Wait())
+	// the other worker may finish one more item before canceled
+	assert.GreaterOrEqual(10, count)
+}
+
+func TestPipeline_CollectAll(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPipeline_CollectAll")
+
+	p := NewPipeline(context.Background(), WithCollectAll())
+
+	nums := NewChannel[int]().Generate(p.Context(), 1, 2, 3, 4, 5, 6)
+	odds := Stage(p, nums, 2, func(ctx context.Context, n int) (int, error) {
+		if n%2 == 0 {
+			return 0, fmt.Errorf("%d is even", n)
+		}
+		return n, nil
+	})
+	doubled := Stage(p, odds, 2, func(ctx context.Context, n int) (int, error) {
+		return n * 2, nil
+	})
+
+	var result []int
+	for n := range doubled {
+		result = append(result, n)
+	}
+	sort.Ints(result)
+
+	assert.Equal([]int{2, 6, 10}, result)
+
+	err := p.Wait()
+	var multiErr *MultiError
+	assert.Equal(true, errors.As(err, &multiErr))
+	assert.Equal(3, len(multiErr.Errors))
+}
+
+func TestPipeline_Cancel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPipeline_Cancel")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewPipeline(ctx)
+
+	var running int32
+	nums := NewChannel[int]().Repeat(p.Context(), 1)
+	out := Stage(p, nums, 2, func(ctx context.Context, n int) (int, error) {
+		atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(time.Millisecond):
+			return n, nil
+		}
+	})
+
+	<-out
+	cancel()
+	for range out {
+	}
+
+	assert.Equal(context.Canceled, p.Wait())
+	// the running calls are finished before Wait returns
+	assert.Equal(int32(0), atomic.LoadInt32(&running))
+}
+
+func TestPipeline_Stop(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPipeline_Stop")
+
+	p := NewPipeline(context.Background(), WithTaskTimeout(time.Second))
+
+	nums := NewChannel[int]().Repeat(p.Context(), 1)
+	out := Stage(p, nums, 0, func(ctx context.Context, n int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	p.Stop()
+	for range out {
+	}
+
+	// the errors caused by stopping are not reported
+	assert.IsNil(p.Wait())
+}
+
+func TestPipeline_TaskTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPipeline_TaskTimeout")
+
+	p := NewPipeline(context.Background(), WithTaskTimeout(10*time.Millisecond))
+
+	nums := NewChannel[int]().Generate(p.Context(), 1)
+	out := Stage(p, nums, 1, func(ctx context.Context, n int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	for range out {
+	}
+
+	assert.Equal(context.DeadlineExceeded, p.Wait())
+}
@@ -0,0 +1,173 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// PoolResult is the result of an item processed by Pool.Stream.
+type PoolResult[T any, R any] struct {
+	// Index is the index of item in the input channel
+	Index int
+	Item  T
+	Value R
+	Err   error
+}
+
+// poolJob is an item of Pool.Stream with its index.
+type poolJob[T any] struct {
+	index int
+	item  T
+}
+
+// Pool processes the items by fn and gathers the typed results, at most workers calls of fn run at the same time,
+// even if the pool is used by many goroutines. It accepts the ParallelOption: by default, it stops on the first
+// error like Map, WithCollectAll makes it process all the items, and WithTaskTimeout sets the timeout of every
+// call of fn, which is started after waiting for a free worker.
+type Pool[T any, R any] struct {
+	fn     func(ctx context.Context, item T) (R, error)
+	sem    chan struct{}
+	config ParallelConfig
+}
+
+// NewPool creates a Pool pointer instance. If workers <= 0, runtime.NumCPU() is used.
+func NewPool[T any, R any](workers int, fn func(ctx context.Context, item T) (R, error), opts ...ParallelOption) *Pool[T, R] {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	p := &Pool[T, R]{
+		fn:  fn,
+		sem: make(chan struct{}, workers),
+	}
+	for _, opt := range opts {
+		opt(&p.config)
+	}
+
+	return p
+}
+
+// Run processes items and returns the results in the order of items. The errors are handled in the same way as
+// Map, the returned slice always has the same length of items and the results of failed or skipped items are
+// zero values.
+func (p *Pool[T, R]) Run(ctx context.Context, items []T) ([]R, error) {
+	result := make([]R, len(items))
+
+	var opts []ParallelOption
+	if p.config.collectAll {
+		opts = append(opts, WithCollectAll())
+	}
+
+	err := runParallel(ctx, len(items), cap(p.sem), func(ctx context.Context, i int) error {
+		r, err := p.process(ctx, items[i])
+		if err != nil {
+			return err
+		}
+		result[i] = r
+		return nil
+	}, opts...)
+
+	return result, err
+}
+
+// Stream processes the items received from the channel, and sends the results to the returned channel in the
+// order of completion, PoolResult.Index could be used to reorder them. The returned channel is closed after items
+// is closed and all the results are sent, or ctx is done. By default, the first failed result is the last one
+// sent, and the remaining items are not processed. The returned channel should be drained or ctx be canceled,
+// otherwise the goroutines of stream are blocked.
+func (p *Pool[T, R]) Stream(ctx context.Context, items <-chan T) <-chan PoolResult[T, R] {
+	workers := cap(p.sem)
+	out := make(chan PoolResult[T, R], workers)
+	jobs := make(chan poolJob[T])
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		defer close(jobs)
+
+		for index := 0; ; index++ {
+			select {
+			case item, ok := <-items:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- poolJob[T]{index: index, item: item}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+	)
+
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				value, err := p.process(ctx, job.item)
+				if ctx.Err() != nil {
+					// canceled by the caller or the first error, the results are dropped
+					continue
+				}
+
+				// the results are sent with lock, so no result is sent after the first error
+				mu.Lock()
+				if !failed {
+					failed = err != nil && !p.config.collectAll
+
+					select {
+					case out <- PoolResult[T, R]{Index: job.index, Item: job.item, Value: value, Err: err}:
+					case <-ctx.Done():
+					}
+
+					if failed {
+						cancel()
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(out)
+	}()
+
+	return out
+}
+
+// process calls fn with a free worker.
+func (p *Pool[T, R]) process(ctx context.Context, item T) (R, error) {
+	var result R
+
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return result, ctx.Err()
+	}
+	defer func() { <-p.sem }()
+
+	err := p.config.call(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = p.fn(ctx, item)
+		return err
+	})
+
+	return result, err
+}
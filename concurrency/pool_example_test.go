@@ -0,0 +1,46 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func ExamplePool() {
+	pool := NewPool(2, func(ctx context.Context, url string) (int, error) {
+		// fetch the url
+		time.Sleep(time.Millisecond)
+		return len(url), nil
+	}, WithTaskTimeout(time.Second))
+
+	result, err := pool.Run(context.Background(), []string{"a.com", "bb.com", "ccc.com"})
+
+	fmt.Println(result, err)
+
+	// Output:
+	// [5 6 7] <nil>
+}
+
+func ExamplePool_Stream() {
+	pool := NewPool(2, func(ctx context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+
+	items := make(chan int)
+	go func() {
+		defer close(items)
+		for i := 1; i <= 3; i++ {
+			items <- i
+		}
+	}()
+
+	sum := 0
+	for result := range pool.Stream(context.Background(), items) {
+		sum += result.Value
+	}
+
+	fmt.Println(sum)
+
+	// Output:
+	// 14
+}
@@ -0,0 +1,227 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestPool_Run(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPool_Run")
+
+	var running, maxRunning int32
+	pool := NewPool(2, func(ctx context.Context, item int) (string, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		return string(rune('a' + item)), nil
+	})
+
+	// the limit of workers is shared by the calls
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := pool.Run(context.Background(), []int{0, 1, 2, 3, 4})
+			assert.IsNil(err)
+			assert.Equal([]string{"a", "b", "c", "d", "e"}, result)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(int32(2), atomic.LoadInt32(&maxRunning))
+
+	result, err := pool.Run(context.Background(), nil)
+	assert.IsNil(err)
+	assert.Equal([]string{}, result)
+}
+
+func TestPool_RunErrors(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPool_RunErrors")
+
+	errOdd := errors.New("odd")
+	process := func(ctx context.Context, item int) (int, error) {
+		if item%2 == 1 {
+			return 0, errOdd
+		}
+		return item * 10, nil
+	}
+
+	_, err := NewPool(1, process).Run(context.Background(), []int{0, 1, 2, 3})
+	assert.Equal(errOdd, err)
+
+	result, err := NewPool(2, process, WithCollectAll()).Run(context.Background(), []int{0, 1, 2, 3})
+	assert.Equal([]int{0, 0, 20, 0}, result)
+
+	var multiErr *MultiError
+	assert.Equal(true, errors.As(err, &multiErr))
+	assert.Equal(2, len(multiErr.Errors))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewPool(2, process).Run(ctx, []int{0, 2})
+	assert.Equal(context.Canceled, err)
+}
+
+func TestPool_TaskTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPool_TaskTimeout")
+
+	pool := NewPool(1, func(ctx context.Context, d time.Duration) (time.Duration, error) {
+		select {
+		case <-time.After(d):
+			return d, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}, WithTaskTimeout(50*time.Millisecond), WithCollectAll())
+
+	// the timeout is started after waiting for the worker
+	result, err := pool.Run(context.Background(), []time.Duration{
+		30 * time.Millisecond, 30 * time.Millisecond, time.Second,
+	})
+
+	assert.Equal([]time.Duration{30 * time.Millisecond, 30 * time.Millisecond, 0}, result)
+	assert.Equal(true, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestPool_Stream(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPool_Stream")
+
+	pool := NewPool(3, func(ctx context.Context, item int) (int, error) {
+		return item * item, nil
+	})
+
+	items := make(chan int)
+	go func() {
+		defer close(items)
+		for i := 0; i < 10; i++ {
+			items <- i
+		}
+	}()
+
+	var indexes []int
+	for result := range pool.Stream(context.Background(), items) {
+		assert.IsNil(result.Err)
+		assert.Equal(result.Item*result.Item, result.Value)
+		assert.Equal(result.Index, result.Item)
+		indexes = append(indexes, result.Index)
+	}
+
+	sort.Ints(indexes)
+	assert.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, indexes)
+}
+
+func TestPool_StreamFailFast(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPool_StreamFailFast")
+
+	errFail := errors.New("fail")
+	var processed int32
+	pool := NewPool(2, func(ctx context.Context, item int) (int, error) {
+		atomic.AddInt32(&processed, 1)
+		if item == 3 {
+			return 0, errFail
+		}
+		return item, nil
+	})
+
+	// items are never closed
+	items := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case items <- i:
+			case <-time.After(time.Second):
+				return
+			}
+		}
+	}()
+
+	var results []PoolResult[int, int]
+	for result := range pool.Stream(context.Background(), items) {
+		results = append(results, result)
+	}
+
+	last := results[len(results)-1]
+	assert.Equal(errFail, last.Err)
+	assert.Equal(3, last.Index)
+	for _, result := range results[:len(results)-1] {
+		assert.IsNil(result.Err)
+	}
+	assert.Greater(int32(10), atomic.LoadInt32(&processed))
+}
+
+func TestPool_StreamCollectAll(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPool_StreamCollectAll")
+
+	pool := NewPool(2, func(ctx context.Context, item int) (int, error) {
+		if item%2 == 0 {
+			return 0, errors.New("even")
+		}
+		return item, nil
+	}, WithCollectAll())
+
+	items := make(chan int, 6)
+	for i := 0; i < 6; i++ {
+		items <- i
+	}
+	close(items)
+
+	failed := 0
+	count := 0
+	for result := range pool.Stream(context.Background(), items) {
+		count++
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	assert.Equal(6, count)
+	assert.Equal(3, failed)
+}
+
+func TestPool_StreamCanceled(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPool_StreamCanceled")
+
+	pool := NewPool(2, func(ctx context.Context, item int) (int, error) {
+		return item, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	items := make(chan int)
+	results := pool.Stream(ctx, items)
+
+	items <- 1
+	assert.Equal(1, (<-results).Value)
+
+	cancel()
+	for range results {
+	}
+}
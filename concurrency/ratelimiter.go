@@ -0,0 +1,166 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
+)
+
+// Rate is the number of events per second.
+type Rate float64
+
+// PerSecond returns the rate of n events per second.
+func PerSecond(n int) Rate {
+	return Rate(n)
+}
+
+// PerMinute returns the rate of n events per minute.
+func PerMinute(n int) Rate {
+	return Rate(float64(n) / 60)
+}
+
+// Every returns the rate of one event every interval.
+func Every(interval time.Duration) Rate {
+	if interval <= 0 {
+		return Rate(math.Inf(1))
+	}
+
+	return Rate(float64(time.Second) / float64(interval))
+}
+
+// RateLimiterConfig is config for RateLimiter.
+type RateLimiterConfig struct {
+	clock datetime.Clock
+}
+
+// RateLimiterOption is for adding RateLimiter config.
+type RateLimiterOption func(*RateLimiterConfig)
+
+// WithRateLimiterClock sets the clock of RateLimiter, eg: a datetime.FakeClock in tests.
+func WithRateLimiterClock(clock datetime.Clock) RateLimiterOption {
+	if clock == nil {
+		panic("programming error: clock must be not nil")
+	}
+
+	return func(rc *RateLimiterConfig) {
+		rc.clock = clock
+	}
+}
+
+// RateLimiter limits the rate of events by token bucket: the bucket holds at most burst tokens and is refilled at
+// the rate, an event takes a token. So the events are allowed at the rate on average, and up to burst events are
+// allowed at once after idle.
+type RateLimiter struct {
+	rate  Rate
+	burst int
+	clock datetime.Clock
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter pointer instance with a full bucket, eg: NewRateLimiter(PerMinute(60), 10).
+// If burst <= 0, 1 is used.
+func NewRateLimiter(rate Rate, burst int, opts ...RateLimiterOption) *RateLimiter {
+	if rate <= 0 {
+		panic("programming error: rate must be greater than 0")
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	config := &RateLimiterConfig{clock: datetime.SystemClock}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &RateLimiter{
+		rate:   rate,
+		burst:  burst,
+		clock:  config.clock,
+		tokens: float64(burst),
+		last:   config.clock.Now(),
+	}
+}
+
+// Allow reports whether an event is allowed now, the token is taken if it's allowed. It's for dropping the events
+// exceeding the rate.
+func (rl *RateLimiter) Allow() bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.refill(rl.clock.Now())
+	if rl.tokens < 1 {
+		return false
+	}
+	rl.tokens--
+
+	return true
+}
+
+// Wait blocks until an event is allowed or ctx is done, and returns the error of ctx in the latter case. It's for
+// delaying the events exceeding the rate, the waiting calls are allowed in order.
+func (rl *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	rl.mu.Lock()
+	now := rl.clock.Now()
+	rl.refill(now)
+	// the token is reserved, and the bucket goes negative for the later calls
+	rl.tokens--
+	wait := rl.delay(-rl.tokens)
+	rl.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-rl.clock.After(wait):
+		return nil
+	case <-ctx.Done():
+		// give back the reserved token
+		rl.mu.Lock()
+		rl.refill(rl.clock.Now())
+		rl.tokens = math.Min(rl.tokens+1, float64(rl.burst))
+		rl.mu.Unlock()
+
+		return ctx.Err()
+	}
+}
+
+// Tokens returns the number of available tokens now, it's negative if there are waiting calls.
+func (rl *RateLimiter) Tokens() float64 {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.refill(rl.clock.Now())
+
+	return rl.tokens
+}
+
+// refill adds the tokens generated since last refill.
+func (rl *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(rl.last); elapsed > 0 {
+		rl.tokens = math.Min(rl.tokens+elapsed.Seconds()*float64(rl.rate), float64(rl.burst))
+		rl.last = now
+	}
+}
+
+// delay returns the duration to generate n tokens.
+func (rl *RateLimiter) delay(n float64) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(n / float64(rl.rate) * float64(time.Second)))
+}
@@ -0,0 +1,18 @@
+package concurrency
+
+import (
+	"fmt"
+)
+
+func ExampleRateLimiter() {
+	rl := NewRateLimiter(PerMinute(60), 2)
+
+	fmt.Println(rl.Allow())
+	fmt.Println(rl.Allow())
+	fmt.Println(rl.Allow())
+
+	// Output:
+	// true
+	// true
+	// false
+}
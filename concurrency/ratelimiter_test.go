@@ -0,0 +1,115 @@
+package concurrency
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestRate(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestRate")
+
+	assert.Equal(Rate(10), PerSecond(10))
+	assert.Equal(Rate(0.5), PerMinute(30))
+	assert.Equal(Rate(4), Every(250*time.Millisecond))
+	assert.Equal(true, math.IsInf(float64(Every(0)), 1))
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestRateLimiter_Allow")
+
+	clock := datetime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rl := NewRateLimiter(PerSecond(2), 3, WithRateLimiterClock(clock))
+
+	// burst
+	assert.Equal(true, rl.Allow())
+	assert.Equal(true, rl.Allow())
+	assert.Equal(true, rl.Allow())
+	assert.Equal(false, rl.Allow())
+
+	clock.Advance(400 * time.Millisecond)
+	assert.Equal(false, rl.Allow())
+	clock.Advance(100 * time.Millisecond)
+	assert.Equal(true, rl.Allow())
+	assert.Equal(false, rl.Allow())
+
+	// the bucket is not more than burst after idle
+	clock.Advance(time.Hour)
+	assert.Equal(float64(3), rl.Tokens())
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestRateLimiter_Wait")
+
+	clock := datetime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rl := NewRateLimiter(PerMinute(60), 1, WithRateLimiterClock(clock))
+
+	assert.IsNil(rl.Wait(context.Background()))
+
+	done := make(chan error)
+	go func() {
+		done <- rl.Wait(context.Background())
+	}()
+	clock.BlockUntil(1)
+
+	select {
+	case <-done:
+		t.Fatal("Wait returns before the token is generated")
+	default:
+	}
+	assert.Equal(float64(-1), rl.Tokens())
+
+	clock.Advance(time.Second)
+	assert.IsNil(<-done)
+	assert.Equal(false, rl.Allow())
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestRateLimiter_WaitCanceled")
+
+	clock := datetime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rl := NewRateLimiter(PerSecond(1), 1, WithRateLimiterClock(clock))
+	assert.Equal(true, rl.Allow())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- rl.Wait(ctx)
+	}()
+	clock.BlockUntil(1)
+	cancel()
+
+	assert.Equal(context.Canceled, <-done)
+	// the reserved token is given back
+	assert.Equal(float64(0), rl.Tokens())
+
+	canceled, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	assert.Equal(context.Canceled, rl.Wait(canceled))
+
+	clock.Advance(time.Second)
+	assert.Equal(true, rl.Allow())
+}
+
+func TestRateLimiter_RealClock(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestRateLimiter_RealClock")
+
+	rl := NewRateLimiter(PerSecond(100), 0)
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		assert.IsNil(rl.Wait(context.Background()))
+	}
+
+	// 1 token at first, 5 tokens in 50ms
+	assert.GreaterOrEqual(time.Since(start), 45*time.Millisecond)
+}
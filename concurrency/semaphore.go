@@ -0,0 +1,72 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"context"
+)
+
+// Semaphore limits the number of goroutines doing something at the same time, eg: the concurrent requests to a
+// server. A goroutine acquires the semaphore before doing it, and releases it after.
+type Semaphore struct {
+	ch chan struct{}
+}
+
+// NewSemaphore creates a Semaphore pointer instance, at most n goroutines could acquire it at the same time.
+func NewSemaphore(n int) *Semaphore {
+	if n <= 0 {
+		panic("programming error: semaphore size must be greater than 0")
+	}
+
+	return &Semaphore{ch: make(chan struct{}, n)}
+}
+
+// Acquire acquires the semaphore, it blocks until the semaphore is available.
+func (s *Semaphore) Acquire() {
+	s.ch <- struct{}{}
+}
+
+// TryAcquire tries to acquire the semaphore without blocking, and reports whether it succeeds.
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.ch <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// AcquireWithContext acquires the semaphore, it blocks until the semaphore is available or ctx is done, and
+// returns the error of ctx in the latter case.
+func (s *Semaphore) AcquireWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case s.ch <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release releases the semaphore acquired before, it panics if the semaphore is not acquired.
+func (s *Semaphore) Release() {
+	select {
+	case <-s.ch:
+	default:
+		panic("concurrency: release of unacquired semaphore")
+	}
+}
+
+// Size returns the max number of goroutines acquiring the semaphore.
+func (s *Semaphore) Size() int {
+	return cap(s.ch)
+}
+
+// Available returns the number of goroutines could acquire the semaphore without blocking now.
+func (s *Semaphore) Available() int {
+	return cap(s.ch) - len(s.ch)
+}
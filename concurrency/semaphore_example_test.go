@@ -0,0 +1,23 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+)
+
+func ExampleSemaphore() {
+	sem := NewSemaphore(2)
+
+	fmt.Println(sem.TryAcquire())
+	fmt.Println(sem.TryAcquire())
+	fmt.Println(sem.TryAcquire())
+
+	sem.Release()
+	fmt.Println(sem.AcquireWithContext(context.Background()))
+
+	// Output:
+	// true
+	// true
+	// false
+	// <nil>
+}
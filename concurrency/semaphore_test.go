@@ -0,0 +1,108 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestSemaphore(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSemaphore")
+
+	sem := NewSemaphore(3)
+	assert.Equal(3, sem.Size())
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem.Acquire()
+			defer sem.Release()
+
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(true, atomic.LoadInt32(&maxRunning) <= 3)
+	assert.Equal(3, sem.Available())
+}
+
+func TestSemaphore_TryAcquire(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSemaphore_TryAcquire")
+
+	sem := NewSemaphore(2)
+
+	assert.Equal(true, sem.TryAcquire())
+	assert.Equal(true, sem.TryAcquire())
+	assert.Equal(false, sem.TryAcquire())
+	assert.Equal(0, sem.Available())
+
+	sem.Release()
+	assert.Equal(1, sem.Available())
+	assert.Equal(true, sem.TryAcquire())
+}
+
+func TestSemaphore_AcquireWithContext(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSemaphore_AcquireWithContext")
+
+	sem := NewSemaphore(1)
+	assert.IsNil(sem.AcquireWithContext(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, sem.AcquireWithContext(ctx))
+
+	canceled, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	sem.Release()
+	// no acquiring with the done context even if available
+	assert.Equal(context.Canceled, sem.AcquireWithContext(canceled))
+	assert.Equal(1, sem.Available())
+
+	done := make(chan error)
+	sem.Acquire()
+	go func() {
+		done <- sem.AcquireWithContext(context.Background())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sem.Release()
+	assert.IsNil(<-done)
+}
+
+func TestSemaphore_Panic(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestSemaphore_Panic")
+
+	func() {
+		defer func() {
+			assert.IsNotNil(recover())
+		}()
+		NewSemaphore(0)
+	}()
+
+	func() {
+		defer func() {
+			assert.IsNotNil(recover())
+		}()
+		NewSemaphore(1).Release()
+	}()
+}
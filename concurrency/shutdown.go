@@ -0,0 +1,273 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const defaultShutdownTimeout = 30 * time.Second
+
+var (
+	// ErrShutdownStarted is returned by ShutdownGroup.Register after the shutdown is started.
+	ErrShutdownStarted = errors.New("concurrency: shutdown already started")
+	// ErrShutdownHookTimeout is the error of a stop hook not returned in its timeout.
+	ErrShutdownHookTimeout = errors.New("concurrency: shutdown hook timed out")
+)
+
+// ShutdownConfig is config for ShutdownGroup.
+type ShutdownConfig struct {
+	timeout time.Duration
+	signals []os.Signal
+}
+
+// ShutdownOption is for adding ShutdownGroup config.
+type ShutdownOption func(*ShutdownConfig)
+
+// WithShutdownTimeout sets the timeout of the whole shutdown started by Wait, the default is 30 seconds.
+func WithShutdownTimeout(timeout time.Duration) ShutdownOption {
+	return func(sc *ShutdownConfig) {
+		sc.timeout = timeout
+	}
+}
+
+// WithShutdownSignals sets the OS signals starting the shutdown in Wait, the default are os.Interrupt and
+// syscall.SIGTERM.
+func WithShutdownSignals(signals ...os.Signal) ShutdownOption {
+	return func(sc *ShutdownConfig) {
+		sc.signals = signals
+	}
+}
+
+// ShutdownHookConfig is config for a stop hook of ShutdownGroup.
+type ShutdownHookConfig struct {
+	priority int
+	timeout  time.Duration
+}
+
+// ShutdownHookOption is for adding stop hook config.
+type ShutdownHookOption func(*ShutdownHookConfig)
+
+// WithHookPriority sets the priority of stop hook, the default is 0. The hooks with higher priority are stopped
+// earlier, eg: the http server should stop accepting requests before the database is closed.
+func WithHookPriority(priority int) ShutdownHookOption {
+	return func(hc *ShutdownHookConfig) {
+		hc.priority = priority
+	}
+}
+
+// WithHookTimeout sets the timeout of stop hook, the context passed to the hook is done after timeout. If the
+// hook doesn't return in time, its error is ErrShutdownHookTimeout and the shutdown goes on without waiting.
+func WithHookTimeout(timeout time.Duration) ShutdownHookOption {
+	return func(hc *ShutdownHookConfig) {
+		hc.timeout = timeout
+	}
+}
+
+// shutdownTimeoutError is ErrShutdownHookTimeout caused by the error of context.
+type shutdownTimeoutError struct {
+	cause error
+}
+
+func (e *shutdownTimeoutError) Error() string {
+	return ErrShutdownHookTimeout.Error() + ": " + e.cause.Error()
+}
+
+func (e *shutdownTimeoutError) Is(target error) bool {
+	return target == ErrShutdownHookTimeout
+}
+
+func (e *shutdownTimeoutError) Unwrap() error {
+	return e.cause
+}
+
+type shutdownHook struct {
+	name   string
+	fn     func(ctx context.Context) error
+	config ShutdownHookConfig
+}
+
+// ShutdownGroup coordinates the graceful shutdown of a service: the components register their stop hooks, and the
+// hooks are called when the shutdown is started by OS signals, context or Shutdown. The hooks are called by their
+// priorities, the hooks of the same priority are called concurrently.
+type ShutdownGroup struct {
+	config ShutdownConfig
+
+	mu      sync.Mutex
+	hooks   []shutdownHook
+	started bool
+
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+// NewShutdownGroup creates a ShutdownGroup pointer instance.
+func NewShutdownGroup(opts ...ShutdownOption) *ShutdownGroup {
+	config := ShutdownConfig{
+		timeout: defaultShutdownTimeout,
+		signals: []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &ShutdownGroup{
+		config: config,
+		done:   make(chan struct{}),
+	}
+}
+
+// Register adds a stop hook with name, which is used in the errors. It returns ErrShutdownStarted if the shutdown
+// is started.
+func (sg *ShutdownGroup) Register(name string, fn func(ctx context.Context) error, opts ...ShutdownHookOption) error {
+	hook := shutdownHook{name: name, fn: fn}
+	for _, opt := range opts {
+		opt(&hook.config)
+	}
+
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+
+	if sg.started {
+		return ErrShutdownStarted
+	}
+	sg.hooks = append(sg.hooks, hook)
+
+	return nil
+}
+
+// Wait blocks until one of the signals is received or ctx is done, then shuts down with the timeout of
+// WithShutdownTimeout and returns the result of Shutdown. It returns at once if the shutdown is started by others.
+func (sg *ShutdownGroup) Wait(ctx context.Context) error {
+	signals := make(chan os.Signal, 1)
+	if len(sg.config.signals) > 0 {
+		signal.Notify(signals, sg.config.signals...)
+		defer signal.Stop(signals)
+	}
+
+	select {
+	case <-signals:
+	case <-ctx.Done():
+	case <-sg.done:
+		return sg.err
+	}
+
+	shutdownCtx := context.Background()
+	if sg.config.timeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, sg.config.timeout)
+		defer cancel()
+	}
+
+	return sg.Shutdown(shutdownCtx)
+}
+
+// Shutdown calls all the stop hooks from the highest priority, and returns *MultiError of the hook errors, or nil
+// if all the hooks succeed. When ctx is done, the remaining hooks are called with the done context immediately.
+// The shutdown runs only once, the later calls wait for it and return the same result.
+func (sg *ShutdownGroup) Shutdown(ctx context.Context) error {
+	sg.once.Do(func() {
+		sg.mu.Lock()
+		sg.started = true
+		hooks := append([]shutdownHook{}, sg.hooks...)
+		sg.mu.Unlock()
+
+		sg.err = runShutdownHooks(ctx, hooks)
+		close(sg.done)
+	})
+
+	<-sg.done
+
+	return sg.err
+}
+
+// Done returns a channel that's closed when the shutdown is finished.
+func (sg *ShutdownGroup) Done() <-chan struct{} {
+	return sg.done
+}
+
+// runShutdownHooks calls the hooks by priorities, the errors are in the order of calling stages and registration.
+func runShutdownHooks(ctx context.Context, hooks []shutdownHook) error {
+	sort.SliceStable(hooks, func(i, j int) bool {
+		return hooks[i].config.priority > hooks[j].config.priority
+	})
+
+	var errs []error
+	for start := 0; start < len(hooks); {
+		end := start + 1
+		for end < len(hooks) && hooks[end].config.priority == hooks[start].config.priority {
+			end++
+		}
+
+		stageErrs := make([]error, end-start)
+		var wg sync.WaitGroup
+		for i := start; i < end; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				stageErrs[i-start] = callShutdownHook(ctx, hooks[i])
+			}(i)
+		}
+		wg.Wait()
+
+		for _, err := range stageErrs {
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		start = end
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &MultiError{Errors: errs}
+}
+
+// callShutdownHook calls the hook with its timeout, a panic of hook is returned as error.
+func callShutdownHook(ctx context.Context, hook shutdownHook) error {
+	if hook.config.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hook.config.timeout)
+		defer cancel()
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				result <- fmt.Errorf("concurrency: shutdown hook panicked: %v", r)
+			}
+		}()
+		result <- hook.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		// the hook ignores the context, stop waiting for it
+		select {
+		case err = <-result:
+		default:
+			err = &shutdownTimeoutError{cause: ctx.Err()}
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("shutdown %s: %w", hook.name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func ExampleShutdownGroup() {
+	sg := NewShutdownGroup(WithShutdownTimeout(10 * time.Second))
+
+	sg.Register("database", func(ctx context.Context) error {
+		fmt.Println("close database")
+		return nil
+	})
+	sg.Register("http server", func(ctx context.Context) error {
+		fmt.Println("stop http server")
+		return nil
+	}, WithHookPriority(10), WithHookTimeout(5*time.Second))
+
+	// in main, it's usually sg.Wait(ctx) to wait for the signals
+	err := sg.Shutdown(context.Background())
+	fmt.Println(err)
+
+	// Output:
+	// stop http server
+	// close database
+	// <nil>
+}
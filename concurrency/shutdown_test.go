@@ -0,0 +1,167 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestShutdownGroup_Priority(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestShutdownGroup_Priority")
+
+	sg := NewShutdownGroup()
+
+	var mu sync.Mutex
+	var order []string
+	hook := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	assert.IsNil(sg.Register("db", hook("db")))
+	assert.IsNil(sg.Register("server", hook("server"), WithHookPriority(10)))
+	assert.IsNil(sg.Register("worker", hook("worker"), WithHookPriority(5)))
+	assert.IsNil(sg.Register("cache", hook("cache"), WithHookPriority(-1)))
+
+	assert.IsNil(sg.Shutdown(context.Background()))
+	assert.Equal([]string{"server", "worker", "db", "cache"}, order)
+
+	// only once
+	assert.IsNil(sg.Shutdown(context.Background()))
+	assert.Equal(4, len(order))
+
+	assert.Equal(ErrShutdownStarted, sg.Register("late", hook("late")))
+
+	select {
+	case <-sg.Done():
+	default:
+		t.Fatal("Done is not closed")
+	}
+}
+
+func TestShutdownGroup_SamePriorityConcurrent(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestShutdownGroup_SamePriorityConcurrent")
+
+	sg := NewShutdownGroup()
+
+	// the hooks wait for each other, they deadlock if called sequentially
+	var wg sync.WaitGroup
+	wg.Add(2)
+	for _, name := range []string{"a", "b"} {
+		sg.Register(name, func(ctx context.Context) error {
+			wg.Done()
+			wg.Wait()
+			return nil
+		}, WithHookTimeout(time.Second))
+	}
+
+	assert.IsNil(sg.Shutdown(context.Background()))
+}
+
+func TestShutdownGroup_Errors(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestShutdownGroup_Errors")
+
+	sg := NewShutdownGroup()
+
+	errClose := errors.New("close failed")
+	called := false
+
+	sg.Register("db", func(ctx context.Context) error {
+		return errClose
+	})
+	sg.Register("stuck", func(ctx context.Context) error {
+		select {}
+	}, WithHookTimeout(20*time.Millisecond), WithHookPriority(2))
+	sg.Register("panic", func(ctx context.Context) error {
+		panic("boom")
+	}, WithHookPriority(1))
+	sg.Register("ok", func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	err := sg.Shutdown(context.Background())
+
+	var multiErr *MultiError
+	assert.Equal(true, errors.As(err, &multiErr))
+	assert.Equal(3, len(multiErr.Errors))
+	assert.Equal(true, errors.Is(multiErr.Errors[0], ErrShutdownHookTimeout))
+	assert.Equal(true, errors.Is(multiErr.Errors[0], context.DeadlineExceeded))
+	assert.Equal("shutdown panic: concurrency: shutdown hook panicked: boom", multiErr.Errors[1].Error())
+	assert.Equal(true, errors.Is(err, errClose))
+	assert.Equal("shutdown db: close failed", multiErr.Errors[2].Error())
+
+	// the hooks after failed ones are called
+	assert.Equal(true, called)
+
+	// the same result
+	assert.Equal(err, sg.Shutdown(context.Background()))
+}
+
+func TestShutdownGroup_ShutdownContext(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestShutdownGroup_ShutdownContext")
+
+	sg := NewShutdownGroup()
+
+	hasDeadline := make(chan bool, 1)
+	sg.Register("server", func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		hasDeadline <- ok
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := sg.Shutdown(ctx)
+	// the hook returns the error of ctx, or it's timed out if the hook doesn't return in time
+	assert.Equal(true, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(true, <-hasDeadline)
+}
+
+func TestShutdownGroup_WaitContext(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestShutdownGroup_WaitContext")
+
+	sg := NewShutdownGroup(WithShutdownSignals(), WithShutdownTimeout(time.Second))
+
+	stopped := make(chan struct{})
+	sg.Register("worker", func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.Equal(true, ok)
+		close(stopped)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		result <- sg.Wait(ctx)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("stopped before ctx is canceled")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	assert.IsNil(<-result)
+	<-stopped
+
+	// Wait returns at once after shutdown
+	assert.IsNil(sg.Wait(context.Background()))
+}
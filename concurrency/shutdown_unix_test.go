@@ -0,0 +1,43 @@
+//go:build linux || darwin || freebsd
+
+package concurrency
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestShutdownGroup_WaitSignal(t *testing.T) {
+	assert := internal.NewAssert(t, "TestShutdownGroup_WaitSignal")
+
+	sg := NewShutdownGroup(WithShutdownSignals(syscall.SIGUSR1))
+
+	stopped := false
+	sg.Register("worker", func(ctx context.Context) error {
+		stopped = true
+		return nil
+	})
+
+	result := make(chan error)
+	go func() {
+		result <- sg.Wait(context.Background())
+	}()
+
+	// wait for the signal handler to be installed
+	time.Sleep(20 * time.Millisecond)
+	process, _ := os.FindProcess(os.Getpid())
+	assert.IsNil(process.Signal(syscall.SIGUSR1))
+
+	select {
+	case err := <-result:
+		assert.IsNil(err)
+	case <-time.After(time.Second):
+		t.Fatal("Wait doesn't return after signal")
+	}
+	assert.Equal(true, stopped)
+}
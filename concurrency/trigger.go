@@ -0,0 +1,122 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"sync"
+	"time"
+)
+
+// Trigger coalesces bursts of notifications and runs a handler in a single worker goroutine.
+// After the first Notify, the handler runs once the interval is elapsed, all the notifications
+// received in the meantime are merged into that single run. So the handler runs at most once per interval.
+// It's useful for logic like "save after changes settle".
+type Trigger struct {
+	interval time.Duration
+	handler  func()
+	notifyCh chan struct{}
+	flushCh  chan chan struct{}
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewTrigger creates a Trigger and starts its worker goroutine.
+func NewTrigger(interval time.Duration, handler func()) *Trigger {
+	if handler == nil {
+		panic("programming error: trigger handler must be not nil")
+	}
+
+	t := &Trigger{
+		interval: interval,
+		handler:  handler,
+		notifyCh: make(chan struct{}, 1),
+		flushCh:  make(chan chan struct{}),
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+
+	go t.run()
+
+	return t
+}
+
+// Notify signals that the handler needs to run. It never blocks, and does nothing after Stop.
+func (t *Trigger) Notify() {
+	select {
+	case t.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Flush runs the handler immediately if there are pending notifications, and waits until it returns.
+func (t *Trigger) Flush() {
+	done := make(chan struct{})
+
+	select {
+	case t.flushCh <- done:
+		<-done
+	case <-t.doneCh:
+	}
+}
+
+// Stop runs the handler for pending notifications, then stops the worker goroutine.
+// It waits until the worker goroutine exits, calling Stop more than once is safe.
+func (t *Trigger) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopCh)
+	})
+	<-t.doneCh
+}
+
+func (t *Trigger) run() {
+	defer close(t.doneCh)
+
+	var (
+		timer   *time.Timer
+		timerC  <-chan time.Time
+		pending bool
+	)
+
+	// fire runs the handler if there are pending notifications.
+	fire := func() {
+		select {
+		case <-t.notifyCh:
+			pending = true
+		default:
+		}
+
+		if !pending {
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+		timerC = nil
+		pending = false
+
+		t.handler()
+	}
+
+	for {
+		select {
+		case <-t.notifyCh:
+			if !pending {
+				pending = true
+				timer = time.NewTimer(t.interval)
+				timerC = timer.C
+			}
+		case <-timerC:
+			timer = nil
+			fire()
+		case done := <-t.flushCh:
+			fire()
+			close(done)
+		case <-t.stopCh:
+			fire()
+			return
+		}
+	}
+}
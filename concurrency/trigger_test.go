@@ -0,0 +1,81 @@
+package concurrency
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestTrigger_Coalesce(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTrigger_Coalesce")
+
+	var count int32
+	trigger := NewTrigger(50*time.Millisecond, func() {
+		atomic.AddInt32(&count, 1)
+	})
+	defer trigger.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			trigger.Notify()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(int32(0), atomic.LoadInt32(&count))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+
+	trigger.Notify()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(int32(2), atomic.LoadInt32(&count))
+}
+
+func TestTrigger_Flush(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTrigger_Flush")
+
+	var count int32
+	trigger := NewTrigger(time.Hour, func() {
+		atomic.AddInt32(&count, 1)
+	})
+	defer trigger.Stop()
+
+	trigger.Flush()
+	assert.Equal(int32(0), atomic.LoadInt32(&count))
+
+	trigger.Notify()
+	trigger.Notify()
+	trigger.Flush()
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+
+	trigger.Flush()
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+}
+
+func TestTrigger_Stop(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTrigger_Stop")
+
+	var count int32
+	trigger := NewTrigger(time.Hour, func() {
+		atomic.AddInt32(&count, 1)
+	})
+
+	trigger.Notify()
+	trigger.Stop()
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+
+	trigger.Notify()
+	trigger.Flush()
+	trigger.Stop()
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+}
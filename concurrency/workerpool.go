@@ -0,0 +1,308 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package concurrency
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrPoolClosed is returned by Submit if the worker pool is closed.
+	ErrPoolClosed = errors.New("concurrency: worker pool is closed")
+	// ErrTaskExpired is the result of a task which is not started before its deadline.
+	ErrTaskExpired = errors.New("concurrency: task is not started before its deadline")
+)
+
+// TaskConfig is config for the task submitted to WorkerPool.
+type TaskConfig struct {
+	priority int
+	deadline time.Time
+}
+
+// TaskOption is for adding task config.
+type TaskOption func(*TaskConfig)
+
+// WithPriority sets the priority of task, the task with higher priority is started first, and the tasks with the
+// same priority are started in the order of submission. The default priority is 0.
+func WithPriority(priority int) TaskOption {
+	return func(tc *TaskConfig) {
+		tc.priority = priority
+	}
+}
+
+// WithDeadline sets the time before which the task should be started, otherwise the task is dropped from the queue
+// and its result is ErrTaskExpired. The task started in time is not interrupted by the deadline.
+func WithDeadline(deadline time.Time) TaskOption {
+	return func(tc *TaskConfig) {
+		tc.deadline = deadline
+	}
+}
+
+// PoolMetrics is the snapshot of the state of WorkerPool.
+type PoolMetrics struct {
+	// Workers is the count of workers the pool is resized to
+	Workers int
+	// Queued is the count of tasks waiting for workers
+	Queued int
+	// Running is the count of tasks being run
+	Running int
+	// Completed is the count of tasks returned nil
+	Completed int64
+	// Failed is the count of tasks returned error or panicked, or whose context is done before started
+	Failed int64
+	// Expired is the count of tasks not started before their deadlines
+	Expired int64
+}
+
+// poolTask is a task in the queue of WorkerPool.
+type poolTask struct {
+	ctx      context.Context
+	fn       func(ctx context.Context) error
+	priority int
+	seq      uint64
+	timer    *time.Timer
+	// index is the index of task in the queue, or -1 if it's not queued
+	index  int
+	result chan error
+}
+
+// taskQueue is the heap of tasks ordered by priority and seq.
+type taskQueue []*poolTask
+
+func (q taskQueue) Len() int { return len(q) }
+
+func (q taskQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q taskQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *taskQueue) Push(x any) {
+	task := x.(*poolTask)
+	task.index = len(*q)
+	*q = append(*q, task)
+}
+
+func (q *taskQueue) Pop() any {
+	old := *q
+	n := len(old)
+	task := old[n-1]
+	old[n-1] = nil
+	task.index = -1
+	*q = old[:n-1]
+	return task
+}
+
+// WorkerPool runs the submitted tasks by a group of worker goroutines, the tasks waiting for workers are started by
+// their priorities, and dropped if they're not started before their deadlines. The count of workers could be
+// resized at runtime.
+type WorkerPool struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	wg   sync.WaitGroup
+
+	queue taskQueue
+	seq   uint64
+
+	// workers is the count of alive goroutines, and target is the count to resize to
+	workers int
+	target  int
+	running int
+	closed  bool
+
+	completed int64
+	failed    int64
+	expired   int64
+}
+
+// NewWorkerPool creates a WorkerPool pointer instance with the count of workers, which is at least 1.
+func NewWorkerPool(workers int) *WorkerPool {
+	p := &WorkerPool{}
+	p.cond = sync.NewCond(&p.mu)
+	p.Resize(workers)
+
+	return p
+}
+
+// Submit adds the task into the queue, and returns a channel which receives the result of task: the error returned
+// by fn, ErrTaskExpired if it's expired, or ctx.Err() if ctx is done before it's started. ctx is passed to fn.
+// It returns ErrPoolClosed if the pool is closed.
+func (p *WorkerPool) Submit(ctx context.Context, fn func(ctx context.Context) error, opts ...TaskOption) (<-chan error, error) {
+	config := &TaskConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	task := &poolTask{
+		ctx:      ctx,
+		fn:       fn,
+		priority: config.priority,
+		index:    -1,
+		result:   make(chan error, 1),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	if !config.deadline.IsZero() {
+		wait := time.Until(config.deadline)
+		if wait <= 0 {
+			p.expired++
+			task.result <- ErrTaskExpired
+			return task.result, nil
+		}
+		task.timer = time.AfterFunc(wait, func() {
+			p.expire(task)
+		})
+	}
+
+	task.seq = p.seq
+	p.seq++
+	heap.Push(&p.queue, task)
+	p.cond.Signal()
+
+	return task.result, nil
+}
+
+// Resize changes the count of workers, which is at least 1. If it's shrunk, the busy workers exit after their
+// running tasks are finished.
+func (p *WorkerPool) Resize(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	p.target = workers
+	for p.workers < p.target {
+		p.workers++
+		p.wg.Add(1)
+		go p.work()
+	}
+	p.cond.Broadcast()
+}
+
+// QueueLen returns the count of tasks waiting for workers.
+func (p *WorkerPool) QueueLen() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.queue)
+}
+
+// Metrics returns the snapshot of the state of pool.
+func (p *WorkerPool) Metrics() PoolMetrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return PoolMetrics{
+		Workers:   p.target,
+		Queued:    len(p.queue),
+		Running:   p.running,
+		Completed: p.completed,
+		Failed:    p.failed,
+		Expired:   p.expired,
+	}
+}
+
+// Close stops accepting new tasks, and waits for the queued and running tasks to finish. The queued tasks may
+// still expire by their deadlines.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+// expire drops the task from the queue if it's not started yet.
+func (p *WorkerPool) expire(task *poolTask) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if task.index < 0 {
+		return
+	}
+
+	heap.Remove(&p.queue, task.index)
+	p.expired++
+	task.result <- ErrTaskExpired
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !p.closed && p.workers <= p.target {
+			p.cond.Wait()
+		}
+		if p.workers > p.target || len(p.queue) == 0 {
+			p.workers--
+			p.mu.Unlock()
+			return
+		}
+
+		task := heap.Pop(&p.queue).(*poolTask)
+		if task.timer != nil {
+			task.timer.Stop()
+		}
+
+		if err := task.ctx.Err(); err != nil {
+			p.failed++
+			p.mu.Unlock()
+			task.result <- err
+			continue
+		}
+
+		p.running++
+		p.mu.Unlock()
+
+		err := runTask(task)
+
+		p.mu.Lock()
+		p.running--
+		if err == nil {
+			p.completed++
+		} else {
+			p.failed++
+		}
+		p.mu.Unlock()
+
+		task.result <- err
+	}
+}
+
+// runTask runs the task, and converts its panic into error, so a task can't kill the worker.
+func runTask(task *poolTask) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("concurrency: task panicked: %v", r)
+		}
+	}()
+
+	return task.fn(task.ctx)
+}
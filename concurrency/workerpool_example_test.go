@@ -0,0 +1,46 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func ExampleWorkerPool() {
+	pool := NewWorkerPool(1)
+
+	// occupy the only worker, so the following tasks are queued
+	started, release := make(chan struct{}), make(chan struct{})
+	pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	for _, priority := range []int{1, 3, 2} {
+		p := priority
+		pool.Submit(context.Background(), func(ctx context.Context) error {
+			fmt.Println("priority", p)
+			return nil
+		}, WithPriority(p))
+	}
+
+	expired, _ := pool.Submit(context.Background(), func(ctx context.Context) error {
+		return nil
+	}, WithDeadline(time.Now().Add(10*time.Millisecond)))
+
+	fmt.Println(<-expired)
+
+	close(release)
+	pool.Close()
+
+	fmt.Println(pool.Metrics().Completed)
+
+	// Output:
+	// concurrency: task is not started before its deadline
+	// priority 3
+	// priority 2
+	// priority 1
+	// 4
+}
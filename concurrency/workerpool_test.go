@@ -0,0 +1,234 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// blockPool occupies all the workers of pool until the returned function is called.
+func blockPool(t *testing.T, p *WorkerPool, workers int) func() {
+	t.Helper()
+
+	started := make(chan struct{}, workers)
+	release := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		_, err := p.Submit(context.Background(), func(ctx context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}, WithPriority(1<<20))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < workers; i++ {
+		<-started
+	}
+
+	return func() { close(release) }
+}
+
+func TestWorkerPool(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWorkerPool")
+
+	p := NewWorkerPool(4)
+
+	var sum int64
+	results := make([]<-chan error, 0, 100)
+	for i := 1; i <= 100; i++ {
+		n := int64(i)
+		result, err := p.Submit(context.Background(), func(ctx context.Context) error {
+			atomic.AddInt64(&sum, n)
+			return nil
+		})
+		assert.IsNil(err)
+		results = append(results, result)
+	}
+
+	for _, result := range results {
+		assert.IsNil(<-result)
+	}
+	assert.Equal(int64(5050), atomic.LoadInt64(&sum))
+
+	p.Close()
+	metrics := p.Metrics()
+	assert.Equal(int64(100), metrics.Completed)
+	assert.Equal(0, metrics.Queued)
+	assert.Equal(0, metrics.Running)
+
+	_, err := p.Submit(context.Background(), func(ctx context.Context) error { return nil })
+	assert.Equal(ErrPoolClosed, err)
+}
+
+func TestWorkerPool_Priority(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWorkerPool_Priority")
+
+	p := NewWorkerPool(1)
+	release := blockPool(t, p, 1)
+
+	var mu sync.Mutex
+	var order []int
+	submit := func(id, priority int) {
+		_, err := p.Submit(context.Background(), func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+			return nil
+		}, WithPriority(priority))
+		assert.IsNil(err)
+	}
+
+	submit(1, 0)
+	submit(2, 5)
+	submit(3, -1)
+	submit(4, 5)
+	submit(5, 0)
+	assert.Equal(5, p.QueueLen())
+
+	release()
+	p.Close()
+
+	assert.Equal([]int{2, 4, 1, 5, 3}, order)
+}
+
+func TestWorkerPool_Deadline(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWorkerPool_Deadline")
+
+	p := NewWorkerPool(1)
+	release := blockPool(t, p, 1)
+
+	var ran int32
+	fn := func(ctx context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	}
+
+	expiring, err := p.Submit(context.Background(), fn, WithDeadline(time.Now().Add(20*time.Millisecond)))
+	assert.IsNil(err)
+	expired, err := p.Submit(context.Background(), fn, WithDeadline(time.Now().Add(-time.Second)))
+	assert.IsNil(err)
+	inTime, err := p.Submit(context.Background(), fn, WithDeadline(time.Now().Add(time.Hour)))
+	assert.IsNil(err)
+
+	assert.Equal(ErrTaskExpired, <-expired)
+
+	// the task is dropped while the worker is still busy
+	select {
+	case err := <-expiring:
+		assert.Equal(ErrTaskExpired, err)
+	case <-time.After(time.Second):
+		t.Fatal("the task is not expired in time")
+	}
+	assert.Equal(1, p.QueueLen())
+
+	release()
+	assert.IsNil(<-inTime)
+	p.Close()
+
+	assert.Equal(int32(1), atomic.LoadInt32(&ran))
+	assert.Equal(int64(2), p.Metrics().Expired)
+}
+
+func TestWorkerPool_Errors(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWorkerPool_Errors")
+
+	p := NewWorkerPool(2)
+	defer p.Close()
+
+	errTask := errors.New("task error")
+	result, _ := p.Submit(context.Background(), func(ctx context.Context) error { return errTask })
+	assert.Equal(errTask, <-result)
+
+	result, _ = p.Submit(context.Background(), func(ctx context.Context) error { panic("boom") })
+	assert.Equal("concurrency: task panicked: boom", (<-result).Error())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, _ = p.Submit(ctx, func(ctx context.Context) error { return nil })
+	assert.Equal(context.Canceled, <-result)
+
+	assert.Equal(int64(3), p.Metrics().Failed)
+
+	// the workers still work after panic
+	result, _ = p.Submit(context.Background(), func(ctx context.Context) error { return nil })
+	assert.IsNil(<-result)
+}
+
+func TestWorkerPool_Resize(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWorkerPool_Resize")
+
+	p := NewWorkerPool(1)
+	defer p.Close()
+
+	var running, maxRunning int32
+	release := make(chan struct{})
+	task := func(ctx context.Context) error {
+		cur := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&maxRunning)
+			if cur <= old || atomic.CompareAndSwapInt32(&maxRunning, old, cur) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	results := make([]<-chan error, 0, 6)
+	for i := 0; i < 6; i++ {
+		result, err := p.Submit(context.Background(), task)
+		assert.IsNil(err)
+		results = append(results, result)
+	}
+
+	p.Resize(3)
+	assert.Equal(3, p.Metrics().Workers)
+
+	deadline := time.Now().Add(time.Second)
+	for p.Metrics().Running < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(3, p.Metrics().Running)
+	assert.Equal(3, p.Metrics().Queued)
+
+	p.Resize(0)
+	assert.Equal(1, p.Metrics().Workers)
+
+	close(release)
+	for _, result := range results {
+		assert.IsNil(<-result)
+	}
+
+	assert.Equal(int32(3), atomic.LoadInt32(&maxRunning))
+	assert.Equal(int64(6), p.Metrics().Completed)
+
+	// the extra workers exit after shrinking
+	deadline = time.Now().Add(time.Second)
+	for {
+		p.mu.Lock()
+		workers := p.workers
+		p.mu.Unlock()
+		if workers == 1 || time.Now().After(deadline) {
+			assert.Equal(1, workers)
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package convertor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the layouts tried by ToTimeE in order.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	time.UnixDate,
+	time.RubyDate,
+}
+
+// ToBoolE converts value to bool. It accepts bool, numbers (non-zero is true), json.Number and strings
+// parsed by strconv.ParseBool plus "yes", "no", "y", "n", "on" and "off" (case insensitive).
+// nil is converted to false.
+func ToBoolE(value any) (bool, error) {
+	if value == nil {
+		return false, nil
+	}
+
+	switch val := value.(type) {
+	case bool:
+		return val, nil
+	case json.Number:
+		f, err := val.Float64()
+		if err != nil {
+			return false, fmt.Errorf("ToBoolE: invalid number %q", string(val))
+		}
+		return f != 0, nil
+	case string:
+		s := strings.ToLower(strings.TrimSpace(val))
+		switch s {
+		case "yes", "y", "on":
+			return true, nil
+		case "no", "n", "off":
+			return false, nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return false, fmt.Errorf("ToBoolE: unable to parse %q as bool", val)
+		}
+		return b, nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() != 0, nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float() != 0, nil
+	}
+
+	return false, fmt.Errorf("ToBoolE: unable to convert %#v of type %T to bool", value, value)
+}
+
+// ToDurationE converts value to time.Duration. Numbers, json.Number and numeric strings are nanoseconds,
+// other strings are parsed by time.ParseDuration, e.g. "1h30m".
+func ToDurationE(value any) (time.Duration, error) {
+	switch val := value.(type) {
+	case time.Duration:
+		return val, nil
+	case json.Number:
+		return parseDuration(string(val))
+	case string:
+		return parseDuration(val)
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Duration(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("ToDurationE: %d overflows time.Duration", v.Uint())
+		}
+		return time.Duration(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || f > math.MaxInt64 || f < math.MinInt64 {
+			return 0, fmt.Errorf("ToDurationE: %v overflows time.Duration", f)
+		}
+		return time.Duration(f), nil
+	}
+
+	return 0, fmt.Errorf("ToDurationE: unable to convert %#v of type %T to time.Duration", value, value)
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return ToDurationE(f)
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("ToDurationE: unable to parse %q as duration", s)
+	}
+
+	return d, nil
+}
+
+// ToTimeE converts value to time.Time. It accepts time.Time, *time.Time, numbers, json.Number and numeric
+// strings as unix seconds, and strings in the given layouts or the common layouts like RFC3339,
+// "2006-01-02 15:04:05" and "2006-01-02". Strings without time zone are parsed as UTC.
+func ToTimeE(value any, layouts ...string) (time.Time, error) {
+	switch val := value.(type) {
+	case time.Time:
+		return val, nil
+	case *time.Time:
+		if val == nil {
+			return time.Time{}, fmt.Errorf("ToTimeE: nil *time.Time")
+		}
+		return *val, nil
+	case json.Number:
+		return parseTime(string(val), layouts)
+	case string:
+		return parseTime(val, layouts)
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Unix(v.Int(), 0), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return time.Unix(int64(v.Uint()), 0), nil
+	case reflect.Float32, reflect.Float64:
+		sec, frac := math.Modf(v.Float())
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	}
+
+	return time.Time{}, fmt.Errorf("ToTimeE: unable to convert %#v of type %T to time.Time", value, value)
+}
+
+func parseTime(s string, layouts []string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(n, 0), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return ToTimeE(f)
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("ToTimeE: unable to parse %q as time", s)
+}
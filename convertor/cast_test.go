@@ -0,0 +1,93 @@
+package convertor
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestToBoolE(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestToBoolE")
+
+	cases := []any{true, false, nil, 1, 0, int64(-1), uint8(0), 0.5, 0.0,
+		json.Number("1"), json.Number("0"), "true", "FALSE", "1", " yes ", "off", "N"}
+	expected := []bool{true, false, false, true, false, true, false, true, false,
+		true, false, true, false, true, true, false, false}
+
+	for i, c := range cases {
+		actual, err := ToBoolE(c)
+		assert.IsNil(err)
+		assert.Equal(expected[i], actual)
+	}
+
+	for _, c := range []any{"abc", json.Number("x"), []int{1}, struct{}{}} {
+		_, err := ToBoolE(c)
+		assert.IsNotNil(err)
+	}
+}
+
+func TestToDurationE(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestToDurationE")
+
+	cases := []any{time.Second, 1000, int32(5), uint(7), 1.5e9, json.Number("100"), "1h30m", "250ms", "42", "1.5"}
+	expected := []time.Duration{time.Second, 1000, 5, 7, 1500 * time.Millisecond, 100,
+		90 * time.Minute, 250 * time.Millisecond, 42, 1}
+
+	for i, c := range cases {
+		actual, err := ToDurationE(c)
+		assert.IsNil(err)
+		assert.Equal(expected[i], actual)
+	}
+
+	for _, c := range []any{"abc", nil, true, uint64(1 << 63), 1e30} {
+		_, err := ToDurationE(c)
+		assert.IsNotNil(err)
+	}
+}
+
+func TestToTimeE(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestToTimeE")
+
+	expected := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []any{
+		expected,
+		&expected,
+		"2024-01-02T03:04:05Z",
+		"2024-01-02 03:04:05",
+		"2024-01-02T03:04:05",
+		"2024/01/02 03:04:05",
+		"Tue, 02 Jan 2024 03:04:05 +0000",
+		expected.Unix(),
+		json.Number("1704164645"),
+		"1704164645",
+		float64(expected.Unix()),
+	}
+	for _, c := range cases {
+		actual, err := ToTimeE(c)
+		assert.IsNil(err)
+		assert.Equal(true, expected.Equal(actual))
+	}
+
+	day, err := ToTimeE("2024-01-02")
+	assert.IsNil(err)
+	assert.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), day)
+
+	custom, err := ToTimeE("02.01.2024 03:04", "02.01.2006 15:04")
+	assert.IsNil(err)
+	assert.Equal(time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC), custom)
+
+	var nilTime *time.Time
+	for _, c := range []any{"abc", nil, true, nilTime} {
+		_, err := ToTimeE(c)
+		assert.IsNotNil(err)
+	}
+}
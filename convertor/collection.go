@@ -0,0 +1,107 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package convertor
+
+import (
+	"fmt"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// CastSlice converts every element of slice by conv. All elements are converted even if some of them fail,
+// the failed positions hold zero value of U, and the errors are returned together, each one annotated with
+// the element index.
+func CastSlice[T, U any](in []T, conv func(T) (U, error)) ([]U, error) {
+	result := make([]U, len(in))
+	var errs []error
+
+	for i, v := range in {
+		u, err := conv(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, err))
+			continue
+		}
+		result[i] = u
+	}
+
+	return result, internal.JoinError(errs...)
+}
+
+// ToAnySlice converts a typed slice to []any.
+func ToAnySlice[T any](in []T) []any {
+	result := make([]any, len(in))
+	for i, v := range in {
+		result[i] = v
+	}
+
+	return result
+}
+
+// FromAnySlice converts []any to a typed slice, e.g. the array decoded from json.
+// Elements which are not T hold zero value, and the errors are returned together.
+func FromAnySlice[T any](in []any) ([]T, error) {
+	return CastSlice(in, assertType[T])
+}
+
+// CastMapKeys converts every key of map by conv. The errors are returned together, each one annotated
+// with the key, and the failed entries are dropped.
+func CastMapKeys[K1, K2 comparable, V any](m map[K1]V, conv func(K1) (K2, error)) (map[K2]V, error) {
+	result := make(map[K2]V, len(m))
+	var errs []error
+
+	for k, v := range m {
+		key, err := conv(k)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("key %v: %w", k, err))
+			continue
+		}
+		result[key] = v
+	}
+
+	return result, internal.JoinError(errs...)
+}
+
+// CastMapValues converts every value of map by conv. The errors are returned together, each one annotated
+// with the key, and the failed entries are dropped.
+func CastMapValues[K comparable, V1, V2 any](m map[K]V1, conv func(V1) (V2, error)) (map[K]V2, error) {
+	result := make(map[K]V2, len(m))
+	var errs []error
+
+	for k, v := range m {
+		value, err := conv(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("key %v: %w", k, err))
+			continue
+		}
+		result[k] = value
+	}
+
+	return result, internal.JoinError(errs...)
+}
+
+// ToAnyMap converts the values of map to any.
+func ToAnyMap[K comparable, V any](m map[K]V) map[K]any {
+	result := make(map[K]any, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+
+	return result
+}
+
+// FromAnyMap converts map[K]any to a typed map, e.g. the object decoded from json.
+// Entries whose value is not V are dropped, and the errors are returned together.
+func FromAnyMap[K comparable, V any](m map[K]any) (map[K]V, error) {
+	return CastMapValues(m, assertType[V])
+}
+
+func assertType[T any](v any) (T, error) {
+	t, ok := v.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("expected %T, got %T", zero, v)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,84 @@
+package convertor
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestCastSlice(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCastSlice")
+
+	result, err := CastSlice([]string{"1", "2", "3"}, strconv.Atoi)
+	assert.IsNil(err)
+	assert.Equal([]int{1, 2, 3}, result)
+
+	result, err = CastSlice([]string{"1", "a", "3", "b"}, strconv.Atoi)
+	assert.IsNotNil(err)
+	assert.Equal([]int{1, 0, 3, 0}, result)
+	assert.Equal(true, strings.Contains(err.Error(), "index 1:"))
+	assert.Equal(true, strings.Contains(err.Error(), "index 3:"))
+
+	var numErr *strconv.NumError
+	assert.Equal(true, errors.As(err.(interface{ Unwrap() []error }).Unwrap()[0], &numErr))
+
+	empty, err := CastSlice([]string{}, strconv.Atoi)
+	assert.IsNil(err)
+	assert.Equal([]int{}, empty)
+}
+
+func TestAnySlice(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAnySlice")
+
+	assert.Equal([]any{1, 2, 3}, ToAnySlice([]int{1, 2, 3}))
+
+	var decoded []any
+	assert.IsNil(json.Unmarshal([]byte(`["a", "b"]`), &decoded))
+
+	strs, err := FromAnySlice[string](decoded)
+	assert.IsNil(err)
+	assert.Equal([]string{"a", "b"}, strs)
+
+	strs, err = FromAnySlice[string]([]any{"a", 1})
+	assert.Equal([]string{"a", ""}, strs)
+	assert.Equal("index 1: expected string, got int", err.Error())
+}
+
+func TestCastMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCastMap")
+
+	keys, err := CastMapKeys(map[string]bool{"1": true, "2": false}, strconv.Atoi)
+	assert.IsNil(err)
+	assert.Equal(map[int]bool{1: true, 2: false}, keys)
+
+	keys, err = CastMapKeys(map[string]bool{"1": true, "x": false}, strconv.Atoi)
+	assert.Equal(map[int]bool{1: true}, keys)
+	assert.Equal(true, strings.HasPrefix(err.Error(), "key x:"))
+
+	values, err := CastMapValues(map[string]string{"a": "1", "b": "2"}, strconv.Atoi)
+	assert.IsNil(err)
+	assert.Equal(map[string]int{"a": 1, "b": 2}, values)
+
+	values, err = CastMapValues(map[string]string{"a": "1", "b": "y"}, strconv.Atoi)
+	assert.Equal(map[string]int{"a": 1}, values)
+	assert.Equal(true, strings.HasPrefix(err.Error(), "key b:"))
+
+	assert.Equal(map[string]any{"a": 1}, ToAnyMap(map[string]int{"a": 1}))
+
+	var decoded map[string]any
+	assert.IsNil(json.Unmarshal([]byte(`{"a": 1, "b": 2.5, "c": "x"}`), &decoded))
+
+	floats, err := FromAnyMap[string, float64](decoded)
+	assert.Equal(map[string]float64{"a": 1, "b": 2.5}, floats)
+	assert.Equal("key c: expected float64, got string", err.Error())
+}
@@ -99,56 +99,19 @@ func ToChannel[T any](array []T) <-chan T {
 	return ch
 }
 
-// ToString convert value to string
-// for number, string, []byte, will convert to string
-// for other type (slice, map, array, struct) will call json.Marshal.
+// ToString convert value to string by the default Stringifier, use NewStringifier for custom formatting.
+// for number, bool, string (including named string types), []byte, will convert to string.
+// for time.Time, will format it with time.RFC3339Nano, without the quotes of json.
+// for error and fmt.Stringer (e.g. time.Duration), will call Error and String.
+// for nil, nil pointer, nil slice and nil map, will return empty string, a pointer is converted by the value it points to.
+// for other type (slice, map, array, struct) will call json.Marshal, and fmt %+v if json.Marshal fails.
+// Breaking change: earlier versions called json.Marshal for every type other than number, string and []byte,
+// so the result differs for fmt.Stringer and error (time.Second was "1000000000", an error was "{}"),
+// named string types and string pointers (were quoted), time.Time (was quoted),
+// and nil slice, nil map and nil pointer (were "null").
 // Play: https://go.dev/play/p/nF1zOOslpQq
 func ToString(value any) string {
-	if value == nil {
-		return ""
-	}
-
-	switch val := value.(type) {
-	case float32:
-		return strconv.FormatFloat(float64(val), 'f', -1, 32)
-	case float64:
-		return strconv.FormatFloat(val, 'f', -1, 64)
-	case int:
-		return strconv.FormatInt(int64(val), 10)
-	case int8:
-		return strconv.FormatInt(int64(val), 10)
-	case int16:
-		return strconv.FormatInt(int64(val), 10)
-	case int32:
-		return strconv.FormatInt(int64(val), 10)
-	case int64:
-		return strconv.FormatInt(val, 10)
-	case uint:
-		return strconv.FormatUint(uint64(val), 10)
-	case uint8:
-		return strconv.FormatUint(uint64(val), 10)
-	case uint16:
-		return strconv.FormatUint(uint64(val), 10)
-	case uint32:
-		return strconv.FormatUint(uint64(val), 10)
-	case uint64:
-		return strconv.FormatUint(val, 10)
-	case string:
-		return val
-	case []byte:
-		return string(val)
-	default:
-		b, err := json.Marshal(val)
-		if err != nil {
-			return ""
-		}
-		return string(b)
-
-		// todo: maybe we should't supprt other type conversion
-		// v := reflect.ValueOf(value)
-		// log.Panicf("Unsupported data type: %s ", v.String())
-		// return ""
-	}
+	return defaultStringifier.ToString(value)
 }
 
 // ToJson convert value to a json string.
@@ -571,3 +571,90 @@ func ExampleToRawUrlBase64() {
 	// dHJ1ZQ
 	// ZXJy
 }
+
+func ExampleNewStringifier() {
+	type celsius float64
+
+	s := NewStringifier(
+		WithFloatPrecision(2),
+		WithNilValue("null"),
+		WithFormatter(func(c celsius) string {
+			return fmt.Sprintf("%.1f°C", float64(c))
+		}),
+	)
+
+	result1 := s.ToString(3.14159)
+	result2 := s.ToString(nil)
+	result3 := s.ToString(celsius(36.6))
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(result3)
+
+	// Output:
+	// 3.14
+	// null
+	// 36.6°C
+}
+
+func ExampleCastSlice() {
+	result1, err1 := CastSlice([]string{"1", "2", "3"}, strconv.Atoi)
+	result2, err2 := CastSlice([]string{"1", "a"}, strconv.Atoi)
+
+	fmt.Println(result1, err1)
+	fmt.Println(result2, err2)
+
+	// Output:
+	// [1 2 3] <nil>
+	// [1 0] index 1: strconv.Atoi: parsing "a": invalid syntax
+}
+
+func ExampleFromAnySlice() {
+	result, err := FromAnySlice[string]([]any{"a", "b", 1})
+
+	fmt.Println(result, len(result))
+	fmt.Println(err)
+
+	// Output:
+	// [a b ] 3
+	// index 2: expected string, got int
+}
+
+func ExampleToBoolE() {
+	result1, _ := ToBoolE("yes")
+	result2, _ := ToBoolE(0)
+	_, err := ToBoolE("abc")
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(err)
+
+	// Output:
+	// true
+	// false
+	// ToBoolE: unable to parse "abc" as bool
+}
+
+func ExampleToDurationE() {
+	result1, _ := ToDurationE("1h30m")
+	result2, _ := ToDurationE(1000000)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// 1h30m0s
+	// 1ms
+}
+
+func ExampleToTimeE() {
+	result1, _ := ToTimeE("2024-01-02 03:04:05")
+	result2, _ := ToTimeE("02.01.2024", "02.01.2006")
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// 2024-01-02 03:04:05 +0000 UTC
+	// 2024-01-02 00:00:00 +0000 UTC
+}
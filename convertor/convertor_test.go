@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 	"unicode/utf8"
 	"unsafe"
 
@@ -163,6 +164,28 @@ func TestToString(t *testing.T) {
 		assert.Equal(expected[i], actual)
 	}
 }
+
+func TestToStringNotJson(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestToStringNotJson")
+
+	type name string
+	var nilSlice []int
+	var nilMap map[string]int
+	var nilPtr *int
+	str := "abc"
+
+	assert.Equal("1s", ToString(time.Second))
+	assert.Equal("a", ToString(name("a")))
+	assert.Equal("2024-01-02T03:04:05Z", ToString(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	assert.Equal("error message", ToString(errors.New("error message")))
+	assert.Equal("", ToString(nilSlice))
+	assert.Equal("", ToString(nilMap))
+	assert.Equal("", ToString(nilPtr))
+	assert.Equal("abc", ToString(&str))
+}
+
 func TestToJson(t *testing.T) {
 	t.Parallel()
 
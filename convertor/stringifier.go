@@ -0,0 +1,156 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package convertor
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// StructFormat is how Stringifier renders struct values.
+type StructFormat int
+
+const (
+	// StructAsJson renders struct as json, it's the default format.
+	StructAsJson StructFormat = iota
+	// StructAsVerbose renders struct with fmt %+v, e.g. {Name:abc Age:1}.
+	StructAsVerbose
+)
+
+// Stringifier converts values of any type to string with configurable formatting.
+// It's safe for concurrent use once created.
+type Stringifier struct {
+	floatPrecision int
+	timeLayout     string
+	nilValue       string
+	structFormat   StructFormat
+	formatters     map[reflect.Type]func(any) string
+}
+
+// StringifierOption is option of NewStringifier.
+type StringifierOption func(*Stringifier)
+
+var defaultStringifier = NewStringifier()
+
+// NewStringifier creates a Stringifier. Default is the smallest float precision which represents
+// the value exactly, time.RFC3339Nano time layout, empty string for nil and json for struct.
+func NewStringifier(opts ...StringifierOption) *Stringifier {
+	s := &Stringifier{
+		floatPrecision: -1,
+		timeLayout:     time.RFC3339Nano,
+		structFormat:   StructAsJson,
+		formatters:     map[reflect.Type]func(any) string{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithFloatPrecision sets the number of digits after the decimal point of float, -1 means
+// the smallest number of digits necessary to represent the value exactly.
+func WithFloatPrecision(precision int) StringifierOption {
+	return func(s *Stringifier) {
+		s.floatPrecision = precision
+	}
+}
+
+// WithTimeLayout sets the layout of time.Time.
+func WithTimeLayout(layout string) StringifierOption {
+	return func(s *Stringifier) {
+		s.timeLayout = layout
+	}
+}
+
+// WithNilValue sets the result of nil, including nil pointer, map, slice, channel and func.
+func WithNilValue(nilValue string) StringifierOption {
+	return func(s *Stringifier) {
+		s.nilValue = nilValue
+	}
+}
+
+// WithStructFormat sets how struct is rendered.
+func WithStructFormat(format StructFormat) StringifierOption {
+	return func(s *Stringifier) {
+		s.structFormat = format
+	}
+}
+
+// WithFormatter registers the formatter of type T, it takes precedence over the builtin rules.
+// Values inside slice, map and json rendered struct are not affected.
+func WithFormatter[T any](formatter func(T) string) StringifierOption {
+	return func(s *Stringifier) {
+		s.formatters[reflect.TypeOf((*T)(nil)).Elem()] = func(v any) string {
+			return formatter(v.(T))
+		}
+	}
+}
+
+// ToString converts value to string.
+// The rules in order: registered formatter, nil, time.Time, []byte, error, fmt.Stringer, pointer
+// (converts the value it points to), basic kinds, struct, and json for the others like slice and map.
+func (s *Stringifier) ToString(value any) string {
+	if value == nil {
+		return s.nilValue
+	}
+
+	if formatter, ok := s.formatters[reflect.TypeOf(value)]; ok {
+		return formatter(value)
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		if v.IsNil() {
+			return s.nilValue
+		}
+	}
+
+	switch val := value.(type) {
+	case time.Time:
+		return val.Format(s.timeLayout)
+	case []byte:
+		return string(val)
+	case error:
+		return val.Error()
+	case fmt.Stringer:
+		return val.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		return s.ToString(v.Elem().Interface())
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', s.floatPrecision, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', s.floatPrecision, 64)
+	case reflect.Complex64:
+		return strconv.FormatComplex(v.Complex(), 'f', s.floatPrecision, 64)
+	case reflect.Complex128:
+		return strconv.FormatComplex(v.Complex(), 'f', s.floatPrecision, 128)
+	case reflect.Struct:
+		if s.structFormat == StructAsVerbose {
+			return fmt.Sprintf("%+v", value)
+		}
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%+v", value)
+	}
+
+	return string(b)
+}
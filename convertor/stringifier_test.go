@@ -0,0 +1,68 @@
+package convertor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type testCelsius float64
+
+type testPoint struct {
+	X, Y int
+}
+
+func (p *testPoint) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+func TestStringifier(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStringifier")
+
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	var nilPtr *int
+	var nilMap map[string]int
+	num := 10
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	s := NewStringifier()
+	assert.Equal("", s.ToString(nil))
+	assert.Equal("", s.ToString(nilPtr))
+	assert.Equal("", s.ToString(nilMap))
+	assert.Equal("10", s.ToString(&num))
+	assert.Equal("2024-01-02T03:04:05Z", s.ToString(tm))
+	assert.Equal("1m30s", s.ToString(90*time.Second))
+	assert.Equal("failed", s.ToString(errors.New("failed")))
+	assert.Equal("(1,2)", s.ToString(&testPoint{1, 2}))
+	assert.Equal("36.6", s.ToString(testCelsius(36.6)))
+	assert.Equal("(1.5+2i)", s.ToString(complex(1.5, 2)))
+	assert.Equal(`{"Name":"abc","Age":1}`, s.ToString(user{Name: "abc", Age: 1}))
+	assert.Equal(`{"Name":"abc","Age":1}`, s.ToString(&user{Name: "abc", Age: 1}))
+
+	s = NewStringifier(
+		WithFloatPrecision(2),
+		WithTimeLayout("2006-01-02"),
+		WithNilValue("<nil>"),
+		WithStructFormat(StructAsVerbose),
+		WithFormatter(func(c testCelsius) string {
+			return fmt.Sprintf("%.1f°C", float64(c))
+		}),
+	)
+	assert.Equal("<nil>", s.ToString(nil))
+	assert.Equal("<nil>", s.ToString(nilPtr))
+	assert.Equal("3.14", s.ToString(3.14159))
+	assert.Equal("1.00", s.ToString(float32(1)))
+	assert.Equal("2024-01-02", s.ToString(tm))
+	assert.Equal("36.6°C", s.ToString(testCelsius(36.6)))
+	assert.Equal("{Name:abc Age:1}", s.ToString(user{Name: "abc", Age: 1}))
+	assert.Equal("[1,2]", s.ToString([]int{1, 2}))
+}
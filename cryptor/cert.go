@@ -0,0 +1,350 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package cryptor
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+)
+
+// KeyType is the type of private key generated for certificate.
+type KeyType int
+
+const (
+	// KeyTypeECDSA is the ECDSA P-256 key, it's the default key type.
+	KeyTypeECDSA KeyType = iota
+	// KeyTypeRSA is the RSA key, default size is 2048 bits.
+	KeyTypeRSA
+	// KeyTypeEd25519 is the Ed25519 key.
+	KeyTypeEd25519
+)
+
+// CertOptions is the options to generate certificate and certificate request.
+type CertOptions struct {
+	CommonName   string
+	Organization []string
+	DNSNames     []string
+	IPAddresses  []net.IP
+	Emails       []string
+	// ValidFor is the validity duration of certificate from now, default is 1 year.
+	ValidFor time.Duration
+	// IsCA makes the certificate be able to sign other certificates.
+	IsCA    bool
+	KeyType KeyType
+	// RSABits is the size of RSA key, default is 2048.
+	RSABits int
+}
+
+// CertificateInfo is the summary of a certificate.
+type CertificateInfo struct {
+	Subject      string
+	Issuer       string
+	SerialNumber string
+	NotBefore    time.Time
+	NotAfter     time.Time
+	DNSNames     []string
+	IPAddresses  []string
+	Emails       []string
+	IsCA         bool
+	// IsSelfSigned reports whether the issuer is the subject itself.
+	IsSelfSigned bool
+}
+
+// ParseCertificatePEM parses the first certificate in PEM data.
+func ParseCertificatePEM(data []byte) (*x509.Certificate, error) {
+	certs, err := ParseCertificatesPEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return certs[0], nil
+}
+
+// ParseCertificatesPEM parses all certificates in PEM data, e.g. a certificate chain file.
+func ParseCertificatesPEM(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found in pem data")
+	}
+
+	return certs, nil
+}
+
+// ReadCertificateFile reads and parses all certificates in PEM file.
+func ReadCertificateFile(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCertificatesPEM(data)
+}
+
+// ParsePrivateKeyPEM parses the private key in PEM data, PKCS#1, PKCS#8 and SEC 1 (EC) formats are supported.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+
+	// return nil explicitly on error, the typed nil pointer is a non-nil crypto.Signer
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported pem block type %q", block.Type)
+	}
+}
+
+// ParsePublicKeyPEM parses the public key in PEM data, PKIX and PKCS#1 formats are supported.
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported pem block type %q", block.Type)
+	}
+}
+
+// GetCertificateInfo returns the summary of certificate.
+func GetCertificateInfo(cert *x509.Certificate) CertificateInfo {
+	ips := make([]string, len(cert.IPAddresses))
+	for i, ip := range cert.IPAddresses {
+		ips[i] = ip.String()
+	}
+
+	return CertificateInfo{
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		NotBefore:    cert.NotBefore,
+		NotAfter:     cert.NotAfter,
+		DNSNames:     cert.DNSNames,
+		IPAddresses:  ips,
+		Emails:       cert.EmailAddresses,
+		IsCA:         cert.IsCA,
+		IsSelfSigned: bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+			cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil,
+	}
+}
+
+// IsCertificateExpired checks if the certificate is expired or not valid yet.
+func IsCertificateExpired(cert *x509.Certificate) bool {
+	now := time.Now()
+	return now.After(cert.NotAfter) || now.Before(cert.NotBefore)
+}
+
+// CertificateExpiresWithin checks if the certificate expires within the duration d from now.
+func CertificateExpiresWithin(cert *x509.Certificate, d time.Duration) bool {
+	return time.Now().Add(d).After(cert.NotAfter)
+}
+
+// VerifyCertificate verifies the certificate chain against roots. If dnsName is not empty, it's checked
+// with the certificate too. It returns the verified chains.
+func VerifyCertificate(cert *x509.Certificate, roots *x509.CertPool, intermediates []*x509.Certificate,
+	dnsName string) ([][]*x509.Certificate, error) {
+	pool := x509.NewCertPool()
+	for _, c := range intermediates {
+		pool.AddCert(c)
+	}
+
+	return cert.Verify(x509.VerifyOptions{
+		DNSName:       dnsName,
+		Roots:         roots,
+		Intermediates: pool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+}
+
+// GenerateSelfSignedCert generates a self signed certificate and its private key, both in PEM format.
+func GenerateSelfSignedCert(opts CertOptions) (certPEM []byte, keyPEM []byte, err error) {
+	return GenerateCert(opts, nil, nil)
+}
+
+// GenerateCert generates a certificate signed by parent certificate and parentKey, and its private key,
+// both in PEM format. The certificate is self signed if parent is nil.
+func GenerateCert(opts CertOptions, parent *x509.Certificate, parentKey crypto.Signer) (certPEM []byte,
+	keyPEM []byte, err error) {
+	key, err := generateSigner(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validFor := opts.ValidFor
+	if validFor <= 0 {
+		validFor = 365 * 24 * time.Hour
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:   serial,
+		Subject:        pkix.Name{CommonName: opts.CommonName, Organization: opts.Organization},
+		DNSNames:       opts.DNSNames,
+		IPAddresses:    opts.IPAddresses,
+		EmailAddresses: opts.Emails,
+		NotBefore:      now.Add(-time.Minute),
+		NotAfter:       now.Add(validFor),
+		KeyUsage:       x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+
+		BasicConstraintsValid: true,
+		IsCA:                  opts.IsCA,
+	}
+	if opts.IsCA {
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	if parentKey == nil {
+		return nil, nil, errors.New("parent key is required to sign certificate")
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err = EncodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM, nil
+}
+
+// GenerateCSR generates a certificate signing request signed by key in PEM format,
+// the subject and SANs are taken from opts.
+func GenerateCSR(opts CertOptions, key crypto.Signer) ([]byte, error) {
+	template := &x509.CertificateRequest{
+		Subject:        pkix.Name{CommonName: opts.CommonName, Organization: opts.Organization},
+		DNSNames:       opts.DNSNames,
+		IPAddresses:    opts.IPAddresses,
+		EmailAddresses: opts.Emails,
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// ParseCSRPEM parses the certificate signing request in PEM data and checks its signature.
+func ParseCSRPEM(data []byte) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, errors.New("no certificate request found in pem data")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, err
+	}
+
+	return csr, nil
+}
+
+// GeneratePrivateKey generates a private key of key type, bits is only used for RSA key.
+func GeneratePrivateKey(keyType KeyType, bits int) (crypto.Signer, error) {
+	return generateSigner(CertOptions{KeyType: keyType, RSABits: bits})
+}
+
+// EncodePrivateKeyPEM encodes the private key in PKCS#8 PEM format.
+func EncodePrivateKeyPEM(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func generateSigner(opts CertOptions) (crypto.Signer, error) {
+	switch opts.KeyType {
+	case KeyTypeECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyTypeRSA:
+		bits := opts.RSABits
+		if bits <= 0 {
+			bits = 2048
+		}
+		return rsa.GenerateKey(rand.Reader, bits)
+	case KeyTypeEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key type %d", opts.KeyType)
+	}
+}
@@ -0,0 +1,161 @@
+package cryptor
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestGenerateSelfSignedCert(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenerateSelfSignedCert")
+
+	opts := CertOptions{
+		CommonName:   "lancet.test",
+		Organization: []string{"lancet"},
+		DNSNames:     []string{"lancet.test", "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ValidFor:     time.Hour,
+	}
+
+	certPEM, keyPEM, err := GenerateSelfSignedCert(opts)
+	assert.IsNil(err)
+
+	cert, err := ParseCertificatePEM(certPEM)
+	assert.IsNil(err)
+
+	info := GetCertificateInfo(cert)
+	assert.Equal("CN=lancet.test,O=lancet", info.Subject)
+	assert.Equal(info.Subject, info.Issuer)
+	assert.Equal([]string{"lancet.test", "localhost"}, info.DNSNames)
+	assert.Equal([]string{"127.0.0.1"}, info.IPAddresses)
+	assert.Equal(true, info.IsSelfSigned)
+	assert.Equal(false, info.IsCA)
+
+	assert.Equal(false, IsCertificateExpired(cert))
+	assert.Equal(false, CertificateExpiresWithin(cert, 30*time.Minute))
+	assert.Equal(true, CertificateExpiresWithin(cert, 2*time.Hour))
+
+	key, err := ParsePrivateKeyPEM(keyPEM)
+	assert.IsNil(err)
+	_, ok := key.(*ecdsa.PrivateKey)
+	assert.Equal(true, ok)
+
+	for _, keyType := range []KeyType{KeyTypeRSA, KeyTypeEd25519} {
+		_, keyPEM, err := GenerateSelfSignedCert(CertOptions{CommonName: "a", KeyType: keyType, RSABits: 1024})
+		assert.IsNil(err)
+
+		key, err := ParsePrivateKeyPEM(keyPEM)
+		assert.IsNil(err)
+
+		switch keyType {
+		case KeyTypeRSA:
+			_, ok = key.(*rsa.PrivateKey)
+		case KeyTypeEd25519:
+			_, ok = key.(ed25519.PrivateKey)
+		}
+		assert.Equal(true, ok)
+	}
+
+	_, err = ParseCertificatePEM([]byte("invalid"))
+	assert.IsNotNil(err)
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestVerifyCertificate")
+
+	caPEM, caKeyPEM, err := GenerateSelfSignedCert(CertOptions{CommonName: "lancet ca", IsCA: true})
+	assert.IsNil(err)
+
+	ca, _ := ParseCertificatePEM(caPEM)
+	caKey, _ := ParsePrivateKeyPEM(caKeyPEM)
+
+	certPEM, _, err := GenerateCert(CertOptions{CommonName: "server", DNSNames: []string{"lancet.test"}}, ca, caKey)
+	assert.IsNil(err)
+
+	// certificate chain file
+	certs, err := ParseCertificatesPEM(append(certPEM, caPEM...))
+	assert.IsNil(err)
+	assert.Equal(2, len(certs))
+
+	cert := certs[0]
+	assert.Equal(false, GetCertificateInfo(cert).IsSelfSigned)
+	assert.Equal("CN=lancet ca", GetCertificateInfo(cert).Issuer)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	chains, err := VerifyCertificate(cert, roots, nil, "lancet.test")
+	assert.IsNil(err)
+	assert.Equal(2, len(chains[0]))
+
+	_, err = VerifyCertificate(cert, roots, nil, "other.test")
+	assert.IsNotNil(err)
+
+	_, err = VerifyCertificate(cert, x509.NewCertPool(), nil, "")
+	assert.IsNotNil(err)
+}
+
+func TestGenerateCSR(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGenerateCSR")
+
+	key, err := GeneratePrivateKey(KeyTypeECDSA, 0)
+	assert.IsNil(err)
+
+	csrPEM, err := GenerateCSR(CertOptions{CommonName: "lancet.test", DNSNames: []string{"lancet.test"}}, key)
+	assert.IsNil(err)
+
+	csr, err := ParseCSRPEM(csrPEM)
+	assert.IsNil(err)
+	assert.Equal("lancet.test", csr.Subject.CommonName)
+	assert.Equal([]string{"lancet.test"}, csr.DNSNames)
+
+	keyPEM, err := EncodePrivateKeyPEM(key)
+	assert.IsNil(err)
+	parsed, err := ParsePrivateKeyPEM(keyPEM)
+	assert.IsNil(err)
+	assert.Equal(true, parsed.(*ecdsa.PrivateKey).Equal(key))
+}
+
+func TestParsePrivateKeyPEMInvalid(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestParsePrivateKeyPEMInvalid")
+
+	for _, typ := range []string{"RSA PRIVATE KEY", "EC PRIVATE KEY", "PRIVATE KEY"} {
+		data := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: []byte("invalid")})
+
+		key, err := ParsePrivateKeyPEM(data)
+		assert.IsNotNil(err)
+		// not a typed nil pointer
+		assert.Equal(true, key == nil)
+	}
+}
+
+func TestParsePublicKeyPEM(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestParsePublicKeyPEM")
+
+	pub, err := ParsePublicKeyPEM([]byte(`-----BEGIN PUBLIC KEY-----
+MCowBQYDK2VwAyEAGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE=
+-----END PUBLIC KEY-----`))
+	assert.IsNil(err)
+	_, ok := pub.(ed25519.PublicKey)
+	assert.Equal(true, ok)
+
+	_, err = ParsePublicKeyPEM([]byte("invalid"))
+	assert.IsNotNil(err)
+}
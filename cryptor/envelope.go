@@ -0,0 +1,298 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package cryptor
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	envelopeMagic   = "LENV"
+	envelopeVersion = 1
+	dataKeySize     = 32
+	// magic(4) + version(1) + wrapped key length(2)
+	envelopeHeaderSize = 7
+)
+
+// ErrInvalidEnvelope is returned when the envelope blob is malformed or fails authentication.
+var ErrInvalidEnvelope = errors.New("invalid envelope")
+
+// KeyWrapper encrypts and decrypts the data key of envelope with a key encryption key (KEK).
+type KeyWrapper interface {
+	// WrapKey encrypts the data key.
+	WrapKey(dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts the wrapped data key.
+	UnwrapKey(wrapped []byte) ([]byte, error)
+}
+
+// Envelope implements envelope encryption: every payload is encrypted with a new random data key
+// by AES-256-GCM, and the data key is encrypted by the KeyWrapper. The result is a single blob
+// which contains a versioned header, the wrapped data key, the nonce and the ciphertext.
+type Envelope struct {
+	kek KeyWrapper
+}
+
+// NewEnvelope creates an Envelope which wraps data keys with kek.
+func NewEnvelope(kek KeyWrapper) *Envelope {
+	return &Envelope{kek: kek}
+}
+
+// Seal encrypts plaintext and returns the envelope blob, additionalData is authenticated but not
+// encrypted, the same additionalData should be passed to Open.
+func (e *Envelope) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	// pass a copy, the data key is still used after wrapping
+	wrapped, err := e.kek.WrapKey(append([]byte(nil), dataKey...))
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) > 0xffff {
+		return nil, errors.New("wrapped data key is too long")
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, envelopeHeaderSize, envelopeHeaderSize+len(wrapped))
+	copy(header, envelopeMagic)
+	header[4] = envelopeVersion
+	binary.BigEndian.PutUint16(header[5:], uint16(len(wrapped)))
+	header = append(header, wrapped...)
+
+	blob := make([]byte, 0, len(header)+len(nonce)+len(plaintext)+gcm.Overhead())
+	blob = append(blob, header...)
+	blob = append(blob, nonce...)
+
+	return gcm.Seal(blob, nonce, plaintext, envelopeAAD(header, additionalData)), nil
+}
+
+// Open decrypts the envelope blob created by Seal.
+func (e *Envelope) Open(blob, additionalData []byte) ([]byte, error) {
+	if len(blob) < envelopeHeaderSize || string(blob[:4]) != envelopeMagic {
+		return nil, ErrInvalidEnvelope
+	}
+	if blob[4] != envelopeVersion {
+		return nil, errors.New("unsupported envelope version")
+	}
+
+	wrappedLen := int(binary.BigEndian.Uint16(blob[5:]))
+	headerLen := envelopeHeaderSize + wrappedLen
+	if len(blob) < headerLen {
+		return nil, ErrInvalidEnvelope
+	}
+	header := blob[:headerLen]
+
+	// pass a copy, the header is authenticated so it should not be modified by the key wrapper
+	wrapped := append([]byte(nil), blob[envelopeHeaderSize:headerLen]...)
+	dataKey, err := e.kek.UnwrapKey(wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := blob[headerLen:]
+	if len(rest) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, ErrInvalidEnvelope
+	}
+	nonce, ciphertext := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, envelopeAAD(header, additionalData))
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+
+	return plaintext, nil
+}
+
+// envelopeAAD binds the header and wrapped key to ciphertext, so they can't be replaced.
+func envelopeAAD(header, additionalData []byte) []byte {
+	aad := make([]byte, 0, len(header)+len(additionalData))
+	aad = append(aad, header...)
+
+	return append(aad, additionalData...)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+type rsaKeyWrapper struct {
+	publicKey  *rsa.PublicKey
+	privateKey *rsa.PrivateKey
+}
+
+// NewRsaKeyWrapper creates a KeyWrapper which wraps data keys with RSA-OAEP (SHA-256).
+// The private key is used to unwrap, so it could be nil if only Seal is needed.
+func NewRsaKeyWrapper(publicKey *rsa.PublicKey, privateKey *rsa.PrivateKey) KeyWrapper {
+	if publicKey == nil && privateKey != nil {
+		publicKey = &privateKey.PublicKey
+	}
+
+	return &rsaKeyWrapper{publicKey: publicKey, privateKey: privateKey}
+}
+
+func (w *rsaKeyWrapper) WrapKey(dataKey []byte) ([]byte, error) {
+	if w.publicKey == nil {
+		return nil, errors.New("rsa public key is required to wrap key")
+	}
+
+	return rsa.EncryptOAEP(sha256.New(), rand.Reader, w.publicKey, dataKey, []byte(envelopeMagic))
+}
+
+func (w *rsaKeyWrapper) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if w.privateKey == nil {
+		return nil, errors.New("rsa private key is required to unwrap key")
+	}
+
+	return rsa.DecryptOAEP(sha256.New(), rand.Reader, w.privateKey, wrapped, []byte(envelopeMagic))
+}
+
+type ecKeyWrapper struct {
+	publicKey  *ecdsa.PublicKey
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewEcKeyWrapper creates a KeyWrapper which wraps data keys with ECIES: an ephemeral ECDH key
+// agreement on the curve of key, SHA-256 key derivation and AES-256-GCM.
+// The private key is used to unwrap, so it could be nil if only Seal is needed.
+func NewEcKeyWrapper(publicKey *ecdsa.PublicKey, privateKey *ecdsa.PrivateKey) KeyWrapper {
+	if publicKey == nil && privateKey != nil {
+		publicKey = &privateKey.PublicKey
+	}
+
+	return &ecKeyWrapper{publicKey: publicKey, privateKey: privateKey}
+}
+
+// WrapKey returns ephemeral public key | nonce | encrypted data key.
+func (w *ecKeyWrapper) WrapKey(dataKey []byte) ([]byte, error) {
+	if w.publicKey == nil {
+		return nil, errors.New("ec public key is required to wrap key")
+	}
+
+	curve := w.publicKey.Curve
+	ephemeral, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	ephemeralPub := elliptic.Marshal(curve, ephemeral.X, ephemeral.Y)
+
+	x, _ := curve.ScalarMult(w.publicKey.X, w.publicKey.Y, ephemeral.D.Bytes())
+	gcm, err := newGCM(deriveEcKey(curve, x.Bytes(), ephemeralPub))
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	wrapped := append(ephemeralPub, nonce...)
+
+	return gcm.Seal(wrapped, nonce, dataKey, nil), nil
+}
+
+func (w *ecKeyWrapper) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if w.privateKey == nil {
+		return nil, errors.New("ec private key is required to unwrap key")
+	}
+
+	curve := w.privateKey.Curve
+	pubLen := 1 + 2*((curve.Params().BitSize+7)/8)
+	if len(wrapped) < pubLen {
+		return nil, ErrInvalidEnvelope
+	}
+
+	ex, ey := elliptic.Unmarshal(curve, wrapped[:pubLen])
+	if ex == nil {
+		return nil, ErrInvalidEnvelope
+	}
+
+	x, _ := curve.ScalarMult(ex, ey, w.privateKey.D.Bytes())
+	gcm, err := newGCM(deriveEcKey(curve, x.Bytes(), wrapped[:pubLen]))
+	if err != nil {
+		return nil, err
+	}
+
+	rest := wrapped[pubLen:]
+	if len(rest) < gcm.NonceSize() {
+		return nil, ErrInvalidEnvelope
+	}
+
+	dataKey, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+
+	return dataKey, nil
+}
+
+// deriveEcKey derives the AES key from the shared secret and the ephemeral public key.
+func deriveEcKey(curve elliptic.Curve, shared, ephemeralPub []byte) []byte {
+	size := (curve.Params().BitSize + 7) / 8
+	secret := make([]byte, size)
+	copy(secret[size-len(shared):], shared)
+
+	h := sha256.New()
+	h.Write(secret)
+	h.Write(ephemeralPub)
+
+	return h.Sum(nil)
+}
+
+type funcKeyWrapper struct {
+	wrap   func(dataKey []byte) ([]byte, error)
+	unwrap func(wrapped []byte) ([]byte, error)
+}
+
+// NewFuncKeyWrapper creates a KeyWrapper from callbacks, e.g. the encrypt and decrypt api of external KMS.
+func NewFuncKeyWrapper(wrap func(dataKey []byte) ([]byte, error),
+	unwrap func(wrapped []byte) ([]byte, error)) KeyWrapper {
+	return &funcKeyWrapper{wrap: wrap, unwrap: unwrap}
+}
+
+func (w *funcKeyWrapper) WrapKey(dataKey []byte) ([]byte, error) {
+	if w.wrap == nil {
+		return nil, errors.New("wrap function is nil")
+	}
+
+	return w.wrap(dataKey)
+}
+
+func (w *funcKeyWrapper) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if w.unwrap == nil {
+		return nil, errors.New("unwrap function is nil")
+	}
+
+	return w.unwrap(wrapped)
+}
@@ -0,0 +1,117 @@
+package cryptor
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestEnvelope(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEnvelope")
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+	// a fake kms which xors the data key
+	kms := NewFuncKeyWrapper(func(dataKey []byte) ([]byte, error) {
+		return xorBytes(dataKey, 0x5a), nil
+	}, func(wrapped []byte) ([]byte, error) {
+		return xorBytes(wrapped, 0x5a), nil
+	})
+
+	// a fake kms which xors the data key in place, like AesCbcDecrypt
+	inPlace := func(b []byte) ([]byte, error) {
+		for i := range b {
+			b[i] ^= 0x5a
+		}
+		return b, nil
+	}
+
+	wrappers := map[string]KeyWrapper{
+		"rsa":  NewRsaKeyWrapper(nil, rsaKey),
+		"p256": NewEcKeyWrapper(nil, p256Key),
+		"p384": NewEcKeyWrapper(nil, p384Key),
+		"kms":  kms,
+		"kms2": NewFuncKeyWrapper(inPlace, inPlace),
+	}
+
+	plaintext := []byte("hello lancet envelope")
+	aad := []byte("user:1")
+
+	for name, kek := range wrappers {
+		envelope := NewEnvelope(kek)
+
+		blob, err := envelope.Seal(plaintext, aad)
+		assert.IsNil(err)
+		assert.Equal(envelopeMagic, string(blob[:4]))
+
+		blob2, _ := envelope.Seal(plaintext, aad)
+		assert.Equal(false, bytes.Equal(blob, blob2))
+
+		decrypted, err := envelope.Open(blob, aad)
+		assert.IsNil(err)
+		assert.Equal(string(plaintext), string(decrypted))
+
+		_, err = envelope.Open(blob, []byte("user:2"))
+		assert.Equal(true, errors.Is(err, ErrInvalidEnvelope))
+
+		tampered := append([]byte{}, blob...)
+		tampered[len(tampered)-1] ^= 1
+		_, err = envelope.Open(tampered, aad)
+		assert.Equal(true, errors.Is(err, ErrInvalidEnvelope))
+
+		_, err = envelope.Open(blob[:10], aad)
+		assert.IsNotNil(err)
+
+		if name == "kms" {
+			empty, err := envelope.Seal(nil, nil)
+			assert.IsNil(err)
+			decrypted, err = envelope.Open(empty, nil)
+			assert.IsNil(err)
+			assert.Equal(0, len(decrypted))
+		}
+	}
+}
+
+func TestEnvelopeWrapOnly(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEnvelopeWrapOnly")
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	blob, err := NewEnvelope(NewRsaKeyWrapper(&rsaKey.PublicKey, nil)).Seal([]byte("secret"), nil)
+	assert.IsNil(err)
+
+	_, err = NewEnvelope(NewRsaKeyWrapper(&rsaKey.PublicKey, nil)).Open(blob, nil)
+	assert.IsNotNil(err)
+
+	decrypted, err := NewEnvelope(NewRsaKeyWrapper(nil, rsaKey)).Open(blob, nil)
+	assert.IsNil(err)
+	assert.Equal("secret", string(decrypted))
+
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	blob, err = NewEnvelope(NewEcKeyWrapper(&ecKey.PublicKey, nil)).Seal([]byte("secret"), nil)
+	assert.IsNil(err)
+
+	_, err = NewEnvelope(NewEcKeyWrapper(nil, otherKey)).Open(blob, nil)
+	assert.Equal(true, errors.Is(err, ErrInvalidEnvelope))
+}
+
+func xorBytes(b []byte, x byte) []byte {
+	result := make([]byte, len(b))
+	for i := range b {
+		result[i] = b[i] ^ x
+	}
+	return result
+}
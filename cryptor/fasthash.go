@@ -0,0 +1,371 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package cryptor
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Non-cryptographic hashes: FNV-1a, Murmur3 (fasthash.go) and xxHash (xxhash.go).
+// They are fast and well distributed, used for hash tables, sharding and deduplication,
+// but they are NOT cryptographic hashes, don't use them for passwords, signatures
+// or anything against malicious inputs. Use Sha256 etc. instead.
+// All of them take a seed, the zero seed gives the standard result of the algorithm.
+
+const (
+	fnv32Offset uint32 = 2166136261
+	fnv32Prime  uint32 = 16777619
+	fnv64Offset uint64 = 14695981039346656037
+	fnv64Prime  uint64 = 1099511628211
+)
+
+// Fnv1a32 returns the 32 bits FNV-1a hash of data, the seed is xored into the offset basis.
+// It's not a cryptographic hash.
+func Fnv1a32(data []byte, seed uint32) uint32 {
+	h := fnv32Offset ^ seed
+	for _, b := range data {
+		h ^= uint32(b)
+		h *= fnv32Prime
+	}
+
+	return h
+}
+
+// Fnv1a64 returns the 64 bits FNV-1a hash of data, the seed is xored into the offset basis.
+// It's not a cryptographic hash.
+func Fnv1a64(data []byte, seed uint64) uint64 {
+	h := fnv64Offset ^ seed
+	for _, b := range data {
+		h ^= uint64(b)
+		h *= fnv64Prime
+	}
+
+	return h
+}
+
+type fnv1a32Digest struct {
+	seed uint32
+	h    uint32
+}
+
+// NewFnv1a32 returns a streaming 32 bits FNV-1a hash.Hash32 with seed.
+func NewFnv1a32(seed uint32) hash.Hash32 {
+	return &fnv1a32Digest{seed: seed, h: fnv32Offset ^ seed}
+}
+
+func (d *fnv1a32Digest) Write(p []byte) (int, error) {
+	h := d.h
+	for _, b := range p {
+		h ^= uint32(b)
+		h *= fnv32Prime
+	}
+	d.h = h
+
+	return len(p), nil
+}
+
+func (d *fnv1a32Digest) Sum(b []byte) []byte {
+	return append(b, byte(d.h>>24), byte(d.h>>16), byte(d.h>>8), byte(d.h))
+}
+
+func (d *fnv1a32Digest) Sum32() uint32 { return d.h }
+
+func (d *fnv1a32Digest) Reset() { d.h = fnv32Offset ^ d.seed }
+
+func (d *fnv1a32Digest) Size() int { return 4 }
+
+func (d *fnv1a32Digest) BlockSize() int { return 1 }
+
+type fnv1a64Digest struct {
+	seed uint64
+	h    uint64
+}
+
+// NewFnv1a64 returns a streaming 64 bits FNV-1a hash.Hash64 with seed.
+func NewFnv1a64(seed uint64) hash.Hash64 {
+	return &fnv1a64Digest{seed: seed, h: fnv64Offset ^ seed}
+}
+
+func (d *fnv1a64Digest) Write(p []byte) (int, error) {
+	h := d.h
+	for _, b := range p {
+		h ^= uint64(b)
+		h *= fnv64Prime
+	}
+	d.h = h
+
+	return len(p), nil
+}
+
+func (d *fnv1a64Digest) Sum(b []byte) []byte { return appendUint64(b, d.h) }
+
+func (d *fnv1a64Digest) Sum64() uint64 { return d.h }
+
+func (d *fnv1a64Digest) Reset() { d.h = fnv64Offset ^ d.seed }
+
+func (d *fnv1a64Digest) Size() int { return 8 }
+
+func (d *fnv1a64Digest) BlockSize() int { return 1 }
+
+const (
+	murmur32C1 uint32 = 0xcc9e2d51
+	murmur32C2 uint32 = 0x1b873593
+
+	murmur128C1 uint64 = 0x87c37b91114253d5
+	murmur128C2 uint64 = 0x4cf5ad432745937f
+)
+
+// Murmur3Hash32 returns the 32 bits MurmurHash3 (x86_32) of data with seed. It's not a cryptographic hash.
+func Murmur3Hash32(data []byte, seed uint32) uint32 {
+	h := seed
+	n := len(data)
+
+	for len(data) >= 4 {
+		h = murmur32Block(h, binary.LittleEndian.Uint32(data))
+		data = data[4:]
+	}
+
+	return murmur32Finalize(h, data, uint32(n))
+}
+
+func murmur32Block(h, k uint32) uint32 {
+	k *= murmur32C1
+	k = bits.RotateLeft32(k, 15)
+	k *= murmur32C2
+
+	h ^= k
+	h = bits.RotateLeft32(h, 13)
+
+	return h*5 + 0xe6546b64
+}
+
+func murmur32Finalize(h uint32, tail []byte, n uint32) uint32 {
+	var k uint32
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= murmur32C1
+		k = bits.RotateLeft32(k, 15)
+		k *= murmur32C2
+		h ^= k
+	}
+
+	h ^= n
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+
+	return h
+}
+
+// Murmur3Hash128 returns the 128 bits MurmurHash3 (x64_128) of data with seed, as h1 and h2.
+// It's not a cryptographic hash.
+func Murmur3Hash128(data []byte, seed uint32) (h1 uint64, h2 uint64) {
+	h1, h2 = uint64(seed), uint64(seed)
+	n := len(data)
+
+	for len(data) >= 16 {
+		h1, h2 = murmur128Block(h1, h2, binary.LittleEndian.Uint64(data), binary.LittleEndian.Uint64(data[8:]))
+		data = data[16:]
+	}
+
+	return murmur128Finalize(h1, h2, data, uint64(n))
+}
+
+func murmur128Block(h1, h2, k1, k2 uint64) (uint64, uint64) {
+	k1 *= murmur128C1
+	k1 = bits.RotateLeft64(k1, 31)
+	k1 *= murmur128C2
+	h1 ^= k1
+
+	h1 = bits.RotateLeft64(h1, 27)
+	h1 += h2
+	h1 = h1*5 + 0x52dce729
+
+	k2 *= murmur128C2
+	k2 = bits.RotateLeft64(k2, 33)
+	k2 *= murmur128C1
+	h2 ^= k2
+
+	h2 = bits.RotateLeft64(h2, 31)
+	h2 += h1
+	h2 = h2*5 + 0x38495ab5
+
+	return h1, h2
+}
+
+func murmur128Finalize(h1, h2 uint64, tail []byte, n uint64) (uint64, uint64) {
+	var k1, k2 uint64
+
+	for i := len(tail) - 1; i >= 8; i-- {
+		k2 ^= uint64(tail[i]) << (uint(i-8) * 8)
+	}
+	if len(tail) > 8 {
+		k2 *= murmur128C2
+		k2 = bits.RotateLeft64(k2, 33)
+		k2 *= murmur128C1
+		h2 ^= k2
+	}
+
+	if len(tail) > 8 {
+		tail = tail[:8]
+	}
+	for i := len(tail) - 1; i >= 0; i-- {
+		k1 ^= uint64(tail[i]) << (uint(i) * 8)
+	}
+	if len(tail) > 0 {
+		k1 *= murmur128C1
+		k1 = bits.RotateLeft64(k1, 31)
+		k1 *= murmur128C2
+		h1 ^= k1
+	}
+
+	h1 ^= n
+	h2 ^= n
+
+	h1 += h2
+	h2 += h1
+
+	h1 = murmurFmix64(h1)
+	h2 = murmurFmix64(h2)
+
+	h1 += h2
+	h2 += h1
+
+	return h1, h2
+}
+
+func murmurFmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+
+	return k
+}
+
+type murmur32Digest struct {
+	seed uint32
+	h    uint32
+	buf  [4]byte
+	n    int
+	len  uint32
+}
+
+// NewMurmur3Hash32 returns a streaming 32 bits MurmurHash3 hash.Hash32 with seed.
+func NewMurmur3Hash32(seed uint32) hash.Hash32 {
+	return &murmur32Digest{seed: seed, h: seed}
+}
+
+func (d *murmur32Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint32(n)
+
+	if d.n > 0 {
+		c := copy(d.buf[d.n:], p)
+		d.n += c
+		p = p[c:]
+		if d.n < 4 {
+			return n, nil
+		}
+		d.h = murmur32Block(d.h, binary.LittleEndian.Uint32(d.buf[:]))
+		d.n = 0
+	}
+
+	for len(p) >= 4 {
+		d.h = murmur32Block(d.h, binary.LittleEndian.Uint32(p))
+		p = p[4:]
+	}
+	d.n = copy(d.buf[:], p)
+
+	return n, nil
+}
+
+func (d *murmur32Digest) Sum32() uint32 {
+	return murmur32Finalize(d.h, d.buf[:d.n], d.len)
+}
+
+func (d *murmur32Digest) Sum(b []byte) []byte {
+	h := d.Sum32()
+	return append(b, byte(h>>24), byte(h>>16), byte(h>>8), byte(h))
+}
+
+func (d *murmur32Digest) Reset() {
+	d.h = d.seed
+	d.n = 0
+	d.len = 0
+}
+
+func (d *murmur32Digest) Size() int { return 4 }
+
+func (d *murmur32Digest) BlockSize() int { return 4 }
+
+type murmur128Digest struct {
+	seed   uint32
+	h1, h2 uint64
+	buf    [16]byte
+	n      int
+	len    uint64
+}
+
+// NewMurmur3Hash128 returns a streaming 128 bits MurmurHash3 hash.Hash with seed,
+// Sum appends h1 and h2 in big endian.
+func NewMurmur3Hash128(seed uint32) hash.Hash {
+	return &murmur128Digest{seed: seed, h1: uint64(seed), h2: uint64(seed)}
+}
+
+func (d *murmur128Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+
+	if d.n > 0 {
+		c := copy(d.buf[d.n:], p)
+		d.n += c
+		p = p[c:]
+		if d.n < 16 {
+			return n, nil
+		}
+		d.h1, d.h2 = murmur128Block(d.h1, d.h2, binary.LittleEndian.Uint64(d.buf[:]), binary.LittleEndian.Uint64(d.buf[8:]))
+		d.n = 0
+	}
+
+	for len(p) >= 16 {
+		d.h1, d.h2 = murmur128Block(d.h1, d.h2, binary.LittleEndian.Uint64(p), binary.LittleEndian.Uint64(p[8:]))
+		p = p[16:]
+	}
+	d.n = copy(d.buf[:], p)
+
+	return n, nil
+}
+
+// Sum128 returns h1 and h2 of hash.
+func (d *murmur128Digest) Sum128() (uint64, uint64) {
+	return murmur128Finalize(d.h1, d.h2, d.buf[:d.n], d.len)
+}
+
+func (d *murmur128Digest) Sum(b []byte) []byte {
+	h1, h2 := d.Sum128()
+	return appendUint64(appendUint64(b, h1), h2)
+}
+
+func (d *murmur128Digest) Reset() {
+	d.h1, d.h2 = uint64(d.seed), uint64(d.seed)
+	d.n = 0
+	d.len = 0
+}
+
+func (d *murmur128Digest) Size() int { return 16 }
+
+func (d *murmur128Digest) BlockSize() int { return 16 }
@@ -0,0 +1,211 @@
+package cryptor
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestXxHash64(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestXxHash64")
+
+	tests := []struct {
+		input    string
+		expected uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"as", 0x1c330fb2d66be179},
+		{"asd", 0x631c37ce72a97393},
+		{"asdf", 0x415872f599cea71e},
+		{"Call me Ishmael. Some years ago--never mind how long precisely-", 0x02a2e85470d6fd96},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, XxHash64([]byte(tt.input), 0))
+
+		h := NewXxHash64(0)
+		h.Write([]byte(tt.input))
+		assert.Equal(tt.expected, h.Sum64())
+	}
+
+	assert.NotEqual(XxHash64([]byte("hello"), 0), XxHash64([]byte("hello"), 1))
+}
+
+func TestXxHash128(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestXxHash128")
+
+	hi, lo := XxHash128(nil, 0)
+	assert.Equal(uint64(0x99aa06d3014798d8), hi)
+	assert.Equal(uint64(0x6001c324468d497f), lo)
+
+	h := NewXxHash128(0)
+	assert.Equal([]byte{0x99, 0xaa, 0x06, 0xd3, 0x01, 0x47, 0x98, 0xd8,
+		0x60, 0x01, 0xc3, 0x24, 0x46, 0x8d, 0x49, 0x7f}, h.Sum(nil))
+
+	hi1, lo1 := XxHash128([]byte("hello"), 1)
+	hi2, lo2 := XxHash128([]byte("hello"), 2)
+	assert.Equal(false, hi1 == hi2 && lo1 == lo2)
+
+	// the known answers of every length class, data[i] = (i*31 + 7) % 251
+	tests := []struct {
+		n      int
+		seed   uint64
+		hi, lo uint64
+	}{
+		{1, 0, 0x495b62073ef70ca4, 0x4c5cca45d0f4811f},
+		{16, 0, 0xda917c385cc874c0, 0x0d463cb04ceffbaf},
+		{17, 0, 0xd443578f2c4e2fb4, 0x95c34448580e19c8},
+		{128, 0, 0x22c34350373a38ae, 0x5b77925b2c683a12},
+		{129, 0, 0xc4a7d8f7893f2090, 0xd6d9e73553568be1},
+		{240, 0, 0xe29d70b8920fd24b, 0xc6ed4333f79384f8},
+		{241, 0, 0xf91b3cb8ed0fa91a, 0x07525dbc14902c7f},
+		{1025, 0, 0xa906cca0f6e772a7, 0x134c652ba3d6fb9e},
+		{2048, 0, 0xaceedd29e1caaad3, 0x63a78a59658d80f4},
+		{0, 0x9e3779b97f4a7c15, 0xd142977a2cca554b, 0x4ca5176998171787},
+		{1, 0x9e3779b97f4a7c15, 0x00a711eb5a736b26, 0x2f3acd3805f81de3},
+		{16, 0x9e3779b97f4a7c15, 0xd175571c64ac17f3, 0x8ab955e9071c613a},
+		{17, 0x9e3779b97f4a7c15, 0x41274f88a053406f, 0xca44306f75409378},
+		{128, 0x9e3779b97f4a7c15, 0xba1de144f757c443, 0x733941be75b07397},
+		{129, 0x9e3779b97f4a7c15, 0x6a936b772191ae3c, 0xa9e85b3be25c45b9},
+		{240, 0x9e3779b97f4a7c15, 0x946bcf0b82294de8, 0x26c7586d7cbb3321},
+		{241, 0x9e3779b97f4a7c15, 0x9c07b9f6a0d1ebb2, 0xccc1f1e52ae7b2af},
+		{1025, 0x9e3779b97f4a7c15, 0x60987165fd5d3fdd, 0xaaf31c675f4460f2},
+		{2048, 0x9e3779b97f4a7c15, 0x829377900d286c4c, 0x61d9d77ed79d4fea},
+	}
+
+	for _, tt := range tests {
+		data := make([]byte, tt.n)
+		for i := range data {
+			data[i] = byte((i*31 + 7) % 251)
+		}
+
+		hi, lo := XxHash128(data, tt.seed)
+		assert.Equal(tt.hi, hi)
+		assert.Equal(tt.lo, lo)
+	}
+}
+
+func TestXxHashStreaming(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestXxHashStreaming")
+
+	r := rand.New(rand.NewSource(1))
+	data := make([]byte, 2100)
+	r.Read(data)
+
+	for _, seed := range []uint64{0, 42} {
+		for n := 0; n <= len(data); n += 1 + n/20 {
+			input := data[:n]
+
+			h64 := NewXxHash64(seed)
+			h128 := NewXxHash128(seed).(*xxHash128Digest)
+			for rest := input; len(rest) > 0; {
+				c := 1 + r.Intn(300)
+				if c > len(rest) {
+					c = len(rest)
+				}
+				h64.Write(rest[:c])
+				h128.Write(rest[:c])
+				rest = rest[c:]
+			}
+
+			assert.Equal(XxHash64(input, seed), h64.Sum64())
+
+			hi, lo := XxHash128(input, seed)
+			shi, slo := h128.Sum128()
+			assert.Equal(hi, shi)
+			assert.Equal(lo, slo)
+		}
+	}
+}
+
+func TestFnv1a(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFnv1a")
+
+	for _, input := range []string{"", "a", "hello world", "The quick brown fox jumps over the lazy dog"} {
+		std32 := fnv.New32a()
+		std32.Write([]byte(input))
+		assert.Equal(std32.Sum32(), Fnv1a32([]byte(input), 0))
+
+		std64 := fnv.New64a()
+		std64.Write([]byte(input))
+		assert.Equal(std64.Sum64(), Fnv1a64([]byte(input), 0))
+
+		h32 := NewFnv1a32(7)
+		h32.Write([]byte(input))
+		assert.Equal(Fnv1a32([]byte(input), 7), h32.Sum32())
+
+		h64 := NewFnv1a64(7)
+		h64.Write([]byte(input))
+		assert.Equal(Fnv1a64([]byte(input), 7), h64.Sum64())
+	}
+
+	assert.NotEqual(Fnv1a64([]byte("hello"), 0), Fnv1a64([]byte("hello"), 1))
+}
+
+func TestMurmur3Hash32(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMurmur3Hash32")
+
+	tests := []struct {
+		input    string
+		seed     uint32
+		expected uint32
+	}{
+		{"", 0, 0},
+		{"", 1, 0x514e28b7},
+		{"hello", 0, 0x248bfa47},
+		{"The quick brown fox jumps over the lazy dog", 0, 0x2e4ff723},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, Murmur3Hash32([]byte(tt.input), tt.seed))
+
+		h := NewMurmur3Hash32(tt.seed)
+		for i := 0; i < len(tt.input); i += 3 {
+			end := i + 3
+			if end > len(tt.input) {
+				end = len(tt.input)
+			}
+			h.Write([]byte(tt.input[i:end]))
+		}
+		assert.Equal(tt.expected, h.Sum32())
+	}
+}
+
+func TestMurmur3Hash128(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMurmur3Hash128")
+
+	h1, h2 := Murmur3Hash128(nil, 0)
+	assert.Equal(uint64(0), h1)
+	assert.Equal(uint64(0), h2)
+
+	input := []byte("The quick brown fox jumps over the lazy dog")
+	h1, h2 = Murmur3Hash128(input, 0)
+	assert.Equal(uint64(0xe34bbc7bbc071b6c), h1)
+	assert.Equal(uint64(0x7a433ca9c49a9347), h2)
+
+	for n := 0; n <= len(input); n++ {
+		h := NewMurmur3Hash128(3).(*murmur128Digest)
+		h.Write(input[:n/2])
+		h.Write(input[n/2 : n])
+
+		e1, e2 := Murmur3Hash128(input[:n], 3)
+		s1, s2 := h.Sum128()
+		assert.Equal(e1, s1)
+		assert.Equal(e2, s2)
+	}
+}
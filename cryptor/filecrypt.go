@@ -0,0 +1,369 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package cryptor
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	fileCryptMagic   = "LENC"
+	fileCryptVersion = 1
+	fileSaltSize     = 16
+	fileNoncePrefix  = 7
+	// magic(4) + version(1) + argon2 time(4) + memory(4) + threads(1) + salt + chunk size(4) + nonce prefix
+	fileCryptHeaderSize = 4 + 1 + 4 + 4 + 1 + fileSaltSize + 4 + fileNoncePrefix
+
+	defaultFileChunkSize = 64 * 1024
+	maxFileChunkSize     = 16 * 1024 * 1024
+	// maxArgon2Memory limits the memory (in KiB) requested by the header of encrypted data, it's 4 GiB
+	maxArgon2Memory = 4 * 1024 * 1024
+)
+
+// ErrFileDecrypt is returned when the password is wrong or the encrypted data is corrupted.
+var ErrFileDecrypt = errors.New("wrong password or corrupted encrypted data")
+
+// FileCryptProgress is called after every chunk is processed, processed is the number of
+// plaintext bytes done and total is the size of source file (-1 if unknown).
+type FileCryptProgress func(processed, total int64)
+
+// FileCryptOption is option of EncryptFile, DecryptFile, EncryptStream and DecryptStream.
+type FileCryptOption func(*fileCryptConfig)
+
+type fileCryptConfig struct {
+	time      uint32
+	memory    uint32
+	threads   uint8
+	chunkSize int
+	output    string
+	progress  FileCryptProgress
+}
+
+func newFileCryptConfig(opts []FileCryptOption) *fileCryptConfig {
+	c := &fileCryptConfig{
+		time:      1,
+		memory:    64 * 1024,
+		threads:   4,
+		chunkSize: defaultFileChunkSize,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithArgon2Params sets the argon2id parameters used to derive key from password when encrypting,
+// memory is in KiB. Default is time 1, memory 64 MiB and 4 threads. Decryption always uses
+// the parameters stored in the encrypted header.
+func WithArgon2Params(time, memory uint32, threads uint8) FileCryptOption {
+	return func(c *fileCryptConfig) {
+		if time > 0 {
+			c.time = time
+		}
+		if memory > 0 {
+			c.memory = memory
+		}
+		if threads > 0 {
+			c.threads = threads
+		}
+	}
+}
+
+// WithChunkSize sets the plaintext size of every encrypted chunk, default is 64 KiB.
+func WithChunkSize(size int) FileCryptOption {
+	return func(c *fileCryptConfig) {
+		if size > 0 && size <= maxFileChunkSize {
+			c.chunkSize = size
+		}
+	}
+}
+
+// WithOutputPath writes the result of EncryptFile or DecryptFile to path instead of replacing the source file.
+func WithOutputPath(path string) FileCryptOption {
+	return func(c *fileCryptConfig) {
+		c.output = path
+	}
+}
+
+// WithProgress sets the progress callback.
+func WithProgress(fn FileCryptProgress) FileCryptOption {
+	return func(c *fileCryptConfig) {
+		c.progress = fn
+	}
+}
+
+// EncryptFile encrypts the file with password. The key is derived by argon2id with a random salt,
+// and the content is encrypted by AES-256-GCM in chunks, so large files are processed with constant memory.
+// The file is replaced by the encrypted one atomically, unless WithOutputPath is set.
+func EncryptFile(path, password string, opts ...FileCryptOption) error {
+	c := newFileCryptConfig(opts)
+
+	return transformFile(path, c, func(dst io.Writer, src io.Reader, total int64) error {
+		return encryptStream(dst, src, password, total, c)
+	})
+}
+
+// DecryptFile decrypts the file encrypted by EncryptFile with password. The file is replaced
+// by the decrypted one atomically, unless WithOutputPath is set. Nothing is written if the password
+// is wrong or the file is corrupted.
+func DecryptFile(path, password string, opts ...FileCryptOption) error {
+	c := newFileCryptConfig(opts)
+
+	return transformFile(path, c, func(dst io.Writer, src io.Reader, total int64) error {
+		return decryptStream(dst, src, password, total, c)
+	})
+}
+
+// EncryptDir encrypts every regular file in dir and its sub directories in place with password,
+// symbolic links are skipped and WithOutputPath is ignored. The progress callback is called for each file.
+func EncryptDir(dir, password string, opts ...FileCryptOption) error {
+	return walkRegularFiles(dir, func(path string) error {
+		return EncryptFile(path, password, append(opts, WithOutputPath(""))...)
+	})
+}
+
+// DecryptDir decrypts every regular file in dir and its sub directories encrypted by EncryptDir in place.
+func DecryptDir(dir, password string, opts ...FileCryptOption) error {
+	return walkRegularFiles(dir, func(path string) error {
+		return DecryptFile(path, password, append(opts, WithOutputPath(""))...)
+	})
+}
+
+func walkRegularFiles(dir string, fn func(path string) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		return fn(path)
+	})
+}
+
+// EncryptStream encrypts data read from src with password and writes it to dst, in the same format as EncryptFile.
+func EncryptStream(dst io.Writer, src io.Reader, password string, opts ...FileCryptOption) error {
+	return encryptStream(dst, src, password, -1, newFileCryptConfig(opts))
+}
+
+// DecryptStream decrypts data read from src with password and writes it to dst. Since the data is
+// written chunk by chunk, dst may receive part of the plaintext before an error is returned.
+func DecryptStream(dst io.Writer, src io.Reader, password string, opts ...FileCryptOption) error {
+	return decryptStream(dst, src, password, -1, newFileCryptConfig(opts))
+}
+
+func encryptStream(dst io.Writer, src io.Reader, password string, total int64, c *fileCryptConfig) error {
+	header := make([]byte, fileCryptHeaderSize)
+	copy(header, fileCryptMagic)
+	header[4] = fileCryptVersion
+	binary.BigEndian.PutUint32(header[5:], c.time)
+	binary.BigEndian.PutUint32(header[9:], c.memory)
+	header[13] = c.threads
+	salt := header[14 : 14+fileSaltSize]
+	binary.BigEndian.PutUint32(header[14+fileSaltSize:], uint32(c.chunkSize))
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(rand.Reader, header[fileCryptHeaderSize-fileNoncePrefix:]); err != nil {
+		return err
+	}
+
+	aead, err := newGCM(argon2.IDKey([]byte(password), salt, c.time, c.memory, c.threads, 32))
+	if err != nil {
+		return err
+	}
+
+	if _, err := dst.Write(header); err != nil {
+		return err
+	}
+
+	r := bufio.NewReaderSize(src, c.chunkSize)
+	buf := make([]byte, c.chunkSize, c.chunkSize+aead.Overhead())
+	var processed int64
+
+	for counter := uint32(0); ; counter++ {
+		n, last, err := readChunk(r, buf)
+		if err != nil {
+			return err
+		}
+
+		nonce, err := chunkNonce(header, counter, last)
+		if err != nil {
+			return err
+		}
+		if _, err := dst.Write(aead.Seal(buf[:0], nonce, buf[:n], header)); err != nil {
+			return err
+		}
+
+		processed += int64(n)
+		if c.progress != nil {
+			c.progress(processed, total)
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+func decryptStream(dst io.Writer, src io.Reader, password string, total int64, c *fileCryptConfig) error {
+	header := make([]byte, fileCryptHeaderSize)
+	if _, err := io.ReadFull(src, header); err != nil || string(header[:4]) != fileCryptMagic {
+		return ErrFileDecrypt
+	}
+	if header[4] != fileCryptVersion {
+		return errors.New("unsupported encrypted file version")
+	}
+
+	argonTime := binary.BigEndian.Uint32(header[5:])
+	memory := binary.BigEndian.Uint32(header[9:])
+	threads := header[13]
+	salt := header[14 : 14+fileSaltSize]
+	chunkSize := int(binary.BigEndian.Uint32(header[14+fileSaltSize:]))
+	if argonTime == 0 || threads == 0 || chunkSize <= 0 || chunkSize > maxFileChunkSize {
+		return ErrFileDecrypt
+	}
+	if memory > maxArgon2Memory {
+		return errors.New("argon2 memory of encrypted data is too large")
+	}
+
+	aead, err := newGCM(argon2.IDKey([]byte(password), salt, argonTime, memory, threads, 32))
+	if err != nil {
+		return err
+	}
+
+	// total is the size of encrypted data, convert it to the size of plaintext
+	if total >= fileCryptHeaderSize {
+		sealedSize := int64(chunkSize + aead.Overhead())
+		total -= fileCryptHeaderSize
+		total -= (total + sealedSize - 1) / sealedSize * int64(aead.Overhead())
+	}
+
+	r := bufio.NewReaderSize(src, chunkSize+aead.Overhead())
+	buf := make([]byte, chunkSize+aead.Overhead())
+	var processed int64
+
+	for counter := uint32(0); ; counter++ {
+		n, last, err := readChunk(r, buf)
+		if err != nil {
+			return err
+		}
+
+		nonce, err := chunkNonce(header, counter, last)
+		if err != nil {
+			return err
+		}
+		plaintext, err := aead.Open(buf[:0], nonce, buf[:n], header)
+		if err != nil {
+			return ErrFileDecrypt
+		}
+		if _, err := dst.Write(plaintext); err != nil {
+			return err
+		}
+
+		processed += int64(len(plaintext))
+		if c.progress != nil {
+			c.progress(processed, total)
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// readChunk fills buf from r, last reports whether there is no more data after the chunk.
+func readChunk(r *bufio.Reader, buf []byte) (n int, last bool, err error) {
+	n, err = io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return n, true, nil
+	}
+	if err != nil {
+		return n, false, err
+	}
+
+	if _, err := r.Peek(1); err != nil {
+		if err == io.EOF {
+			return n, true, nil
+		}
+		return n, false, err
+	}
+
+	return n, false, nil
+}
+
+// chunkNonce returns nonce prefix | counter | last flag, the flag prevents truncation at chunk boundary.
+func chunkNonce(header []byte, counter uint32, last bool) ([]byte, error) {
+	if counter == ^uint32(0) {
+		return nil, errors.New("too many chunks to encrypt")
+	}
+
+	nonce := make([]byte, fileNoncePrefix+5)
+	copy(nonce, header[fileCryptHeaderSize-fileNoncePrefix:])
+	binary.BigEndian.PutUint32(nonce[fileNoncePrefix:], counter)
+	if last {
+		nonce[fileNoncePrefix+4] = 1
+	}
+
+	return nonce, nil
+}
+
+// transformFile writes the result of fn to a temporary file in the same directory of output,
+// and renames it to the output path when fn succeeds.
+func transformFile(path string, c *fileCryptConfig, fn func(dst io.Writer, src io.Reader, total int64) error) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	output := c.output
+	if output == "" {
+		output = path
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	err = fn(tmp, src, info.Size())
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpName, info.Mode().Perm())
+	}
+	if err == nil {
+		// close source before rename, windows can't replace an opened file
+		src.Close()
+		err = os.Rename(tmpName, output)
+	}
+	if err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package cryptor
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// use cheap argon2 parameters to keep tests fast
+var testArgon2 = WithArgon2Params(1, 8*1024, 1)
+
+func TestEncryptFile(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEncryptFile")
+
+	dir := t.TempDir()
+	for _, size := range []int{0, 1, 1024, 4096, 10000} {
+		data := make([]byte, size)
+		rand.Read(data)
+
+		path := filepath.Join(dir, "test.txt")
+		assert.IsNil(os.WriteFile(path, data, 0600))
+
+		var progress []int64
+		err := EncryptFile(path, "secret", testArgon2, WithChunkSize(1024),
+			WithProgress(func(processed, total int64) {
+				assert.Equal(int64(size), total)
+				progress = append(progress, processed)
+			}))
+		assert.IsNil(err)
+		assert.Equal(int64(size), progress[len(progress)-1])
+
+		encrypted, _ := os.ReadFile(path)
+		// a short plaintext could be found in the random ciphertext by chance
+		if size >= 16 {
+			assert.Equal(false, bytes.Contains(encrypted, data))
+		}
+
+		progress = nil
+		err = DecryptFile(path, "secret", WithProgress(func(processed, total int64) {
+			assert.Equal(int64(size), total)
+			progress = append(progress, processed)
+		}))
+		assert.IsNil(err)
+		assert.Equal(int64(size), progress[len(progress)-1])
+
+		decrypted, _ := os.ReadFile(path)
+		assert.Equal(data, decrypted)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	assert.Equal(1, len(entries))
+}
+
+func TestDecryptFileWithWrongPassword(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDecryptFileWithWrongPassword")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.txt")
+	assert.IsNil(os.WriteFile(path, []byte("hello world"), 0600))
+	assert.IsNil(EncryptFile(path, "secret", testArgon2))
+
+	encrypted, _ := os.ReadFile(path)
+
+	err := DecryptFile(path, "wrong")
+	assert.Equal(ErrFileDecrypt, err)
+
+	content, _ := os.ReadFile(path)
+	assert.Equal(encrypted, content)
+
+	entries, _ := os.ReadDir(dir)
+	assert.Equal(1, len(entries))
+}
+
+func TestEncryptFileWithOutputPath(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEncryptFileWithOutputPath")
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "test.txt")
+	encrypted := filepath.Join(dir, "test.txt.enc")
+	decrypted := filepath.Join(dir, "test.dec.txt")
+	assert.IsNil(os.WriteFile(src, []byte("hello world"), 0600))
+
+	assert.IsNil(EncryptFile(src, "secret", testArgon2, WithOutputPath(encrypted)))
+	assert.IsNil(DecryptFile(encrypted, "secret", WithOutputPath(decrypted)))
+
+	content, _ := os.ReadFile(src)
+	assert.Equal("hello world", string(content))
+
+	content, _ = os.ReadFile(decrypted)
+	assert.Equal("hello world", string(content))
+}
+
+func TestDecryptStreamTampered(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDecryptStreamTampered")
+
+	data := make([]byte, 3000)
+	rand.Read(data)
+
+	var buf bytes.Buffer
+	assert.IsNil(EncryptStream(&buf, bytes.NewReader(data), "secret", testArgon2, WithChunkSize(1000)))
+	encrypted := buf.Bytes()
+
+	var out bytes.Buffer
+	assert.IsNil(DecryptStream(&out, bytes.NewReader(encrypted), "secret"))
+	assert.Equal(data, out.Bytes())
+
+	// drop the last chunk, the previous chunk is not marked as the last one
+	truncated := encrypted[:fileCryptHeaderSize+2*(1000+16)]
+	assert.Equal(ErrFileDecrypt, DecryptStream(&bytes.Buffer{}, bytes.NewReader(truncated), "secret"))
+
+	modified := append([]byte{}, encrypted...)
+	modified[fileCryptHeaderSize+10] ^= 1
+	assert.Equal(ErrFileDecrypt, DecryptStream(&bytes.Buffer{}, bytes.NewReader(modified), "secret"))
+
+	assert.Equal(ErrFileDecrypt, DecryptStream(&bytes.Buffer{}, bytes.NewReader([]byte("hello")), "secret"))
+}
+
+func TestEncryptDir(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEncryptDir")
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.txt":       "hello",
+		"sub/b.txt":   "world",
+		"sub/c/d.txt": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.IsNil(os.MkdirAll(filepath.Dir(path), 0755))
+		assert.IsNil(os.WriteFile(path, []byte(content), 0600))
+	}
+
+	assert.IsNil(EncryptDir(dir, "secret", testArgon2))
+	for name := range files {
+		content, _ := os.ReadFile(filepath.Join(dir, name))
+		assert.Equal(fileCryptMagic, string(content[:4]))
+	}
+
+	assert.IsNil(DecryptDir(dir, "secret"))
+	for name, expected := range files {
+		content, _ := os.ReadFile(filepath.Join(dir, name))
+		assert.Equal(expected, string(content))
+	}
+}
@@ -0,0 +1,550 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package cryptor
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// The xxHash functions are NOT cryptographic hashes, see fasthash.go.
+
+const (
+	xxPrime32_1 uint64 = 0x9E3779B1
+	xxPrime32_2 uint64 = 0x85EBCA77
+	xxPrime32_3 uint64 = 0xC2B2AE3D
+
+	xxPrime64_1 uint64 = 0x9E3779B185EBCA87
+	xxPrime64_2 uint64 = 0xC2B2AE3D27D4EB4F
+	xxPrime64_3 uint64 = 0x165667B19E3779F9
+	xxPrime64_4 uint64 = 0x85EBCA77C2B2AE63
+	xxPrime64_5 uint64 = 0x27D4EB2F165667C5
+
+	xxPrimeMx1 uint64 = 0x165667919E3779F9
+	xxPrimeMx2 uint64 = 0x9FB21C651E98DF25
+)
+
+// XxHash64 returns the xxHash64 (XXH64) of data with seed. It's not a cryptographic hash.
+func XxHash64(data []byte, seed uint64) uint64 {
+	n := len(data)
+
+	var h uint64
+	if n >= 32 {
+		v1 := seed + xxPrime64_1 + xxPrime64_2
+		v2 := seed + xxPrime64_2
+		v3 := seed
+		v4 := seed - xxPrime64_1
+
+		for len(data) >= 32 {
+			v1 = xxh64Round(v1, binary.LittleEndian.Uint64(data))
+			v2 = xxh64Round(v2, binary.LittleEndian.Uint64(data[8:]))
+			v3 = xxh64Round(v3, binary.LittleEndian.Uint64(data[16:]))
+			v4 = xxh64Round(v4, binary.LittleEndian.Uint64(data[24:]))
+			data = data[32:]
+		}
+
+		h = xxh64MergeAccs(v1, v2, v3, v4)
+	} else {
+		h = seed + xxPrime64_5
+	}
+
+	h += uint64(n)
+
+	return xxh64Finalize(h, data)
+}
+
+func xxh64Round(acc, input uint64) uint64 {
+	acc += input * xxPrime64_2
+	acc = bits.RotateLeft64(acc, 31)
+
+	return acc * xxPrime64_1
+}
+
+func xxh64MergeRound(acc, val uint64) uint64 {
+	acc ^= xxh64Round(0, val)
+
+	return acc*xxPrime64_1 + xxPrime64_4
+}
+
+func xxh64MergeAccs(v1, v2, v3, v4 uint64) uint64 {
+	h := bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) + bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+	h = xxh64MergeRound(h, v1)
+	h = xxh64MergeRound(h, v2)
+	h = xxh64MergeRound(h, v3)
+
+	return xxh64MergeRound(h, v4)
+}
+
+// xxh64Finalize consumes the remaining data less than 32 bytes and avalanches h.
+func xxh64Finalize(h uint64, data []byte) uint64 {
+	for len(data) >= 8 {
+		h ^= xxh64Round(0, binary.LittleEndian.Uint64(data))
+		h = bits.RotateLeft64(h, 27)*xxPrime64_1 + xxPrime64_4
+		data = data[8:]
+	}
+	if len(data) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(data)) * xxPrime64_1
+		h = bits.RotateLeft64(h, 23)*xxPrime64_2 + xxPrime64_3
+		data = data[4:]
+	}
+	for _, b := range data {
+		h ^= uint64(b) * xxPrime64_5
+		h = bits.RotateLeft64(h, 11) * xxPrime64_1
+	}
+
+	return xxh64Avalanche(h)
+}
+
+func xxh64Avalanche(h uint64) uint64 {
+	h ^= h >> 33
+	h *= xxPrime64_2
+	h ^= h >> 29
+	h *= xxPrime64_3
+	h ^= h >> 32
+
+	return h
+}
+
+type xxHash64Digest struct {
+	seed  uint64
+	v     [4]uint64
+	total uint64
+	buf   [32]byte
+	n     int
+}
+
+// NewXxHash64 returns a streaming xxHash64 hash.Hash64 with seed, Sum appends the hash in big endian.
+func NewXxHash64(seed uint64) hash.Hash64 {
+	d := &xxHash64Digest{seed: seed}
+	d.Reset()
+
+	return d
+}
+
+func (d *xxHash64Digest) Reset() {
+	d.v = [4]uint64{d.seed + xxPrime64_1 + xxPrime64_2, d.seed + xxPrime64_2, d.seed, d.seed - xxPrime64_1}
+	d.total = 0
+	d.n = 0
+}
+
+func (d *xxHash64Digest) Size() int { return 8 }
+
+func (d *xxHash64Digest) BlockSize() int { return 32 }
+
+func (d *xxHash64Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.total += uint64(n)
+
+	if d.n+len(p) < 32 {
+		d.n += copy(d.buf[d.n:], p)
+		return n, nil
+	}
+
+	if d.n > 0 {
+		c := copy(d.buf[d.n:], p)
+		d.consume(d.buf[:])
+		p = p[c:]
+		d.n = 0
+	}
+
+	for len(p) >= 32 {
+		d.consume(p[:32])
+		p = p[32:]
+	}
+
+	d.n = copy(d.buf[:], p)
+
+	return n, nil
+}
+
+func (d *xxHash64Digest) consume(block []byte) {
+	d.v[0] = xxh64Round(d.v[0], binary.LittleEndian.Uint64(block))
+	d.v[1] = xxh64Round(d.v[1], binary.LittleEndian.Uint64(block[8:]))
+	d.v[2] = xxh64Round(d.v[2], binary.LittleEndian.Uint64(block[16:]))
+	d.v[3] = xxh64Round(d.v[3], binary.LittleEndian.Uint64(block[24:]))
+}
+
+func (d *xxHash64Digest) Sum64() uint64 {
+	var h uint64
+	if d.total >= 32 {
+		h = xxh64MergeAccs(d.v[0], d.v[1], d.v[2], d.v[3])
+	} else {
+		h = d.seed + xxPrime64_5
+	}
+	h += d.total
+
+	return xxh64Finalize(h, d.buf[:d.n])
+}
+
+func (d *xxHash64Digest) Sum(b []byte) []byte {
+	return appendUint64(b, d.Sum64())
+}
+
+// xxh3Secret is the default secret of XXH3.
+var xxh3Secret = [192]byte{
+	0xb8, 0xfe, 0x6c, 0x39, 0x23, 0xa4, 0x4b, 0xbe, 0x7c, 0x01, 0x81, 0x2c, 0xf7, 0x21, 0xad, 0x1c,
+	0xde, 0xd4, 0x6d, 0xe9, 0x83, 0x90, 0x97, 0xdb, 0x72, 0x40, 0xa4, 0xa4, 0xb7, 0xb3, 0x67, 0x1f,
+	0xcb, 0x79, 0xe6, 0x4e, 0xcc, 0xc0, 0xe5, 0x78, 0x82, 0x5a, 0xd0, 0x7d, 0xcc, 0xff, 0x72, 0x21,
+	0xb8, 0x08, 0x46, 0x74, 0xf7, 0x43, 0x24, 0x8e, 0xe0, 0x35, 0x90, 0xe6, 0x81, 0x3a, 0x26, 0x4c,
+	0x3c, 0x28, 0x52, 0xbb, 0x91, 0xc3, 0x00, 0xcb, 0x88, 0xd0, 0x65, 0x8b, 0x1b, 0x53, 0x2e, 0xa3,
+	0x71, 0x64, 0x48, 0x97, 0xa2, 0x0d, 0xf9, 0x4e, 0x38, 0x19, 0xef, 0x46, 0xa9, 0xde, 0xac, 0xd8,
+	0xa8, 0xfa, 0x76, 0x3f, 0xe3, 0x9c, 0x34, 0x3f, 0xf9, 0xdc, 0xbb, 0xc7, 0xc7, 0x0b, 0x4f, 0x1d,
+	0x8a, 0x51, 0xe0, 0x4b, 0xcd, 0xb4, 0x59, 0x31, 0xc8, 0x9f, 0x7e, 0xc9, 0xd9, 0x78, 0x73, 0x64,
+	0xea, 0xc5, 0xac, 0x83, 0x34, 0xd3, 0xeb, 0xc3, 0xc5, 0x81, 0xa0, 0xff, 0xfa, 0x13, 0x63, 0xeb,
+	0x17, 0x0d, 0xdd, 0x51, 0xb7, 0xf0, 0xda, 0x49, 0xd3, 0x16, 0x55, 0x26, 0x29, 0xd4, 0x68, 0x9e,
+	0x2b, 0x16, 0xbe, 0x58, 0x7d, 0x47, 0xa1, 0xfc, 0x8f, 0xf8, 0xb8, 0xd1, 0x7a, 0xd0, 0x31, 0xce,
+	0x45, 0xcb, 0x3a, 0x8f, 0x95, 0x16, 0x04, 0x28, 0xaf, 0xd7, 0xfb, 0xca, 0xbb, 0x4b, 0x40, 0x7e,
+}
+
+const (
+	xxh3StripeLen          = 64
+	xxh3SecretConsumeRate  = 8
+	xxh3StripesPerBlock    = (len(xxh3Secret) - xxh3StripeLen) / xxh3SecretConsumeRate
+	xxh3BlockLen           = xxh3StripeLen * xxh3StripesPerBlock
+	xxh3MidSizeMax         = 240
+	xxh3MidSizeStartOffset = 3
+	xxh3MidSizeLastOffset  = 17
+	xxh3SecretSizeMin      = 136
+	xxh3SecretLastAccStart = 7
+	xxh3SecretMergeStart   = 11
+	xxh3BufferSize         = 256
+)
+
+// XxHash128 returns the 128 bits xxHash (XXH3_128bits) of data with seed, as high and low 64 bits.
+// It's not a cryptographic hash.
+func XxHash128(data []byte, seed uint64) (hi uint64, lo uint64) {
+	n := len(data)
+	secret := xxh3Secret[:]
+
+	switch {
+	case n <= 16:
+		return xxh3Len0To16(data, secret, seed)
+	case n <= 128:
+		return xxh3Len17To128(data, secret, seed)
+	case n <= xxh3MidSizeMax:
+		return xxh3Len129To240(data, secret, seed)
+	}
+
+	if seed != 0 {
+		secret = xxh3CustomSecret(seed)
+	}
+
+	acc := xxh3InitAcc()
+
+	nbBlocks := (n - 1) / xxh3BlockLen
+	for i := 0; i < nbBlocks; i++ {
+		xxh3Accumulate(&acc, data[i*xxh3BlockLen:], secret, xxh3StripesPerBlock)
+		xxh3ScrambleAcc(&acc, secret[len(secret)-xxh3StripeLen:])
+	}
+
+	nbStripes := ((n - 1) - xxh3BlockLen*nbBlocks) / xxh3StripeLen
+	xxh3Accumulate(&acc, data[nbBlocks*xxh3BlockLen:], secret, nbStripes)
+	xxh3Accumulate512(&acc, data[n-xxh3StripeLen:], secret[len(secret)-xxh3StripeLen-xxh3SecretLastAccStart:])
+
+	return xxh3Merge128(&acc, secret, uint64(n))
+}
+
+func xxh3InitAcc() [8]uint64 {
+	return [8]uint64{xxPrime32_3, xxPrime64_1, xxPrime64_2, xxPrime64_3, xxPrime64_4, xxPrime32_2, xxPrime64_5, xxPrime32_1}
+}
+
+func xxh3CustomSecret(seed uint64) []byte {
+	secret := make([]byte, len(xxh3Secret))
+	for i := 0; i < len(secret); i += 16 {
+		binary.LittleEndian.PutUint64(secret[i:], binary.LittleEndian.Uint64(xxh3Secret[i:])+seed)
+		binary.LittleEndian.PutUint64(secret[i+8:], binary.LittleEndian.Uint64(xxh3Secret[i+8:])-seed)
+	}
+
+	return secret
+}
+
+func xxh3Avalanche(h uint64) uint64 {
+	h ^= h >> 37
+	h *= xxPrimeMx1
+	h ^= h >> 32
+
+	return h
+}
+
+func xxh3Mul128Fold64(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+
+	return hi ^ lo
+}
+
+func xxh3Mix16B(data, secret []byte, seed uint64) uint64 {
+	return xxh3Mul128Fold64(
+		binary.LittleEndian.Uint64(data)^(binary.LittleEndian.Uint64(secret)+seed),
+		binary.LittleEndian.Uint64(data[8:])^(binary.LittleEndian.Uint64(secret[8:])-seed),
+	)
+}
+
+func xxh3Mix32B(lo, hi uint64, data1, data2, secret []byte, seed uint64) (uint64, uint64) {
+	lo += xxh3Mix16B(data1, secret, seed)
+	lo ^= binary.LittleEndian.Uint64(data2) + binary.LittleEndian.Uint64(data2[8:])
+	hi += xxh3Mix16B(data2, secret[16:], seed)
+	hi ^= binary.LittleEndian.Uint64(data1) + binary.LittleEndian.Uint64(data1[8:])
+
+	return lo, hi
+}
+
+func xxh3Len0To16(data, secret []byte, seed uint64) (uint64, uint64) {
+	n := len(data)
+
+	switch {
+	case n > 8:
+		bitflipLo := (binary.LittleEndian.Uint64(secret[32:]) ^ binary.LittleEndian.Uint64(secret[40:])) - seed
+		bitflipHi := (binary.LittleEndian.Uint64(secret[48:]) ^ binary.LittleEndian.Uint64(secret[56:])) + seed
+		inputLo := binary.LittleEndian.Uint64(data)
+		inputHi := binary.LittleEndian.Uint64(data[n-8:])
+
+		mHi, mLo := bits.Mul64(inputLo^inputHi^bitflipLo, xxPrime64_1)
+		mLo += uint64(n-1) << 54
+		inputHi ^= bitflipHi
+		mHi += inputHi + uint64(uint32(inputHi))*(xxPrime32_2-1)
+		mLo ^= bits.ReverseBytes64(mHi)
+
+		hHi, hLo := bits.Mul64(mLo, xxPrime64_2)
+		hHi += mHi * xxPrime64_2
+
+		return xxh3Avalanche(hHi), xxh3Avalanche(hLo)
+
+	case n >= 4:
+		seed ^= uint64(bits.ReverseBytes32(uint32(seed))) << 32
+		inputLo := uint64(binary.LittleEndian.Uint32(data))
+		inputHi := uint64(binary.LittleEndian.Uint32(data[n-4:]))
+		input64 := inputLo + inputHi<<32
+		bitflip := (binary.LittleEndian.Uint64(secret[16:]) ^ binary.LittleEndian.Uint64(secret[24:])) + seed
+		keyed := input64 ^ bitflip
+
+		mHi, mLo := bits.Mul64(keyed, xxPrime64_1+uint64(n)<<2)
+		mHi += mLo << 1
+		mLo ^= mHi >> 3
+		mLo ^= mLo >> 35
+		mLo *= xxPrimeMx2
+		mLo ^= mLo >> 28
+
+		return xxh3Avalanche(mHi), mLo
+
+	case n > 0:
+		c1, c2, c3 := uint32(data[0]), uint32(data[n>>1]), uint32(data[n-1])
+		combinedLo := c1<<16 | c2<<24 | c3 | uint32(n)<<8
+		combinedHi := bits.RotateLeft32(bits.ReverseBytes32(combinedLo), 13)
+		bitflipLo := uint64(binary.LittleEndian.Uint32(secret)^binary.LittleEndian.Uint32(secret[4:])) + seed
+		bitflipHi := uint64(binary.LittleEndian.Uint32(secret[8:])^binary.LittleEndian.Uint32(secret[12:])) - seed
+
+		return xxh64Avalanche(uint64(combinedHi) ^ bitflipHi), xxh64Avalanche(uint64(combinedLo) ^ bitflipLo)
+
+	default:
+		bitflipLo := binary.LittleEndian.Uint64(secret[64:]) ^ binary.LittleEndian.Uint64(secret[72:])
+		bitflipHi := binary.LittleEndian.Uint64(secret[80:]) ^ binary.LittleEndian.Uint64(secret[88:])
+
+		return xxh64Avalanche(seed ^ bitflipHi), xxh64Avalanche(seed ^ bitflipLo)
+	}
+}
+
+func xxh3Len17To128(data, secret []byte, seed uint64) (uint64, uint64) {
+	n := len(data)
+	lo, hi := uint64(n)*xxPrime64_1, uint64(0)
+
+	if n > 32 {
+		if n > 64 {
+			if n > 96 {
+				lo, hi = xxh3Mix32B(lo, hi, data[48:], data[n-64:], secret[96:], seed)
+			}
+			lo, hi = xxh3Mix32B(lo, hi, data[32:], data[n-48:], secret[64:], seed)
+		}
+		lo, hi = xxh3Mix32B(lo, hi, data[16:], data[n-32:], secret[32:], seed)
+	}
+	lo, hi = xxh3Mix32B(lo, hi, data, data[n-16:], secret, seed)
+
+	return xxh3Finalize128(lo, hi, uint64(n), seed)
+}
+
+func xxh3Len129To240(data, secret []byte, seed uint64) (uint64, uint64) {
+	n := len(data)
+	nbRounds := n / 32
+	lo, hi := uint64(n)*xxPrime64_1, uint64(0)
+
+	for i := 0; i < 4; i++ {
+		lo, hi = xxh3Mix32B(lo, hi, data[32*i:], data[32*i+16:], secret[32*i:], seed)
+	}
+	lo, hi = xxh3Avalanche(lo), xxh3Avalanche(hi)
+
+	for i := 4; i < nbRounds; i++ {
+		lo, hi = xxh3Mix32B(lo, hi, data[32*i:], data[32*i+16:], secret[xxh3MidSizeStartOffset+32*(i-4):], seed)
+	}
+	lo, hi = xxh3Mix32B(lo, hi, data[n-16:], data[n-32:], secret[xxh3SecretSizeMin-xxh3MidSizeLastOffset-16:], -seed)
+
+	return xxh3Finalize128(lo, hi, uint64(n), seed)
+}
+
+func xxh3Finalize128(lo, hi, n, seed uint64) (uint64, uint64) {
+	hLo := lo + hi
+	hHi := lo*xxPrime64_1 + hi*xxPrime64_4 + (n-seed)*xxPrime64_2
+
+	return -xxh3Avalanche(hHi), xxh3Avalanche(hLo)
+}
+
+func xxh3Accumulate512(acc *[8]uint64, data, secret []byte) {
+	for i := 0; i < 8; i++ {
+		value := binary.LittleEndian.Uint64(data[8*i:])
+		key := value ^ binary.LittleEndian.Uint64(secret[8*i:])
+		acc[i^1] += value
+		acc[i] += uint64(uint32(key)) * (key >> 32)
+	}
+}
+
+func xxh3Accumulate(acc *[8]uint64, data, secret []byte, nbStripes int) {
+	for i := 0; i < nbStripes; i++ {
+		xxh3Accumulate512(acc, data[i*xxh3StripeLen:], secret[i*xxh3SecretConsumeRate:])
+	}
+}
+
+func xxh3ScrambleAcc(acc *[8]uint64, secret []byte) {
+	for i := 0; i < 8; i++ {
+		a := acc[i]
+		a ^= a >> 47
+		a ^= binary.LittleEndian.Uint64(secret[8*i:])
+		a *= xxPrime32_1
+		acc[i] = a
+	}
+}
+
+func xxh3MergeAccs(acc *[8]uint64, secret []byte, start uint64) uint64 {
+	result := start
+	for i := 0; i < 4; i++ {
+		result += xxh3Mul128Fold64(
+			acc[2*i]^binary.LittleEndian.Uint64(secret[16*i:]),
+			acc[2*i+1]^binary.LittleEndian.Uint64(secret[16*i+8:]),
+		)
+	}
+
+	return xxh3Avalanche(result)
+}
+
+func xxh3Merge128(acc *[8]uint64, secret []byte, n uint64) (uint64, uint64) {
+	lo := xxh3MergeAccs(acc, secret[xxh3SecretMergeStart:], n*xxPrime64_1)
+	hi := xxh3MergeAccs(acc, secret[len(secret)-xxh3StripeLen-xxh3SecretMergeStart:], ^(n * xxPrime64_2))
+
+	return hi, lo
+}
+
+type xxHash128Digest struct {
+	seed   uint64
+	secret []byte
+
+	acc          [8]uint64
+	buf          [xxh3BufferSize]byte
+	n            int
+	stripesSoFar int
+	total        uint64
+}
+
+// NewXxHash128 returns a streaming XXH3 128 bits hash.Hash with seed,
+// Sum appends the high and low 64 bits in big endian.
+func NewXxHash128(seed uint64) hash.Hash {
+	d := &xxHash128Digest{seed: seed, secret: xxh3Secret[:]}
+	if seed != 0 {
+		d.secret = xxh3CustomSecret(seed)
+	}
+	d.Reset()
+
+	return d
+}
+
+func (d *xxHash128Digest) Reset() {
+	d.acc = xxh3InitAcc()
+	d.n = 0
+	d.stripesSoFar = 0
+	d.total = 0
+}
+
+func (d *xxHash128Digest) Size() int { return 16 }
+
+func (d *xxHash128Digest) BlockSize() int { return xxh3StripeLen }
+
+func (d *xxHash128Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.total += uint64(n)
+
+	// keep at least one byte in the buffer, so the last stripe is always processed by Sum
+	if d.n+len(p) <= xxh3BufferSize {
+		d.n += copy(d.buf[d.n:], p)
+		return n, nil
+	}
+
+	if d.n > 0 {
+		c := copy(d.buf[d.n:], p)
+		p = p[c:]
+		d.consumeStripes(&d.acc, &d.stripesSoFar, d.buf[:], xxh3BufferSize/xxh3StripeLen)
+		d.n = 0
+	}
+
+	if len(p) > xxh3BufferSize {
+		i := 0
+		for len(p)-i > xxh3BufferSize {
+			d.consumeStripes(&d.acc, &d.stripesSoFar, p[i:], xxh3BufferSize/xxh3StripeLen)
+			i += xxh3BufferSize
+		}
+		// keep the last consumed stripe, it's required by Sum if less than a stripe is buffered
+		copy(d.buf[xxh3BufferSize-xxh3StripeLen:], p[i-xxh3StripeLen:i])
+		p = p[i:]
+	}
+
+	d.n = copy(d.buf[:], p)
+
+	return n, nil
+}
+
+func (d *xxHash128Digest) consumeStripes(acc *[8]uint64, stripesSoFar *int, data []byte, nbStripes int) {
+	if xxh3StripesPerBlock-*stripesSoFar <= nbStripes {
+		toEnd := xxh3StripesPerBlock - *stripesSoFar
+		xxh3Accumulate(acc, data, d.secret[*stripesSoFar*xxh3SecretConsumeRate:], toEnd)
+		xxh3ScrambleAcc(acc, d.secret[len(d.secret)-xxh3StripeLen:])
+		xxh3Accumulate(acc, data[toEnd*xxh3StripeLen:], d.secret, nbStripes-toEnd)
+		*stripesSoFar = nbStripes - toEnd
+	} else {
+		xxh3Accumulate(acc, data, d.secret[*stripesSoFar*xxh3SecretConsumeRate:], nbStripes)
+		*stripesSoFar += nbStripes
+	}
+}
+
+// Sum128 returns the high and low 64 bits of hash.
+func (d *xxHash128Digest) Sum128() (uint64, uint64) {
+	if d.total <= xxh3MidSizeMax {
+		return XxHash128(d.buf[:d.n], d.seed)
+	}
+
+	acc := d.acc
+	stripesSoFar := d.stripesSoFar
+
+	if d.n >= xxh3StripeLen {
+		nbStripes := (d.n - 1) / xxh3StripeLen
+		d.consumeStripes(&acc, &stripesSoFar, d.buf[:], nbStripes)
+		xxh3Accumulate512(&acc, d.buf[d.n-xxh3StripeLen:], d.secret[len(d.secret)-xxh3StripeLen-xxh3SecretLastAccStart:])
+	} else {
+		var lastStripe [xxh3StripeLen]byte
+		catchup := xxh3StripeLen - d.n
+		copy(lastStripe[:], d.buf[xxh3BufferSize-catchup:])
+		copy(lastStripe[catchup:], d.buf[:d.n])
+		xxh3Accumulate512(&acc, lastStripe[:], d.secret[len(d.secret)-xxh3StripeLen-xxh3SecretLastAccStart:])
+	}
+
+	return xxh3Merge128(&acc, d.secret, d.total)
+}
+
+func (d *xxHash128Digest) Sum(b []byte) []byte {
+	hi, lo := d.Sum128()
+	return appendUint64(appendUint64(b, hi), lo)
+}
+
+// appendUint64 appends v to b in big endian.
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+
+	return append(b, buf[:]...)
+}
@@ -0,0 +1,340 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. BitSet is a growable set of non-negative integers.
+package datastructure
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const wordSize = 64
+
+// ErrInvalidBitSetData is returned by UnmarshalBinary if the length of data is not a multiple of 8.
+var ErrInvalidBitSetData = errors.New("bitset: length of data should be a multiple of 8")
+
+// BitSet is a growable vector of bits (thread unsafe), bit i is set means i is in the set. It grows when a bit
+// beyond its length is set, and the zero BitSet is an empty set ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates a BitSet pointer which could hold bits [0, size) without growing.
+func NewBitSet(size int) *BitSet {
+	if size < 0 {
+		size = 0
+	}
+
+	return &BitSet{words: make([]uint64, wordsOf(size))}
+}
+
+// wordsOf returns the count of words to hold n bits.
+func wordsOf(n int) int {
+	return (n + wordSize - 1) / wordSize
+}
+
+func checkIndex(i int) {
+	if i < 0 {
+		panic("bitset: index should not be negative, got " + strconv.Itoa(i))
+	}
+}
+
+// grow makes sure the bitset holds n words.
+func (b *BitSet) grow(n int) {
+	if n <= len(b.words) {
+		return
+	}
+
+	if n <= cap(b.words) {
+		b.words = b.words[:n]
+		return
+	}
+
+	words := make([]uint64, n, n+n/2)
+	copy(words, b.words)
+	b.words = words
+}
+
+// Set sets bit i, it panics if i is negative.
+func (b *BitSet) Set(i int) {
+	checkIndex(i)
+	b.grow(i/wordSize + 1)
+	b.words[i/wordSize] |= 1 << (uint(i) % wordSize)
+}
+
+// SetRange sets bits [from, to).
+func (b *BitSet) SetRange(from, to int) {
+	checkIndex(from)
+	if to <= from {
+		return
+	}
+
+	b.grow(wordsOf(to))
+	for i := from; i < to; {
+		if i%wordSize == 0 && to-i >= wordSize {
+			b.words[i/wordSize] = ^uint64(0)
+			i += wordSize
+			continue
+		}
+		b.words[i/wordSize] |= 1 << (uint(i) % wordSize)
+		i++
+	}
+}
+
+// Clear clears bit i, it panics if i is negative.
+func (b *BitSet) Clear(i int) {
+	checkIndex(i)
+	if i/wordSize < len(b.words) {
+		b.words[i/wordSize] &^= 1 << (uint(i) % wordSize)
+	}
+}
+
+// Flip flips bit i, it panics if i is negative.
+func (b *BitSet) Flip(i int) {
+	checkIndex(i)
+	b.grow(i/wordSize + 1)
+	b.words[i/wordSize] ^= 1 << (uint(i) % wordSize)
+}
+
+// Test checks if bit i is set.
+func (b *BitSet) Test(i int) bool {
+	if i < 0 || i/wordSize >= len(b.words) {
+		return false
+	}
+
+	return b.words[i/wordSize]&(1<<(uint(i)%wordSize)) != 0
+}
+
+// Count returns the count of set bits.
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+
+	return count
+}
+
+// Len returns the index of the highest set bit plus 1, it's 0 if no bit is set.
+func (b *BitSet) Len() int {
+	for i := len(b.words) - 1; i >= 0; i-- {
+		if b.words[i] != 0 {
+			return i*wordSize + bits.Len64(b.words[i])
+		}
+	}
+
+	return 0
+}
+
+// IsEmpty checks if no bit is set.
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ClearAll clears all the bits.
+func (b *BitSet) ClearAll() {
+	for i := range b.words {
+		b.words[i] = 0
+	}
+}
+
+// NextSetBit returns the index of the first set bit at or after from, it returns false if there is none.
+// All the set bits could be iterated by:
+//
+//	for i, ok := b.NextSetBit(0); ok; i, ok = b.NextSetBit(i + 1) {}
+func (b *BitSet) NextSetBit(from int) (int, bool) {
+	if from < 0 {
+		from = 0
+	}
+
+	i := from / wordSize
+	if i >= len(b.words) {
+		return 0, false
+	}
+
+	w := b.words[i] >> (uint(from) % wordSize)
+	if w != 0 {
+		return from + bits.TrailingZeros64(w), true
+	}
+
+	for i++; i < len(b.words); i++ {
+		if b.words[i] != 0 {
+			return i*wordSize + bits.TrailingZeros64(b.words[i]), true
+		}
+	}
+
+	return 0, false
+}
+
+// NextClearBit returns the index of the first clear bit at or after from.
+func (b *BitSet) NextClearBit(from int) int {
+	if from < 0 {
+		from = 0
+	}
+
+	i := from / wordSize
+	if i >= len(b.words) {
+		return from
+	}
+
+	w := ^b.words[i] >> (uint(from) % wordSize)
+	if w != 0 {
+		return from + bits.TrailingZeros64(w)
+	}
+
+	for i++; i < len(b.words); i++ {
+		if b.words[i] != ^uint64(0) {
+			return i*wordSize + bits.TrailingZeros64(^b.words[i])
+		}
+	}
+
+	return len(b.words) * wordSize
+}
+
+// Values returns the indexes of all the set bits in ascending order.
+func (b *BitSet) Values() []int {
+	result := make([]int, 0, b.Count())
+	for i, w := range b.words {
+		for w != 0 {
+			result = append(result, i*wordSize+bits.TrailingZeros64(w))
+			// clear the lowest set bit
+			w &= w - 1
+		}
+	}
+
+	return result
+}
+
+// Clone returns a copy of the bitset.
+func (b *BitSet) Clone() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+
+	return &BitSet{words: words}
+}
+
+// Equal checks if the two bitsets have the same set bits, no matter their lengths.
+func (b *BitSet) Equal(other *BitSet) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// And returns a new bitset of the bits set in both b and other.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	n := len(b.words)
+	if len(other.words) < n {
+		n = len(other.words)
+	}
+
+	result := &BitSet{words: make([]uint64, n)}
+	for i := range result.words {
+		result.words[i] = b.words[i] & other.words[i]
+	}
+
+	return result
+}
+
+// Or returns a new bitset of the bits set in either b or other.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns a new bitset of the bits set in only one of b and other.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns a new bitset of the bits set in b but not in other.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// combine applies op on the words of b and other, the missing words of the shorter one are 0.
+func (b *BitSet) combine(other *BitSet, op func(x, y uint64) uint64) *BitSet {
+	n := len(b.words)
+	if len(other.words) > n {
+		n = len(other.words)
+	}
+
+	result := &BitSet{words: make([]uint64, n)}
+	for i := range result.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(other.words) {
+			y = other.words[i]
+		}
+		result.words[i] = op(x, y)
+	}
+
+	return result
+}
+
+// String returns the set bits like {1, 3, 5}.
+func (b *BitSet) String() string {
+	var builder strings.Builder
+	builder.WriteByte('{')
+
+	for i, ok := b.NextSetBit(0); ok; i, ok = b.NextSetBit(i + 1) {
+		if builder.Len() > 1 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(strconv.Itoa(i))
+	}
+	builder.WriteByte('}')
+
+	return builder.String()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, the words are encoded in little endian without the
+// trailing zero words.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	n := wordsOf(b.Len())
+	data := make([]byte, n*8)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(data[i*8:], b.words[i])
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, it replaces the bits with data encoded by MarshalBinary.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return ErrInvalidBitSetData
+	}
+
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	b.words = words
+
+	return nil
+}
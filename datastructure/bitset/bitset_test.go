@@ -0,0 +1,160 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestBitSet_SetAndClear(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBitSet_SetAndClear")
+
+	var b BitSet
+	assert.Equal(true, b.IsEmpty())
+	assert.Equal(0, b.Len())
+
+	b.Set(1)
+	b.Set(64)
+	b.Set(200)
+	assert.Equal(true, b.Test(1))
+	assert.Equal(true, b.Test(64))
+	assert.Equal(false, b.Test(2))
+	assert.Equal(false, b.Test(1000))
+	assert.Equal(false, b.Test(-1))
+	assert.Equal(3, b.Count())
+	assert.Equal(201, b.Len())
+
+	b.Clear(64)
+	b.Clear(1000)
+	assert.Equal(false, b.Test(64))
+	assert.Equal([]int{1, 200}, b.Values())
+
+	b.Flip(1)
+	b.Flip(2)
+	assert.Equal([]int{2, 200}, b.Values())
+
+	b.SetRange(60, 140)
+	assert.Equal(82, b.Count())
+	assert.Equal(true, b.Test(60))
+	assert.Equal(true, b.Test(139))
+	assert.Equal(false, b.Test(140))
+
+	b.ClearAll()
+	assert.Equal(true, b.IsEmpty())
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	b.Set(-1)
+}
+
+func TestBitSet_NextBit(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBitSet_NextBit")
+
+	b := NewBitSet(256)
+	for _, i := range []int{0, 5, 63, 64, 130} {
+		b.Set(i)
+	}
+
+	var result []int
+	for i, ok := b.NextSetBit(0); ok; i, ok = b.NextSetBit(i + 1) {
+		result = append(result, i)
+	}
+	assert.Equal([]int{0, 5, 63, 64, 130}, result)
+
+	_, ok := b.NextSetBit(131)
+	assert.Equal(false, ok)
+	_, ok = b.NextSetBit(1000)
+	assert.Equal(false, ok)
+
+	assert.Equal(1, b.NextClearBit(0))
+	assert.Equal(65, b.NextClearBit(63))
+	assert.Equal(1000, b.NextClearBit(1000))
+
+	full := NewBitSet(0)
+	full.SetRange(0, 128)
+	assert.Equal(128, full.NextClearBit(0))
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBitSet_Operations")
+
+	a, b := NewBitSet(0), NewBitSet(0)
+	for _, i := range []int{1, 2, 3, 100} {
+		a.Set(i)
+	}
+	for _, i := range []int{2, 3, 4} {
+		b.Set(i)
+	}
+
+	assert.Equal([]int{2, 3}, a.And(b).Values())
+	assert.Equal([]int{1, 2, 3, 4, 100}, a.Or(b).Values())
+	assert.Equal([]int{1, 4, 100}, a.Xor(b).Values())
+	assert.Equal([]int{1, 100}, a.AndNot(b).Values())
+	assert.Equal([]int{4}, b.AndNot(a).Values())
+
+	// the operands are not changed
+	assert.Equal([]int{1, 2, 3, 100}, a.Values())
+
+	c := a.Clone()
+	assert.Equal(true, c.Equal(a))
+	c.Set(1000)
+	c.Clear(1000)
+	assert.Equal(true, c.Equal(a))
+	assert.Equal(true, a.Equal(c))
+	c.Clear(100)
+	assert.Equal(false, c.Equal(a))
+
+	assert.Equal("{1, 2, 3, 100}", a.String())
+	assert.Equal("{}", NewBitSet(10).String())
+}
+
+func TestBitSet_Sieve(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBitSet_Sieve")
+
+	n := 50
+	composite := NewBitSet(n)
+	for i := 2; i*i < n; i++ {
+		if !composite.Test(i) {
+			for j := i * i; j < n; j += i {
+				composite.Set(j)
+			}
+		}
+	}
+
+	var primes []int
+	for i := composite.NextClearBit(2); i < n; i = composite.NextClearBit(i + 1) {
+		primes = append(primes, i)
+	}
+	assert.Equal([]int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47}, primes)
+}
+
+func TestBitSet_Marshal(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBitSet_Marshal")
+
+	b := NewBitSet(1024)
+	b.Set(3)
+	b.Set(70)
+
+	data, err := b.MarshalBinary()
+	assert.IsNil(err)
+	// the trailing zero words are trimmed
+	assert.Equal(16, len(data))
+
+	var decoded BitSet
+	assert.IsNil(decoded.UnmarshalBinary(data))
+	assert.Equal(true, decoded.Equal(b))
+	assert.Equal([]int{3, 70}, decoded.Values())
+
+	assert.Equal(ErrInvalidBitSetData, decoded.UnmarshalBinary([]byte{1, 2, 3}))
+}
@@ -0,0 +1,203 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package datastructure contains some data structure. Grid is a 2D grid of cells, like game board or image mask.
+package datastructure
+
+import "errors"
+
+// ErrInvalidGridData is returned by NewGridFrom if the rows of data have different lengths.
+var ErrInvalidGridData = errors.New("grid: rows of data should have the same length")
+
+// Point is the position of a cell in grid.
+type Point struct {
+	Row int
+	Col int
+}
+
+var (
+	directions4 = []Point{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+	directions8 = []Point{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}
+)
+
+// neighbors returns the points around (row, col) in directions which are inside rows x cols.
+func neighbors(rows, cols, row, col int, directions []Point) []Point {
+	result := make([]Point, 0, len(directions))
+	for _, d := range directions {
+		r, c := row+d.Row, col+d.Col
+		if r >= 0 && r < rows && c >= 0 && c < cols {
+			result = append(result, Point{Row: r, Col: c})
+		}
+	}
+
+	return result
+}
+
+// floodFill visits the cells connected with (row, col) in 4 directions which are the same as it, and calls fill
+// for each of them. It returns the count of visited cells.
+func floodFill(rows, cols, row, col int, same func(row, col int) bool, fill func(row, col int)) int {
+	if row < 0 || row >= rows || col < 0 || col >= cols {
+		return 0
+	}
+
+	// the cells are checked before filled, so the fill value could be the same as the original one
+	visited := map[Point]struct{}{{Row: row, Col: col}: {}}
+	stack := []Point{{Row: row, Col: col}}
+
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, n := range neighbors(rows, cols, p.Row, p.Col, directions4) {
+			if _, ok := visited[n]; ok || !same(n.Row, n.Col) {
+				continue
+			}
+			visited[n] = struct{}{}
+			stack = append(stack, n)
+		}
+	}
+
+	for p := range visited {
+		fill(p.Row, p.Col)
+	}
+
+	return len(visited)
+}
+
+// Grid is a dense 2D grid of rows x cols cells stored in row major order (thread unsafe).
+type Grid[T any] struct {
+	rows  int
+	cols  int
+	cells []T
+}
+
+// NewGrid creates a Grid pointer of rows x cols cells with zero values, it panics if rows or cols is negative.
+func NewGrid[T any](rows, cols int) *Grid[T] {
+	if rows < 0 || cols < 0 {
+		panic("grid: rows and cols should not be negative")
+	}
+
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// NewGridFrom creates a Grid pointer with a copy of data, data[i] is row i.
+func NewGridFrom[T any](data [][]T) (*Grid[T], error) {
+	rows, cols := len(data), 0
+	if rows > 0 {
+		cols = len(data[0])
+	}
+
+	g := NewGrid[T](rows, cols)
+	for i, row := range data {
+		if len(row) != cols {
+			return nil, ErrInvalidGridData
+		}
+		copy(g.cells[i*cols:], row)
+	}
+
+	return g, nil
+}
+
+// Rows returns the count of rows.
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the count of columns.
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// InBounds checks if (row, col) is inside the grid.
+func (g *Grid[T]) InBounds(row, col int) bool {
+	return row >= 0 && row < g.rows && col >= 0 && col < g.cols
+}
+
+// Get returns the value of cell (row, col), it returns false if the cell is out of bounds.
+func (g *Grid[T]) Get(row, col int) (T, bool) {
+	if !g.InBounds(row, col) {
+		var zero T
+		return zero, false
+	}
+
+	return g.cells[row*g.cols+col], true
+}
+
+// Set sets the value of cell (row, col), it returns false if the cell is out of bounds.
+func (g *Grid[T]) Set(row, col int, value T) bool {
+	if !g.InBounds(row, col) {
+		return false
+	}
+	g.cells[row*g.cols+col] = value
+
+	return true
+}
+
+// Fill sets all the cells to value.
+func (g *Grid[T]) Fill(value T) {
+	for i := range g.cells {
+		g.cells[i] = value
+	}
+}
+
+// Neighbors4 returns the cells above, right, below and left to (row, col) inside the grid.
+func (g *Grid[T]) Neighbors4(row, col int) []Point {
+	return neighbors(g.rows, g.cols, row, col, directions4)
+}
+
+// Neighbors8 returns the cells around (row, col) inside the grid, including the diagonal ones, clockwise from above.
+func (g *Grid[T]) Neighbors8(row, col int) []Point {
+	return neighbors(g.rows, g.cols, row, col, directions8)
+}
+
+// ForEach calls iteratee for each cell in row major order.
+func (g *Grid[T]) ForEach(iteratee func(row, col int, value T)) {
+	for i, v := range g.cells {
+		iteratee(i/g.cols, i%g.cols, v)
+	}
+}
+
+// Rotate returns a new grid rotated 90 degrees clockwise, the rows x cols grid becomes cols x rows.
+func (g *Grid[T]) Rotate() *Grid[T] {
+	result := NewGrid[T](g.cols, g.rows)
+	for r := 0; r < g.rows; r++ {
+		for c := 0; c < g.cols; c++ {
+			result.cells[c*result.cols+(g.rows-1-r)] = g.cells[r*g.cols+c]
+		}
+	}
+
+	return result
+}
+
+// FloodFill sets the cells connected with (row, col) in 4 directions which are equal to it to value, like
+// the bucket tool of paint. It returns the count of filled cells.
+func (g *Grid[T]) FloodFill(row, col int, value T, equal func(a, b T) bool) int {
+	origin, ok := g.Get(row, col)
+	if !ok {
+		return 0
+	}
+
+	return floodFill(g.rows, g.cols, row, col,
+		func(r, c int) bool { return equal(g.cells[r*g.cols+c], origin) },
+		func(r, c int) { g.cells[r*g.cols+c] = value },
+	)
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	result := NewGrid[T](g.rows, g.cols)
+	copy(result.cells, g.cells)
+
+	return result
+}
+
+// ToSlice returns the cells as rows, the slices are copies.
+func (g *Grid[T]) ToSlice() [][]T {
+	result := make([][]T, g.rows)
+	for r := range result {
+		result[r] = make([]T, g.cols)
+		copy(result[r], g.cells[r*g.cols:(r+1)*g.cols])
+	}
+
+	return result
+}
@@ -0,0 +1,117 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestGrid_GetAndSet(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGrid_GetAndSet")
+
+	g := NewGrid[int](2, 3)
+	assert.Equal(2, g.Rows())
+	assert.Equal(3, g.Cols())
+
+	assert.Equal(true, g.Set(1, 2, 5))
+	assert.Equal(false, g.Set(2, 0, 5))
+	assert.Equal(false, g.Set(0, -1, 5))
+
+	v, ok := g.Get(1, 2)
+	assert.Equal(true, ok)
+	assert.Equal(5, v)
+
+	_, ok = g.Get(0, 3)
+	assert.Equal(false, ok)
+
+	g.Fill(1)
+	assert.Equal([][]int{{1, 1, 1}, {1, 1, 1}}, g.ToSlice())
+
+	var sum int
+	g.ForEach(func(row, col, value int) {
+		sum += value
+	})
+	assert.Equal(6, sum)
+
+	c := g.Clone()
+	c.Set(0, 0, 9)
+	v, _ = g.Get(0, 0)
+	assert.Equal(1, v)
+}
+
+func TestNewGridFrom(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestNewGridFrom")
+
+	data := [][]int{{1, 2}, {3, 4}, {5, 6}}
+	g, err := NewGridFrom(data)
+	assert.IsNil(err)
+	assert.Equal(data, g.ToSlice())
+
+	data[0][0] = 100
+	v, _ := g.Get(0, 0)
+	assert.Equal(1, v)
+
+	_, err = NewGridFrom([][]int{{1, 2}, {3}})
+	assert.Equal(ErrInvalidGridData, err)
+
+	empty, err := NewGridFrom[int](nil)
+	assert.IsNil(err)
+	assert.Equal(0, empty.Rows())
+}
+
+func TestGrid_Neighbors(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGrid_Neighbors")
+
+	g := NewGrid[int](3, 3)
+
+	assert.Equal([]Point{{0, 1}, {1, 2}, {2, 1}, {1, 0}}, g.Neighbors4(1, 1))
+	assert.Equal([]Point{{0, 1}, {1, 0}}, g.Neighbors4(0, 0))
+	assert.Equal(8, len(g.Neighbors8(1, 1)))
+	assert.Equal([]Point{{0, 1}, {1, 1}, {1, 0}}, g.Neighbors8(0, 0))
+	assert.Equal([]Point{{1, 2}, {2, 1}, {1, 1}}, g.Neighbors8(2, 2))
+}
+
+func TestGrid_Rotate(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGrid_Rotate")
+
+	g, _ := NewGridFrom([][]int{{1, 2, 3}, {4, 5, 6}})
+
+	r := g.Rotate()
+	assert.Equal([][]int{{4, 1}, {5, 2}, {6, 3}}, r.ToSlice())
+	assert.Equal(g.ToSlice(), r.Rotate().Rotate().Rotate().ToSlice())
+}
+
+func TestGrid_FloodFill(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGrid_FloodFill")
+
+	equal := func(a, b int) bool { return a == b }
+
+	g, _ := NewGridFrom([][]int{
+		{1, 1, 0, 0},
+		{1, 0, 0, 1},
+		{1, 1, 0, 1},
+		{0, 1, 1, 1},
+	})
+
+	assert.Equal(5, g.FloodFill(0, 2, 2, equal))
+	assert.Equal([][]int{
+		{1, 1, 2, 2},
+		{1, 2, 2, 1},
+		{1, 1, 2, 1},
+		{0, 1, 1, 1},
+	}, g.ToSlice())
+
+	// same value
+	assert.Equal(1, g.FloodFill(3, 0, 0, equal))
+	assert.Equal(0, g.FloodFill(4, 0, 0, equal))
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+// SparseGrid is a 2D grid of rows x cols cells backed by map (thread unsafe), only the cells set to a value
+// are stored, and the others have the default value. It's suitable for large grids with few cells set.
+type SparseGrid[T any] struct {
+	rows         int
+	cols         int
+	defaultValue T
+	cells        map[Point]T
+}
+
+// NewSparseGrid creates a SparseGrid pointer of rows x cols cells with default value, it panics if rows or cols
+// is negative.
+func NewSparseGrid[T any](rows, cols int, defaultValue T) *SparseGrid[T] {
+	if rows < 0 || cols < 0 {
+		panic("grid: rows and cols should not be negative")
+	}
+
+	return &SparseGrid[T]{rows: rows, cols: cols, defaultValue: defaultValue, cells: map[Point]T{}}
+}
+
+// Rows returns the count of rows.
+func (g *SparseGrid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the count of columns.
+func (g *SparseGrid[T]) Cols() int {
+	return g.cols
+}
+
+// Len returns the count of stored cells.
+func (g *SparseGrid[T]) Len() int {
+	return len(g.cells)
+}
+
+// InBounds checks if (row, col) is inside the grid.
+func (g *SparseGrid[T]) InBounds(row, col int) bool {
+	return row >= 0 && row < g.rows && col >= 0 && col < g.cols
+}
+
+// Get returns the value of cell (row, col), which is the default value if it's not set. It returns false if the
+// cell is out of bounds.
+func (g *SparseGrid[T]) Get(row, col int) (T, bool) {
+	if !g.InBounds(row, col) {
+		var zero T
+		return zero, false
+	}
+
+	if v, ok := g.cells[Point{Row: row, Col: col}]; ok {
+		return v, true
+	}
+
+	return g.defaultValue, true
+}
+
+// Set sets the value of cell (row, col), it returns false if the cell is out of bounds.
+func (g *SparseGrid[T]) Set(row, col int, value T) bool {
+	if !g.InBounds(row, col) {
+		return false
+	}
+	g.cells[Point{Row: row, Col: col}] = value
+
+	return true
+}
+
+// Delete resets cell (row, col) to the default value, so it's not stored any more.
+func (g *SparseGrid[T]) Delete(row, col int) {
+	delete(g.cells, Point{Row: row, Col: col})
+}
+
+// Fill sets all the cells to value, which becomes the default value, so no cell is stored.
+func (g *SparseGrid[T]) Fill(value T) {
+	g.defaultValue = value
+	g.cells = map[Point]T{}
+}
+
+// Neighbors4 returns the cells above, right, below and left to (row, col) inside the grid.
+func (g *SparseGrid[T]) Neighbors4(row, col int) []Point {
+	return neighbors(g.rows, g.cols, row, col, directions4)
+}
+
+// Neighbors8 returns the cells around (row, col) inside the grid, including the diagonal ones, clockwise from above.
+func (g *SparseGrid[T]) Neighbors8(row, col int) []Point {
+	return neighbors(g.rows, g.cols, row, col, directions8)
+}
+
+// ForEach calls iteratee for each stored cell, the order is not specified.
+func (g *SparseGrid[T]) ForEach(iteratee func(row, col int, value T)) {
+	for p, v := range g.cells {
+		iteratee(p.Row, p.Col, v)
+	}
+}
+
+// Rotate returns a new grid rotated 90 degrees clockwise, the rows x cols grid becomes cols x rows.
+func (g *SparseGrid[T]) Rotate() *SparseGrid[T] {
+	result := NewSparseGrid(g.cols, g.rows, g.defaultValue)
+	for p, v := range g.cells {
+		result.cells[Point{Row: p.Col, Col: g.rows - 1 - p.Row}] = v
+	}
+
+	return result
+}
+
+// FloodFill sets the cells connected with (row, col) in 4 directions which are equal to it to value, it returns
+// the count of filled cells. The cells with default value are stored after filled, so filling a large area of
+// default value makes the grid dense.
+func (g *SparseGrid[T]) FloodFill(row, col int, value T, equal func(a, b T) bool) int {
+	origin, ok := g.Get(row, col)
+	if !ok {
+		return 0
+	}
+
+	return floodFill(g.rows, g.cols, row, col,
+		func(r, c int) bool {
+			v, _ := g.Get(r, c)
+			return equal(v, origin)
+		},
+		func(r, c int) { g.cells[Point{Row: r, Col: c}] = value },
+	)
+}
+
+// ToGrid converts the sparse grid into a dense Grid.
+func (g *SparseGrid[T]) ToGrid() *Grid[T] {
+	result := NewGrid[T](g.rows, g.cols)
+	result.Fill(g.defaultValue)
+	for p, v := range g.cells {
+		result.cells[p.Row*g.cols+p.Col] = v
+	}
+
+	return result
+}
@@ -0,0 +1,88 @@
+package datastructure
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestSparseGrid_GetAndSet(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSparseGrid_GetAndSet")
+
+	g := NewSparseGrid(1000000, 1000000, '.')
+	assert.Equal(0, g.Len())
+
+	assert.Equal(true, g.Set(999999, 5, '#'))
+	assert.Equal(false, g.Set(1000000, 5, '#'))
+	assert.Equal(1, g.Len())
+
+	v, ok := g.Get(999999, 5)
+	assert.Equal(true, ok)
+	assert.Equal('#', v)
+
+	v, ok = g.Get(3, 3)
+	assert.Equal(true, ok)
+	assert.Equal('.', v)
+
+	_, ok = g.Get(-1, 3)
+	assert.Equal(false, ok)
+
+	g.Delete(999999, 5)
+	assert.Equal(0, g.Len())
+
+	g.Set(1, 1, '#')
+	g.Fill('x')
+	assert.Equal(0, g.Len())
+	v, _ = g.Get(1, 1)
+	assert.Equal('x', v)
+
+	assert.Equal([]Point{{0, 1}, {1, 0}}, g.Neighbors4(0, 0))
+	assert.Equal(8, len(g.Neighbors8(5, 5)))
+}
+
+func TestSparseGrid_Rotate(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSparseGrid_Rotate")
+
+	g := NewSparseGrid(2, 3, 0)
+	g.Set(0, 0, 1)
+	g.Set(1, 2, 6)
+
+	r := g.Rotate()
+	assert.Equal(3, r.Rows())
+	assert.Equal(2, r.Cols())
+	assert.Equal([][]int{{0, 1}, {0, 0}, {6, 0}}, r.ToGrid().ToSlice())
+
+	dense, _ := NewGridFrom(g.ToGrid().ToSlice())
+	assert.Equal(dense.Rotate().ToSlice(), r.ToGrid().ToSlice())
+
+	count := 0
+	r.ForEach(func(row, col, value int) {
+		count++
+	})
+	assert.Equal(2, count)
+}
+
+func TestSparseGrid_FloodFill(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSparseGrid_FloodFill")
+
+	equal := func(a, b int) bool { return a == b }
+
+	// a wall splits the grid into two parts
+	g := NewSparseGrid(3, 4, 0)
+	for r := 0; r < 3; r++ {
+		g.Set(r, 1, 1)
+	}
+
+	assert.Equal(3, g.FloodFill(0, 0, 2, equal))
+	assert.Equal([][]int{
+		{2, 1, 0, 0},
+		{2, 1, 0, 0},
+		{2, 1, 0, 0},
+	}, g.ToGrid().ToSlice())
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"sync"
+
+	"github.com/duke-git/lancet/v2/concurrency/atomicx"
+)
+
+// CowMap is a copy-on-write map for read-heavy data like configuration: readers load an immutable snapshot
+// without lock, and writers copy the snapshot, modify the copy and swap it in atomically. Writers are serialized
+// by a mutex, so a write is never lost. The zero CowMap is an empty map ready to use.
+type CowMap[K comparable, V any] struct {
+	mu   sync.Mutex
+	data atomicx.Value[map[K]V]
+}
+
+// NewCowMap creates a CowMap pointer with a copy of data.
+func NewCowMap[K comparable, V any](data map[K]V) *CowMap[K, V] {
+	m := &CowMap[K, V]{}
+	m.data.Store(cloneMap(data, 0))
+
+	return m
+}
+
+// Load returns the current snapshot, which is shared by all readers and should not be modified.
+func (m *CowMap[K, V]) Load() map[K]V {
+	return m.data.Load()
+}
+
+// Get returns the value of key in current snapshot.
+func (m *CowMap[K, V]) Get(key K) (V, bool) {
+	v, ok := m.data.Load()[key]
+	return v, ok
+}
+
+// Len returns the count of entries in current snapshot.
+func (m *CowMap[K, V]) Len() int {
+	return len(m.data.Load())
+}
+
+// Range calls iteratee for each entry of current snapshot until it returns false, the writes during the
+// iteration are not visible.
+func (m *CowMap[K, V]) Range(iteratee func(key K, value V) bool) {
+	for k, v := range m.data.Load() {
+		if !iteratee(k, v) {
+			return
+		}
+	}
+}
+
+// Put sets the value of key.
+func (m *CowMap[K, V]) Put(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := cloneMap(m.data.Load(), 1)
+	data[key] = value
+	m.data.Store(data)
+}
+
+// Delete deletes key, it returns false if the key doesn't exist.
+func (m *CowMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old := m.data.Load()
+	if _, ok := old[key]; !ok {
+		return false
+	}
+
+	data := cloneMap(old, 0)
+	delete(data, key)
+	m.data.Store(data)
+
+	return true
+}
+
+// Store replaces the snapshot with a copy of data.
+func (m *CowMap[K, V]) Store(data map[K]V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.data.Store(cloneMap(data, 0))
+}
+
+// Update calls fn with a copy of current snapshot to modify, and stores it as the new snapshot, so a batch of
+// writes is visible to readers at once.
+func (m *CowMap[K, V]) Update(fn func(data map[K]V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := cloneMap(m.data.Load(), 0)
+	fn(data)
+	m.data.Store(data)
+}
+
+// cloneMap copies data into a new map with extra capacity.
+func cloneMap[K comparable, V any](data map[K]V, extra int) map[K]V {
+	result := make(map[K]V, len(data)+extra)
+	for k, v := range data {
+		result[k] = v
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package datastructure
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestCowMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCowMap")
+
+	source := map[string]int{"a": 1, "b": 2}
+	m := NewCowMap(source)
+	source["c"] = 3
+
+	assert.Equal(2, m.Len())
+	snapshot := m.Load()
+
+	m.Put("c", 3)
+	m.Put("a", 10)
+	assert.Equal(true, m.Delete("b"))
+	assert.Equal(false, m.Delete("d"))
+
+	assert.Equal(map[string]int{"a": 10, "c": 3}, m.Load())
+	// the old snapshot is not changed
+	assert.Equal(map[string]int{"a": 1, "b": 2}, snapshot)
+
+	v, ok := m.Get("a")
+	assert.Equal(true, ok)
+	assert.Equal(10, v)
+	_, ok = m.Get("b")
+	assert.Equal(false, ok)
+
+	m.Update(func(data map[string]int) {
+		data["x"] = 1
+		data["y"] = 2
+		delete(data, "a")
+	})
+	assert.Equal(map[string]int{"c": 3, "x": 1, "y": 2}, m.Load())
+
+	count := 0
+	m.Range(func(key string, value int) bool {
+		count++
+		return false
+	})
+	assert.Equal(1, count)
+
+	m.Store(map[string]int{"z": 0})
+	assert.Equal(map[string]int{"z": 0}, m.Load())
+}
+
+func TestCowMap_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCowMap_ZeroValue")
+
+	var m CowMap[string, int]
+	assert.Equal(0, m.Len())
+
+	_, ok := m.Get("a")
+	assert.Equal(false, ok)
+
+	m.Put("a", 1)
+	assert.Equal(map[string]int{"a": 1}, m.Load())
+}
+
+func TestCowMap_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCowMap_Concurrent")
+
+	m := NewCowMap[int, int](nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Put(i*100+j, j)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Get(j)
+				m.Range(func(key, value int) bool { return true })
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(1000, m.Len())
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"sync"
+
+	"github.com/duke-git/lancet/v2/concurrency/atomicx"
+)
+
+// CowSlice is a copy-on-write slice for read-heavy data like configuration: readers load an immutable snapshot
+// without lock, and writers copy the snapshot, modify the copy and swap it in atomically. Writers are serialized
+// by a mutex, so a write is never lost. The zero CowSlice is an empty slice ready to use.
+type CowSlice[T any] struct {
+	mu   sync.Mutex
+	data atomicx.Value[[]T]
+}
+
+// NewCowSlice creates a CowSlice pointer with a copy of data.
+func NewCowSlice[T any](data []T) *CowSlice[T] {
+	s := &CowSlice[T]{}
+	s.data.Store(cloneSlice(data))
+
+	return s
+}
+
+// Load returns the current snapshot, which is shared by all readers and should not be modified.
+func (s *CowSlice[T]) Load() []T {
+	return s.data.Load()
+}
+
+// Len returns the length of current snapshot.
+func (s *CowSlice[T]) Len() int {
+	return len(s.data.Load())
+}
+
+// Get returns the element at index of current snapshot.
+func (s *CowSlice[T]) Get(index int) (T, bool) {
+	data := s.data.Load()
+	if index < 0 || index >= len(data) {
+		var zero T
+		return zero, false
+	}
+
+	return data[index], true
+}
+
+// Store replaces the snapshot with a copy of data.
+func (s *CowSlice[T]) Store(data []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Store(cloneSlice(data))
+}
+
+// Update calls fn with a copy of current snapshot, and stores the returned slice as the new snapshot.
+// The writes are serialized, so fn always sees the result of the previous write.
+func (s *CowSlice[T]) Update(fn func(data []T) []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Store(fn(cloneSlice(s.data.Load())))
+}
+
+// Append appends items to the slice.
+func (s *CowSlice[T]) Append(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.data.Load()
+	data := make([]T, len(old), len(old)+len(items))
+	copy(data, old)
+	s.data.Store(append(data, items...))
+}
+
+// Set replaces the element at index, it returns false if the index is out of range.
+func (s *CowSlice[T]) Set(index int, item T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.data.Load()
+	if index < 0 || index >= len(old) {
+		return false
+	}
+
+	data := cloneSlice(old)
+	data[index] = item
+	s.data.Store(data)
+
+	return true
+}
+
+// DeleteAt deletes the element at index, it returns false if the index is out of range.
+func (s *CowSlice[T]) DeleteAt(index int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old := s.data.Load()
+	if index < 0 || index >= len(old) {
+		return false
+	}
+
+	data := make([]T, 0, len(old)-1)
+	data = append(data, old[:index]...)
+	s.data.Store(append(data, old[index+1:]...))
+
+	return true
+}
+
+func cloneSlice[T any](data []T) []T {
+	result := make([]T, len(data))
+	copy(result, data)
+
+	return result
+}
@@ -0,0 +1,89 @@
+package datastructure
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestCowSlice(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCowSlice")
+
+	source := []int{1, 2, 3}
+	s := NewCowSlice(source)
+	source[0] = 100
+
+	assert.Equal([]int{1, 2, 3}, s.Load())
+	assert.Equal(3, s.Len())
+
+	snapshot := s.Load()
+
+	s.Append(4, 5)
+	assert.Equal(true, s.Set(0, 10))
+	assert.Equal(false, s.Set(5, 10))
+	assert.Equal(true, s.DeleteAt(1))
+	assert.Equal(false, s.DeleteAt(-1))
+
+	assert.Equal([]int{10, 3, 4, 5}, s.Load())
+	// the old snapshot is not changed
+	assert.Equal([]int{1, 2, 3}, snapshot)
+
+	v, ok := s.Get(1)
+	assert.Equal(true, ok)
+	assert.Equal(3, v)
+	_, ok = s.Get(4)
+	assert.Equal(false, ok)
+
+	s.Update(func(data []int) []int {
+		data[0] = 0
+		return data[:2]
+	})
+	assert.Equal([]int{0, 3}, s.Load())
+
+	s.Store([]int{7})
+	assert.Equal([]int{7}, s.Load())
+}
+
+func TestCowSlice_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCowSlice_ZeroValue")
+
+	var s CowSlice[string]
+	assert.Equal(0, s.Len())
+
+	s.Append("a")
+	assert.Equal([]string{"a"}, s.Load())
+}
+
+func TestCowSlice_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCowSlice_Concurrent")
+
+	s := NewCowSlice[int](nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Append(i)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for range s.Load() {
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(1000, s.Len())
+}
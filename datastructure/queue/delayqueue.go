@@ -0,0 +1,151 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+type delayedItem[T any] struct {
+	value T
+	at    time.Time
+	// seq keeps the order of items with the same time
+	seq uint64
+}
+
+// delayHeap implements heap.Interface, the earliest item is at the top.
+type delayHeap[T any] []delayedItem[T]
+
+func (h delayHeap[T]) Len() int { return len(h) }
+
+func (h delayHeap[T]) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].at.Before(h[j].at)
+}
+
+func (h delayHeap[T]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *delayHeap[T]) Push(x any)   { *h = append(*h, x.(delayedItem[T])) }
+
+func (h *delayHeap[T]) Pop() any {
+	old := *h
+	n := len(old) - 1
+	item := old[n]
+	old[n] = delayedItem[T]{}
+	*h = old[:n]
+
+	return item
+}
+
+// DelayQueue is an unbounded queue of delayed items (thread safe), an item is available only after its delay
+// expires, and the items are dequeued in the order of their expiration, e.g. for scheduling jobs in process.
+type DelayQueue[T any] struct {
+	mu     sync.Mutex
+	items  delayHeap[T]
+	seq    uint64
+	signal signal
+}
+
+// NewDelayQueue return a pointer of DelayQueue
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return &DelayQueue[T]{signal: newSignal()}
+}
+
+// Put inserts item into queue, which is available after delay.
+func (q *DelayQueue[T]) Put(item T, delay time.Duration) {
+	q.PutAt(item, time.Now().Add(delay))
+}
+
+// PutAt inserts item into queue, which is available at time at.
+func (q *DelayQueue[T]) PutAt(item T, at time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq++
+	heap.Push(&q.items, delayedItem[T]{value: item, at: at, seq: q.seq})
+	q.signal.broadcast()
+}
+
+// TryPoll deletes and returns the earliest expired item without blocking, it returns false if there is none.
+func (q *DelayQueue[T]) TryPoll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 || q.items[0].at.After(time.Now()) {
+		var zero T
+		return zero, false
+	}
+
+	return heap.Pop(&q.items).(delayedItem[T]).value, true
+}
+
+// Poll deletes and returns the earliest item, it blocks until the delay of an item expires or ctx is done, and
+// returns the error of ctx in the latter case.
+func (q *DelayQueue[T]) Poll(ctx context.Context) (T, error) {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		q.mu.Lock()
+		var expire <-chan time.Time
+		if len(q.items) > 0 {
+			delay := time.Until(q.items[0].at)
+			if delay <= 0 {
+				item := heap.Pop(&q.items).(delayedItem[T]).value
+				q.mu.Unlock()
+				return item, nil
+			}
+
+			if timer == nil {
+				timer = time.NewTimer(delay)
+			} else {
+				resetTimer(timer, delay)
+			}
+			expire = timer.C
+		}
+		// the earlier item may be put during waiting
+		wait := q.signal.ch
+		q.mu.Unlock()
+
+		select {
+		case <-expire:
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// resetTimer resets timer which may be fired or not.
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
+
+// Size returns the number of items in the queue, including the ones not expired.
+func (q *DelayQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.items)
+}
+
+// IsEmpty checks if the queue is empty or not.
+func (q *DelayQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
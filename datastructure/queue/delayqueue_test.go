@@ -0,0 +1,89 @@
+package datastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestDelayQueue_TryPoll(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDelayQueue_TryPoll")
+
+	q := NewDelayQueue[string]()
+	assert.Equal(true, q.IsEmpty())
+
+	now := time.Now()
+	q.PutAt("b", now.Add(-time.Second))
+	q.PutAt("a", now.Add(-2*time.Second))
+	q.PutAt("c", now.Add(-time.Second))
+	q.Put("later", time.Hour)
+	assert.Equal(4, q.Size())
+
+	var result []string
+	for {
+		v, ok := q.TryPoll()
+		if !ok {
+			break
+		}
+		result = append(result, v)
+	}
+
+	// the items with the same time keep the order of put
+	assert.Equal([]string{"a", "b", "c"}, result)
+	assert.Equal(1, q.Size())
+}
+
+func TestDelayQueue_Poll(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDelayQueue_Poll")
+
+	q := NewDelayQueue[int]()
+
+	start := time.Now()
+	q.Put(2, 40*time.Millisecond)
+	q.Put(1, 20*time.Millisecond)
+
+	v, err := q.Poll(context.Background())
+	assert.IsNil(err)
+	assert.Equal(1, v)
+	assert.Equal(true, time.Since(start) >= 20*time.Millisecond)
+
+	v, err = q.Poll(context.Background())
+	assert.IsNil(err)
+	assert.Equal(2, v)
+	assert.Equal(true, time.Since(start) >= 40*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	q.Put(3, time.Hour)
+	_, err = q.Poll(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+}
+
+func TestDelayQueue_EarlierPut(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDelayQueue_EarlierPut")
+
+	q := NewDelayQueue[int]()
+	q.Put(1, time.Hour)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		// the waiting Poll is woken up by the earlier item
+		q.Put(2, 10*time.Millisecond)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	v, err := q.Poll(ctx)
+	assert.IsNil(err)
+	assert.Equal(2, v)
+}
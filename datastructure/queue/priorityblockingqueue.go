@@ -0,0 +1,138 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"github.com/duke-git/lancet/v2/constraints"
+)
+
+// signal wakes up the goroutines waiting for the change of queue, it's closed and replaced on every change,
+// so the waiters could also select with context and timer.
+type signal struct {
+	ch chan struct{}
+}
+
+func newSignal() signal {
+	return signal{ch: make(chan struct{})}
+}
+
+// broadcast wakes up all the waiters, it should be called with lock held.
+func (s *signal) broadcast() {
+	close(s.ch)
+	s.ch = make(chan struct{})
+}
+
+// comparatorHeap implements heap.Interface, the max item according to comparator is at the top.
+type comparatorHeap[T any] struct {
+	items      []T
+	comparator constraints.Comparator
+}
+
+func (h *comparatorHeap[T]) Len() int           { return len(h.items) }
+func (h *comparatorHeap[T]) Less(i, j int) bool { return h.comparator.Compare(h.items[i], h.items[j]) > 0 }
+func (h *comparatorHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *comparatorHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *comparatorHeap[T]) Pop() any {
+	n := len(h.items) - 1
+	item := h.items[n]
+
+	var zero T
+	h.items[n] = zero
+	h.items = h.items[:n]
+
+	return item
+}
+
+// PriorityBlockingQueue is an unbounded priority queue (thread safe), Take blocks until an item is available.
+// The max item according to comparator is dequeued first, like PriorityQueue.
+// type T should implements Compare function in constraints.Comparator interface.
+type PriorityBlockingQueue[T any] struct {
+	mu     sync.Mutex
+	items  *comparatorHeap[T]
+	signal signal
+}
+
+// NewPriorityBlockingQueue return a pointer of PriorityBlockingQueue
+// param `comparator` is used to compare values in the queue
+func NewPriorityBlockingQueue[T any](comparator constraints.Comparator) *PriorityBlockingQueue[T] {
+	return &PriorityBlockingQueue[T]{
+		items:  &comparatorHeap[T]{comparator: comparator},
+		signal: newSignal(),
+	}
+}
+
+// Put inserts item into queue, and wakes up the goroutines blocked in Take.
+func (q *PriorityBlockingQueue[T]) Put(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	heap.Push(q.items, item)
+	q.signal.broadcast()
+}
+
+// Poll deletes and returns the max item without blocking, it returns false if the queue is empty.
+func (q *PriorityBlockingQueue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.items.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return heap.Pop(q.items).(T), true
+}
+
+// Take deletes and returns the max item, it blocks until an item is available or ctx is done, and returns the
+// error of ctx in the latter case.
+func (q *PriorityBlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if q.items.Len() > 0 {
+			item := heap.Pop(q.items).(T)
+			q.mu.Unlock()
+			return item, nil
+		}
+		wait := q.signal.ch
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Peek returns the max item without deleting it, it returns false if the queue is empty.
+func (q *PriorityBlockingQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.items.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.items.items[0], true
+}
+
+// Size returns the number of items in the queue.
+func (q *PriorityBlockingQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.items.Len()
+}
+
+// IsEmpty checks if the queue is empty or not.
+func (q *PriorityBlockingQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
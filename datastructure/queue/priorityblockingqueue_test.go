@@ -0,0 +1,96 @@
+package datastructure
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestPriorityBlockingQueue(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPriorityBlockingQueue")
+
+	q := NewPriorityBlockingQueue[int](&intComparator{})
+	assert.Equal(true, q.IsEmpty())
+
+	_, ok := q.Poll()
+	assert.Equal(false, ok)
+
+	for _, v := range []int{3, 1, 5, 2, 4} {
+		q.Put(v)
+	}
+	assert.Equal(5, q.Size())
+
+	top, ok := q.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(5, top)
+
+	var result []int
+	for !q.IsEmpty() {
+		v, _ := q.Poll()
+		result = append(result, v)
+	}
+	assert.Equal([]int{5, 4, 3, 2, 1}, result)
+}
+
+func TestPriorityBlockingQueue_Take(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPriorityBlockingQueue_Take")
+
+	q := NewPriorityBlockingQueue[int](&intComparator{})
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Put(1)
+	}()
+
+	v, err := q.Take(context.Background())
+	assert.IsNil(err)
+	assert.Equal(1, v)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = q.Take(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+}
+
+func TestPriorityBlockingQueue_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPriorityBlockingQueue_Concurrent")
+
+	q := NewPriorityBlockingQueue[int](&intComparator{})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	taken := map[int]bool{}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 250; j++ {
+				v, err := q.Take(context.Background())
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				taken[v] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < 1000; i++ {
+		q.Put(i)
+	}
+	wg.Wait()
+
+	assert.Equal(1000, len(taken))
+	assert.Equal(true, q.IsEmpty())
+}
@@ -2,7 +2,7 @@
 // Use of this source code is governed by MIT license
 
 // Package datastructure contains some data structure.
-// Queue structure contains ArrayQueue, LinkedQueue, CircularQueue, and PriorityQueue.
+// Queue structure contains ArrayQueue, LinkedQueue, CircularQueue, PriorityQueue, PriorityBlockingQueue and DelayQueue.
 package datastructure
 
 import (
@@ -0,0 +1,476 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datastructure
+
+import (
+	"errors"
+
+	"github.com/duke-git/lancet/v2/constraints"
+)
+
+// ErrUnsortedKeys is returned by BuildBTree if the keys are not sorted in strictly ascending order.
+var ErrUnsortedKeys = errors.New("btree: keys should be sorted in strictly ascending order without duplicates")
+
+type bTreeNode[T any] struct {
+	keys []T
+	// children is nil for leaf, otherwise len(children) == len(keys)+1
+	children []*bTreeNode[T]
+}
+
+func (n *bTreeNode[T]) isLeaf() bool {
+	return n.children == nil
+}
+
+// BTree is a B-tree of ordered keys (thread unsafe): every node except root has degree-1 to 2*degree-1 keys,
+// and all the leaves are in the same depth. It keeps many keys in a node, so it's more cache friendly and
+// has less pointers than BSTree for large key sets.
+// type T should implements Compare function in constraints.Comparator interface.
+type BTree[T any] struct {
+	root       *bTreeNode[T]
+	degree     int
+	size       int
+	comparator constraints.Comparator
+}
+
+// NewBTree creates a BTree pointer with min degree, which is at least 2.
+// param `comparator` is used to compare keys in the tree
+func NewBTree[T any](degree int, comparator constraints.Comparator) *BTree[T] {
+	if degree < 2 {
+		degree = 2
+	}
+
+	return &BTree[T]{
+		root:       &bTreeNode[T]{},
+		degree:     degree,
+		comparator: comparator,
+	}
+}
+
+// BuildBTree builds a BTree from sorted keys in O(n), which is much faster than inserting them one by one.
+// It returns ErrUnsortedKeys if keys are not in strictly ascending order.
+func BuildBTree[T any](degree int, sortedKeys []T, comparator constraints.Comparator) (*BTree[T], error) {
+	t := NewBTree[T](degree, comparator)
+
+	for i := 1; i < len(sortedKeys); i++ {
+		if comparator.Compare(sortedKeys[i-1], sortedKeys[i]) >= 0 {
+			return nil, ErrUnsortedKeys
+		}
+	}
+	if len(sortedKeys) == 0 {
+		return t, nil
+	}
+
+	// the min height which could hold all the keys, a tree of height h holds at most (2*degree)^h-1 keys
+	height, capacity := 1, 2*t.degree
+	for capacity-1 < len(sortedKeys) {
+		height++
+		capacity *= 2 * t.degree
+	}
+
+	keys := make([]T, len(sortedKeys))
+	copy(keys, sortedKeys)
+
+	t.root = t.build(keys, height, true)
+	t.size = len(keys)
+
+	return t, nil
+}
+
+// build builds a subtree of height from keys, the keys are split into children evenly.
+func (t *BTree[T]) build(keys []T, height int, isRoot bool) *bTreeNode[T] {
+	if height == 1 {
+		return &bTreeNode[T]{keys: keys}
+	}
+
+	// childCapacity is the max count of keys plus 1 of a child subtree
+	childCapacity := 1
+	for i := 1; i < height; i++ {
+		childCapacity *= 2 * t.degree
+	}
+
+	// n keys and count-1 separators make n+1 slots, and each child takes at most childCapacity
+	slots := len(keys) + 1
+	count := (slots + childCapacity - 1) / childCapacity
+	minCount := t.degree
+	if isRoot {
+		minCount = 2
+	}
+	if count < minCount {
+		count = minCount
+	}
+
+	node := &bTreeNode[T]{
+		keys:     make([]T, 0, count-1),
+		children: make([]*bTreeNode[T], 0, count),
+	}
+
+	start := 0
+	for i := 0; i < count; i++ {
+		childSlots := slots / count
+		if i < slots%count {
+			childSlots++
+		}
+
+		end := start + childSlots - 1
+		node.children = append(node.children, t.build(keys[start:end:end], height-1, false))
+		if i < count-1 {
+			node.keys = append(node.keys, keys[end])
+		}
+		start = end + 1
+	}
+
+	return node
+}
+
+// Size returns the count of keys in the tree.
+func (t *BTree[T]) Size() int {
+	return t.size
+}
+
+// IsEmpty checks if the tree is empty.
+func (t *BTree[T]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Height returns the height of the tree, it's 0 if the tree is empty.
+func (t *BTree[T]) Height() int {
+	if t.size == 0 {
+		return 0
+	}
+
+	height := 1
+	for n := t.root; !n.isLeaf(); n = n.children[0] {
+		height++
+	}
+
+	return height
+}
+
+// search returns the index of the first key in n which is not less than key, and if it's equal to key.
+func (t *BTree[T]) search(n *bTreeNode[T], key T) (int, bool) {
+	low, high := 0, len(n.keys)
+	for low < high {
+		mid := int(uint(low+high) >> 1)
+		if t.comparator.Compare(n.keys[mid], key) < 0 {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	return low, low < len(n.keys) && t.comparator.Compare(n.keys[low], key) == 0
+}
+
+// Contain checks if key is in the tree.
+func (t *BTree[T]) Contain(key T) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Get returns the key in the tree which is equal to key, it's useful if the comparator only compares part of T.
+func (t *BTree[T]) Get(key T) (T, bool) {
+	for n := t.root; ; {
+		i, found := t.search(n, key)
+		if found {
+			return n.keys[i], true
+		}
+		if n.isLeaf() {
+			var zero T
+			return zero, false
+		}
+		n = n.children[i]
+	}
+}
+
+// Insert inserts key into the tree, the existing equal key is replaced. It returns true if the key is new.
+func (t *BTree[T]) Insert(key T) bool {
+	if len(t.root.keys) == t.maxKeys() {
+		t.root = &bTreeNode[T]{children: []*bTreeNode[T]{t.root}}
+		t.splitChild(t.root, 0)
+	}
+
+	if !t.insertNonFull(t.root, key) {
+		return false
+	}
+	t.size++
+
+	return true
+}
+
+func (t *BTree[T]) maxKeys() int {
+	return 2*t.degree - 1
+}
+
+// splitChild splits the full child n.children[i] into two, and moves its median key up to n.
+func (t *BTree[T]) splitChild(n *bTreeNode[T], i int) {
+	child := n.children[i]
+	mid := t.degree - 1
+	median := child.keys[mid]
+
+	right := &bTreeNode[T]{keys: append([]T{}, child.keys[mid+1:]...)}
+	if !child.isLeaf() {
+		right.children = append([]*bTreeNode[T]{}, child.children[mid+1:]...)
+		child.children = child.children[:mid+1]
+	}
+	child.keys = child.keys[:mid]
+
+	n.keys = insertAt(n.keys, i, median)
+	n.children = insertAt(n.children, i+1, right)
+}
+
+func (t *BTree[T]) insertNonFull(n *bTreeNode[T], key T) bool {
+	for {
+		i, found := t.search(n, key)
+		if found {
+			n.keys[i] = key
+			return false
+		}
+
+		if n.isLeaf() {
+			n.keys = insertAt(n.keys, i, key)
+			return true
+		}
+
+		if len(n.children[i].keys) == t.maxKeys() {
+			t.splitChild(n, i)
+			switch c := t.comparator.Compare(key, n.keys[i]); {
+			case c == 0:
+				n.keys[i] = key
+				return false
+			case c > 0:
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// Delete deletes key from the tree, it returns true if the key exists.
+func (t *BTree[T]) Delete(key T) bool {
+	if !t.delete(t.root, key) {
+		return false
+	}
+	t.size--
+
+	if len(t.root.keys) == 0 && !t.root.isLeaf() {
+		t.root = t.root.children[0]
+	}
+
+	return true
+}
+
+// delete deletes key from the subtree of n, n has at least degree keys unless it's root.
+func (t *BTree[T]) delete(n *bTreeNode[T], key T) bool {
+	i, found := t.search(n, key)
+
+	if n.isLeaf() {
+		if found {
+			n.keys = removeAt(n.keys, i)
+		}
+		return found
+	}
+
+	if found {
+		switch {
+		case len(n.children[i].keys) >= t.degree:
+			// replace with the predecessor
+			pred := t.maxNode(n.children[i])
+			n.keys[i] = pred.keys[len(pred.keys)-1]
+			return t.delete(n.children[i], n.keys[i])
+		case len(n.children[i+1].keys) >= t.degree:
+			// replace with the successor
+			succ := t.minNode(n.children[i+1])
+			n.keys[i] = succ.keys[0]
+			return t.delete(n.children[i+1], n.keys[i])
+		default:
+			t.merge(n, i)
+			return t.delete(n.children[i], key)
+		}
+	}
+
+	// make sure the child has at least degree keys before descending
+	if len(n.children[i].keys) < t.degree {
+		i = t.fill(n, i)
+	}
+
+	return t.delete(n.children[i], key)
+}
+
+// fill makes n.children[i] have at least degree keys by borrowing from sibling or merging with it,
+// and returns the new index of the child.
+func (t *BTree[T]) fill(n *bTreeNode[T], i int) int {
+	switch {
+	case i > 0 && len(n.children[i-1].keys) >= t.degree:
+		child, left := n.children[i], n.children[i-1]
+
+		child.keys = insertAt(child.keys, 0, n.keys[i-1])
+		n.keys[i-1] = left.keys[len(left.keys)-1]
+		left.keys = left.keys[:len(left.keys)-1]
+
+		if !left.isLeaf() {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = left.children[:len(left.children)-1]
+		}
+		return i
+	case i < len(n.keys) && len(n.children[i+1].keys) >= t.degree:
+		child, right := n.children[i], n.children[i+1]
+
+		child.keys = append(child.keys, n.keys[i])
+		n.keys[i] = right.keys[0]
+		right.keys = removeAt(right.keys, 0)
+
+		if !right.isLeaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+		return i
+	case i < len(n.keys):
+		t.merge(n, i)
+		return i
+	default:
+		t.merge(n, i-1)
+		return i - 1
+	}
+}
+
+// merge merges n.children[i+1] and the separator key into n.children[i].
+func (t *BTree[T]) merge(n *bTreeNode[T], i int) {
+	left, right := n.children[i], n.children[i+1]
+
+	left.keys = append(left.keys, n.keys[i])
+	left.keys = append(left.keys, right.keys...)
+	if !left.isLeaf() {
+		left.children = append(left.children, right.children...)
+	}
+
+	n.keys = removeAt(n.keys, i)
+	n.children = removeAt(n.children, i+1)
+}
+
+func (t *BTree[T]) minNode(n *bTreeNode[T]) *bTreeNode[T] {
+	for !n.isLeaf() {
+		n = n.children[0]
+	}
+	return n
+}
+
+func (t *BTree[T]) maxNode(n *bTreeNode[T]) *bTreeNode[T] {
+	for !n.isLeaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n
+}
+
+// Min returns the min key in the tree.
+func (t *BTree[T]) Min() (T, bool) {
+	var zero T
+	if t.size == 0 {
+		return zero, false
+	}
+
+	return t.minNode(t.root).keys[0], true
+}
+
+// Max returns the max key in the tree.
+func (t *BTree[T]) Max() (T, bool) {
+	var zero T
+	if t.size == 0 {
+		return zero, false
+	}
+
+	n := t.maxNode(t.root)
+	return n.keys[len(n.keys)-1], true
+}
+
+// Ascend calls iteratee for each key in ascending order until it returns false.
+func (t *BTree[T]) Ascend(iteratee func(key T) bool) {
+	t.ascend(t.root, nil, nil, iteratee)
+}
+
+// AscendRange calls iteratee for each key in [from, to) in ascending order until it returns false.
+func (t *BTree[T]) AscendRange(from, to T, iteratee func(key T) bool) {
+	t.ascend(t.root, &from, &to, iteratee)
+}
+
+// AscendGreaterOrEqual calls iteratee for each key >= from in ascending order until it returns false.
+func (t *BTree[T]) AscendGreaterOrEqual(from T, iteratee func(key T) bool) {
+	t.ascend(t.root, &from, nil, iteratee)
+}
+
+// ascend visits the keys in [from, to) of subtree n, nil bound is unlimited. It returns false if stopped.
+func (t *BTree[T]) ascend(n *bTreeNode[T], from, to *T, iteratee func(key T) bool) bool {
+	i := 0
+	if from != nil {
+		i, _ = t.search(n, *from)
+	}
+
+	for ; i <= len(n.keys); i++ {
+		if !n.isLeaf() && !t.ascend(n.children[i], from, to, iteratee) {
+			return false
+		}
+		if i == len(n.keys) {
+			break
+		}
+		if to != nil && t.comparator.Compare(n.keys[i], *to) >= 0 {
+			return false
+		}
+		if !iteratee(n.keys[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Descend calls iteratee for each key in descending order until it returns false.
+func (t *BTree[T]) Descend(iteratee func(key T) bool) {
+	t.descend(t.root, iteratee)
+}
+
+func (t *BTree[T]) descend(n *bTreeNode[T], iteratee func(key T) bool) bool {
+	for i := len(n.keys); i >= 0; i-- {
+		if !n.isLeaf() && !t.descend(n.children[i], iteratee) {
+			return false
+		}
+		if i > 0 && !iteratee(n.keys[i-1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Values returns all the keys in ascending order.
+func (t *BTree[T]) Values() []T {
+	result := make([]T, 0, t.size)
+	t.Ascend(func(key T) bool {
+		result = append(result, key)
+		return true
+	})
+
+	return result
+}
+
+// Clear removes all the keys.
+func (t *BTree[T]) Clear() {
+	t.root = &bTreeNode[T]{}
+	t.size = 0
+}
+
+func insertAt[E any](s []E, i int, v E) []E {
+	var zero E
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+
+	return s
+}
+
+func removeAt[E any](s []E, i int) []E {
+	copy(s[i:], s[i+1:])
+	var zero E
+	s[len(s)-1] = zero
+
+	return s[:len(s)-1]
+}
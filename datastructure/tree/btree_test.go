@@ -0,0 +1,224 @@
+package datastructure
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// checkBTree checks the key counts, the order of keys and the depth of leaves.
+func checkBTree[T any](t *testing.T, tree *BTree[T]) {
+	t.Helper()
+
+	leafDepth := -1
+	var walk func(n *bTreeNode[T], depth int, isRoot bool) int
+	walk = func(n *bTreeNode[T], depth int, isRoot bool) int {
+		if len(n.keys) > tree.maxKeys() || (!isRoot && len(n.keys) < tree.degree-1) {
+			t.Fatalf("node has %d keys with degree %d", len(n.keys), tree.degree)
+		}
+		for i := 1; i < len(n.keys); i++ {
+			if tree.comparator.Compare(n.keys[i-1], n.keys[i]) >= 0 {
+				t.Fatalf("keys of node are not sorted")
+			}
+		}
+
+		if n.isLeaf() {
+			if leafDepth < 0 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Fatalf("leaves are in different depth %d and %d", leafDepth, depth)
+			}
+			return len(n.keys)
+		}
+
+		if len(n.children) != len(n.keys)+1 {
+			t.Fatalf("node has %d keys but %d children", len(n.keys), len(n.children))
+		}
+		count := len(n.keys)
+		for _, child := range n.children {
+			count += walk(child, depth+1, false)
+		}
+		return count
+	}
+
+	if count := walk(tree.root, 1, true); count != tree.Size() {
+		t.Fatalf("tree has %d keys but size is %d", count, tree.Size())
+	}
+	if tree.Size() > 0 && leafDepth != tree.Height() {
+		t.Fatalf("height is %d but leaves are in depth %d", tree.Height(), leafDepth)
+	}
+}
+
+func TestBTree_InsertAndDelete(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBTree_InsertAndDelete")
+
+	tree := NewBTree[int](2, &intComparator{})
+	assert.Equal(true, tree.IsEmpty())
+	assert.Equal(0, tree.Height())
+
+	for _, v := range []int{5, 3, 8, 1, 4, 7, 9, 2, 6} {
+		assert.Equal(true, tree.Insert(v))
+	}
+	assert.Equal(false, tree.Insert(5))
+	checkBTree(t, tree)
+
+	assert.Equal(9, tree.Size())
+	assert.Equal([]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, tree.Values())
+	assert.Equal(true, tree.Contain(6))
+	assert.Equal(false, tree.Contain(10))
+
+	assert.Equal(true, tree.Delete(5))
+	assert.Equal(false, tree.Delete(5))
+	assert.Equal(true, tree.Delete(1))
+	checkBTree(t, tree)
+
+	assert.Equal([]int{2, 3, 4, 6, 7, 8, 9}, tree.Values())
+
+	tree.Clear()
+	assert.Equal(0, tree.Size())
+	assert.Equal([]int{}, tree.Values())
+}
+
+func TestBTree_Random(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBTree_Random")
+
+	r := rand.New(rand.NewSource(1))
+
+	for _, degree := range []int{2, 3, 5, 16} {
+		tree := NewBTree[int](degree, &intComparator{})
+		expected := map[int]bool{}
+
+		for i := 0; i < 3000; i++ {
+			v := r.Intn(500)
+			if r.Intn(3) == 0 {
+				assert.Equal(expected[v], tree.Delete(v))
+				delete(expected, v)
+			} else {
+				assert.Equal(!expected[v], tree.Insert(v))
+				expected[v] = true
+			}
+		}
+		checkBTree(t, tree)
+
+		keys := make([]int, 0, len(expected))
+		for v := range expected {
+			keys = append(keys, v)
+		}
+		sort.Ints(keys)
+		assert.Equal(keys, tree.Values())
+
+		for _, v := range keys {
+			assert.Equal(true, tree.Delete(v))
+		}
+		checkBTree(t, tree)
+		assert.Equal(true, tree.IsEmpty())
+	}
+}
+
+func TestBTree_MinMax(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBTree_MinMax")
+
+	tree := NewBTree[int](3, &intComparator{})
+
+	_, ok := tree.Min()
+	assert.Equal(false, ok)
+	_, ok = tree.Max()
+	assert.Equal(false, ok)
+
+	for i := 1; i <= 100; i++ {
+		tree.Insert(i)
+	}
+
+	min, ok := tree.Min()
+	assert.Equal(true, ok)
+	assert.Equal(1, min)
+
+	max, ok := tree.Max()
+	assert.Equal(true, ok)
+	assert.Equal(100, max)
+}
+
+func TestBTree_Iterate(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBTree_Iterate")
+
+	tree := NewBTree[int](2, &intComparator{})
+	for i := 0; i < 50; i++ {
+		tree.Insert(i * 2)
+	}
+
+	var result []int
+	tree.AscendRange(11, 21, func(key int) bool {
+		result = append(result, key)
+		return true
+	})
+	assert.Equal([]int{12, 14, 16, 18, 20}, result)
+
+	result = nil
+	tree.AscendRange(10, 20, func(key int) bool {
+		result = append(result, key)
+		return true
+	})
+	assert.Equal([]int{10, 12, 14, 16, 18}, result)
+
+	result = nil
+	tree.AscendGreaterOrEqual(93, func(key int) bool {
+		result = append(result, key)
+		return true
+	})
+	assert.Equal([]int{94, 96, 98}, result)
+
+	result = nil
+	tree.Ascend(func(key int) bool {
+		result = append(result, key)
+		return len(result) < 3
+	})
+	assert.Equal([]int{0, 2, 4}, result)
+
+	result = nil
+	tree.Descend(func(key int) bool {
+		result = append(result, key)
+		return len(result) < 3
+	})
+	assert.Equal([]int{98, 96, 94}, result)
+}
+
+func TestBuildBTree(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBuildBTree")
+
+	for _, degree := range []int{2, 3, 4, 10} {
+		for n := 0; n <= 300; n++ {
+			keys := make([]int, n)
+			for i := range keys {
+				keys[i] = i
+			}
+
+			tree, err := BuildBTree(degree, keys, &intComparator{})
+			assert.IsNil(err)
+			checkBTree(t, tree)
+			assert.Equal(keys, tree.Values())
+
+			// the tree still works after bulk load
+			tree.Insert(-1)
+			tree.Delete(n / 2)
+			checkBTree(t, tree)
+		}
+	}
+
+	_, err := BuildBTree(2, []int{1, 3, 2}, &intComparator{})
+	assert.Equal(ErrUnsortedKeys, err)
+
+	_, err = BuildBTree(2, []int{1, 2, 2}, &intComparator{})
+	assert.Equal(ErrUnsortedKeys, err)
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datetime
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is an abstraction of time, time-dependent code uses it instead of the time package,
+// so it could be tested with FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// Sleep pauses the current goroutine for at least the duration d.
+	Sleep(d time.Duration)
+	// NewTicker returns a new Ticker containing a channel that will send the current time with a period of d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is the ticker created by Clock.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *realTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// FakeClock is a Clock for testing, its time only moves forward when Advance or Set is called.
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	until  time.Time
+	period time.Duration // period of ticker, 0 for After and Sleep
+	ch     chan time.Time
+}
+
+// NewFakeClock creates a FakeClock whose current time is start.
+func NewFakeClock(start time.Time) *FakeClock {
+	fc := &FakeClock{now: start}
+	fc.cond = sync.NewCond(&fc.mu)
+
+	return fc
+}
+
+// Now returns the current time of fake clock.
+func (fc *FakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	return fc.now
+}
+
+// After returns a channel which receives the time once the fake clock is advanced by d.
+func (fc *FakeClock) After(d time.Duration) <-chan time.Time {
+	return fc.addWaiter(d, 0).ch
+}
+
+// Sleep blocks until the fake clock is advanced by d.
+func (fc *FakeClock) Sleep(d time.Duration) {
+	<-fc.After(d)
+}
+
+// NewTicker returns a ticker which ticks every time the fake clock is advanced by a period of d.
+// Like time.Ticker, ticks are dropped if the receiver is slow.
+func (fc *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("programming error: non-positive interval for NewTicker")
+	}
+
+	return &fakeTicker{clock: fc, waiter: fc.addWaiter(d, d)}
+}
+
+// Advance moves the fake clock forward by d, and fires the timers and tickers which are due.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.setLocked(fc.now.Add(d))
+}
+
+// Set moves the fake clock to t, and fires the timers and tickers which are due.
+// It does nothing if t is before the current time of fake clock.
+func (fc *FakeClock) Set(t time.Time) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if t.After(fc.now) {
+		fc.setLocked(t)
+	}
+}
+
+// BlockUntil blocks until there are at least n goroutines or tickers waiting on the fake clock,
+// it's used to make sure the code under test is waiting before calling Advance.
+func (fc *FakeClock) BlockUntil(n int) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	for len(fc.waiters) < n {
+		fc.cond.Wait()
+	}
+}
+
+// WaiterCount returns the count of pending timers and tickers.
+func (fc *FakeClock) WaiterCount() int {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	return len(fc.waiters)
+}
+
+func (fc *FakeClock) addWaiter(d, period time.Duration) *fakeWaiter {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	w := &fakeWaiter{
+		until:  fc.now.Add(d),
+		period: period,
+		ch:     make(chan time.Time, 1),
+	}
+
+	if d <= 0 && period == 0 {
+		w.ch <- fc.now
+		return w
+	}
+
+	fc.waiters = append(fc.waiters, w)
+	fc.cond.Broadcast()
+
+	return w
+}
+
+func (fc *FakeClock) removeWaiter(w *fakeWaiter) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	for i, v := range fc.waiters {
+		if v == w {
+			fc.waiters = append(fc.waiters[:i], fc.waiters[i+1:]...)
+			fc.cond.Broadcast()
+			return
+		}
+	}
+}
+
+func (fc *FakeClock) setLocked(t time.Time) {
+	fc.now = t
+
+	sort.SliceStable(fc.waiters, func(i, j int) bool {
+		return fc.waiters[i].until.Before(fc.waiters[j].until)
+	})
+
+	remaining := fc.waiters[:0]
+	for _, w := range fc.waiters {
+		if w.until.After(t) {
+			remaining = append(remaining, w)
+			continue
+		}
+
+		select {
+		case w.ch <- t:
+		default:
+		}
+
+		if w.period > 0 {
+			for !w.until.After(t) {
+				w.until = w.until.Add(w.period)
+			}
+			remaining = append(remaining, w)
+		}
+	}
+	fc.waiters = remaining
+
+	fc.cond.Broadcast()
+}
+
+type fakeTicker struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.removeWaiter(t.waiter)
+}
@@ -0,0 +1,120 @@
+package datetime
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestRealClock(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRealClock")
+
+	clock := SystemClock
+
+	before := time.Now()
+	now := clock.Now()
+	assert.Equal(false, now.Before(before))
+
+	start := time.Now()
+	clock.Sleep(5 * time.Millisecond)
+	<-clock.After(5 * time.Millisecond)
+	assert.GreaterOrEqual(time.Since(start), 10*time.Millisecond)
+
+	ticker := clock.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+}
+
+func TestFakeClock_After(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFakeClock_After")
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	assert.Equal(start, clock.Now())
+
+	ch := clock.After(time.Minute)
+	assert.Equal(1, clock.WaiterCount())
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("should not fire before the duration elapsed")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(start.Add(time.Minute), <-ch)
+	assert.Equal(0, clock.WaiterCount())
+
+	// non-positive duration fires immediately
+	assert.Equal(start.Add(time.Minute), <-clock.After(0))
+
+	clock.Set(start)
+	assert.Equal(start.Add(time.Minute), clock.Now())
+}
+
+func TestFakeClock_Sleep(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFakeClock_Sleep")
+
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	var woke int32
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Hour)
+		atomic.StoreInt32(&woke, 1)
+		close(done)
+	}()
+
+	clock.BlockUntil(1)
+	assert.Equal(int32(0), atomic.LoadInt32(&woke))
+
+	clock.Advance(time.Hour)
+	<-done
+	assert.Equal(int32(1), atomic.LoadInt32(&woke))
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFakeClock_Ticker")
+
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Advance(time.Second)
+	assert.Equal(start.Add(time.Second), <-ticker.C())
+
+	clock.Advance(time.Second)
+	assert.Equal(start.Add(2*time.Second), <-ticker.C())
+
+	// slow receiver drops ticks
+	clock.Advance(time.Second)
+	clock.Advance(time.Second)
+	assert.Equal(start.Add(3*time.Second), <-ticker.C())
+	select {
+	case <-ticker.C():
+		t.Fatal("tick should be dropped")
+	default:
+	}
+
+	ticker.Stop()
+	assert.Equal(0, clock.WaiterCount())
+	clock.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("stopped ticker should not tick")
+	default:
+	}
+}
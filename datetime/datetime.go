@@ -390,3 +390,166 @@ func TraceFuncTime() func() {
 		fmt.Println("Costs Time:\t", elapsed)
 	}
 }
+
+// TruncateTo returns the result of rounding t down to a multiple of unit, calculated with the wall clock of loc.
+// Unlike time.Truncate which works on absolute time since the zero time, eg: TruncateTo(t, 24*time.Hour, loc)
+// returns the beginning of day in loc. If loc is nil, the location of t is used.
+func TruncateTo(t time.Time, unit time.Duration, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = t.Location()
+	}
+	if unit <= 0 {
+		return t.In(loc)
+	}
+
+	return fromWallClock(toWallClock(t.In(loc)).Truncate(unit), loc)
+}
+
+// RoundToNearest returns the result of rounding t to the nearest multiple of unit, calculated with the wall clock
+// of the location of t. The halfway values are rounded up.
+func RoundToNearest(t time.Time, unit time.Duration) time.Time {
+	if unit <= 0 {
+		return t
+	}
+
+	return fromWallClock(toWallClock(t).Round(unit), t.Location())
+}
+
+// NextOccurrenceOf returns the first time after t whose clock is hour:minute:second in the location of t.
+// If weekdays are given, the weekday of returned time should be one of them.
+func NextOccurrenceOf(t time.Time, hour, minute, second int, weekdays ...time.Weekday) time.Time {
+	return findOccurrence(t, hour, minute, second, 1, weekdays)
+}
+
+// PrevOccurrenceOf returns the last time before t whose clock is hour:minute:second in the location of t.
+// If weekdays are given, the weekday of returned time should be one of them.
+func PrevOccurrenceOf(t time.Time, hour, minute, second int, weekdays ...time.Weekday) time.Time {
+	return findOccurrence(t, hour, minute, second, -1, weekdays)
+}
+
+func findOccurrence(t time.Time, hour, minute, second int, step int, weekdays []time.Weekday) time.Time {
+	y, m, d := t.Date()
+
+	for i := 0; i <= 8; i++ {
+		candidate := skipDSTGap(time.Date(y, m, d+i*step, hour, minute, second, 0, t.Location()), hour, minute, second)
+
+		if step > 0 && !candidate.After(t) || step < 0 && !candidate.Before(t) {
+			continue
+		}
+		if len(weekdays) == 0 || containsWeekday(weekdays, candidate.Weekday()) {
+			return candidate
+		}
+	}
+
+	return t
+}
+
+// skipDSTGap returns the first valid instant after the gap, if the wall clock of t is skipped by the daylight
+// saving time transition, e.g. 02:30 on the spring-forward day, which time.Date normalizes out of the gap.
+func skipDSTGap(t time.Time, hour, minute, second int) time.Time {
+	y, m, d := t.Date()
+	shift := toWallClock(t).Sub(time.Date(y, m, d, hour, minute, second, 0, time.UTC))
+	if shift == 0 || shift <= -24*time.Hour || shift >= 24*time.Hour {
+		return t
+	}
+
+	// the transition is between t and the instant of requested wall clock in the offset of t
+	lo, hi := t.Unix(), t.Add(-shift).Unix()
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	zoneOffset := func(sec int64) int {
+		_, offset := time.Unix(sec, 0).In(t.Location()).Zone()
+		return offset
+	}
+
+	offset := zoneOffset(hi)
+	if zoneOffset(lo) == offset {
+		return t
+	}
+
+	// binary search the first second of the new offset
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if zoneOffset(mid) == offset {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return time.Unix(hi, 0).In(t.Location())
+}
+
+func containsWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, w := range weekdays {
+		if w == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toWallClock returns the time in UTC which has the same wall clock with t.
+func toWallClock(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// fromWallClock returns the time in loc which has the same wall clock with t.
+func fromWallClock(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// Age returns the full years from birth to now, it's 0 if now is before birth.
+// The time of day is ignored, and people born on Feb 29 turn a year older on Mar 1 in common years.
+func Age(birth, now time.Time) int {
+	years, _, _ := DiffYMD(birth, now)
+	if years < 0 {
+		return 0
+	}
+
+	return years
+}
+
+// DiffYMD returns the difference from a to b in years, months and days with calendar accuracy,
+// the time of day is ignored. If the day of month of a doesn't exist in the target month, it's clamped to
+// the end of month, eg: the difference from Jan 31 to Feb 28 is 28 days, and to Mar 1 is 1 month and 1 day.
+// If b is before a, all the returned values are negative or zero.
+func DiffYMD(a, b time.Time) (years, months, days int) {
+	y1, m1, d1 := a.Date()
+	y2, m2, d2 := b.Date()
+
+	start := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+
+	if end.Before(start) {
+		years, months, days = DiffYMD(b, a)
+		return -years, -months, -days
+	}
+
+	totalMonths := (y2*12 + int(m2)) - (y1*12 + int(m1))
+	if d2 < d1 {
+		totalMonths--
+	}
+
+	days = int(end.Sub(addMonthsClamped(start, totalMonths)).Hours() / 24)
+
+	return totalMonths / 12, totalMonths % 12, days
+}
+
+// addMonthsClamped adds months to t, the day is clamped to the end of target month if it doesn't exist.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+
+	firstDay := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstDay.AddDate(0, 1, -1).Day()
+	if d > lastDay {
+		d = lastDay
+	}
+
+	return dateInLocation(firstDay.Year(), firstDay.Month(), d, t, t.Location())
+}
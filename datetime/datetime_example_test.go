@@ -408,3 +408,157 @@ func ExampleIsWeekend() {
 	// true
 	// false
 }
+
+func ExampleTruncateTo() {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	date := time.Date(2023, 06, 01, 5, 37, 42, 0, loc)
+
+	result1 := TruncateTo(date, 24*time.Hour, loc)
+	result2 := TruncateTo(date, 15*time.Minute, nil)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// 2023-06-01 00:00:00 +0800 CST
+	// 2023-06-01 05:30:00 +0800 CST
+}
+
+func ExampleRoundToNearest() {
+	date := time.Date(2023, 06, 01, 5, 37, 42, 0, time.UTC)
+
+	result := RoundToNearest(date, 15*time.Minute)
+
+	fmt.Println(result)
+
+	// Output:
+	// 2023-06-01 05:45:00 +0000 UTC
+}
+
+func ExampleNextOccurrenceOf() {
+	date := time.Date(2023, 06, 01, 9, 0, 0, 0, time.UTC)
+
+	result1 := NextOccurrenceOf(date, 8, 0, 0)
+	result2 := NextOccurrenceOf(date, 8, 0, 0, time.Monday)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// 2023-06-02 08:00:00 +0000 UTC
+	// 2023-06-05 08:00:00 +0000 UTC
+}
+
+func ExamplePrevOccurrenceOf() {
+	date := time.Date(2023, 06, 01, 9, 0, 0, 0, time.UTC)
+
+	result1 := PrevOccurrenceOf(date, 18, 0, 0)
+	result2 := PrevOccurrenceOf(date, 18, 0, 0, time.Monday)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// 2023-05-31 18:00:00 +0000 UTC
+	// 2023-05-29 18:00:00 +0000 UTC
+}
+
+func ExampleSolarToLunar() {
+	date := time.Date(2024, 02, 10, 0, 0, 0, 0, time.Local)
+
+	lunar, _ := SolarToLunar(date)
+
+	fmt.Println(lunar.Year, lunar.Month, lunar.Day, lunar.IsLeapMonth)
+	fmt.Println(lunar.Zodiac())
+	fmt.Println(lunar)
+
+	// Output:
+	// 2024 1 1 false
+	// Dragon
+	// 甲辰年正月初一
+}
+
+func ExampleLunarToSolar() {
+	date, _ := LunarToSolar(2024, 8, 15, false)
+
+	fmt.Println(date.Format("2006-01-02"))
+
+	// Output:
+	// 2024-09-17
+}
+
+func ExampleAge() {
+	birth := time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	result1 := Age(birth, time.Date(2023, 6, 14, 0, 0, 0, 0, time.UTC))
+	result2 := Age(birth, time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC))
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// 32
+	// 33
+}
+
+func ExampleDiffYMD() {
+	years, months, days := DiffYMD(
+		time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+	)
+
+	fmt.Println(years, months, days)
+
+	// Output:
+	// 0 1 1
+}
+
+func ExampleConvertTimezone() {
+	meeting := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	result, _ := ConvertTimezone(meeting, "Asia/Shanghai", "America/New_York")
+
+	fmt.Println(result)
+
+	// Output:
+	// 2023-12-31 20:00:00 -0500 EST
+}
+
+func ExampleAddDays() {
+	loc, _ := time.LoadLocation("America/New_York")
+
+	// the day before DST begins
+	t := time.Date(2024, 3, 9, 9, 0, 0, 0, loc)
+
+	fmt.Println(AddDays(t, 1))
+	fmt.Println(AddDay(t, 1))
+
+	// Output:
+	// 2024-03-10 09:00:00 -0400 EDT
+	// 2024-03-10 10:00:00 -0400 EDT
+}
+
+func ExampleAddMonths() {
+	t := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	fmt.Println(AddMonths(t, 1))
+
+	// Output:
+	// 2024-02-29 09:00:00 +0000 UTC
+}
+
+func ExampleFromUnixAuto() {
+	for _, n := range []int64{1700000000, 1700000000000, 1700000000000000} {
+		t, _ := FromUnixAuto(n)
+		fmt.Println(t.UTC())
+	}
+
+	_, err := FromUnixMilli(99999999999999)
+	fmt.Println(err)
+
+	// Output:
+	// 2023-11-14 22:13:20 +0000 UTC
+	// 2023-11-14 22:13:20 +0000 UTC
+	// 2023-11-14 22:13:20 +0000 UTC
+	// datetime: invalid timestamp: 99999999999999 in millisecond is 5138-11-16T09:46:39Z, out of [1900-01-01T00:00:00Z, 2200-01-01T00:00:00Z]
+}
@@ -410,3 +410,153 @@ func TestTimestamp(t *testing.T) {
 	ts4 := TimestampNano()
 	t.Log(ts4)
 }
+
+func TestTruncateTo(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTruncateTo")
+
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	tm := time.Date(2023, 6, 1, 5, 37, 42, 100, loc)
+
+	assert.Equal(time.Date(2023, 6, 1, 0, 0, 0, 0, loc), TruncateTo(tm, 24*time.Hour, loc))
+	assert.Equal(time.Date(2023, 6, 1, 5, 30, 0, 0, loc), TruncateTo(tm, 15*time.Minute, nil))
+	assert.Equal(time.Date(2023, 6, 1, 4, 0, 0, 0, loc), TruncateTo(tm, 2*time.Hour, loc))
+
+	// in UTC it's 2023-05-31 21:37:42
+	assert.Equal(time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC), TruncateTo(tm, 24*time.Hour, time.UTC))
+
+	assert.Equal(tm, TruncateTo(tm, 0, nil))
+}
+
+func TestRoundToNearest(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRoundToNearest")
+
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+
+	assert.Equal(time.Date(2023, 6, 1, 5, 45, 0, 0, loc),
+		RoundToNearest(time.Date(2023, 6, 1, 5, 37, 30, 0, loc), 15*time.Minute))
+	assert.Equal(time.Date(2023, 6, 1, 5, 30, 0, 0, loc),
+		RoundToNearest(time.Date(2023, 6, 1, 5, 37, 29, 0, loc), 15*time.Minute))
+	assert.Equal(time.Date(2023, 6, 2, 0, 0, 0, 0, loc),
+		RoundToNearest(time.Date(2023, 6, 1, 12, 0, 0, 0, loc), 24*time.Hour))
+	assert.Equal(time.Date(2023, 6, 1, 0, 0, 0, 0, loc),
+		RoundToNearest(time.Date(2023, 6, 1, 11, 59, 59, 0, loc), 24*time.Hour))
+}
+
+func TestNextOccurrenceOf(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestNextOccurrenceOf")
+
+	// Thursday
+	tm := time.Date(2023, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(time.Date(2023, 6, 1, 18, 30, 0, 0, time.UTC), NextOccurrenceOf(tm, 18, 30, 0))
+	assert.Equal(time.Date(2023, 6, 2, 8, 0, 0, 0, time.UTC), NextOccurrenceOf(tm, 8, 0, 0))
+	assert.Equal(time.Date(2023, 6, 2, 9, 0, 0, 0, time.UTC), NextOccurrenceOf(tm, 9, 0, 0))
+	assert.Equal(time.Date(2023, 6, 5, 8, 0, 0, 0, time.UTC), NextOccurrenceOf(tm, 8, 0, 0, time.Monday))
+	assert.Equal(time.Date(2023, 6, 8, 8, 0, 0, 0, time.UTC), NextOccurrenceOf(tm, 8, 0, 0, time.Thursday))
+	assert.Equal(time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC), NextOccurrenceOf(tm, 10, 0, 0, time.Thursday))
+	assert.Equal(time.Date(2023, 6, 3, 10, 0, 0, 0, time.UTC),
+		NextOccurrenceOf(tm, 10, 0, 0, time.Saturday, time.Sunday))
+}
+
+func TestNextOccurrenceOfDSTGap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestNextOccurrenceOfDSTGap")
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone data is not available")
+	}
+
+	// 02:00-03:00 is skipped on 2024-03-10
+	transition := time.Date(2024, 3, 10, 3, 0, 0, 0, loc)
+
+	tm := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+	assert.Equal(transition, NextOccurrenceOf(tm, 2, 30, 0))
+	assert.Equal(time.Date(2024, 3, 11, 2, 30, 0, 0, loc), NextOccurrenceOf(transition, 2, 30, 0))
+
+	tm = time.Date(2024, 3, 10, 3, 10, 0, 0, loc)
+	assert.Equal(transition, PrevOccurrenceOf(tm, 2, 30, 0))
+	assert.Equal(time.Date(2024, 3, 11, 2, 30, 0, 0, loc), NextOccurrenceOf(tm, 2, 30, 0))
+
+	// the wall clock not skipped is not changed
+	assert.Equal(time.Date(2024, 3, 10, 3, 30, 0, 0, loc), NextOccurrenceOf(tm, 3, 30, 0))
+	assert.Equal(time.Date(2024, 3, 10, 1, 30, 0, 0, loc), PrevOccurrenceOf(tm, 1, 30, 0))
+}
+
+func TestPrevOccurrenceOf(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPrevOccurrenceOf")
+
+	// Thursday
+	tm := time.Date(2023, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(time.Date(2023, 6, 1, 8, 30, 0, 0, time.UTC), PrevOccurrenceOf(tm, 8, 30, 0))
+	assert.Equal(time.Date(2023, 5, 31, 18, 0, 0, 0, time.UTC), PrevOccurrenceOf(tm, 18, 0, 0))
+	assert.Equal(time.Date(2023, 5, 31, 9, 0, 0, 0, time.UTC), PrevOccurrenceOf(tm, 9, 0, 0))
+	assert.Equal(time.Date(2023, 5, 29, 8, 0, 0, 0, time.UTC), PrevOccurrenceOf(tm, 8, 0, 0, time.Monday))
+	assert.Equal(time.Date(2023, 5, 25, 10, 0, 0, 0, time.UTC), PrevOccurrenceOf(tm, 10, 0, 0, time.Thursday))
+}
+
+func TestDiffYMD(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiffYMD")
+
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		a, b                time.Time
+		years, months, days int
+	}{
+		{date(2023, 1, 1), date(2023, 1, 1), 0, 0, 0},
+		{date(2020, 3, 15), date(2023, 6, 20), 3, 3, 5},
+		{date(2020, 3, 15), date(2023, 6, 10), 3, 2, 26},
+		{date(2023, 1, 31), date(2023, 2, 28), 0, 0, 28},
+		{date(2023, 1, 31), date(2023, 3, 1), 0, 1, 1},
+		{date(2024, 1, 31), date(2024, 2, 29), 0, 0, 29},
+		{date(2023, 1, 31), date(2023, 3, 31), 0, 2, 0},
+		{date(2020, 2, 29), date(2021, 2, 28), 0, 11, 30},
+		{date(2020, 2, 29), date(2021, 3, 1), 1, 0, 1},
+		{date(2020, 2, 29), date(2024, 2, 29), 4, 0, 0},
+		{date(2023, 12, 25), date(2024, 1, 5), 0, 0, 11},
+		{date(2023, 6, 20), date(2020, 3, 15), -3, -3, -5},
+	}
+
+	for _, tt := range tests {
+		y, m, d := DiffYMD(tt.a, tt.b)
+		assert.Equal(tt.years, y)
+		assert.Equal(tt.months, m)
+		assert.Equal(tt.days, d)
+	}
+
+	// time of day is ignored
+	y, m, d := DiffYMD(time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC), time.Date(2023, 1, 2, 1, 0, 0, 0, time.UTC))
+	assert.Equal([]int{0, 0, 1}, []int{y, m, d})
+}
+
+func TestAge(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAge")
+
+	birth := time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(32, Age(birth, time.Date(2023, 6, 14, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(33, Age(birth, time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(0, Age(birth, time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	leapBirth := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+	assert.Equal(22, Age(leapBirth, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(23, Age(leapBirth, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(24, Age(leapBirth, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+}
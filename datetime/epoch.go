@@ -0,0 +1,149 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTimestamp is returned if the unix timestamp is out of the valid bounds.
+var ErrInvalidTimestamp = errors.New("datetime: invalid timestamp")
+
+// UnixPrecision is the unit of unix timestamp.
+type UnixPrecision int
+
+const (
+	// UnixSecond is the timestamp in seconds, eg: 1700000000
+	UnixSecond UnixPrecision = iota
+	// UnixMilli is the timestamp in milliseconds, eg: 1700000000000
+	UnixMilli
+	// UnixMicro is the timestamp in microseconds, eg: 1700000000000000
+	UnixMicro
+	// UnixNano is the timestamp in nanoseconds, eg: 1700000000000000000
+	UnixNano
+)
+
+// String returns the name of precision.
+func (p UnixPrecision) String() string {
+	switch p {
+	case UnixSecond:
+		return "second"
+	case UnixMilli:
+		return "millisecond"
+	case UnixMicro:
+		return "microsecond"
+	case UnixNano:
+		return "nanosecond"
+	}
+
+	return fmt.Sprintf("UnixPrecision(%d)", int(p))
+}
+
+var (
+	// defaultMinUnixTime and defaultMaxUnixTime are the default bounds of valid timestamps
+	defaultMinUnixTime = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	defaultMaxUnixTime = time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// UnixConfig is config for converting unix timestamp.
+type UnixConfig struct {
+	min time.Time
+	max time.Time
+}
+
+// UnixOption is for adding unix timestamp config.
+type UnixOption func(*UnixConfig)
+
+// WithUnixBounds sets the valid range [min, max] of the converted time, the default is from 1900-01-01 to
+// 2200-01-01 UTC. The zero min or max means no bound.
+func WithUnixBounds(min, max time.Time) UnixOption {
+	return func(uc *UnixConfig) {
+		uc.min = min
+		uc.max = max
+	}
+}
+
+// DetectUnixPrecision detects the unit of unix timestamp by its magnitude: seconds if |n| < 1e11 (before year
+// 5138), milliseconds if |n| < 1e14, microseconds if |n| < 1e17, otherwise nanoseconds. It's reliable since
+// the timestamps of other units for the recent centuries are in different ranges, but the timestamps near 1970
+// are ambiguous, eg: 1e10 milliseconds is in 1970-04, but it's detected as seconds in 2286.
+func DetectUnixPrecision(n int64) UnixPrecision {
+	if n < 0 {
+		// -n overflows for math.MinInt64, which is nanoseconds anyway
+		if n == -n {
+			return UnixNano
+		}
+		n = -n
+	}
+
+	switch {
+	case n < 1e11:
+		return UnixSecond
+	case n < 1e14:
+		return UnixMilli
+	case n < 1e17:
+		return UnixMicro
+	}
+
+	return UnixNano
+}
+
+// FromUnixAuto converts the unix timestamp of unknown unit to local time, the unit is detected by
+// DetectUnixPrecision, eg: for the timestamps from the third party APIs which use different units.
+// It returns ErrInvalidTimestamp if the time is out of bounds.
+func FromUnixAuto(n int64, opts ...UnixOption) (time.Time, error) {
+	return fromUnix(n, DetectUnixPrecision(n), opts)
+}
+
+// FromUnix converts the unix timestamp in seconds to local time, it returns ErrInvalidTimestamp if the time is
+// out of bounds.
+func FromUnix(sec int64, opts ...UnixOption) (time.Time, error) {
+	return fromUnix(sec, UnixSecond, opts)
+}
+
+// FromUnixMilli converts the unix timestamp in milliseconds to local time, it returns ErrInvalidTimestamp if the
+// time is out of bounds.
+func FromUnixMilli(msec int64, opts ...UnixOption) (time.Time, error) {
+	return fromUnix(msec, UnixMilli, opts)
+}
+
+// FromUnixMicro converts the unix timestamp in microseconds to local time, it returns ErrInvalidTimestamp if the
+// time is out of bounds.
+func FromUnixMicro(usec int64, opts ...UnixOption) (time.Time, error) {
+	return fromUnix(usec, UnixMicro, opts)
+}
+
+// FromUnixNano converts the unix timestamp in nanoseconds to local time, it returns ErrInvalidTimestamp if the
+// time is out of bounds.
+func FromUnixNano(nsec int64, opts ...UnixOption) (time.Time, error) {
+	return fromUnix(nsec, UnixNano, opts)
+}
+
+func fromUnix(n int64, precision UnixPrecision, opts []UnixOption) (time.Time, error) {
+	config := &UnixConfig{min: defaultMinUnixTime, max: defaultMaxUnixTime}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	var t time.Time
+	switch precision {
+	case UnixMilli:
+		t = time.UnixMilli(n)
+	case UnixMicro:
+		t = time.UnixMicro(n)
+	case UnixNano:
+		t = time.Unix(0, n)
+	default:
+		t = time.Unix(n, 0)
+	}
+
+	if !config.min.IsZero() && t.Before(config.min) || !config.max.IsZero() && t.After(config.max) {
+		return time.Time{}, fmt.Errorf("%w: %d in %s is %s, out of [%s, %s]", ErrInvalidTimestamp, n, precision,
+			t.UTC().Format(time.RFC3339), config.min.UTC().Format(time.RFC3339), config.max.UTC().Format(time.RFC3339))
+	}
+
+	return t, nil
+}
@@ -0,0 +1,105 @@
+package datetime
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestDetectUnixPrecision(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDetectUnixPrecision")
+
+	tests := []struct {
+		n        int64
+		expected UnixPrecision
+	}{
+		{0, UnixSecond},
+		{1700000000, UnixSecond},
+		{-1700000000, UnixSecond},
+		{1700000000123, UnixMilli},
+		{-631152000000, UnixMilli},
+		{1700000000123456, UnixMicro},
+		{1700000000123456789, UnixNano},
+		{math.MaxInt64, UnixNano},
+		{math.MinInt64, UnixNano},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, DetectUnixPrecision(tt.n))
+	}
+
+	assert.Equal("millisecond", UnixMilli.String())
+	assert.Equal("UnixPrecision(9)", UnixPrecision(9).String())
+}
+
+func TestFromUnixAuto(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFromUnixAuto")
+
+	expected := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+
+	for _, n := range []int64{1700000000, 1700000000000, 1700000000000000, 1700000000000000000} {
+		result, err := FromUnixAuto(n)
+		assert.IsNil(err)
+		assert.Equal(true, result.Equal(expected))
+		assert.Equal(time.Local, result.Location())
+	}
+
+	result, err := FromUnixAuto(1700000000123)
+	assert.IsNil(err)
+	assert.Equal(123*time.Millisecond, result.Sub(expected))
+
+	// before 1970
+	result, err = FromUnixAuto(-631152000)
+	assert.IsNil(err)
+	assert.Equal(1950, result.UTC().Year())
+
+	// 2262 is out of the default bounds
+	_, err = FromUnixAuto(math.MaxInt64)
+	assert.Equal(true, errors.Is(err, ErrInvalidTimestamp))
+}
+
+func TestFromUnix_Bounds(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFromUnix_Bounds")
+
+	_, err := FromUnix(99999999999)
+	assert.Equal(true, errors.Is(err, ErrInvalidTimestamp))
+	assert.Equal("datetime: invalid timestamp: 99999999999 in second is 5138-11-16T09:46:39Z, out of "+
+		"[1900-01-01T00:00:00Z, 2200-01-01T00:00:00Z]", err.Error())
+
+	_, err = FromUnixMilli(-3000000000000)
+	assert.Equal(true, errors.Is(err, ErrInvalidTimestamp))
+
+	result, err := FromUnixMilli(1700000000123)
+	assert.IsNil(err)
+	assert.Equal(int64(1700000000123), result.UnixMilli())
+
+	result, err = FromUnixMicro(1700000000123456)
+	assert.IsNil(err)
+	assert.Equal(int64(1700000000123456), result.UnixMicro())
+
+	result, err = FromUnixNano(1700000000123456789)
+	assert.IsNil(err)
+	assert.Equal(int64(1700000000123456789), result.UnixNano())
+
+	// the custom bounds
+	min := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err = FromUnix(0, WithUnixBounds(min, max))
+	assert.Equal(true, errors.Is(err, ErrInvalidTimestamp))
+
+	_, err = FromUnix(min.Unix(), WithUnixBounds(min, max))
+	assert.IsNil(err)
+
+	_, err = FromUnix(99999999999, WithUnixBounds(time.Time{}, time.Time{}))
+	assert.IsNil(err)
+}
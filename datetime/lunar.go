@@ -0,0 +1,378 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datetime
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// lunarInfo is the data of lunar years from 1900 to 2100.
+// bits 0-3: the leap month of the year, 0 if there is no leap month.
+// bits 4-15: days of month 12 to month 1, 1 means 30 days, 0 means 29 days.
+// bit 16: days of the leap month, 1 means 30 days, 0 means 29 days.
+var lunarInfo = [...]int{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900-1909
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, // 1910-1919
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, // 1920-1929
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, // 1930-1939
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, // 1940-1949
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, // 1950-1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, // 1960-1969
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, // 1970-1979
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, // 1980-1989
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, // 1990-1999
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000-2009
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, // 2010-2019
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, // 2020-2029
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, // 2030-2039
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, // 2040-2049
+	0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, // 2050-2059
+	0x092e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, // 2060-2069
+	0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, // 2070-2079
+	0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, // 2080-2089
+	0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, // 2090-2099
+	0x0d520, // 2100
+}
+
+const (
+	minLunarYear = 1900
+	maxLunarYear = 2100
+)
+
+var (
+	// the first day of lunar year 1900 is 1900-01-31
+	lunarBaseDate = time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	chinaLocation = time.FixedZone("CST", 8*3600)
+
+	heavenlyStems   = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	earthlyBranches = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	zodiacNames     = []string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"}
+	zodiacCnNames   = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+	lunarMonthNames = []string{"正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"}
+	lunarDayPrefix  = []string{"初", "十", "廿", "三"}
+	chineseNumbers  = []string{"十", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+)
+
+// LunarDate is a date of Chinese lunar calendar.
+type LunarDate struct {
+	Year        int
+	Month       int
+	Day         int
+	IsLeapMonth bool
+}
+
+// SolarTerm is one of the 24 solar terms of Chinese calendar.
+type SolarTerm struct {
+	Name        string
+	ChineseName string
+	Time        time.Time // the moment the term begins, in China Standard Time (UTC+8)
+}
+
+// Festival is a traditional Chinese festival.
+type Festival struct {
+	Name        string
+	ChineseName string
+}
+
+// solarTermNames are ordered from Minor Cold, which is the first solar term of a gregorian year.
+var solarTermNames = [24][2]string{
+	{"Minor Cold", "小寒"}, {"Major Cold", "大寒"}, {"Start of Spring", "立春"}, {"Rain Water", "雨水"},
+	{"Awakening of Insects", "惊蛰"}, {"Spring Equinox", "春分"}, {"Pure Brightness", "清明"}, {"Grain Rain", "谷雨"},
+	{"Start of Summer", "立夏"}, {"Grain Buds", "小满"}, {"Grain in Ear", "芒种"}, {"Summer Solstice", "夏至"},
+	{"Minor Heat", "小暑"}, {"Major Heat", "大暑"}, {"Start of Autumn", "立秋"}, {"End of Heat", "处暑"},
+	{"White Dew", "白露"}, {"Autumn Equinox", "秋分"}, {"Cold Dew", "寒露"}, {"Frost's Descent", "霜降"},
+	{"Start of Winter", "立冬"}, {"Minor Snow", "小雪"}, {"Major Snow", "大雪"}, {"Winter Solstice", "冬至"},
+}
+
+// lunarFestivals are keyed by lunar month*100 + day.
+var lunarFestivals = map[int]Festival{
+	101:  {"Spring Festival", "春节"},
+	115:  {"Lantern Festival", "元宵节"},
+	202:  {"Dragon Head Raising Day", "龙抬头"},
+	505:  {"Dragon Boat Festival", "端午节"},
+	707:  {"Qixi Festival", "七夕节"},
+	715:  {"Ghost Festival", "中元节"},
+	815:  {"Mid-Autumn Festival", "中秋节"},
+	909:  {"Double Ninth Festival", "重阳节"},
+	1208: {"Laba Festival", "腊八节"},
+}
+
+// SolarToLunar converts the date of t (the time of day and location are ignored) to lunar date.
+// The supported date range is from 1900-01-31 to 2100-12-31.
+func SolarToLunar(t time.Time) (*LunarDate, error) {
+	y, m, d := t.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	offset := int(date.Sub(lunarBaseDate).Hours() / 24)
+	if offset < 0 || y > maxLunarYear {
+		return nil, fmt.Errorf("date %s is out of range of lunar calendar", date.Format("2006-01-02"))
+	}
+
+	year := minLunarYear
+	for ; year <= maxLunarYear; year++ {
+		days := lunarYearDays(year)
+		if offset < days {
+			break
+		}
+		offset -= days
+	}
+
+	leap := lunarLeapMonth(year)
+	isLeap := false
+
+	month := 1
+	for ; month <= 12; month++ {
+		days := lunarMonthDays(year, month)
+		if offset < days {
+			break
+		}
+		offset -= days
+
+		if month == leap {
+			days = lunarLeapMonthDays(year)
+			if offset < days {
+				isLeap = true
+				break
+			}
+			offset -= days
+		}
+	}
+
+	return &LunarDate{Year: year, Month: month, Day: offset + 1, IsLeapMonth: isLeap}, nil
+}
+
+// LunarToSolar converts lunar date to gregorian date, the returned time is at 00:00:00 in time.Local.
+func LunarToSolar(year, month, day int, isLeapMonth bool) (time.Time, error) {
+	if year < minLunarYear || year > maxLunarYear {
+		return time.Time{}, fmt.Errorf("lunar year %d is out of range [%d, %d]", year, minLunarYear, maxLunarYear)
+	}
+	if month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("invalid lunar month %d", month)
+	}
+	if isLeapMonth && lunarLeapMonth(year) != month {
+		return time.Time{}, fmt.Errorf("lunar year %d has no leap month %d", year, month)
+	}
+
+	monthDays := lunarMonthDays(year, month)
+	if isLeapMonth {
+		monthDays = lunarLeapMonthDays(year)
+	}
+	if day < 1 || day > monthDays {
+		return time.Time{}, fmt.Errorf("invalid lunar day %d", day)
+	}
+
+	offset := 0
+	for y := minLunarYear; y < year; y++ {
+		offset += lunarYearDays(y)
+	}
+
+	leap := lunarLeapMonth(year)
+	for m := 1; m < month; m++ {
+		offset += lunarMonthDays(year, m)
+		if m == leap {
+			offset += lunarLeapMonthDays(year)
+		}
+	}
+	if isLeapMonth {
+		offset += lunarMonthDays(year, month)
+	}
+
+	y, m, d := lunarBaseDate.AddDate(0, 0, offset+day-1).Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local), nil
+}
+
+// LeapMonth returns the leap month of the lunar year, 0 if there is no leap month.
+func (l *LunarDate) LeapMonth() int {
+	return lunarLeapMonth(l.Year)
+}
+
+// GanZhiYear returns the sexagenary (Heavenly Stems and Earthly Branches) name of the lunar year, eg: "甲辰".
+func (l *LunarDate) GanZhiYear() string {
+	return heavenlyStems[mod(l.Year-4, 10)] + earthlyBranches[mod(l.Year-4, 12)]
+}
+
+// Zodiac returns the english zodiac name of the lunar year, eg: "Dragon".
+func (l *LunarDate) Zodiac() string {
+	return zodiacNames[mod(l.Year-4, 12)]
+}
+
+// ChineseZodiac returns the chinese zodiac name of the lunar year, eg: "龙".
+func (l *LunarDate) ChineseZodiac() string {
+	return zodiacCnNames[mod(l.Year-4, 12)]
+}
+
+// MonthName returns the chinese name of lunar month, eg: "正月", "闰二月".
+func (l *LunarDate) MonthName() string {
+	name := lunarMonthNames[l.Month-1] + "月"
+	if l.IsLeapMonth {
+		return "闰" + name
+	}
+
+	return name
+}
+
+// DayName returns the chinese name of lunar day, eg: "初一", "廿三".
+func (l *LunarDate) DayName() string {
+	switch l.Day {
+	case 10:
+		return "初十"
+	case 20:
+		return "二十"
+	case 30:
+		return "三十"
+	}
+
+	return lunarDayPrefix[l.Day/10] + chineseNumbers[l.Day%10]
+}
+
+// String returns the chinese representation of the lunar date, eg: "甲辰年正月初一".
+func (l *LunarDate) String() string {
+	return l.GanZhiYear() + "年" + l.MonthName() + l.DayName()
+}
+
+// Festival returns the traditional festival of the lunar date, including the New Year's Eve.
+func (l *LunarDate) Festival() (Festival, bool) {
+	if !l.IsLeapMonth {
+		if f, ok := lunarFestivals[l.Month*100+l.Day]; ok {
+			return f, true
+		}
+
+		if l.Month == 12 && l.Day == lunarMonthDays(l.Year, 12) {
+			return Festival{"New Year's Eve", "除夕"}, true
+		}
+	}
+
+	return Festival{}, false
+}
+
+// GetLunarFestival returns the traditional chinese festival of the date of t, eg: Spring Festival,
+// Mid-Autumn Festival, and the Qingming Festival which is decided by solar term.
+func GetLunarFestival(t time.Time) (Festival, bool) {
+	lunar, err := SolarToLunar(t)
+	if err != nil {
+		return Festival{}, false
+	}
+
+	if f, ok := lunar.Festival(); ok {
+		return f, true
+	}
+
+	if term, ok := GetSolarTerm(t); ok && term.ChineseName == "清明" {
+		return Festival{"Qingming Festival", "清明节"}, true
+	}
+
+	return Festival{}, false
+}
+
+// GetSolarTerms returns the 24 solar terms of the gregorian year, from Minor Cold to Winter Solstice.
+// The terms are calculated by the apparent longitude of the sun, the error is within a few minutes.
+func GetSolarTerms(year int) []SolarTerm {
+	terms := make([]SolarTerm, 24)
+
+	for i := range terms {
+		longitude := math.Mod(285+15*float64(i), 360)
+		guess := time.Date(year, time.January, 6, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(float64(i)*15.22))
+
+		terms[i] = SolarTerm{
+			Name:        solarTermNames[i][0],
+			ChineseName: solarTermNames[i][1],
+			Time:        julianDayToTime(solveSunLongitude(timeToJulianDay(guess), longitude)).In(chinaLocation),
+		}
+	}
+
+	return terms
+}
+
+// GetSolarTerm returns the solar term which begins at the date of t in China Standard Time.
+func GetSolarTerm(t time.Time) (SolarTerm, bool) {
+	y, m, d := t.Date()
+
+	for _, term := range GetSolarTerms(y) {
+		ty, tm, td := term.Time.Date()
+		if ty == y && tm == m && td == d {
+			return term, true
+		}
+	}
+
+	return SolarTerm{}, false
+}
+
+func lunarYearDays(year int) int {
+	days := 348
+	for mask := 0x8000; mask > 0x8; mask >>= 1 {
+		if lunarInfo[year-minLunarYear]&mask != 0 {
+			days++
+		}
+	}
+
+	return days + lunarLeapMonthDays(year)
+}
+
+func lunarLeapMonth(year int) int {
+	return lunarInfo[year-minLunarYear] & 0xf
+}
+
+func lunarLeapMonthDays(year int) int {
+	if lunarLeapMonth(year) == 0 {
+		return 0
+	}
+	if lunarInfo[year-minLunarYear]&0x10000 != 0 {
+		return 30
+	}
+
+	return 29
+}
+
+func lunarMonthDays(year, month int) int {
+	if lunarInfo[year-minLunarYear]&(0x10000>>month) != 0 {
+		return 30
+	}
+
+	return 29
+}
+
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
+
+// solveSunLongitude finds the julian day near jd when the apparent longitude of the sun equals to longitude.
+func solveSunLongitude(jd, longitude float64) float64 {
+	for i := 0; i < 50; i++ {
+		delta := math.Mod(longitude-sunApparentLongitude(jd)+540, 360) - 180
+		if math.Abs(delta) < 1e-7 {
+			return jd
+		}
+		jd += delta * 365.2422 / 360
+	}
+
+	return jd
+}
+
+// sunApparentLongitude calculates the apparent longitude of the sun in degree,
+// with the low accuracy algorithm in Astronomical Algorithms by Jean Meeus.
+func sunApparentLongitude(jd float64) float64 {
+	t := (jd - 2451545.0) / 36525
+
+	l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	m := (357.52911 + 35999.05029*t - 0.0001537*t*t) * math.Pi / 180
+	c := (1.914602-0.004817*t-0.000014*t*t)*math.Sin(m) +
+		(0.019993-0.000101*t)*math.Sin(2*m) +
+		0.000289*math.Sin(3*m)
+	omega := (125.04 - 1934.136*t) * math.Pi / 180
+
+	return math.Mod(l0+c-0.00569-0.00478*math.Sin(omega), 360)
+}
+
+func timeToJulianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + 2440587.5
+}
+
+func julianDayToTime(jd float64) time.Time {
+	seconds := (jd - 2440587.5) * 86400
+	return time.Unix(0, int64(math.Round(seconds))*int64(time.Second)).UTC()
+}
@@ -0,0 +1,164 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestSolarToLunar(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSolarToLunar")
+
+	tests := []struct {
+		date  time.Time
+		lunar LunarDate
+	}{
+		{time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC), LunarDate{1900, 1, 1, false}},
+		{time.Date(2000, 2, 5, 0, 0, 0, 0, time.UTC), LunarDate{2000, 1, 1, false}},
+		{time.Date(2023, 1, 22, 0, 0, 0, 0, time.UTC), LunarDate{2023, 1, 1, false}},
+		{time.Date(2023, 3, 22, 0, 0, 0, 0, time.UTC), LunarDate{2023, 2, 1, true}},
+		{time.Date(2023, 9, 29, 0, 0, 0, 0, time.UTC), LunarDate{2023, 8, 15, false}},
+		{time.Date(2024, 2, 9, 23, 59, 59, 0, time.UTC), LunarDate{2023, 12, 30, false}},
+		{time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), LunarDate{2024, 1, 1, false}},
+		{time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC), LunarDate{2025, 6, 1, true}},
+		{time.Date(2100, 12, 31, 0, 0, 0, 0, time.UTC), LunarDate{2100, 12, 1, false}},
+	}
+
+	for _, tt := range tests {
+		lunar, err := SolarToLunar(tt.date)
+		assert.IsNil(err)
+		assert.Equal(tt.lunar, *lunar)
+	}
+
+	_, err := SolarToLunar(time.Date(1900, 1, 30, 0, 0, 0, 0, time.UTC))
+	assert.IsNotNil(err)
+
+	_, err = SolarToLunar(time.Date(2101, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.IsNotNil(err)
+}
+
+func TestLunarToSolar(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLunarToSolar")
+
+	date, err := LunarToSolar(2024, 1, 1, false)
+	assert.IsNil(err)
+	assert.Equal("2024-02-10", date.Format("2006-01-02"))
+
+	date, err = LunarToSolar(2023, 2, 1, true)
+	assert.IsNil(err)
+	assert.Equal("2023-03-22", date.Format("2006-01-02"))
+
+	date, err = LunarToSolar(2023, 3, 1, false)
+	assert.IsNil(err)
+	assert.Equal("2023-04-20", date.Format("2006-01-02"))
+
+	_, err = LunarToSolar(2024, 2, 1, true)
+	assert.IsNotNil(err)
+
+	_, err = LunarToSolar(2024, 13, 1, false)
+	assert.IsNotNil(err)
+
+	_, err = LunarToSolar(2024, 1, 31, false)
+	assert.IsNotNil(err)
+
+	_, err = LunarToSolar(1899, 1, 1, false)
+	assert.IsNotNil(err)
+
+	// round trip
+	day := time.Date(1950, 1, 1, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 1000; i++ {
+		d := day.AddDate(0, 0, i*37)
+		lunar, _ := SolarToLunar(d)
+		solar, err := LunarToSolar(lunar.Year, lunar.Month, lunar.Day, lunar.IsLeapMonth)
+		assert.IsNil(err)
+		assert.Equal(d.Format("2006-01-02"), solar.Format("2006-01-02"))
+	}
+}
+
+func TestLunarDate_Names(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLunarDate_Names")
+
+	lunar := &LunarDate{Year: 2024, Month: 1, Day: 1}
+	assert.Equal("甲辰", lunar.GanZhiYear())
+	assert.Equal("Dragon", lunar.Zodiac())
+	assert.Equal("龙", lunar.ChineseZodiac())
+	assert.Equal("甲辰年正月初一", lunar.String())
+	assert.Equal(0, lunar.LeapMonth())
+
+	lunar = &LunarDate{Year: 2023, Month: 2, Day: 23, IsLeapMonth: true}
+	assert.Equal("癸卯年闰二月廿三", lunar.String())
+	assert.Equal("Rabbit", lunar.Zodiac())
+	assert.Equal(2, lunar.LeapMonth())
+
+	days := map[int]string{10: "初十", 11: "十一", 20: "二十", 21: "廿一", 30: "三十"}
+	for day, name := range days {
+		assert.Equal(name, (&LunarDate{Year: 2024, Month: 12, Day: day}).DayName())
+	}
+	assert.Equal("腊月", (&LunarDate{Year: 2024, Month: 12, Day: 1}).MonthName())
+}
+
+func TestGetSolarTerms(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGetSolarTerms")
+
+	terms := GetSolarTerms(2024)
+	assert.Equal(24, len(terms))
+
+	expected := []string{
+		"2024-01-06", "2024-01-20", "2024-02-04", "2024-02-19", "2024-03-05", "2024-03-20",
+		"2024-04-04", "2024-04-19", "2024-05-05", "2024-05-20", "2024-06-05", "2024-06-21",
+		"2024-07-06", "2024-07-22", "2024-08-07", "2024-08-22", "2024-09-07", "2024-09-22",
+		"2024-10-08", "2024-10-23", "2024-11-07", "2024-11-22", "2024-12-06", "2024-12-21",
+	}
+	for i, term := range terms {
+		assert.Equal(expected[i], term.Time.Format("2006-01-02"))
+	}
+
+	assert.Equal("Start of Spring", terms[2].Name)
+	assert.Equal("立春", terms[2].ChineseName)
+	assert.Equal("冬至", terms[23].ChineseName)
+
+	term, ok := GetSolarTerm(time.Date(2023, 12, 22, 0, 0, 0, 0, time.UTC))
+	assert.Equal(true, ok)
+	assert.Equal("Winter Solstice", term.Name)
+
+	_, ok = GetSolarTerm(time.Date(2023, 12, 23, 0, 0, 0, 0, time.UTC))
+	assert.Equal(false, ok)
+}
+
+func TestGetLunarFestival(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGetLunarFestival")
+
+	tests := map[string]string{
+		"2024-02-10": "春节",
+		"2024-02-09": "除夕",
+		"2024-02-24": "元宵节",
+		"2024-06-10": "端午节",
+		"2024-09-17": "中秋节",
+		"2024-04-04": "清明节",
+	}
+
+	for date, name := range tests {
+		d, _ := time.Parse("2006-01-02", date)
+		festival, ok := GetLunarFestival(d)
+		assert.Equal(true, ok)
+		assert.Equal(name, festival.ChineseName)
+	}
+
+	_, ok := GetLunarFestival(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(false, ok)
+
+	// 2023 has a leap 2nd month, the 2nd day of it is not Dragon Head Raising Day
+	_, ok = GetLunarFestival(time.Date(2023, 3, 23, 0, 0, 0, 0, time.UTC))
+	assert.Equal(false, ok)
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package datetime
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrTimezoneDatabaseNotFound is returned by ListTimezones if there is no timezone database on the system.
+var ErrTimezoneDatabaseNotFound = errors.New("datetime: timezone database not found")
+
+// zoneinfoDirs is the directories of the timezone database searched by time.LoadLocation on unix systems.
+var zoneinfoDirs = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	"/etc/zoneinfo/",
+}
+
+// ListTimezones returns the sorted IANA timezone names which could be loaded by time.LoadLocation, eg:
+// "America/New_York". The timezone database is searched like time.LoadLocation: the ZONEINFO environment
+// variable, the system directories, then the zoneinfo.zip of Go installation. The database embedded by
+// time/tzdata can't be listed.
+func ListTimezones() ([]string, error) {
+	sources := zoneinfoDirs
+	if zoneinfo := os.Getenv("ZONEINFO"); zoneinfo != "" {
+		sources = append([]string{zoneinfo}, sources...)
+	}
+	sources = append(sources, filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"))
+
+	for _, source := range sources {
+		info, err := os.Stat(source)
+		if err != nil {
+			continue
+		}
+
+		var names []string
+		if info.IsDir() {
+			names, err = listZoneinfoDir(source)
+		} else {
+			names, err = listZoneinfoZip(source)
+		}
+		if err == nil && len(names) > 0 {
+			sort.Strings(names)
+			return names, nil
+		}
+	}
+
+	return nil, ErrTimezoneDatabaseNotFound
+}
+
+// isTimezoneName checks if the path in timezone database is a timezone name rather than the data files like
+// zone.tab or the legacy directories like posix.
+func isTimezoneName(name string) bool {
+	if name == "" || name == "posixrules" || name == "localtime" || strings.Contains(name, ".") {
+		return false
+	}
+	if strings.HasPrefix(name, "posix/") || strings.HasPrefix(name, "right/") {
+		return false
+	}
+
+	return name[0] >= 'A' && name[0] <= 'Z'
+}
+
+func listZoneinfoDir(dir string) ([]string, error) {
+	names := []string{}
+	magic := make([]byte, 4)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if !isTimezoneName(name) {
+			return nil
+		}
+
+		// the timezone files start with "TZif"
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+
+		if _, err := io.ReadFull(f, magic); err == nil && bytes.Equal(magic, []byte("TZif")) {
+			names = append(names, name)
+		}
+		return nil
+	})
+
+	return names, err
+}
+
+func listZoneinfoZip(path string) ([]string, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	names := []string{}
+	for _, f := range r.File {
+		if !f.FileInfo().IsDir() && isTimezoneName(f.Name) {
+			names = append(names, f.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// ConvertTimezone converts the wall clock of t in timezone from to the same instant in timezone to, eg: converts
+// 2024-01-01 09:00 in "Asia/Shanghai" to 2024-01-01 01:00 in "UTC". The location of t is ignored, if from is
+// empty, t is converted from its location.
+func ConvertTimezone(t time.Time, from, to string) (time.Time, error) {
+	toLoc, err := time.LoadLocation(to)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if from != "" {
+		fromLoc, err := time.LoadLocation(from)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t = dateInLocation(t.Year(), t.Month(), t.Day(), t, fromLoc)
+	}
+
+	return t.In(toLoc), nil
+}
+
+// IsDST checks if the daylight saving time is in effect at t in loc. If loc is nil, the location of t is used.
+func IsDST(t time.Time, loc *time.Location) bool {
+	if loc == nil {
+		loc = t.Location()
+	}
+
+	return t.In(loc).IsDST()
+}
+
+// AddDays adds or subs days to t with the calendar of the location of t, the wall clock is kept across the DST
+// transitions, eg: 09:00 of the day before DST begins plus 1 day is 09:00 of the next day, which is 23 hours
+// later. Unlike AddDay which adds 24 hours per day.
+func AddDays(t time.Time, days int) time.Time {
+	y, m, d := t.Date()
+	return dateInLocation(y, m, d+days, t, t.Location())
+}
+
+// AddMonths adds or subs months to t like AddDays, the day is clamped to the end of target month if it doesn't
+// exist, eg: Jan 31 plus 1 month is Feb 28 or Feb 29, rather than Mar 3 of time.AddDate.
+func AddMonths(t time.Time, months int) time.Time {
+	return addMonthsClamped(t, months)
+}
+
+// dateInLocation returns the time in loc of the date and the clock of t. If the wall clock doesn't exist since it's
+// skipped by DST transition, it's moved forward by the length of gap, eg: 02:30 is 03:30 when the clock jumps
+// from 02:00 to 03:00. If the wall clock occurs twice, the earlier one is returned.
+func dateInLocation(year int, month time.Month, day int, t time.Time, loc *time.Location) time.Time {
+	result := time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+
+	// time.Date moves the skipped wall clock backward, so the difference is the length of gap
+	expected := time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	if gap := expected.Sub(toWallClock(result)); gap > 0 {
+		result = result.Add(gap)
+	}
+
+	return result
+}
@@ -0,0 +1,123 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestListTimezones(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestListTimezones")
+
+	names, err := ListTimezones()
+	if err == ErrTimezoneDatabaseNotFound {
+		t.Skip("no timezone database")
+	}
+	assert.IsNil(err)
+
+	contains := func(name string) bool {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+	assert.Equal(true, contains("America/New_York"))
+	assert.Equal(true, contains("UTC"))
+	assert.Equal(false, contains("zone.tab"))
+	assert.Equal(false, contains("posixrules"))
+
+	for _, name := range names[:10] {
+		_, err := time.LoadLocation(name)
+		assert.IsNil(err)
+	}
+}
+
+func TestConvertTimezone(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestConvertTimezone")
+
+	// the location of t is ignored
+	t1 := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	result, err := ConvertTimezone(t1, "Asia/Shanghai", "America/New_York")
+	assert.IsNil(err)
+	assert.Equal("2023-12-31 20:00:00 -0500 EST", result.String())
+
+	result, err = ConvertTimezone(t1, "", "Asia/Shanghai")
+	assert.IsNil(err)
+	assert.Equal("2024-01-01 17:00:00 +0800 CST", result.String())
+	assert.Equal(true, result.Equal(t1))
+
+	_, err = ConvertTimezone(t1, "Invalid/Zone", "UTC")
+	assert.IsNotNil(err)
+	_, err = ConvertTimezone(t1, "UTC", "Invalid/Zone")
+	assert.IsNotNil(err)
+}
+
+func TestIsDST(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIsDST")
+
+	loc, _ := time.LoadLocation("America/New_York")
+
+	assert.Equal(true, IsDST(time.Date(2024, 7, 1, 12, 0, 0, 0, loc), nil))
+	assert.Equal(false, IsDST(time.Date(2024, 1, 1, 12, 0, 0, 0, loc), nil))
+	assert.Equal(true, IsDST(time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), loc))
+	assert.Equal(false, IsDST(time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), nil))
+}
+
+func TestAddDays(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAddDays")
+
+	loc, _ := time.LoadLocation("America/New_York")
+
+	// DST begins at 2024-03-10 02:00
+	t1 := time.Date(2024, 3, 9, 9, 0, 0, 0, loc)
+	result := AddDays(t1, 1)
+	assert.Equal("2024-03-10 09:00:00 -0400 EDT", result.String())
+	assert.Equal(23*time.Hour, result.Sub(t1))
+	assert.Equal("2024-03-10 10:00:00 -0400 EDT", AddDay(t1, 1).String())
+
+	// DST ends at 2024-11-03 02:00
+	t2 := time.Date(2024, 11, 2, 9, 0, 0, 0, loc)
+	assert.Equal("2024-11-03 09:00:00 -0500 EST", AddDays(t2, 1).String())
+	assert.Equal(true, t2.Equal(AddDays(AddDays(t2, 1), -1)))
+
+	// the skipped wall clock is moved forward
+	t3 := time.Date(2024, 3, 9, 2, 30, 0, 0, loc)
+	assert.Equal("2024-03-10 03:30:00 -0400 EDT", AddDays(t3, 1).String())
+
+	// the repeated wall clock is the earlier one
+	t4 := time.Date(2024, 11, 2, 1, 30, 0, 0, loc)
+	assert.Equal("2024-11-03 01:30:00 -0400 EDT", AddDays(t4, 1).String())
+
+	assert.Equal("2024-02-01 09:00:00 -0500 EST", AddDays(time.Date(2024, 1, 31, 9, 0, 0, 0, loc), 1).String())
+}
+
+func TestAddMonths(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAddMonths")
+
+	loc, _ := time.LoadLocation("America/New_York")
+
+	t1 := time.Date(2024, 2, 10, 9, 0, 0, 0, loc)
+	assert.Equal("2024-03-10 09:00:00 -0400 EDT", AddMonths(t1, 1).String())
+	assert.Equal("2023-11-10 09:00:00 -0500 EST", AddMonths(t1, -3).String())
+
+	// the day is clamped
+	t2 := time.Date(2024, 1, 31, 9, 0, 0, 0, loc)
+	assert.Equal("2024-02-29 09:00:00 -0500 EST", AddMonths(t2, 1).String())
+	assert.Equal("2025-02-28 09:00:00 -0500 EST", AddMonths(t2, 13).String())
+
+	t3 := time.Date(2024, 2, 10, 2, 30, 0, 0, loc)
+	assert.Equal("2024-03-10 03:30:00 -0400 EDT", AddMonths(t3, 1).String())
+}
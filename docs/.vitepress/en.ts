@@ -93,6 +93,7 @@ export const enConfig: LocaleSpecificConfig<DefaultTheme.Config> = {
                     collapsed: false,
                     items: [
                         { text: 'algorithm', link: '/en/api/packages/algorithm' },
+                        { text: 'atomicx', link: '/en/api/packages/atomicx' },
                         { text: 'compare', link: '/en/api/packages/compare' },
                         { text: 'concurrency', link: '/en/api/packages/concurrency' },
                         { text: 'condition', link: '/en/api/packages/condition' },
@@ -111,6 +112,8 @@ export const enConfig: LocaleSpecificConfig<DefaultTheme.Config> = {
                                 { text: 'tree', link: '/en/api/packages/datastructure/tree' },
                                 { text: 'set', link: '/en/api/packages/datastructure/set' },
                                 { text: 'hashmap', link: '/en/api/packages/datastructure/hashmap' },
+                                { text: 'bitset', link: '/en/api/packages/datastructure/bitset' },
+                                { text: 'grid', link: '/en/api/packages/datastructure/grid' },
                             ],
                         },
                         { text: 'datetime', link: '/en/api/packages/datetime' },
@@ -120,6 +123,7 @@ export const enConfig: LocaleSpecificConfig<DefaultTheme.Config> = {
                         { text: 'mathutil', link: '/en/api/packages/mathutil' },
                         { text: 'maputil', link: '/en/api/packages/maputil' },
                         { text: 'netutil', link: '/en/api/packages/netutil' },
+                        { text: 'nettest', link: '/en/api/packages/nettest' },
                         { text: 'pointer', link: '/en/api/packages/pointer' },
                         { text: 'random', link: '/en/api/packages/random' },
                         { text: 'retry', link: '/en/api/packages/retry' },
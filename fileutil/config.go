@@ -0,0 +1,752 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadYamlFile reads the yaml file and decodes it into a value of type T, the struct fields are matched by the
+// "yaml" tag, or the field name case-insensitively. If the file contains multiple documents, only the first one
+// is decoded. It supports the yaml used by config files: block and flow collections, plain, quoted and block
+// scalars, comments and multiple documents, the anchors, aliases and tags are not supported.
+func ReadYamlFile[T any](path string) (T, error) {
+	var result T
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, err
+	}
+
+	docs, err := parseYaml(data)
+	if err != nil {
+		return result, err
+	}
+	if len(docs) == 0 {
+		return result, nil
+	}
+
+	err = decodeConfig(docs[0], reflect.ValueOf(&result).Elem(), "yaml", "")
+
+	return result, err
+}
+
+// ReadYamlDocuments reads the multi-document yaml file (documents are separated by "---"),
+// and decodes every document into a value of type T.
+func ReadYamlDocuments[T any](path string) ([]T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := parseYaml(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]T, len(docs))
+	for i, doc := range docs {
+		if err := decodeConfig(doc, reflect.ValueOf(&result[i]).Elem(), "yaml", ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// WriteYamlFile encodes value as yaml and writes it into file. The struct fields are named by the "yaml" tag,
+// or the lowercased field name, and the tag option "omitempty" omits the zero value.
+func WriteYamlFile(path string, value any) error {
+	return writeYaml(path, []any{value})
+}
+
+// WriteYamlDocuments encodes every document as yaml and writes them into a multi-document yaml file.
+func WriteYamlDocuments[T any](path string, documents []T) error {
+	docs := make([]any, len(documents))
+	for i, d := range documents {
+		docs[i] = d
+	}
+
+	return writeYaml(path, docs)
+}
+
+func writeYaml(path string, documents []any) error {
+	var buf bytes.Buffer
+
+	for i, doc := range documents {
+		node, err := encodeConfig(reflect.ValueOf(doc), "yaml")
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		writeYamlDocument(&buf, node)
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// ReadTomlFile reads the toml file and decodes it into a value of type T, the struct fields are matched by the
+// "toml" tag, or the field name case-insensitively. The local date-times, dates and times of toml are decoded
+// into time.Time in time.Local.
+func ReadTomlFile[T any](path string) (T, error) {
+	var result T
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, err
+	}
+
+	table, err := parseToml(data)
+	if err != nil {
+		return result, err
+	}
+
+	err = decodeConfig(table, reflect.ValueOf(&result).Elem(), "toml", "")
+
+	return result, err
+}
+
+// WriteTomlFile encodes value as toml and writes it into file, value should be a struct or map. The struct fields
+// are named by the "toml" tag, or the field name, and the tag option "omitempty" omits the zero value. The nil
+// values are omitted since toml has no null.
+func WriteTomlFile(path string, value any) error {
+	node, err := encodeConfig(reflect.ValueOf(value), "toml")
+	if err != nil {
+		return err
+	}
+
+	table, ok := node.(*configTable)
+	if !ok {
+		return fmt.Errorf("fileutil: toml: can't encode %T as the top level table", value)
+	}
+
+	var buf bytes.Buffer
+	if err := writeTomlTable(&buf, table, nil); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// configTable is the mapping of yaml and toml which keeps the order of keys.
+type configTable struct {
+	keys   []string
+	values map[string]any
+}
+
+func newConfigTable() *configTable {
+	return &configTable{values: make(map[string]any)}
+}
+
+func (t *configTable) get(key string) (any, bool) {
+	v, ok := t.values[key]
+	return v, ok
+}
+
+func (t *configTable) set(key string, value any) {
+	if _, ok := t.values[key]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.values[key] = value
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// configField is an encoded or decoded field of struct.
+type configField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// configFields returns the fields of struct type by tag, the fields of embedded structs without tag are promoted.
+func configFields(t reflect.Type, tag string) []configField {
+	var fields []configField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, opts, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, sub := range configFields(ft, tag) {
+					sub.index = append([]int{i}, sub.index...)
+					fields = append(fields, sub)
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+			if tag == "yaml" {
+				name = strings.ToLower(name)
+			}
+		}
+
+		fields = append(fields, configField{
+			name:      name,
+			index:     []int{i},
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+
+	return fields
+}
+
+// decodeConfig decodes the parsed yaml or toml value into dst, path is the key path for the errors.
+func decodeConfig(src any, dst reflect.Value, format, path string) error {
+	fail := func() error {
+		return fmt.Errorf("fileutil: %s: %scan't decode %s into %s", format, pathPrefix(path), describeConfigValue(src), dst.Type())
+	}
+
+	if s, ok := src.(yamlScalar); ok && s.plain && resolveYamlScalar(s.value) == nil {
+		src = nil
+	}
+
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeConfig(src, dst.Elem(), format, path)
+	}
+
+	// the special types
+	switch dst.Type() {
+	case timeType:
+		switch v := src.(type) {
+		case time.Time:
+			dst.Set(reflect.ValueOf(v))
+			return nil
+		case yamlScalar:
+			t, err := parseConfigTime(v.value)
+			if err != nil {
+				return fail()
+			}
+			dst.Set(reflect.ValueOf(t))
+			return nil
+		case string:
+			t, err := parseConfigTime(v)
+			if err != nil {
+				return fail()
+			}
+			dst.Set(reflect.ValueOf(t))
+			return nil
+		}
+		return fail()
+	case durationType:
+		var text string
+		switch v := src.(type) {
+		case yamlScalar:
+			text = v.value
+		case string:
+			text = v
+		case int64:
+			dst.SetInt(v)
+			return nil
+		default:
+			return fail()
+		}
+		if d, err := time.ParseDuration(text); err == nil {
+			dst.SetInt(int64(d))
+			return nil
+		}
+		if n, ok := resolveYamlScalar(text).(int); ok {
+			dst.SetInt(int64(n))
+			return nil
+		}
+		return fail()
+	}
+
+	if dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+		var text string
+		switch v := src.(type) {
+		case yamlScalar:
+			text = v.value
+		case string:
+			text = v
+		default:
+			return fail()
+		}
+		if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return fmt.Errorf("fileutil: %s: %s%w", format, pathPrefix(path), err)
+		}
+		return nil
+	}
+
+	if s, ok := src.(yamlScalar); ok {
+		if !s.plain || dst.Kind() == reflect.String {
+			if dst.Kind() != reflect.String && dst.Kind() != reflect.Interface {
+				return fail()
+			}
+			if dst.Kind() == reflect.String {
+				dst.SetString(s.value)
+				return nil
+			}
+			src = s.value
+		} else {
+			src = resolveYamlScalar(s.value)
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.Interface:
+		if dst.NumMethod() != 0 {
+			return fail()
+		}
+		dst.Set(reflect.ValueOf(plainConfigValue(src)))
+		return nil
+
+	case reflect.String:
+		v, ok := src.(string)
+		if !ok {
+			return fail()
+		}
+		dst.SetString(v)
+
+	case reflect.Bool:
+		v, ok := src.(bool)
+		if !ok {
+			return fail()
+		}
+		dst.SetBool(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		switch v := src.(type) {
+		case int:
+			n = int64(v)
+		case int64:
+			n = v
+		default:
+			return fail()
+		}
+		if dst.OverflowInt(n) {
+			return fail()
+		}
+		dst.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		switch v := src.(type) {
+		case int:
+			if v < 0 {
+				return fail()
+			}
+			n = uint64(v)
+		case int64:
+			if v < 0 {
+				return fail()
+			}
+			n = uint64(v)
+		case uint64:
+			n = v
+		default:
+			return fail()
+		}
+		if dst.OverflowUint(n) {
+			return fail()
+		}
+		dst.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch v := src.(type) {
+		case int:
+			f = float64(v)
+		case int64:
+			f = float64(v)
+		case uint64:
+			f = float64(v)
+		case float64:
+			f = v
+		default:
+			return fail()
+		}
+		dst.SetFloat(f)
+
+	case reflect.Slice:
+		items, ok := src.([]any)
+		if !ok {
+			return fail()
+		}
+		s := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeConfig(item, s.Index(i), format, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		dst.Set(s)
+
+	case reflect.Array:
+		items, ok := src.([]any)
+		if !ok || len(items) > dst.Len() {
+			return fail()
+		}
+		dst.Set(reflect.Zero(dst.Type()))
+		for i, item := range items {
+			if err := decodeConfig(item, dst.Index(i), format, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		table, ok := src.(*configTable)
+		if !ok {
+			return fail()
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), len(table.keys)))
+		}
+		for _, key := range table.keys {
+			k := reflect.New(dst.Type().Key()).Elem()
+			if err := decodeConfigKey(key, k); err != nil {
+				return fmt.Errorf("fileutil: %s: %scan't decode key %q into %s", format, pathPrefix(path), key, k.Type())
+			}
+			v := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeConfig(table.values[key], v, format, joinConfigPath(path, key)); err != nil {
+				return err
+			}
+			dst.SetMapIndex(k, v)
+		}
+
+	case reflect.Struct:
+		table, ok := src.(*configTable)
+		if !ok {
+			return fail()
+		}
+		fields := configFields(dst.Type(), format)
+		for _, key := range table.keys {
+			field, ok := findConfigField(fields, key)
+			if !ok {
+				continue
+			}
+			fv, err := configFieldByIndex(dst, field.index)
+			if err != nil {
+				return fmt.Errorf("fileutil: %s: %s%w", format, pathPrefix(joinConfigPath(path, key)), err)
+			}
+			if err := decodeConfig(table.values[key], fv, format, joinConfigPath(path, key)); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fail()
+	}
+
+	return nil
+}
+
+// findConfigField finds the field by name, or by name case-insensitively.
+func findConfigField(fields []configField, name string) (configField, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, name) {
+			return f, true
+		}
+	}
+
+	return configField{}, false
+}
+
+// configFieldByIndex returns the field of struct v by index, the nil embedded struct pointers are allocated.
+func configFieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("can't set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, nil
+}
+
+// decodeConfigKey decodes the key of yaml or toml mapping into the map key k.
+func decodeConfigKey(key string, k reflect.Value) error {
+	if k.CanAddr() && k.Addr().Type().Implements(textUnmarshalerType) {
+		return k.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(key))
+	}
+
+	switch k.Kind() {
+	case reflect.String:
+		k.SetString(key)
+		return nil
+	case reflect.Interface:
+		if k.NumMethod() == 0 {
+			k.Set(reflect.ValueOf(key))
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(key, 10, k.Type().Bits())
+		if err != nil {
+			return err
+		}
+		k.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(key, 10, k.Type().Bits())
+		if err != nil {
+			return err
+		}
+		k.SetUint(n)
+		return nil
+	}
+
+	return fmt.Errorf("unsupported key type %s", k.Type())
+}
+
+// plainConfigValue converts the parsed value into map[string]any, []any and the scalars for the interface values.
+func plainConfigValue(src any) any {
+	switch v := src.(type) {
+	case *configTable:
+		m := make(map[string]any, len(v.keys))
+		for _, key := range v.keys {
+			m[key] = plainConfigValue(v.values[key])
+		}
+		return m
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = plainConfigValue(item)
+		}
+		return items
+	case yamlScalar:
+		if v.plain {
+			return resolveYamlScalar(v.value)
+		}
+		return v.value
+	}
+
+	return src
+}
+
+// parseConfigTime parses the timestamp of yaml, or string value of toml.
+func parseConfigTime(s string) (time.Time, error) {
+	layouts := []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05Z07:00", "2006-01-02 15:04:05"}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.ParseInLocation("2006-01-02", s, time.UTC)
+}
+
+func describeConfigValue(src any) string {
+	switch v := src.(type) {
+	case *configTable:
+		return "mapping"
+	case []any:
+		return "sequence"
+	case yamlScalar:
+		return strconv.Quote(v.value)
+	case string:
+		return strconv.Quote(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprintf("%v", src)
+}
+
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func pathPrefix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + ": "
+}
+
+// encodeConfig encodes v into *configTable, []any and the scalars in nil, bool, int64, uint64, float64, string
+// and time.Time, the keys of maps are sorted.
+func encodeConfig(v reflect.Value, format string) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		return v.Interface().(time.Time), nil
+	case durationType:
+		return time.Duration(v.Int()).String(), nil
+	}
+
+	if v.Type().Implements(textMarshalerType) && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("fileutil: %s: %w", format, err)
+		}
+		return string(text), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encodeConfig(v.Elem(), format)
+
+	case reflect.Bool:
+		return v.Bool(), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), nil
+
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+
+	case reflect.String:
+		return v.String(), nil
+
+	case reflect.Slice, reflect.Array:
+		items := make([]any, v.Len())
+		for i := range items {
+			item, err := encodeConfig(v.Index(i), format)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+
+	case reflect.Map:
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := encodeConfigKey(iter.Key())
+			if err != nil {
+				return nil, fmt.Errorf("fileutil: %s: %w", format, err)
+			}
+			keys = append(keys, key)
+			values[key] = iter.Value()
+		}
+		sort.Strings(keys)
+
+		table := newConfigTable()
+		for _, key := range keys {
+			value, err := encodeConfig(values[key], format)
+			if err != nil {
+				return nil, err
+			}
+			table.set(key, value)
+		}
+		return table, nil
+
+	case reflect.Struct:
+		table := newConfigTable()
+		for _, field := range configFields(v.Type(), format) {
+			fv, ok := configFieldValue(v, field.index)
+			if !ok || (field.omitEmpty && fv.IsZero()) {
+				continue
+			}
+			value, err := encodeConfig(fv, format)
+			if err != nil {
+				return nil, err
+			}
+			table.set(field.name, value)
+		}
+		return table, nil
+	}
+
+	return nil, fmt.Errorf("fileutil: %s: unsupported type %s", format, v.Type())
+}
+
+// configFieldValue returns the field of struct v by index, ok is false if an embedded struct pointer is nil.
+func configFieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+func encodeConfigKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	case reflect.Interface:
+		if !k.IsNil() {
+			return encodeConfigKey(k.Elem())
+		}
+	}
+
+	return "", fmt.Errorf("unsupported key type %s", k.Type())
+}
+
+// formatConfigFloat formats the finite float, it always has a decimal point or exponent.
+func formatConfigFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+
+	return s
+}
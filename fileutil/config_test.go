@@ -0,0 +1,329 @@
+package fileutil
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type testConfig struct {
+	Name    string            `yaml:"name" toml:"name"`
+	Port    int               `yaml:"port" toml:"port"`
+	Debug   bool              `yaml:"debug" toml:"debug"`
+	Tags    []string          `yaml:"tags" toml:"tags"`
+	Labels  map[string]string `yaml:"labels" toml:"labels"`
+	Servers []testServer      `yaml:"servers" toml:"servers"`
+}
+
+type testServer struct {
+	Host string `yaml:"host" toml:"host"`
+	Port int    `yaml:"port" toml:"port"`
+}
+
+func TestYamlFile(t *testing.T) {
+	assert := internal.NewAssert(t, "TestYamlFile")
+
+	path := "./testdata/test.yaml"
+	defer os.Remove(path)
+
+	config := testConfig{
+		Name:    "lancet",
+		Port:    8080,
+		Debug:   true,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"env": "dev"},
+		Servers: []testServer{{Host: "127.0.0.1", Port: 80}},
+	}
+
+	err := WriteYamlFile(path, config)
+	assert.IsNil(err)
+
+	result, err := ReadYamlFile[testConfig](path)
+	assert.IsNil(err)
+	assert.Equal(config, result)
+
+	m, err := ReadYamlFile[map[string]any](path)
+	assert.IsNil(err)
+	assert.Equal("lancet", m["name"])
+
+	_, err = ReadYamlFile[testConfig]("./testdata/notexist.yaml")
+	assert.IsNotNil(err)
+}
+
+func TestYamlDocuments(t *testing.T) {
+	assert := internal.NewAssert(t, "TestYamlDocuments")
+
+	path := "./testdata/test_multi.yaml"
+	defer os.Remove(path)
+
+	content := `name: first
+port: 1
+---
+name: second
+port: 2
+`
+	err := os.WriteFile(path, []byte(content), 0644)
+	assert.IsNil(err)
+
+	docs, err := ReadYamlDocuments[testConfig](path)
+	assert.IsNil(err)
+	assert.Equal(2, len(docs))
+	assert.Equal("second", docs[1].Name)
+	assert.Equal(2, docs[1].Port)
+
+	first, err := ReadYamlFile[testConfig](path)
+	assert.IsNil(err)
+	assert.Equal("first", first.Name)
+
+	err = WriteYamlDocuments(path, []testServer{{Host: "a", Port: 1}, {Host: "b", Port: 2}})
+	assert.IsNil(err)
+
+	written, _ := os.ReadFile(path)
+	assert.Equal("host: a\nport: 1\n---\nhost: b\nport: 2\n", string(written))
+
+	servers, err := ReadYamlDocuments[testServer](path)
+	assert.IsNil(err)
+	assert.Equal([]testServer{{Host: "a", Port: 1}, {Host: "b", Port: 2}}, servers)
+}
+
+func TestTomlFile(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTomlFile")
+
+	path := "./testdata/test.toml"
+	defer os.Remove(path)
+
+	config := testConfig{
+		Name:    "lancet",
+		Port:    8080,
+		Debug:   true,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"env": "dev"},
+		Servers: []testServer{{Host: "127.0.0.1", Port: 80}},
+	}
+
+	err := WriteTomlFile(path, config)
+	assert.IsNil(err)
+
+	result, err := ReadTomlFile[testConfig](path)
+	assert.IsNil(err)
+	assert.Equal(config, result)
+
+	err = os.WriteFile(path, []byte("name = \"broken"), 0644)
+	assert.IsNil(err)
+
+	_, err = ReadTomlFile[testConfig](path)
+	assert.IsNotNil(err)
+}
+
+func TestYamlSyntax(t *testing.T) {
+	assert := internal.NewAssert(t, "TestYamlSyntax")
+
+	path := "./testdata/test_syntax.yaml"
+	defer os.Remove(path)
+
+	content := `# comment
+name: "lancet" # comment
+port: 0x1F90
+debug: TRUE
+tags: [a, 'b', "c"]
+labels: {env: dev, zone: ~}
+servers:
+- host: 127.0.0.1
+  port: 80
+- {host: localhost, port: 81}
+literal: |
+  line1
+  line2
+folded: >-
+  folded
+  text
+plain: multi
+  line
+quoted: 'it''s'
+escaped: "tab\tx\u00e9"
+`
+	err := os.WriteFile(path, []byte(content), 0644)
+	assert.IsNil(err)
+
+	config, err := ReadYamlFile[testConfig](path)
+	assert.IsNil(err)
+	assert.Equal(testConfig{
+		Name:    "lancet",
+		Port:    8080,
+		Debug:   true,
+		Tags:    []string{"a", "b", "c"},
+		Labels:  map[string]string{"env": "dev", "zone": ""},
+		Servers: []testServer{{Host: "127.0.0.1", Port: 80}, {Host: "localhost", Port: 81}},
+	}, config)
+
+	m, err := ReadYamlFile[map[string]any](path)
+	assert.IsNil(err)
+	assert.Equal("line1\nline2\n", m["literal"])
+	assert.Equal("folded text", m["folded"])
+	assert.Equal("multi line", m["plain"])
+	assert.Equal("it's", m["quoted"])
+	assert.Equal("tab\txé", m["escaped"])
+	assert.Equal(8080, m["port"])
+
+	invalid := []string{
+		"key: value: bad\n",
+		"a: 1\na: 2\n",
+		"a: &anchor 1\n",
+		"a: [1, 2\n",
+		"a: \"unclosed\n",
+		"a:\n  b: 1\n c: 2\n",
+	}
+	for _, content := range invalid {
+		err := os.WriteFile(path, []byte(content), 0644)
+		assert.IsNil(err)
+
+		_, err = ReadYamlFile[map[string]any](path)
+		assert.IsNotNil(err)
+	}
+
+	err = os.WriteFile(path, []byte("port: abc\n"), 0644)
+	assert.IsNil(err)
+
+	_, err = ReadYamlFile[testConfig](path)
+	assert.IsNotNil(err)
+}
+
+func TestYamlStrings(t *testing.T) {
+	assert := internal.NewAssert(t, "TestYamlStrings")
+
+	path := "./testdata/test_strings.yaml"
+	defer os.Remove(path)
+
+	strs := []string{
+		"", " lead", "trail ", "true", "123", "1.5", "null", "~", "a: b", "- x", "#c", "x #y",
+		"multi\nline\n", "multi\nline", "keep\n\n\n", "tab\tx", "quote\"s", "it's", "[x]", "{y}",
+		"é ü", "\x01ctl", "a\n b", "line\r\n", "---", "trailing \nspace",
+	}
+
+	err := WriteYamlFile(path, map[string][]string{"strs": strs})
+	assert.IsNil(err)
+
+	result, err := ReadYamlFile[map[string][]string](path)
+	assert.IsNil(err)
+	assert.Equal(strs, result["strs"])
+
+	err = WriteYamlFile(path, map[string]string{"text": "line1\nline2\n"})
+	assert.IsNil(err)
+
+	written, _ := os.ReadFile(path)
+	assert.Equal("text: |\n  line1\n  line2\n", string(written))
+}
+
+func TestTomlSyntax(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTomlSyntax")
+
+	path := "./testdata/test_syntax.toml"
+	defer os.Remove(path)
+
+	content := `# comment
+name = "lancet" # comment
+port = 0x1F90
+debug = true
+tags = [
+  "a", # comment
+  'b',
+]
+labels = { env = "dev" }
+
+[[servers]]
+host = "127.0.0.1"
+port = 8_0
+
+[[servers]]
+host = """
+local\
+  host"""
+port = 81
+
+[owner]
+dob = 1979-05-27T07:32:00-08:00
+date = 1979-05-27
+raw = '''C:\path'''
+nested.key = 1.5e3
+`
+	err := os.WriteFile(path, []byte(content), 0644)
+	assert.IsNil(err)
+
+	config, err := ReadTomlFile[testConfig](path)
+	assert.IsNil(err)
+	assert.Equal(testConfig{
+		Name:    "lancet",
+		Port:    8080,
+		Debug:   true,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"env": "dev"},
+		Servers: []testServer{{Host: "127.0.0.1", Port: 80}, {Host: "localhost", Port: 81}},
+	}, config)
+
+	m, err := ReadTomlFile[map[string]any](path)
+	assert.IsNil(err)
+
+	owner := m["owner"].(map[string]any)
+	assert.Equal(time.Date(1979, 5, 27, 15, 32, 0, 0, time.UTC), owner["dob"].(time.Time).UTC())
+	assert.Equal(time.Date(1979, 5, 27, 0, 0, 0, 0, time.Local), owner["date"])
+	assert.Equal(`C:\path`, owner["raw"])
+	assert.Equal(map[string]any{"key": 1500.0}, owner["nested"])
+
+	invalid := []string{
+		"a = 1\na = 2\n",
+		"[a]\n[a]\n",
+		"a = { b = 1 }\n[a.c]\n",
+		"a = [1]\n[[a]]\n",
+		"a = 01\n",
+		"a = 1_\n",
+		"a = 'unclosed\n",
+		"a = 1 b = 2\n",
+	}
+	for _, content := range invalid {
+		err := os.WriteFile(path, []byte(content), 0644)
+		assert.IsNil(err)
+
+		_, err = ReadTomlFile[map[string]any](path)
+		assert.IsNotNil(err)
+	}
+}
+
+func TestWriteTomlFile(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWriteTomlFile")
+
+	path := "./testdata/test_write.toml"
+	defer os.Remove(path)
+
+	value := map[string]any{
+		"name":    "lancet",
+		"servers": []map[string]any{{"host": "a"}, {"host": "b"}},
+		"owner":   map[string]any{"age": 18, "weight": 60.0, "full name": "x\ty"},
+		"empty":   nil,
+	}
+
+	err := WriteTomlFile(path, value)
+	assert.IsNil(err)
+
+	written, _ := os.ReadFile(path)
+	assert.Equal(`name = "lancet"
+
+[owner]
+age = 18
+"full name" = "x\ty"
+weight = 60.0
+
+[[servers]]
+host = "a"
+
+[[servers]]
+host = "b"
+`, string(written))
+
+	err = WriteTomlFile(path, []int{1, 2})
+	assert.IsNotNil(err)
+
+	err = WriteTomlFile(path, map[string]any{"list": []any{1, nil}})
+	assert.IsNotNil(err)
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"container/heap"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// dirSizeWalker sums the file size of a directory, sub directories are walked in new goroutines
+// while the semaphore has room, otherwise in the current goroutine.
+type dirSizeWalker struct {
+	sem  chan struct{}
+	wg   sync.WaitGroup
+	size int64
+
+	mu  sync.Mutex
+	err error
+}
+
+func (w *dirSizeWalker) walk(dir string) {
+	if w.failed() {
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if entry.IsDir() {
+			select {
+			case w.sem <- struct{}{}:
+				w.wg.Add(1)
+				go func() {
+					defer func() {
+						<-w.sem
+						w.wg.Done()
+					}()
+					w.walk(path)
+				}()
+			default:
+				w.walk(path)
+			}
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			w.fail(err)
+			return
+		}
+		atomic.AddInt64(&w.size, info.Size())
+	}
+}
+
+func (w *dirSizeWalker) fail(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *dirSizeWalker) failed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err != nil
+}
+
+// TreeNode is a file or directory in the tree returned by Tree.
+type TreeNode struct {
+	Name    string
+	Path    string
+	IsDir   bool
+	ModTime time.Time
+	// Size is the file size, or the total size of all files in the directory.
+	Size     int64
+	Children []*TreeNode
+}
+
+// Tree returns the structured listing of path. depth limits the levels of children to list,
+// 0 means only the root node, negative depth means no limit. The size of directory is always
+// the total size of all files in it, no matter the depth. Symbolic links are not followed.
+func Tree(path string, depth int) (*TreeNode, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTree(path, info, depth)
+}
+
+func buildTree(path string, info fs.FileInfo, depth int) (*TreeNode, error) {
+	node := &TreeNode{
+		Name:    info.Name(),
+		Path:    path,
+		IsDir:   info.IsDir(),
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+	}
+
+	if !node.IsDir {
+		return node, nil
+	}
+
+	if depth == 0 {
+		size, err := DirSize(path)
+		if err != nil {
+			return nil, err
+		}
+		node.Size = size
+		return node, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Size = 0
+	node.Children = make([]*TreeNode, 0, len(entries))
+	for _, entry := range entries {
+		childInfo, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := buildTree(filepath.Join(path, entry.Name()), childInfo, depth-1)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Size += child.Size
+		node.Children = append(node.Children, child)
+	}
+
+	return node, nil
+}
+
+// String returns the tree in the format of tree command.
+func (n *TreeNode) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(n.Name + "\n")
+	n.writeChildren(&sb, "")
+
+	return sb.String()
+}
+
+func (n *TreeNode) writeChildren(sb *strings.Builder, prefix string) {
+	for i, child := range n.Children {
+		connector, indent := "├── ", "│   "
+		if i == len(n.Children)-1 {
+			connector, indent = "└── ", "    "
+		}
+
+		sb.WriteString(prefix + connector + child.Name + "\n")
+		child.writeChildren(sb, prefix+indent)
+	}
+}
+
+// FileSizeInfo is the path and size of a file.
+type FileSizeInfo struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// TopLargestFiles walks the folder recursively and returns the n largest files, largest first.
+func TopLargestFiles(path string, n int) ([]FileSizeInfo, error) {
+	if n <= 0 {
+		return []FileSizeInfo{}, nil
+	}
+
+	h := &fileSizeHeap{}
+
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		file := FileSizeInfo{Path: p, Size: info.Size(), ModTime: info.ModTime()}
+		if h.Len() < n {
+			heap.Push(h, file)
+		} else if fileSizeLess((*h)[0], file) {
+			(*h)[0] = file
+			heap.Fix(h, 0)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []FileSizeInfo(*h)
+	sort.Slice(result, func(i, j int) bool {
+		return fileSizeLess(result[j], result[i])
+	})
+
+	return result, nil
+}
+
+// fileSizeLess orders files by size, and by path in reverse for the same size,
+// so the largest first result lists files of the same size by path.
+func fileSizeLess(a, b FileSizeInfo) bool {
+	if a.Size != b.Size {
+		return a.Size < b.Size
+	}
+
+	return a.Path > b.Path
+}
+
+// fileSizeHeap is a min heap of files, it holds the largest files seen so far.
+type fileSizeHeap []FileSizeInfo
+
+func (h fileSizeHeap) Len() int           { return len(h) }
+func (h fileSizeHeap) Less(i, j int) bool { return fileSizeLess(h[i], h[j]) }
+func (h fileSizeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *fileSizeHeap) Push(x any) {
+	*h = append(*h, x.(FileSizeInfo))
+}
+
+func (h *fileSizeHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+
+	return x
+}
@@ -0,0 +1,123 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// createTestTree creates:
+//
+//	root
+//	├── a
+//	│   ├── b
+//	│   │   └── c.txt (30 bytes)
+//	│   └── d.txt (20 bytes)
+//	├── e.txt (10 bytes)
+//	└── f.txt (20 bytes)
+func createTestTree(t *testing.T) string {
+	root := filepath.Join(t.TempDir(), "root")
+
+	files := map[string]int{
+		"a/b/c.txt": 30,
+		"a/d.txt":   20,
+		"e.txt":     10,
+		"f.txt":     20,
+	}
+	for name, size := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestDirSize(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDirSize")
+
+	root := createTestTree(t)
+
+	size, err := DirSize(root)
+	assert.IsNil(err)
+	assert.Equal(int64(80), size)
+
+	size, err = DirSize(filepath.Join(root, "a"))
+	assert.IsNil(err)
+	assert.Equal(int64(50), size)
+
+	size, err = DirSize(filepath.Join(root, "e.txt"))
+	assert.IsNil(err)
+	assert.Equal(int64(10), size)
+
+	_, err = DirSize(filepath.Join(root, "notexist"))
+	assert.IsNotNil(err)
+}
+
+func TestTree(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTree")
+
+	root := createTestTree(t)
+
+	tree, err := Tree(root, -1)
+	assert.IsNil(err)
+	assert.Equal("root", tree.Name)
+	assert.Equal(int64(80), tree.Size)
+	assert.Equal(3, len(tree.Children))
+	assert.Equal(true, tree.Children[0].IsDir)
+	assert.Equal(int64(50), tree.Children[0].Size)
+
+	expected := `root
+├── a
+│   ├── b
+│   │   └── c.txt
+│   └── d.txt
+├── e.txt
+└── f.txt
+`
+	assert.Equal(expected, tree.String())
+
+	tree, err = Tree(root, 1)
+	assert.IsNil(err)
+	assert.Equal(int64(80), tree.Size)
+	assert.Equal(0, len(tree.Children[0].Children))
+	assert.Equal(int64(50), tree.Children[0].Size)
+
+	tree, err = Tree(root, 0)
+	assert.IsNil(err)
+	assert.Equal(0, len(tree.Children))
+	assert.Equal(int64(80), tree.Size)
+
+	_, err = Tree(filepath.Join(root, "notexist"), 1)
+	assert.IsNotNil(err)
+}
+
+func TestTopLargestFiles(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTopLargestFiles")
+
+	root := createTestTree(t)
+
+	files, err := TopLargestFiles(root, 3)
+	assert.IsNil(err)
+	assert.Equal(3, len(files))
+	assert.Equal(filepath.Join(root, "a", "b", "c.txt"), files[0].Path)
+	assert.Equal(int64(30), files[0].Size)
+	assert.Equal(filepath.Join(root, "a", "d.txt"), files[1].Path)
+	assert.Equal(filepath.Join(root, "f.txt"), files[2].Path)
+
+	files, err = TopLargestFiles(root, 10)
+	assert.IsNil(err)
+	assert.Equal(4, len(files))
+	assert.Equal(int64(10), files[3].Size)
+
+	files, err = TopLargestFiles(root, 0)
+	assert.IsNil(err)
+	assert.Equal(0, len(files))
+}
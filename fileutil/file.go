@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/duke-git/lancet/v2/validator"
 )
@@ -190,14 +191,25 @@ func RemoveFile(path string) error {
 }
 
 // CopyFile copy src file to dest file.
+// Pass WithSpaceCheck to check the free disk space of dest before copying.
 // Play: https://go.dev/play/p/Jg9AMJMLrJi
-func CopyFile(srcPath string, dstPath string) error {
+func CopyFile(srcPath string, dstPath string, opts ...WriteOption) error {
 	srcFile, err := os.Open(srcPath)
 	if err != nil {
 		return err
 	}
 	defer srcFile.Close()
 
+	if config := newWriteConfig(opts); config.checkSpace {
+		info, err := srcFile.Stat()
+		if err != nil {
+			return err
+		}
+		if err := config.check(dstPath, uint64(info.Size())); err != nil {
+			return err
+		}
+	}
+
 	distFile, err := os.Create(dstPath)
 	if err != nil {
 		return err
@@ -625,22 +637,25 @@ func FileSize(path string) (int64, error) {
 }
 
 // DirSize walks the folder recursively and returns folder size in bytes.
+// The sub directories are traversed in parallel, symbolic links are not followed.
 func DirSize(path string) (int64, error) {
-	var size int64
-	err := filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if !d.IsDir() {
-			info, err := d.Info()
-			if err != nil {
-				return err
-			}
-			size += info.Size()
-		}
-		return err
-	})
-	return size, err
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		return info.Size(), nil
+	}
+
+	walker := &dirSizeWalker{sem: make(chan struct{}, runtime.NumCPU())}
+	walker.walk(path)
+	walker.wg.Wait()
+
+	if walker.err != nil {
+		return 0, walker.err
+	}
+
+	return atomic.LoadInt64(&walker.size), nil
 }
 
 // MTime returns file modified time.
@@ -746,8 +761,13 @@ func WriteCsvFile(filepath string, records [][]string, append bool, delimiter ..
 }
 
 // WriteStringToFile write string to target file.
+// Pass WithSpaceCheck to check the free disk space before writing.
 // Play: https://go.dev/play/p/GhLS6d8lH_g
-func WriteStringToFile(filepath string, content string, append bool) error {
+func WriteStringToFile(filepath string, content string, append bool, opts ...WriteOption) error {
+	if err := newWriteConfig(opts).check(filepath, uint64(len(content))); err != nil {
+		return err
+	}
+
 	var flag int
 	if append {
 		flag = os.O_RDWR | os.O_CREATE | os.O_APPEND
@@ -766,8 +786,13 @@ func WriteStringToFile(filepath string, content string, append bool) error {
 }
 
 // WriteBytesToFile write bytes to target file.
+// Pass WithSpaceCheck to check the free disk space before writing.
 // Play: https://go.dev/play/p/s7QlDxMj3P8
-func WriteBytesToFile(filepath string, content []byte) error {
+func WriteBytesToFile(filepath string, content []byte, opts ...WriteOption) error {
+	if err := newWriteConfig(opts).check(filepath, uint64(len(content))); err != nil {
+		return err
+	}
+
 	f, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
@@ -489,3 +489,37 @@ func ExampleParallelChunkRead() {
 	// Jim,21,male
 	// 2
 }
+
+func ExampleNewJSONLinesWriter() {
+	dir, _ := os.MkdirTemp("", "lancet")
+	defer os.RemoveAll(dir)
+
+	path := dir + "/data.jsonl"
+
+	w, _ := NewJSONLinesWriter(path, WithJSONLinesRotate(WithMaxSize(1024*1024)))
+	w.Write(map[string]any{"id": 1, "name": "lancet"})
+	w.Write(map[string]any{"id": 2, "name": "lancetx"})
+	w.Close()
+
+	records, _ := ReadJSONLinesFile[map[string]any](path)
+
+	for _, r := range records {
+		fmt.Println(r["id"], r["name"])
+	}
+
+	// Output:
+	// 1 lancet
+	// 2 lancetx
+}
+
+func ExampleSafeJoin() {
+	path, err := SafeJoin("/srv/static", "css/app.css")
+	fmt.Println(path, err)
+
+	_, err = SafeJoin("/srv/static", "../../etc/passwd")
+	fmt.Println(err)
+
+	// Output:
+	// /srv/static/css/app.css <nil>
+	// fileutil: path traversal outside of root: "/etc/passwd" is not in "/srv/static"
+}
@@ -0,0 +1,311 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Ini is the content of an INI file. The comments and blank lines are kept,
+// so they are preserved when the Ini is written back.
+type Ini struct {
+	sections []*iniSection
+	// trailing comments at the end of file
+	tail []string
+}
+
+type iniSection struct {
+	name     string
+	comments []string
+	entries  []*iniEntry
+}
+
+type iniEntry struct {
+	key      string
+	value    string
+	comments []string
+	// inline comment after the value, e.g. "; seconds"
+	inline string
+}
+
+// NewIni creates an empty Ini.
+func NewIni() *Ini {
+	return &Ini{sections: []*iniSection{{name: ""}}}
+}
+
+// ReadIni reads and parses the INI file.
+func ReadIni(path string) (*Ini, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseIni(f)
+}
+
+// ParseIni parses INI content from reader. Lines begin with ';' or '#' are comments,
+// keys before the first section belong to the default section whose name is "".
+// A ';' or '#' after whitespace begins an inline comment, unless it's in a quoted value.
+func ParseIni(reader io.Reader) (*Ini, error) {
+	ini := NewIni()
+	current := ini.sections[0]
+
+	var comments []string
+
+	scanner := bufio.NewScanner(reader)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || line[0] == ';' || line[0] == '#':
+			comments = append(comments, line)
+
+		case line[0] == '[':
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("ini: invalid section at line %d: %s", lineNo, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			current = ini.section(name, true)
+			current.comments = append(current.comments, comments...)
+			comments = nil
+
+		default:
+			idx := strings.IndexAny(line, "=:")
+			if idx < 0 {
+				return nil, fmt.Errorf("ini: invalid key value at line %d: %s", lineNo, line)
+			}
+			key := strings.TrimSpace(line[:idx])
+			value, inline := parseIniValue(strings.TrimSpace(line[idx+1:]))
+
+			current.entries = append(current.entries, &iniEntry{key: key, value: value, comments: comments, inline: inline})
+			comments = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	ini.tail = comments
+
+	return ini, nil
+}
+
+// WriteIni writes the Ini into file.
+func WriteIni(path string, ini *Ini) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = ini.WriteTo(f)
+	return err
+}
+
+// WriteTo writes the Ini content into writer, it implements io.WriterTo.
+func (ini *Ini) WriteTo(writer io.Writer) (int64, error) {
+	var sb strings.Builder
+
+	for i, section := range ini.sections {
+		if section.name == "" && len(section.entries) == 0 && len(section.comments) == 0 {
+			continue
+		}
+
+		// separate the sections added by Set with a blank line
+		if i > 0 && sb.Len() > 0 && len(section.comments) == 0 {
+			sb.WriteString("\n")
+		}
+		for _, c := range section.comments {
+			sb.WriteString(c + "\n")
+		}
+		if section.name != "" {
+			sb.WriteString("[" + section.name + "]\n")
+		}
+
+		for _, e := range section.entries {
+			for _, c := range e.comments {
+				sb.WriteString(c + "\n")
+			}
+			sb.WriteString(e.key + " = " + quoteIniValue(e.value))
+			if e.inline != "" {
+				sb.WriteString(" " + e.inline)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	for _, c := range ini.tail {
+		sb.WriteString(c + "\n")
+	}
+
+	n, err := io.WriteString(writer, sb.String())
+	return int64(n), err
+}
+
+// Get returns the value of key in section, use "" for the default section.
+func (ini *Ini) Get(section, key string) (string, bool) {
+	s := ini.section(section, false)
+	if s == nil {
+		return "", false
+	}
+
+	for _, e := range s.entries {
+		if e.key == key {
+			return e.value, true
+		}
+	}
+
+	return "", false
+}
+
+// Set sets the value of key in section, the section and key are appended if not exist.
+func (ini *Ini) Set(section, key, value string) {
+	s := ini.section(section, true)
+
+	for _, e := range s.entries {
+		if e.key == key {
+			e.value = value
+			return
+		}
+	}
+
+	s.entries = append(s.entries, &iniEntry{key: key, value: value})
+}
+
+// Delete removes the key in section, returns false if the key doesn't exist.
+func (ini *Ini) Delete(section, key string) bool {
+	s := ini.section(section, false)
+	if s == nil {
+		return false
+	}
+
+	for i, e := range s.entries {
+		if e.key == key {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeleteSection removes the section and all its keys, returns false if the section doesn't exist.
+func (ini *Ini) DeleteSection(section string) bool {
+	for i, s := range ini.sections {
+		if s.name == section {
+			if section == "" {
+				s.entries = nil
+				s.comments = nil
+			} else {
+				ini.sections = append(ini.sections[:i], ini.sections[i+1:]...)
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sections returns the names of named sections in order.
+func (ini *Ini) Sections() []string {
+	result := make([]string, 0, len(ini.sections))
+	for _, s := range ini.sections {
+		if s.name != "" {
+			result = append(result, s.name)
+		}
+	}
+
+	return result
+}
+
+// Keys returns the keys of section in order.
+func (ini *Ini) Keys(section string) []string {
+	s := ini.section(section, false)
+	if s == nil {
+		return []string{}
+	}
+
+	result := make([]string, len(s.entries))
+	for i, e := range s.entries {
+		result[i] = e.key
+	}
+
+	return result
+}
+
+// SectionMap returns all the key values of section.
+func (ini *Ini) SectionMap(section string) map[string]string {
+	result := make(map[string]string)
+
+	if s := ini.section(section, false); s != nil {
+		for _, e := range s.entries {
+			result[e.key] = e.value
+		}
+	}
+
+	return result
+}
+
+func (ini *Ini) section(name string, create bool) *iniSection {
+	for _, s := range ini.sections {
+		if s.name == name {
+			return s
+		}
+	}
+
+	if !create {
+		return nil
+	}
+
+	s := &iniSection{name: name}
+	ini.sections = append(ini.sections, s)
+
+	return s
+}
+
+// parseIniValue splits the raw value into the value and its inline comment.
+func parseIniValue(raw string) (value, inline string) {
+	if len(raw) > 0 && (raw[0] == '"' || raw[0] == '\'') {
+		if end := strings.IndexByte(raw[1:], raw[0]); end >= 0 {
+			rest := strings.TrimSpace(raw[end+2:])
+			if rest == "" || rest[0] == ';' || rest[0] == '#' {
+				return raw[1 : end+1], rest
+			}
+		}
+	}
+
+	for i := 1; i < len(raw); i++ {
+		if (raw[i] == ';' || raw[i] == '#') && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			return unquoteIniValue(strings.TrimSpace(raw[:i])), raw[i:]
+		}
+	}
+
+	return unquoteIniValue(raw), ""
+}
+
+func unquoteIniValue(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
+
+func quoteIniValue(value string) string {
+	if value != strings.TrimSpace(value) || strings.ContainsAny(value, ";#") {
+		return `"` + value + `"`
+	}
+
+	return value
+}
@@ -0,0 +1,175 @@
+package fileutil
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+const iniContent = `; global settings
+app_name = lancet
+
+# database section
+[database]
+host = localhost
+; the port of db
+port = 3306
+password = "  secret;pwd  "
+
+[server]
+addr: 0.0.0.0
+; end of file
+`
+
+func TestParseIni(t *testing.T) {
+	assert := internal.NewAssert(t, "TestParseIni")
+
+	ini, err := ParseIni(strings.NewReader(iniContent))
+	assert.IsNil(err)
+
+	assert.Equal([]string{"database", "server"}, ini.Sections())
+	assert.Equal([]string{"host", "port", "password"}, ini.Keys("database"))
+
+	v, ok := ini.Get("", "app_name")
+	assert.Equal(true, ok)
+	assert.Equal("lancet", v)
+
+	v, _ = ini.Get("database", "port")
+	assert.Equal("3306", v)
+
+	v, _ = ini.Get("database", "password")
+	assert.Equal("  secret;pwd  ", v)
+
+	v, _ = ini.Get("server", "addr")
+	assert.Equal("0.0.0.0", v)
+
+	_, ok = ini.Get("server", "port")
+	assert.Equal(false, ok)
+
+	_, ok = ini.Get("notexist", "port")
+	assert.Equal(false, ok)
+
+	assert.Equal(map[string]string{"addr": "0.0.0.0"}, ini.SectionMap("server"))
+
+	_, err = ParseIni(strings.NewReader("[broken\nkey=value"))
+	assert.IsNotNil(err)
+
+	_, err = ParseIni(strings.NewReader("[section]\nnovalue"))
+	assert.IsNotNil(err)
+}
+
+func TestWriteIni(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWriteIni")
+
+	path := "./testdata/test.ini"
+	defer os.Remove(path)
+
+	err := os.WriteFile(path, []byte(iniContent), 0644)
+	assert.IsNil(err)
+
+	ini, err := ReadIni(path)
+	assert.IsNil(err)
+
+	// rewrite without changes keeps comments
+	err = WriteIni(path, ini)
+	assert.IsNil(err)
+
+	content, _ := os.ReadFile(path)
+	expected := `; global settings
+app_name = lancet
+
+# database section
+[database]
+host = localhost
+; the port of db
+port = 3306
+password = "  secret;pwd  "
+
+[server]
+addr = 0.0.0.0
+; end of file
+`
+	assert.Equal(expected, string(content))
+
+	ini.Set("database", "port", "3307")
+	ini.Set("cache", "ttl", "60")
+	assert.Equal(true, ini.Delete("server", "addr"))
+	assert.Equal(false, ini.Delete("server", "addr"))
+
+	err = WriteIni(path, ini)
+	assert.IsNil(err)
+
+	content, _ = os.ReadFile(path)
+	expected = `; global settings
+app_name = lancet
+
+# database section
+[database]
+host = localhost
+; the port of db
+port = 3307
+password = "  secret;pwd  "
+
+[server]
+
+[cache]
+ttl = 60
+; end of file
+`
+	assert.Equal(expected, string(content))
+
+	assert.Equal(true, ini.DeleteSection("server"))
+	assert.Equal(false, ini.DeleteSection("server"))
+	assert.Equal([]string{"database", "cache"}, ini.Sections())
+}
+
+func TestIniInlineComment(t *testing.T) {
+	assert := internal.NewAssert(t, "TestIniInlineComment")
+
+	content := `[cache]
+ttl = 60 ; seconds
+size = 100	# entries
+name = "a ; b" ; quoted
+url = http://host/#anchor
+`
+
+	ini, err := ParseIni(strings.NewReader(content))
+	assert.IsNil(err)
+
+	assert.Equal(map[string]string{
+		"ttl":  "60",
+		"size": "100",
+		"name": "a ; b",
+		"url":  "http://host/#anchor",
+	}, ini.SectionMap("cache"))
+
+	// the values and inline comments are kept by round trip
+	var sb strings.Builder
+	_, err = ini.WriteTo(&sb)
+	assert.IsNil(err)
+	assert.Equal(`[cache]
+ttl = 60 ; seconds
+size = 100 # entries
+name = "a ; b" ; quoted
+url = "http://host/#anchor"
+`, sb.String())
+
+	reread, err := ParseIni(strings.NewReader(sb.String()))
+	assert.IsNil(err)
+	assert.Equal(ini.SectionMap("cache"), reread.SectionMap("cache"))
+}
+
+func TestNewIni(t *testing.T) {
+	assert := internal.NewAssert(t, "TestNewIni")
+
+	ini := NewIni()
+	ini.Set("", "name", "foo")
+	ini.Set("section", "key", "value")
+
+	var sb strings.Builder
+	_, err := ini.WriteTo(&sb)
+	assert.IsNil(err)
+	assert.Equal("name = foo\n\n[section]\nkey = value\n", sb.String())
+}
@@ -0,0 +1,276 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultJSONLinesBufferSize is the default buffer size of JSONLinesWriter.
+const defaultJSONLinesBufferSize = 64 * 1024
+
+// JSONLinesError is the error of a line which can't be decoded.
+type JSONLinesError struct {
+	// Line is the line number, starting from 1
+	Line int
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *JSONLinesError) Error() string {
+	return fmt.Sprintf("fileutil: JSON Lines line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the decoding error.
+func (e *JSONLinesError) Unwrap() error {
+	return e.Err
+}
+
+// JSONLinesReader decodes the values from JSON Lines (NDJSON), which is one JSON value per line.
+// The blank lines are skipped, and the lines could be any long.
+type JSONLinesReader[T any] struct {
+	reader *bufio.Reader
+	line   int
+}
+
+// NewJSONLinesReader creates a JSONLinesReader pointer instance reading from r.
+func NewJSONLinesReader[T any](r io.Reader) *JSONLinesReader[T] {
+	return &JSONLinesReader[T]{reader: bufio.NewReader(r)}
+}
+
+// Next decodes the value of next line, it returns io.EOF if there are no more lines. If the line can't be
+// decoded, it returns *JSONLinesError with the line number, and the following lines could still be read by Next.
+func (r *JSONLinesReader[T]) Next() (T, error) {
+	var value T
+
+	for {
+		data, err := r.reader.ReadBytes('\n')
+		if len(data) == 0 && err != nil {
+			return value, err
+		}
+		r.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			if err != nil {
+				return value, err
+			}
+			continue
+		}
+
+		if err := json.Unmarshal(data, &value); err != nil {
+			var zero T
+			return zero, &JSONLinesError{Line: r.line, Err: err}
+		}
+
+		return value, nil
+	}
+}
+
+// Line returns the number of the last line read, starting from 1.
+func (r *JSONLinesReader[T]) Line() int {
+	return r.line
+}
+
+type jsonLinesConfig struct {
+	bufferSize    int
+	flushInterval time.Duration
+	rotateOptions []RotateOption
+}
+
+// JSONLinesOption is the option of JSONLinesWriter.
+type JSONLinesOption func(*jsonLinesConfig)
+
+// WithJSONLinesBufferSize sets the size of buffer, the buffered lines are written into the file when the buffer is
+// full. The default size is 64KB, and size <= 0 disables the buffer.
+func WithJSONLinesBufferSize(size int) JSONLinesOption {
+	return func(c *jsonLinesConfig) {
+		c.bufferSize = size
+	}
+}
+
+// WithJSONLinesFlushInterval flushes the buffer every interval in background, so the lines are not kept in the
+// buffer for too long, eg: for the logs watched by people.
+func WithJSONLinesFlushInterval(interval time.Duration) JSONLinesOption {
+	return func(c *jsonLinesConfig) {
+		c.flushInterval = interval
+	}
+}
+
+// WithJSONLinesRotate sets the options of the RotatingWriter of file, eg: WithMaxSize and WithRotateHook.
+func WithJSONLinesRotate(opts ...RotateOption) JSONLinesOption {
+	return func(c *jsonLinesConfig) {
+		c.rotateOptions = append(c.rotateOptions, opts...)
+	}
+}
+
+// JSONLinesWriter appends the values as JSON Lines into a file with buffer, it's safe for concurrent use.
+// The file is written by RotatingWriter, and a line is never split into two files when it's rotated.
+type JSONLinesWriter struct {
+	writer *RotatingWriter
+	config jsonLinesConfig
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+	// err is the error of background flush, which is returned by the next call
+	err error
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewJSONLinesWriter opens or creates the file for appending, and returns a JSONLinesWriter writing into it.
+// Close should be called to flush the buffer.
+func NewJSONLinesWriter(path string, opts ...JSONLinesOption) (*JSONLinesWriter, error) {
+	config := jsonLinesConfig{bufferSize: defaultJSONLinesBufferSize}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	writer, err := NewRotatingWriter(path, config.rotateOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &JSONLinesWriter{writer: writer, config: config}
+
+	if config.flushInterval > 0 {
+		w.stop = make(chan struct{})
+		w.done = make(chan struct{})
+		go w.flushLoop()
+	}
+
+	return w, nil
+}
+
+// Write encodes value as a line, it's written into the file when the buffer is full.
+func (w *JSONLinesWriter) Write(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	w.buf.Write(data)
+	w.buf.WriteByte('\n')
+
+	if w.buf.Len() >= w.config.bufferSize {
+		return w.flush()
+	}
+
+	return nil
+}
+
+// Flush writes the buffered lines into the file.
+func (w *JSONLinesWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.flush()
+}
+
+// Rotate flushes the buffer, and rotates the file like RotatingWriter.Rotate.
+func (w *JSONLinesWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	return w.writer.Rotate()
+}
+
+// Close flushes the buffer and closes the file, it's safe to call Close more than once.
+func (w *JSONLinesWriter) Close() error {
+	if w.stop != nil {
+		w.stopOnce.Do(func() {
+			close(w.stop)
+			<-w.done
+		})
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.flush()
+	if closeErr := w.writer.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// flush writes the whole buffer by one call, so the lines are not split by rotation.
+// The bytes not written are kept in the buffer for the next flush.
+func (w *JSONLinesWriter) flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	n, err := w.writer.Write(w.buf.Bytes())
+	w.buf.Next(n)
+
+	return err
+}
+
+func (w *JSONLinesWriter) flushLoop() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.config.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.mu.Lock()
+			if err := w.flush(); err != nil && w.err == nil {
+				w.err = err
+			}
+			w.mu.Unlock()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// ReadJSONLinesFile decodes all the values of JSON Lines file, see ReadJSONLines for the iterator.
+func ReadJSONLinesFile[T any](path string) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := []T{}
+	reader := NewJSONLinesReader[T](f)
+	for {
+		value, err := reader.Next()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+//go:build go1.23
+
+package fileutil
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"os"
+)
+
+// ReadJSONLines returns an iterator of the values of JSON Lines file, the file is read line by line, so it
+// works with the large files. If a line can't be decoded, the error is yielded with the zero value, and the
+// iteration continues unless the loop breaks. If the file can't be opened or read, the error is yielded and the
+// iteration stops. The file is closed when the iteration ends.
+func ReadJSONLines[T any](path string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		f, err := os.Open(path)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer f.Close()
+
+		reader := NewJSONLinesReader[T](f)
+		for {
+			value, err := reader.Next()
+			if err == io.EOF {
+				return
+			}
+			var lineErr *JSONLinesError
+			if err != nil && !errors.As(err, &lineErr) {
+				yield(zero, err)
+				return
+			}
+			if !yield(value, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+//go:build go1.23
+
+package fileutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestReadJSONLines(t *testing.T) {
+	assert := internal.NewAssert(t, "TestReadJSONLines")
+
+	path := filepath.Join(t.TempDir(), "data.jsonl")
+	err := os.WriteFile(path, []byte("{\"id\":1}\n{bad}\n\n{\"id\":3}\n"), 0644)
+	assert.IsNil(err)
+
+	var ids []int
+	var lines []int
+	for r, err := range ReadJSONLines[jsonLinesRecord](path) {
+		var lineErr *JSONLinesError
+		if errors.As(err, &lineErr) {
+			lines = append(lines, lineErr.Line)
+			continue
+		}
+		assert.IsNil(err)
+		ids = append(ids, r.ID)
+	}
+	assert.Equal([]int{1, 3}, ids)
+	assert.Equal([]int{2}, lines)
+
+	// break
+	count := 0
+	for range ReadJSONLines[jsonLinesRecord](path) {
+		count++
+		break
+	}
+	assert.Equal(1, count)
+
+	count = 0
+	for _, err := range ReadJSONLines[jsonLinesRecord](filepath.Join(t.TempDir(), "missing.jsonl")) {
+		assert.IsNotNil(err)
+		count++
+	}
+	assert.Equal(1, count)
+}
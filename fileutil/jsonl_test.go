@@ -0,0 +1,177 @@
+package fileutil
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type jsonLinesRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestJSONLinesReader(t *testing.T) {
+	assert := internal.NewAssert(t, "TestJSONLinesReader")
+
+	input := "{\"id\":1,\"name\":\"a\"}\n\n  \r\n{\"id\":2,\"name\":\"b\"}\r\nnot json\n{\"id\":3,\"name\":\"" +
+		strings.Repeat("x", 100000) + "\"}"
+	reader := NewJSONLinesReader[jsonLinesRecord](strings.NewReader(input))
+
+	r, err := reader.Next()
+	assert.IsNil(err)
+	assert.Equal(jsonLinesRecord{ID: 1, Name: "a"}, r)
+
+	r, err = reader.Next()
+	assert.IsNil(err)
+	assert.Equal(jsonLinesRecord{ID: 2, Name: "b"}, r)
+	assert.Equal(4, reader.Line())
+
+	_, err = reader.Next()
+	var lineErr *JSONLinesError
+	assert.Equal(true, errors.As(err, &lineErr))
+	assert.Equal(5, lineErr.Line)
+	assert.Equal(true, strings.Contains(err.Error(), "line 5"))
+
+	// the long line without trailing newline
+	r, err = reader.Next()
+	assert.IsNil(err)
+	assert.Equal(3, r.ID)
+	assert.Equal(100000, len(r.Name))
+
+	_, err = reader.Next()
+	assert.Equal(io.EOF, err)
+}
+
+func TestJSONLinesWriter(t *testing.T) {
+	assert := internal.NewAssert(t, "TestJSONLinesWriter")
+
+	path := filepath.Join(t.TempDir(), "data.jsonl")
+
+	w, err := NewJSONLinesWriter(path)
+	assert.IsNil(err)
+
+	assert.IsNil(w.Write(jsonLinesRecord{ID: 1, Name: "a"}))
+	assert.IsNil(w.Write(map[string]int{"id": 2}))
+
+	// buffered until flush
+	content, _ := ReadFileToString(path)
+	assert.Equal("", content)
+
+	assert.IsNil(w.Flush())
+	content, _ = ReadFileToString(path)
+	assert.Equal("{\"id\":1,\"name\":\"a\"}\n{\"id\":2}\n", content)
+
+	assert.IsNotNil(w.Write(make(chan int)))
+
+	assert.IsNil(w.Write(jsonLinesRecord{ID: 3}))
+	assert.IsNil(w.Close())
+	assert.IsNil(w.Close())
+
+	// appending to the existing file
+	w, err = NewJSONLinesWriter(path, WithJSONLinesBufferSize(0))
+	assert.IsNil(err)
+	assert.IsNil(w.Write(jsonLinesRecord{ID: 4}))
+
+	records, err := ReadJSONLinesFile[jsonLinesRecord](path)
+	assert.IsNil(err)
+	assert.Equal(4, len(records))
+	assert.Equal(4, records[3].ID)
+	assert.IsNil(w.Close())
+
+	_, err = ReadJSONLinesFile[jsonLinesRecord](filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.IsNotNil(err)
+}
+
+func TestJSONLinesWriterFlushInterval(t *testing.T) {
+	assert := internal.NewAssert(t, "TestJSONLinesWriterFlushInterval")
+
+	path := filepath.Join(t.TempDir(), "data.jsonl")
+
+	w, err := NewJSONLinesWriter(path, WithJSONLinesFlushInterval(10*time.Millisecond))
+	assert.IsNil(err)
+	defer w.Close()
+
+	assert.IsNil(w.Write(jsonLinesRecord{ID: 1}))
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if content, _ := ReadFileToString(path); content != "" {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	content, _ := ReadFileToString(path)
+	assert.Equal("{\"id\":1,\"name\":\"\"}\n", content)
+}
+
+func TestJSONLinesWriterRotate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestJSONLinesWriterRotate")
+
+	path := filepath.Join(t.TempDir(), "data.jsonl")
+
+	var mu sync.Mutex
+	var rotated []string
+
+	w, err := NewJSONLinesWriter(path,
+		WithJSONLinesBufferSize(50),
+		WithJSONLinesRotate(WithMaxSize(100), WithRotateHook(func(backup string) {
+			mu.Lock()
+			rotated = append(rotated, backup)
+			mu.Unlock()
+		})),
+	)
+	assert.IsNil(err)
+
+	for i := 0; i < 20; i++ {
+		assert.IsNil(w.Write(jsonLinesRecord{ID: i, Name: "lancet"}))
+	}
+	assert.IsNil(w.Rotate())
+	assert.IsNil(w.Close())
+
+	backups, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "data-*.jsonl"))
+	mu.Lock()
+	assert.Equal(len(backups), len(rotated))
+	mu.Unlock()
+	assert.Equal(true, len(backups) > 1)
+
+	// every file contains whole lines
+	ids := map[int]bool{}
+	for _, file := range append(backups, path) {
+		records, err := ReadJSONLinesFile[jsonLinesRecord](file)
+		assert.IsNil(err)
+		for _, r := range records {
+			ids[r.ID] = true
+		}
+	}
+	assert.Equal(20, len(ids))
+}
+
+func TestJSONLinesWriterFlushFailed(t *testing.T) {
+	assert := internal.NewAssert(t, "TestJSONLinesWriterFlushFailed")
+
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	w, err := NewJSONLinesWriter(path)
+	assert.IsNil(err)
+
+	assert.IsNil(w.Write(jsonLinesRecord{ID: 1, Name: "a"}))
+
+	// the lines failed to write are kept in the buffer
+	w.writer.Close()
+	assert.IsNotNil(w.Flush())
+
+	w.writer, err = NewRotatingWriter(path)
+	assert.IsNil(err)
+	assert.IsNil(w.Close())
+
+	records, err := ReadJSONLinesFile[jsonLinesRecord](path)
+	assert.IsNil(err)
+	assert.Equal([]jsonLinesRecord{{ID: 1, Name: "a"}}, records)
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPathTraversal is returned if the path is outside of the root directory.
+var ErrPathTraversal = errors.New("fileutil: path traversal outside of root")
+
+// ExpandHome replaces the leading ~ of path with the home directory of current user, eg: ~/.config to
+// /home/user/.config. The path starting with ~user is returned as it is.
+func ExpandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, path[1:]), nil
+}
+
+// ExpandPath expands the leading ~ like ExpandHome and the environment variables like $HOME or ${HOME} of path,
+// the undefined variables are replaced with the empty string.
+func ExpandPath(path string) (string, error) {
+	path, err := ExpandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	return os.ExpandEnv(path), nil
+}
+
+// NormalizePath converts both / and \ of path to the separator of current OS, and returns the shortest
+// equivalent path by filepath.Clean, eg: a\b/../c to a/c on Linux. The empty path is normalized to ".".
+func NormalizePath(path string) string {
+	path = strings.ReplaceAll(path, "\\", "/")
+
+	return filepath.Clean(filepath.FromSlash(path))
+}
+
+// SafeJoin joins the elements to root like filepath.Join, it returns ErrPathTraversal if the result is outside of
+// root, eg: the elements from user input containing ../ or absolute paths. It checks the path lexically, the
+// symbolic links are not resolved.
+func SafeJoin(root string, elem ...string) (string, error) {
+	root = filepath.Clean(root)
+
+	for _, e := range elem {
+		if filepath.IsAbs(e) || filepath.VolumeName(e) != "" {
+			return "", fmt.Errorf("%w: %q is absolute", ErrPathTraversal, e)
+		}
+	}
+
+	path := filepath.Join(append([]string{root}, elem...)...)
+	if _, err := RelativeSafe(root, path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// RelativeSafe returns the relative path of target to root like filepath.Rel, it returns ErrPathTraversal if
+// target is outside of root. The relative paths are relative to the current directory.
+func RelativeSafe(root, target string) (string, error) {
+	root, target, err := absPaths(root, target)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, target)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q is not in %q", ErrPathTraversal, target, root)
+	}
+
+	if isParentRel(rel) {
+		return "", fmt.Errorf("%w: %q is not in %q", ErrPathTraversal, target, root)
+	}
+
+	return rel, nil
+}
+
+// IsSubPath checks if path is parent or inside of parent lexically, the relative paths are relative to the
+// current directory.
+func IsSubPath(parent, path string) bool {
+	_, err := RelativeSafe(parent, path)
+
+	return err == nil
+}
+
+// absPaths returns the absolute paths of a and b.
+func absPaths(a, b string) (string, string, error) {
+	a, err := filepath.Abs(a)
+	if err != nil {
+		return "", "", err
+	}
+
+	b, err = filepath.Abs(b)
+	if err != nil {
+		return "", "", err
+	}
+
+	return a, b, nil
+}
+
+// isParentRel checks if the relative path goes up to the parent directory.
+func isParentRel(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,145 @@
+package fileutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestExpandHome(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestExpandHome")
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	result, err := ExpandHome("~")
+	assert.IsNil(err)
+	assert.Equal(home, result)
+
+	result, err = ExpandHome("~/.config/app")
+	assert.IsNil(err)
+	assert.Equal(filepath.Join(home, ".config", "app"), result)
+
+	for _, path := range []string{"", "~user/a", "a/~/b", "/tmp"} {
+		result, err = ExpandHome(path)
+		assert.IsNil(err)
+		assert.Equal(path, result)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	assert := internal.NewAssert(t, "TestExpandPath")
+
+	t.Setenv("LANCET_TEST_DIR", "data")
+
+	result, err := ExpandPath("/var/$LANCET_TEST_DIR/${LANCET_TEST_DIR}.db")
+	assert.IsNil(err)
+	assert.Equal("/var/data/data.db", result)
+
+	result, err = ExpandPath("/var/$LANCET_TEST_UNDEFINED/a")
+	assert.IsNil(err)
+	assert.Equal("/var//a", result)
+
+	home, err := os.UserHomeDir()
+	if err == nil {
+		result, err = ExpandPath("~/$LANCET_TEST_DIR")
+		assert.IsNil(err)
+		assert.Equal(filepath.Join(home, "data"), result)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestNormalizePath")
+
+	assert.Equal(".", NormalizePath(""))
+	assert.Equal(filepath.FromSlash("a/c"), NormalizePath("a\\b/../c"))
+	assert.Equal(filepath.FromSlash("a/b"), NormalizePath("./a//b/"))
+	assert.Equal(filepath.FromSlash("../a"), NormalizePath("..\\a"))
+}
+
+func TestSafeJoin(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSafeJoin")
+
+	root := filepath.FromSlash("/srv/static")
+
+	path, err := SafeJoin(root, "css", "app.css")
+	assert.IsNil(err)
+	assert.Equal(filepath.Join(root, "css", "app.css"), path)
+
+	path, err = SafeJoin(root, "a/../b")
+	assert.IsNil(err)
+	assert.Equal(filepath.Join(root, "b"), path)
+
+	path, err = SafeJoin(root)
+	assert.IsNil(err)
+	assert.Equal(root, path)
+
+	// the name starting with .. is not traversal
+	path, err = SafeJoin(root, "..a")
+	assert.IsNil(err)
+	assert.Equal(filepath.Join(root, "..a"), path)
+
+	for _, elem := range []string{"..", "../static2", "a/../../etc/passwd", filepath.FromSlash("/etc/passwd")} {
+		_, err = SafeJoin(root, elem)
+		assert.Equal(true, errors.Is(err, ErrPathTraversal))
+	}
+
+	// relative root
+	path, err = SafeJoin("data", "a.txt")
+	assert.IsNil(err)
+	assert.Equal(filepath.Join("data", "a.txt"), path)
+
+	_, err = SafeJoin("data", "../a.txt")
+	assert.Equal(true, errors.Is(err, ErrPathTraversal))
+}
+
+func TestRelativeSafe(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRelativeSafe")
+
+	root := filepath.FromSlash("/srv/static")
+
+	rel, err := RelativeSafe(root, filepath.Join(root, "css", "app.css"))
+	assert.IsNil(err)
+	assert.Equal(filepath.Join("css", "app.css"), rel)
+
+	rel, err = RelativeSafe(root, root)
+	assert.IsNil(err)
+	assert.Equal(".", rel)
+
+	_, err = RelativeSafe(root, filepath.FromSlash("/srv/static2/a"))
+	assert.Equal(true, errors.Is(err, ErrPathTraversal))
+
+	_, err = RelativeSafe(root, filepath.FromSlash("/srv"))
+	assert.Equal(true, errors.Is(err, ErrPathTraversal))
+}
+
+func TestIsSubPath(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIsSubPath")
+
+	parent := filepath.FromSlash("/srv/static")
+
+	assert.Equal(true, IsSubPath(parent, parent))
+	assert.Equal(true, IsSubPath(parent, filepath.FromSlash("/srv/static/a/b")))
+	assert.Equal(true, IsSubPath(parent, filepath.FromSlash("/srv/static/a/../b")))
+	assert.Equal(false, IsSubPath(parent, filepath.FromSlash("/srv/static/../b")))
+	assert.Equal(false, IsSubPath(parent, filepath.FromSlash("/srv/staticx")))
+	assert.Equal(false, IsSubPath(parent, filepath.FromSlash("/srv")))
+
+	assert.Equal(true, IsSubPath(".", "a/b"))
+	assert.Equal(false, IsSubPath("a", "b"))
+}
@@ -0,0 +1,251 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// ReadProperties reads the java style .properties file into map.
+func ReadProperties(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseProperties(f)
+}
+
+// ParseProperties parses java style properties content from reader.
+// It supports '#' and '!' comments, '=', ':' and whitespace separators, line continuation
+// with trailing backslash, and escapes like \t, \n and \uXXXX.
+func ParseProperties(reader io.Reader) (map[string]string, error) {
+	result := make(map[string]string)
+
+	scanner := bufio.NewScanner(reader)
+
+	var logical strings.Builder
+	continued := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if continued {
+			line = strings.TrimLeft(line, " \t\f")
+		} else {
+			line = strings.TrimLeft(line, " \t\f")
+			if line == "" || line[0] == '#' || line[0] == '!' {
+				continue
+			}
+		}
+
+		if endsWithOddBackslashes(line) {
+			logical.WriteString(line[:len(line)-1])
+			continued = true
+			continue
+		}
+
+		logical.WriteString(line)
+		continued = false
+
+		key, value, err := parsePropertyLine(logical.String())
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+		logical.Reset()
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if logical.Len() > 0 {
+		key, value, err := parsePropertyLine(logical.String())
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// WriteProperties writes properties into file, keys are sorted, and non-ASCII characters are escaped as \uXXXX.
+func WriteProperties(path string, properties map[string]string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	w := bufio.NewWriter(f)
+	for _, k := range keys {
+		if _, err := w.WriteString(escapeProperty(k, true) + "=" + escapeProperty(properties[k], false) + "\n"); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+func endsWithOddBackslashes(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+
+	return count%2 == 1
+}
+
+// parsePropertyLine splits the logical line into key and value, and unescapes them.
+func parsePropertyLine(line string) (string, string, error) {
+	keyEnd := len(line)
+	valueStart := len(line)
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			keyEnd = i
+			valueStart = i + 1
+			// skip whitespace around separator, and one '=' or ':' after whitespace
+			if c == ' ' || c == '\t' || c == '\f' {
+				for valueStart < len(line) && strings.IndexByte(" \t\f", line[valueStart]) >= 0 {
+					valueStart++
+				}
+				if valueStart < len(line) && (line[valueStart] == '=' || line[valueStart] == ':') {
+					valueStart++
+				}
+			}
+			for valueStart < len(line) && strings.IndexByte(" \t\f", line[valueStart]) >= 0 {
+				valueStart++
+			}
+			break
+		}
+	}
+
+	key, err := unescapeProperty(line[:keyEnd])
+	if err != nil {
+		return "", "", err
+	}
+
+	value, err := unescapeProperty(line[valueStart:])
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, value, nil
+}
+
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	var surrogate rune
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i == len(s)-1 {
+			sb.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+4 >= len(s) {
+				return "", fmt.Errorf("properties: malformed \\uxxxx encoding in %q", s)
+			}
+			code, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("properties: malformed \\uxxxx encoding in %q", s)
+			}
+			i += 4
+
+			r := rune(code)
+			if utf16.IsSurrogate(r) {
+				if surrogate == 0 {
+					surrogate = r
+					continue
+				}
+				r = utf16.DecodeRune(surrogate, r)
+				surrogate = 0
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func escapeProperty(s string, isKey bool) string {
+	var sb strings.Builder
+
+	for i, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				sb.WriteString(`\ `)
+			} else {
+				sb.WriteRune(r)
+			}
+		default:
+			if r < 0x20 || r > 0x7e {
+				for _, u := range utf16Encode(r) {
+					sb.WriteString(fmt.Sprintf(`\u%04X`, u))
+				}
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func utf16Encode(r rune) []uint16 {
+	return utf16.Encode([]rune{r})
+}
@@ -0,0 +1,81 @@
+package fileutil
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestParseProperties(t *testing.T) {
+	assert := internal.NewAssert(t, "TestParseProperties")
+
+	content := `# comment
+! another comment
+name=lancet
+version : 2.0
+author   duke
+empty=
+path=c:\\dir\\file
+greeting=\u4f60\u597d
+emoji=\uD83D\uDE00
+multi=first, \
+      second
+key\ with\ space=value
+  indented = yes
+tab\tkey=escaped\ttab
+`
+
+	props, err := ParseProperties(strings.NewReader(content))
+	assert.IsNil(err)
+
+	expected := map[string]string{
+		"name":           "lancet",
+		"version":        "2.0",
+		"author":         "duke",
+		"empty":          "",
+		"path":           `c:\dir\file`,
+		"greeting":       "你好",
+		"emoji":          "😀",
+		"multi":          "first, second",
+		"key with space": "value",
+		"indented":       "yes",
+		"tab\tkey":       "escaped\ttab",
+	}
+	assert.Equal(expected, props)
+
+	_, err = ParseProperties(strings.NewReader(`bad=\u12`))
+	assert.IsNotNil(err)
+}
+
+func TestWriteProperties(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWriteProperties")
+
+	path := "./testdata/test.properties"
+	defer os.Remove(path)
+
+	props := map[string]string{
+		"name":           "lancet",
+		"greeting":       "你好",
+		"key with space": " leading space",
+		"url":            "http://a.com?x=1#top",
+		"multi":          "line1\nline2",
+	}
+
+	err := WriteProperties(path, props)
+	assert.IsNil(err)
+
+	content, _ := os.ReadFile(path)
+	expected := `greeting=\u4F60\u597D
+key\ with\ space=\ leading space
+multi=line1\nline2
+name=lancet
+url=http\://a.com?x\=1\#top
+`
+	assert.Equal(expected, string(content))
+
+	result, err := ReadProperties(path)
+	assert.IsNil(err)
+	assert.Equal(props, result)
+}
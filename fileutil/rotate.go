@@ -0,0 +1,474 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingWriter is an io.WriteCloser writing into a file, the file is rotated when it reaches
+// the max size or the rotate interval. Rotated files are renamed to name-<time>.ext in the same
+// directory, and optionally gzip compressed. It could be used as the output of log.Logger.
+type RotatingWriter struct {
+	filename string
+	config   rotateConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	// closed is set by Close, file is also nil when the file failed to reopen in rotation
+	closed bool
+
+	// compressMu serializes the background compression and cleanup of backups, Close waits for pending ones
+	compressMu sync.Mutex
+	pending    sync.WaitGroup
+}
+
+type rotateConfig struct {
+	maxSize      int64
+	interval     time.Duration
+	maxBackups   int
+	maxBackupAge time.Duration
+	compress     bool
+	clock        datetime.Clock
+	onRotate     []func(backup string)
+	onError      []func(err error)
+}
+
+// RotateOption is the option of RotatingWriter.
+type RotateOption func(*rotateConfig)
+
+// WithMaxSize rotates the file before its size exceeds maxSize bytes.
+func WithMaxSize(maxSize int64) RotateOption {
+	return func(c *rotateConfig) {
+		c.maxSize = maxSize
+	}
+}
+
+// WithRotateInterval rotates the file when it has been opened for longer than interval.
+func WithRotateInterval(interval time.Duration) RotateOption {
+	return func(c *rotateConfig) {
+		c.interval = interval
+	}
+}
+
+// WithMaxBackups keeps at most n rotated files, the oldest ones are removed.
+func WithMaxBackups(n int) RotateOption {
+	return func(c *rotateConfig) {
+		c.maxBackups = n
+	}
+}
+
+// WithMaxBackupAge removes the rotated files older than age.
+func WithMaxBackupAge(age time.Duration) RotateOption {
+	return func(c *rotateConfig) {
+		c.maxBackupAge = age
+	}
+}
+
+// WithCompress gzip compresses the rotated files in background, Close waits for the compression to finish.
+func WithCompress() RotateOption {
+	return func(c *rotateConfig) {
+		c.compress = true
+	}
+}
+
+// WithRotateClock sets the clock used for rotate interval, backup names and backup age.
+func WithRotateClock(clock datetime.Clock) RotateOption {
+	return func(c *rotateConfig) {
+		c.clock = clock
+	}
+}
+
+// WithRotateHook calls fn after the file is rotated, backup is the path of the rotated file (ends with .gz if it's
+// compressed), eg: to upload or index the backup. fn is called in background after the compression with WithCompress,
+// otherwise it's called while writing is blocked, so it should be quick and should not call the methods of writer.
+func WithRotateHook(fn func(backup string)) RotateOption {
+	return func(c *rotateConfig) {
+		c.onRotate = append(c.onRotate, fn)
+	}
+}
+
+// WithRotateErrorHook calls fn with the errors which don't fail the writes, they are the errors of compressing
+// and removing the backups, and renaming the file in rotation triggered by Write (the data is written into the
+// original file). fn could be called in background like the rotate hook.
+func WithRotateErrorHook(fn func(err error)) RotateOption {
+	return func(c *rotateConfig) {
+		c.onError = append(c.onError, fn)
+	}
+}
+
+// NewRotatingWriter opens or creates the file for appending, and returns a RotatingWriter writing into it.
+// Without options, the file is never rotated automatically.
+func NewRotatingWriter(filename string, opts ...RotateOption) (*RotatingWriter, error) {
+	config := rotateConfig{clock: datetime.SystemClock}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	w := &RotatingWriter{filename: filename, config: config}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Write writes p into the current file, it rotates the file first if needed.
+// A single write larger than max size is written into a new file as a whole.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.ensureOpen(); err != nil {
+		return 0, err
+	}
+
+	var backup string
+	if w.size > 0 && w.shouldRotate(int64(len(p))) {
+		var err error
+		backup, err = w.rotate()
+		if err != nil {
+			if w.file == nil {
+				if backup != "" {
+					w.finishRotate(backup)
+				}
+				return 0, err
+			}
+			// the file failed to rename is reopened, keep writing into it
+			w.reportError(err)
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+
+	if backup != "" {
+		w.finishRotate(backup)
+	}
+
+	return n, err
+}
+
+// Rotate closes the current file, renames it to a backup file and opens a new file.
+// The errors of compressing and removing the backups are passed to the rotate error hooks.
+func (w *RotatingWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.ensureOpen(); err != nil {
+		return err
+	}
+
+	backup, err := w.rotate()
+	if backup != "" {
+		w.finishRotate(backup)
+	}
+
+	return err
+}
+
+// Sync commits the current content of file to stable storage.
+func (w *RotatingWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.ensureOpen(); err != nil {
+		return err
+	}
+
+	return w.file.Sync()
+}
+
+// Close closes the current file and waits for the compression of backups, it's safe to call Close more than once.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+
+	w.closed = true
+
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+
+	w.mu.Unlock()
+
+	// the hooks called in background could lock the writer
+	w.pending.Wait()
+
+	return err
+}
+
+// Backups returns the rotated files of the writer, newest first.
+func (w *RotatingWriter) Backups() ([]string, error) {
+	backups, err := w.listBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(backups))
+	for i, b := range backups {
+		result[i] = b.path
+	}
+
+	return result, nil
+}
+
+func (w *RotatingWriter) shouldRotate(writeSize int64) bool {
+	if w.config.maxSize > 0 && w.size+writeSize > w.config.maxSize {
+		return true
+	}
+
+	return w.config.interval > 0 && w.config.clock.Now().Sub(w.openedAt) >= w.config.interval
+}
+
+// ensureOpen returns os.ErrClosed if the writer is closed, and reopens the file if it failed to open in rotation.
+func (w *RotatingWriter) ensureOpen() error {
+	if w.closed {
+		return os.ErrClosed
+	}
+	if w.file == nil {
+		return w.open()
+	}
+
+	return nil
+}
+
+func (w *RotatingWriter) open() error {
+	if dir := filepath.Dir(w.filename); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(w.filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	w.file = f
+	w.size = info.Size()
+	w.openedAt = w.config.clock.Now()
+
+	return nil
+}
+
+// rotate renames the current file to backup and opens a new file, backup is empty if the file isn't renamed.
+func (w *RotatingWriter) rotate() (string, error) {
+	if err := w.file.Close(); err != nil {
+		return "", err
+	}
+	w.file = nil
+
+	backup := w.backupName(w.config.clock.Now())
+	if err := os.Rename(w.filename, backup); err != nil {
+		// keep writing into the original file
+		if openErr := w.open(); openErr != nil {
+			return "", internal.JoinError(err, openErr)
+		}
+		return "", err
+	}
+
+	// if the new file fails to open, it's opened again by the next call
+	return backup, w.open()
+}
+
+// finishRotate compresses the backup in background if needed, then calls the rotate hooks and removes the old
+// backups. The file has been rotated, so the errors are passed to the error hooks instead of failing the writes.
+func (w *RotatingWriter) finishRotate(backup string) {
+	if !w.config.compress {
+		w.afterBackup(backup)
+		return
+	}
+
+	w.pending.Add(1)
+	go func() {
+		defer w.pending.Done()
+
+		w.compressMu.Lock()
+		defer w.compressMu.Unlock()
+
+		if err := compressFile(backup); err != nil {
+			w.reportError(err)
+		} else {
+			backup += ".gz"
+		}
+		w.afterBackup(backup)
+	}()
+}
+
+func (w *RotatingWriter) afterBackup(backup string) {
+	for _, fn := range w.config.onRotate {
+		fn(backup)
+	}
+
+	if err := w.cleanup(); err != nil {
+		w.reportError(err)
+	}
+}
+
+func (w *RotatingWriter) reportError(err error) {
+	for _, fn := range w.config.onError {
+		fn(err)
+	}
+}
+
+// backupName returns the name of backup file rotated at t, t is moved forward if the name exists.
+func (w *RotatingWriter) backupName(t time.Time) string {
+	dir := filepath.Dir(w.filename)
+	prefix, ext := w.backupPrefixAndExt()
+
+	for {
+		name := filepath.Join(dir, prefix+t.In(time.Local).Format(backupTimeFormat)+ext)
+		if !IsExist(name) && !IsExist(name+".gz") {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+func (w *RotatingWriter) backupPrefixAndExt() (string, string) {
+	base := filepath.Base(w.filename)
+	ext := filepath.Ext(base)
+
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+type backupFile struct {
+	path string
+	time time.Time
+}
+
+func (w *RotatingWriter) listBackups() ([]backupFile, error) {
+	dir := filepath.Dir(w.filename)
+	prefix, ext := w.backupPrefixAndExt()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]backupFile, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		ts := strings.TrimPrefix(name, prefix)
+		switch {
+		case strings.HasSuffix(ts, ext+".gz"):
+			ts = strings.TrimSuffix(ts, ext+".gz")
+		case strings.HasSuffix(ts, ext):
+			ts = strings.TrimSuffix(ts, ext)
+		default:
+			continue
+		}
+
+		t, err := time.ParseInLocation(backupTimeFormat, ts, time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backupFile{path: filepath.Join(dir, name), time: t})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.After(backups[j].time)
+	})
+
+	return backups, nil
+}
+
+// cleanup removes the backup files exceed max backups or max backup age.
+func (w *RotatingWriter) cleanup() error {
+	if w.config.maxBackups <= 0 && w.config.maxBackupAge <= 0 {
+		return nil
+	}
+
+	backups, err := w.listBackups()
+	if err != nil {
+		return err
+	}
+
+	deadline := w.config.clock.Now().Add(-w.config.maxBackupAge)
+
+	var errs []string
+	for i, b := range backups {
+		tooMany := w.config.maxBackups > 0 && i >= w.config.maxBackups
+		tooOld := w.config.maxBackupAge > 0 && b.time.Before(deadline)
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// compressFile gzip compresses the file into path.gz and removes the original file.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+
+	src.Close()
+
+	return os.Remove(path)
+}
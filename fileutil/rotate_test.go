@@ -0,0 +1,303 @@
+package fileutil
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestRotatingWriterMaxSize(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRotatingWriterMaxSize")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	clock := datetime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local))
+	w, err := NewRotatingWriter(path, WithMaxSize(10), WithRotateClock(clock))
+	assert.IsNil(err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("hello"))
+	assert.IsNil(err)
+	_, err = w.Write([]byte("world"))
+	assert.IsNil(err)
+
+	backups, _ := w.Backups()
+	assert.Equal(0, len(backups))
+
+	clock.Advance(time.Second)
+	_, err = w.Write([]byte("lancet"))
+	assert.IsNil(err)
+
+	backups, _ = w.Backups()
+	assert.Equal(1, len(backups))
+	assert.Equal(filepath.Join(dir, "app-2024-01-01T00-00-01.000.log"), backups[0])
+
+	content, _ := ReadFileToString(backups[0])
+	assert.Equal("helloworld", content)
+	content, _ = ReadFileToString(path)
+	assert.Equal("lancet", content)
+
+	// write larger than max size goes into a file as a whole
+	clock.Advance(time.Second)
+	_, err = w.Write([]byte("a long long line"))
+	assert.IsNil(err)
+
+	content, _ = ReadFileToString(path)
+	assert.Equal("a long long line", content)
+}
+
+func TestRotatingWriterInterval(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRotatingWriterInterval")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	clock := datetime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local))
+	w, err := NewRotatingWriter(path, WithRotateInterval(time.Hour), WithRotateClock(clock))
+	assert.IsNil(err)
+	defer w.Close()
+
+	w.Write([]byte("first"))
+	clock.Advance(30 * time.Minute)
+	w.Write([]byte("second"))
+
+	backups, _ := w.Backups()
+	assert.Equal(0, len(backups))
+
+	clock.Advance(30 * time.Minute)
+	w.Write([]byte("third"))
+
+	backups, _ = w.Backups()
+	assert.Equal(1, len(backups))
+
+	content, _ := ReadFileToString(backups[0])
+	assert.Equal("firstsecond", content)
+	content, _ = ReadFileToString(path)
+	assert.Equal("third", content)
+}
+
+func TestRotatingWriterCleanup(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRotatingWriterCleanup")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	clock := datetime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local))
+	w, err := NewRotatingWriter(path, WithMaxBackups(2), WithRotateClock(clock))
+	assert.IsNil(err)
+	defer w.Close()
+
+	for i := 0; i < 4; i++ {
+		w.Write([]byte{byte('a' + i)})
+		clock.Advance(time.Minute)
+		assert.IsNil(w.Rotate())
+	}
+
+	backups, _ := w.Backups()
+	assert.Equal(2, len(backups))
+
+	content, _ := ReadFileToString(backups[0])
+	assert.Equal("d", content)
+	content, _ = ReadFileToString(backups[1])
+	assert.Equal("c", content)
+
+	w2, err := NewRotatingWriter(filepath.Join(dir, "age.log"), WithMaxBackupAge(90*time.Minute), WithRotateClock(clock))
+	assert.IsNil(err)
+	defer w2.Close()
+
+	for i := 0; i < 3; i++ {
+		w2.Write([]byte("x"))
+		assert.IsNil(w2.Rotate())
+		clock.Advance(time.Hour)
+	}
+
+	// rotated at 0h, 1h, 2h and cleaned up at 2h
+	backups, _ = w2.Backups()
+	assert.Equal(2, len(backups))
+}
+
+func TestRotatingWriterCompress(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRotatingWriterCompress")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := NewRotatingWriter(path, WithCompress())
+	assert.IsNil(err)
+
+	w.Write([]byte("hello lancet"))
+	assert.IsNil(w.Rotate())
+
+	_, err = w.Write([]byte("new file"))
+	assert.IsNil(err)
+
+	// close waits for the compression in background
+	assert.IsNil(w.Close())
+
+	content, _ := ReadFileToString(path)
+	assert.Equal("new file", content)
+
+	backups, _ := w.Backups()
+	assert.Equal(1, len(backups))
+	assert.Equal(true, strings.HasSuffix(backups[0], ".log.gz"))
+
+	f, err := os.Open(backups[0])
+	assert.IsNil(err)
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	assert.IsNil(err)
+	data, _ := io.ReadAll(gz)
+	assert.Equal("hello lancet", string(data))
+
+	assert.IsNil(w.Close())
+
+	_, err = w.Write([]byte("closed"))
+	assert.IsNotNil(err)
+}
+
+func TestRotatingWriterAppend(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRotatingWriterAppend")
+
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+
+	w, err := NewRotatingWriter(path, WithMaxSize(8))
+	assert.IsNil(err)
+	w.Write([]byte("hello"))
+	w.Close()
+
+	// reopen keeps the size of existing file
+	w, err = NewRotatingWriter(path, WithMaxSize(8))
+	assert.IsNil(err)
+	defer w.Close()
+
+	w.Write([]byte("world"))
+
+	backups, _ := w.Backups()
+	assert.Equal(1, len(backups))
+
+	content, _ := ReadFileToString(path)
+	assert.Equal("world", content)
+}
+
+func TestRotatingWriterHook(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRotatingWriterHook")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	var rotated []string
+	clock := datetime.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local))
+	w, err := NewRotatingWriter(path, WithRotateClock(clock), WithCompress(),
+		WithRotateHook(func(backup string) {
+			rotated = append(rotated, backup)
+		}))
+	assert.IsNil(err)
+
+	w.Write([]byte("hello"))
+	assert.IsNil(w.Rotate())
+	assert.IsNil(w.Close())
+
+	assert.Equal([]string{filepath.Join(dir, "app-2024-01-01T00-00-00.000.log.gz")}, rotated)
+	assert.Equal(true, IsExist(rotated[0]))
+}
+
+func TestRotatingWriterRotateFailed(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRotatingWriterRotateFailed")
+
+	dir := filepath.Join(t.TempDir(), "logs")
+	path := filepath.Join(dir, "app.log")
+
+	w, err := NewRotatingWriter(path)
+	assert.IsNil(err)
+	defer w.Close()
+
+	w.Write([]byte("hello\n"))
+
+	// rename fails, the original file is reopened
+	os.RemoveAll(dir)
+	assert.IsNotNil(w.Rotate())
+
+	_, err = w.Write([]byte("world\n"))
+	assert.IsNil(err)
+
+	data, _ := os.ReadFile(path)
+	assert.Equal("world\n", string(data))
+
+	// the file failed to open is opened by the next write
+	w.file.Close()
+	w.file = nil
+
+	_, err = w.Write([]byte("again\n"))
+	assert.IsNil(err)
+
+	data, _ = os.ReadFile(path)
+	assert.Equal("world\nagain\n", string(data))
+
+	assert.IsNil(w.Close())
+	_, err = w.Write([]byte("closed\n"))
+	assert.Equal(os.ErrClosed, err)
+}
+
+func TestRotatingWriterErrorHook(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRotatingWriterErrorHook")
+
+	dir := filepath.Join(t.TempDir(), "logs")
+	path := filepath.Join(dir, "app.log")
+
+	var errs []error
+	w, err := NewRotatingWriter(path, WithMaxSize(8), WithRotateErrorHook(func(err error) {
+		errs = append(errs, err)
+	}))
+	assert.IsNil(err)
+	defer w.Close()
+
+	w.Write([]byte("hello\n"))
+
+	// the rename in rotation fails, the data is still written into the reopened file
+	os.RemoveAll(dir)
+	n, err := w.Write([]byte("world\n"))
+	assert.IsNil(err)
+	assert.Equal(6, n)
+	assert.Equal(1, len(errs))
+
+	data, _ := os.ReadFile(path)
+	assert.Equal("world\n", string(data))
+
+	// the compression fails, the data is written into the new file and the backup is kept
+	var backup string
+	w2, err := NewRotatingWriter(filepath.Join(dir, "compress.log"), WithMaxSize(8), WithCompress(),
+		WithRotateHook(func(b string) {
+			backup = b
+		}),
+		WithRotateErrorHook(func(err error) {
+			errs = append(errs, err)
+		}))
+	assert.IsNil(err)
+
+	w2.Write([]byte("hello\n"))
+	w2.compressMu.Lock()
+	n, err = w2.Write([]byte("world\n"))
+	assert.IsNil(err)
+	assert.Equal(6, n)
+
+	backups, _ := w2.Backups()
+	assert.Equal(1, len(backups))
+	os.Remove(backups[0])
+	w2.compressMu.Unlock()
+
+	assert.IsNil(w2.Close())
+	assert.Equal(2, len(errs))
+	assert.Equal(backups[0], backup)
+
+	data, _ = os.ReadFile(filepath.Join(dir, "compress.log"))
+	assert.Equal("world\n", string(data))
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// ErrInsufficientSpace is returned when there is not enough free disk space for the operation,
+// the returned error is an *InsufficientSpaceError, use errors.Is to check it.
+var ErrInsufficientSpace = errors.New("fileutil: insufficient disk space")
+
+// InsufficientSpaceError records the required and available space of the checked path.
+type InsufficientSpaceError struct {
+	Path      string
+	Required  uint64
+	Available uint64
+}
+
+// Error implements the error interface.
+func (e *InsufficientSpaceError) Error() string {
+	return fmt.Sprintf("fileutil: insufficient disk space for %s: required %d bytes, available %d bytes",
+		e.Path, e.Required, e.Available)
+}
+
+// Is reports whether target is ErrInsufficientSpace.
+func (e *InsufficientSpaceError) Is(target error) bool {
+	return target == ErrInsufficientSpace
+}
+
+// WriteOption is the option of copy and write functions.
+type WriteOption func(*writeConfig)
+
+type writeConfig struct {
+	checkSpace bool
+	reserve    uint64
+}
+
+// WithSpaceCheck makes the copy and write functions check the free disk space of destination before writing,
+// reserve is the extra bytes that should remain free after writing.
+// If the space is not enough, nothing is written and ErrInsufficientSpace is returned.
+func WithSpaceCheck(reserve uint64) WriteOption {
+	return func(c *writeConfig) {
+		c.checkSpace = true
+		c.reserve = reserve
+	}
+}
+
+// AvailableSpace returns the free disk space in bytes available to the current user,
+// on the file system where path is located. If path doesn't exist, its nearest existing parent is used.
+func AvailableSpace(path string) (uint64, error) {
+	dir, err := existingDir(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return availableSpace(dir)
+}
+
+// CheckAvailableSpace checks if there are at least need bytes of free disk space for path,
+// returns ErrInsufficientSpace if not.
+func CheckAvailableSpace(path string, need uint64) error {
+	available, err := AvailableSpace(path)
+	if err != nil {
+		return err
+	}
+
+	if available < need {
+		return &InsufficientSpaceError{Path: path, Required: need, Available: available}
+	}
+
+	return nil
+}
+
+func (c *writeConfig) check(path string, size uint64) error {
+	if !c.checkSpace {
+		return nil
+	}
+
+	need := size + c.reserve
+	if need < size {
+		need = math.MaxUint64
+	}
+
+	return CheckAvailableSpace(path, need)
+}
+
+func newWriteConfig(opts []WriteOption) *writeConfig {
+	config := &writeConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+// existingDir returns path itself if it is an existing directory, otherwise its nearest existing parent directory.
+func existingDir(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		info, err := os.Stat(abs)
+		if err == nil {
+			if info.IsDir() {
+				return abs, nil
+			}
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", fmt.Errorf("fileutil: no existing directory for %s", path)
+		}
+		abs = parent
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package fileutil
+
+import (
+	"errors"
+	"runtime"
+)
+
+func availableSpace(dir string) (uint64, error) {
+	return 0, errors.New("fileutil: available space is not supported on " + runtime.GOOS)
+}
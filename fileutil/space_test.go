@@ -0,0 +1,79 @@
+package fileutil
+
+import (
+	"errors"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestCheckAvailableSpace(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCheckAvailableSpace")
+
+	available, err := AvailableSpace("./testdata")
+	assert.IsNil(err)
+	assert.Equal(true, available > 0)
+
+	// not exist path uses its nearest existing parent
+	_, err = AvailableSpace("./testdata/notexist/a/b.txt")
+	assert.IsNil(err)
+
+	assert.IsNil(CheckAvailableSpace("./testdata", 1))
+
+	err = CheckAvailableSpace("./testdata", math.MaxUint64)
+	assert.Equal(true, errors.Is(err, ErrInsufficientSpace))
+
+	var spaceErr *InsufficientSpaceError
+	assert.Equal(true, errors.As(err, &spaceErr))
+	assert.Equal(uint64(math.MaxUint64), spaceErr.Required)
+	assert.Equal("./testdata", spaceErr.Path)
+}
+
+func TestWriteWithSpaceCheck(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWriteWithSpaceCheck")
+
+	path := "./testdata/space_check.txt"
+	defer os.Remove(path)
+
+	err := WriteStringToFile(path, "hello", false, WithSpaceCheck(0))
+	assert.IsNil(err)
+
+	err = WriteStringToFile(path, "world", true, WithSpaceCheck(math.MaxUint64))
+	assert.Equal(true, errors.Is(err, ErrInsufficientSpace))
+
+	content, _ := ReadFileToString(path)
+	assert.Equal("hello", content)
+
+	err = WriteBytesToFile(path, []byte("lancet"), WithSpaceCheck(math.MaxUint64))
+	assert.Equal(true, errors.Is(err, ErrInsufficientSpace))
+
+	err = WriteBytesToFile(path, []byte("lancet"), WithSpaceCheck(1024))
+	assert.IsNil(err)
+
+	content, _ = ReadFileToString(path)
+	assert.Equal("lancet", content)
+}
+
+func TestCopyFileWithSpaceCheck(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCopyFileWithSpaceCheck")
+
+	src := "./testdata/space_src.txt"
+	dst := "./testdata/space_dst.txt"
+	defer os.Remove(src)
+	defer os.Remove(dst)
+
+	err := WriteStringToFile(src, "hello world", false)
+	assert.IsNil(err)
+
+	err = CopyFile(src, dst, WithSpaceCheck(math.MaxUint64))
+	assert.Equal(true, errors.Is(err, ErrInsufficientSpace))
+	assert.Equal(false, IsExist(dst))
+
+	err = CopyFile(src, dst, WithSpaceCheck(0))
+	assert.IsNil(err)
+
+	content, _ := ReadFileToString(dst)
+	assert.Equal("hello world", content)
+}
@@ -0,0 +1,847 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	tomlBareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	tomlIntPattern     = regexp.MustCompile(`^[-+]?(0|[1-9](_?[0-9])*)$`)
+	tomlHexPattern     = regexp.MustCompile(`^0x[0-9A-Fa-f](_?[0-9A-Fa-f])*$`)
+	tomlOctPattern     = regexp.MustCompile(`^0o[0-7](_?[0-7])*$`)
+	tomlBinPattern     = regexp.MustCompile(`^0b[01](_?[01])*$`)
+	tomlFloatPattern   = regexp.MustCompile(`^[-+]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][-+]?[0-9](_?[0-9])*)?$`)
+)
+
+// tomlArrayKey is the key of an array of tables in its parent table.
+type tomlArrayKey struct {
+	table *configTable
+	key   string
+}
+
+// tomlParser parses the toml document into *configTable, the values are *configTable, []any, string, bool,
+// int64, float64 and time.Time.
+type tomlParser struct {
+	src  string
+	pos  int
+	root *configTable
+	// cur is the table of the last [table] or [[array of tables]] header
+	cur *configTable
+	// defined are the tables defined by headers, which can't be defined again
+	defined map[*configTable]bool
+	// dotted are the tables defined by dotted keys, which can't be defined by headers
+	dotted map[*configTable]bool
+	// inline are the inline tables, which can't be extended
+	inline map[*configTable]bool
+	// arrays are the arrays of tables, the static arrays can't be appended by headers
+	arrays map[tomlArrayKey]bool
+}
+
+// parseToml parses the toml document.
+func parseToml(data []byte) (*configTable, error) {
+	content := strings.TrimPrefix(string(data), "\ufeff")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	root := newConfigTable()
+	p := &tomlParser{
+		src:     content,
+		root:    root,
+		cur:     root,
+		defined: make(map[*configTable]bool),
+		dotted:  make(map[*configTable]bool),
+		inline:  make(map[*configTable]bool),
+		arrays:  make(map[tomlArrayKey]bool),
+	}
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+
+		switch p.src[p.pos] {
+		case '\n':
+			p.pos++
+			continue
+		case '#':
+		case '[':
+			if err := p.parseHeader(); err != nil {
+				return nil, err
+			}
+		default:
+			if err := p.parseKeyValue(p.cur); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := p.expectLineEnd(); err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tomlParser) errorf(format string, args ...any) error {
+	pos := p.pos
+	if pos > len(p.src) {
+		pos = len(p.src)
+	}
+	line := strings.Count(p.src[:pos], "\n") + 1
+
+	return fmt.Errorf("fileutil: toml: line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips the spaces and tabs.
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips the whitespaces, line breaks and comments in arrays and inline tables.
+func (p *tomlParser) skipBlank() error {
+	for !p.eof() {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n':
+			p.pos++
+		case '#':
+			if err := p.skipComment(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (p *tomlParser) skipComment() error {
+	for !p.eof() && p.src[p.pos] != '\n' {
+		if c := p.src[p.pos]; (c < 0x20 && c != '\t') || c == 0x7f {
+			return p.errorf("control character %q in comment", c)
+		}
+		p.pos++
+	}
+
+	return nil
+}
+
+// expectLineEnd checks that only the comment is left in current line.
+func (p *tomlParser) expectLineEnd() error {
+	p.skipSpace()
+	if !p.eof() && p.src[p.pos] == '#' {
+		if err := p.skipComment(); err != nil {
+			return err
+		}
+	}
+	if !p.eof() && p.src[p.pos] != '\n' {
+		return p.errorf("expected the end of line, but got %q", p.rest())
+	}
+
+	return nil
+}
+
+// rest returns the rest of current line for the errors.
+func (p *tomlParser) rest() string {
+	rest := p.src[p.pos:]
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[:i]
+	}
+
+	return rest
+}
+
+// parseHeader parses the [table] or [[array of tables]] header.
+func (p *tomlParser) parseHeader() error {
+	isArray := strings.HasPrefix(p.src[p.pos:], "[[")
+	if isArray {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	closing := "]"
+	if isArray {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(p.src[p.pos:], closing) {
+		return p.errorf("expected %q after the table name, but got %q", closing, p.rest())
+	}
+	p.pos += len(closing)
+
+	table := p.root
+	for _, key := range keys[:len(keys)-1] {
+		if table, err = p.headerTable(table, key); err != nil {
+			return err
+		}
+	}
+	last := keys[len(keys)-1]
+	name := strings.Join(keys, ".")
+
+	if isArray {
+		value, exists := table.get(last)
+		if exists && !p.arrays[tomlArrayKey{table, last}] {
+			return p.errorf("key %q is already defined", name)
+		}
+		items, _ := value.([]any)
+		item := newConfigTable()
+		table.set(last, append(items, item))
+		p.arrays[tomlArrayKey{table, last}] = true
+		p.cur = item
+		return nil
+	}
+
+	value, exists := table.get(last)
+	if !exists {
+		sub := newConfigTable()
+		table.set(last, sub)
+		p.defined[sub] = true
+		p.cur = sub
+		return nil
+	}
+	sub, ok := value.(*configTable)
+	if !ok || p.defined[sub] || p.dotted[sub] || p.inline[sub] {
+		return p.errorf("table %q is already defined", name)
+	}
+	p.defined[sub] = true
+	p.cur = sub
+
+	return nil
+}
+
+// headerTable returns the sub table of the header, the missing table is created.
+func (p *tomlParser) headerTable(table *configTable, key string) (*configTable, error) {
+	value, exists := table.get(key)
+	if !exists {
+		sub := newConfigTable()
+		table.set(key, sub)
+		return sub, nil
+	}
+
+	switch v := value.(type) {
+	case *configTable:
+		if p.inline[v] {
+			return nil, p.errorf("inline table %q can't be extended", key)
+		}
+		return v, nil
+	case []any:
+		if p.arrays[tomlArrayKey{table, key}] {
+			return v[len(v)-1].(*configTable), nil
+		}
+	}
+
+	return nil, p.errorf("key %q is already defined", key)
+}
+
+// parseKey parses the bare, quoted or dotted key.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return nil, p.errorf("unexpected end of key")
+		}
+
+		var (
+			key string
+			err error
+		)
+		switch p.src[p.pos] {
+		case '"':
+			key, err = p.parseBasicString()
+		case '\'':
+			key, err = p.parseLiteralString()
+		default:
+			start := p.pos
+			for !p.eof() && isTomlBareKeyChar(p.src[p.pos]) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("invalid key %q", p.rest())
+			}
+			key = p.src[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		p.skipSpace()
+		if p.eof() || p.src[p.pos] != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isTomlBareKeyChar(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// parseKeyValue parses the key/value pair and sets it into table.
+func (p *tomlParser) parseKeyValue(table *configTable) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if p.eof() || p.src[p.pos] != '=' {
+		return p.errorf("expected '=' after the key, but got %q", p.rest())
+	}
+	p.pos++
+	p.skipSpace()
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys[:len(keys)-1] {
+		v, exists := table.get(key)
+		if !exists {
+			sub := newConfigTable()
+			table.set(key, sub)
+			p.dotted[sub] = true
+			table = sub
+			continue
+		}
+		sub, ok := v.(*configTable)
+		if !ok || !p.dotted[sub] {
+			return p.errorf("key %q is already defined", strings.Join(keys, "."))
+		}
+		table = sub
+	}
+
+	last := keys[len(keys)-1]
+	if _, exists := table.get(last); exists {
+		return p.errorf("key %q is already defined", strings.Join(keys, "."))
+	}
+	table.set(last, value)
+
+	return nil
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	if p.eof() {
+		return nil, p.errorf("unexpected end of value")
+	}
+
+	rest := p.src[p.pos:]
+	switch {
+	case strings.HasPrefix(rest, `"""`):
+		return p.parseMultilineString('"')
+	case strings.HasPrefix(rest, "'''"):
+		return p.parseMultilineString('\'')
+	case rest[0] == '"':
+		return p.parseBasicString()
+	case rest[0] == '\'':
+		return p.parseLiteralString()
+	case rest[0] == '[':
+		return p.parseArray()
+	case rest[0] == '{':
+		return p.parseInlineTable()
+	}
+
+	token := p.scanToken()
+	switch token {
+	case "":
+		return nil, p.errorf("invalid value %q", p.rest())
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	if t, ok := parseTomlDatetime(token); ok {
+		return t, nil
+	}
+
+	return p.parseNumber(token)
+}
+
+// scanToken scans the bare value, eg: number, bool and date-time.
+func (p *tomlParser) scanToken() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.src[p.pos]
+		if c == ' ' && p.pos-start == 10 && p.pos+1 < len(p.src) && isTomlDigit(p.src[p.pos+1]) && isTomlDate(p.src[start:p.pos]) {
+			// the date and time in date-time could be separated by a space
+			p.pos++
+			continue
+		}
+		if !isTomlBareKeyChar(c) && c != '+' && c != '.' && c != ':' {
+			break
+		}
+		p.pos++
+	}
+
+	return p.src[start:p.pos]
+}
+
+func isTomlDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isTomlDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+// parseTomlDatetime parses the offset date-time, local date-time, local date and local time.
+func parseTomlDatetime(s string) (time.Time, bool) {
+	if len(s) > 10 && (s[10] == ' ' || s[10] == 't') {
+		s = s[:10] + "T" + s[11:]
+	}
+	if strings.HasSuffix(s, "z") {
+		s = s[:len(s)-1] + "Z"
+	}
+
+	if t, err := time.Parse("2006-01-02T15:04:05.999999999Z07:00", s); err == nil {
+		return t, true
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05.999999999", "2006-01-02", "15:04:05.999999999"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (p *tomlParser) parseNumber(token string) (any, error) {
+	var base int
+	switch {
+	case tomlIntPattern.MatchString(token):
+		base = 10
+	case tomlHexPattern.MatchString(token):
+		base = 16
+	case tomlOctPattern.MatchString(token):
+		base = 8
+	case tomlBinPattern.MatchString(token):
+		base = 2
+	case tomlFloatPattern.MatchString(token):
+		f, err := strconv.ParseFloat(strings.ReplaceAll(token, "_", ""), 64)
+		if err != nil {
+			return nil, p.errorf("invalid float %q", token)
+		}
+		return f, nil
+	default:
+		return nil, p.errorf("invalid value %q", token)
+	}
+
+	digits := strings.ReplaceAll(token, "_", "")
+	if base != 10 {
+		digits = digits[2:]
+	}
+	n, err := strconv.ParseInt(digits, base, 64)
+	if err != nil {
+		return nil, p.errorf("integer %q is out of range", token)
+	}
+
+	return n, nil
+}
+
+// parseBasicString parses the basic string in one line.
+func (p *tomlParser) parseBasicString() (string, error) {
+	var b strings.Builder
+
+	p.pos++
+	for {
+		if p.eof() || p.src[p.pos] == '\n' {
+			return "", p.errorf("unclosed string")
+		}
+
+		c := p.src[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		case c == '\\':
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		case (c < 0x20 && c != '\t') || c == 0x7f:
+			return "", p.errorf("control character %q in string", c)
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseLiteralString parses the literal string in one line.
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	start := p.pos
+	for !p.eof() && p.src[p.pos] != '\'' {
+		if c := p.src[p.pos]; c == '\n' || (c < 0x20 && c != '\t') || c == 0x7f {
+			return "", p.errorf("unclosed string")
+		}
+		p.pos++
+	}
+	if p.eof() {
+		return "", p.errorf("unclosed string")
+	}
+	p.pos++
+
+	return p.src[start : p.pos-1], nil
+}
+
+// parseMultilineString parses the multi-line basic or literal string, the line break after the opening quotes
+// is trimmed.
+func (p *tomlParser) parseMultilineString(quote byte) (string, error) {
+	var b strings.Builder
+
+	p.pos += 3
+	if !p.eof() && p.src[p.pos] == '\n' {
+		p.pos++
+	}
+
+	for {
+		if p.eof() {
+			return "", p.errorf("unclosed multi-line string")
+		}
+
+		c := p.src[p.pos]
+		switch {
+		case c == quote && strings.HasPrefix(p.src[p.pos:], strings.Repeat(string(quote), 3)):
+			// at most 2 quotes are allowed right before the closing quotes
+			n := 3
+			for n < 5 && p.pos+n < len(p.src) && p.src[p.pos+n] == quote {
+				n++
+			}
+			b.WriteString(p.src[p.pos : p.pos+n-3])
+			p.pos += n
+			return b.String(), nil
+		case c == '\\' && quote == '"':
+			// the line ending backslash trims the whitespaces and line breaks after it
+			i := p.pos + 1
+			for i < len(p.src) && (p.src[i] == ' ' || p.src[i] == '\t') {
+				i++
+			}
+			if i < len(p.src) && p.src[i] == '\n' {
+				for i < len(p.src) && strings.IndexByte(" \t\n", p.src[i]) >= 0 {
+					i++
+				}
+				p.pos = i
+				continue
+			}
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		case (c < 0x20 && c != '\t' && c != '\n') || c == 0x7f:
+			return "", p.errorf("control character %q in string", c)
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseEscape parses the escape sequence at current position.
+func (p *tomlParser) parseEscape(b *strings.Builder) error {
+	if p.pos+1 >= len(p.src) {
+		return p.errorf("invalid escape at the end of string")
+	}
+
+	c := p.src[p.pos+1]
+	simple := map[byte]byte{'b': '\b', 't': '\t', 'n': '\n', 'f': '\f', 'r': '\r', 'e': 0x1b, '"': '"', '\\': '\\'}
+	if r, ok := simple[c]; ok {
+		b.WriteByte(r)
+		p.pos += 2
+		return nil
+	}
+
+	size := map[byte]int{'u': 4, 'U': 8}[c]
+	if size == 0 || p.pos+2+size > len(p.src) {
+		return p.errorf("invalid escape \\%c", c)
+	}
+	code, err := strconv.ParseUint(p.src[p.pos+2:p.pos+2+size], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return p.errorf("invalid escape \\%s", p.src[p.pos+1:p.pos+2+size])
+	}
+	b.WriteRune(rune(code))
+	p.pos += 2 + size
+
+	return nil
+}
+
+// parseArray parses the array, which could span multiple lines and contain comments.
+func (p *tomlParser) parseArray() (any, error) {
+	items := []any{}
+
+	p.pos++
+	for {
+		if err := p.skipBlank(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf("unclosed array")
+		}
+		if p.src[p.pos] == ']' {
+			p.pos++
+			return items, nil
+		}
+
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		if err := p.skipBlank(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf("unclosed array")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("expected ',' or ']' in array, but got %q", p.rest())
+		}
+	}
+}
+
+// parseInlineTable parses the inline table, eg: { x = 1, y = 2 }.
+func (p *tomlParser) parseInlineTable() (any, error) {
+	table := newConfigTable()
+
+	p.pos++
+	for {
+		if err := p.skipBlank(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf("unclosed inline table")
+		}
+		if p.src[p.pos] == '}' {
+			p.pos++
+			p.inline[table] = true
+			return table, nil
+		}
+
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+
+		if err := p.skipBlank(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf("unclosed inline table")
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table, but got %q", p.rest())
+		}
+	}
+}
+
+// writeTomlTable writes the table at path, the key/values are written before the sub tables and arrays of tables.
+func writeTomlTable(buf *bytes.Buffer, table *configTable, path []string) error {
+	for _, key := range table.keys {
+		value := table.values[key]
+		if value == nil || isTomlTable(value) {
+			continue
+		}
+		text, err := formatTomlValue(value)
+		if err != nil {
+			return fmt.Errorf("fileutil: toml: %s: %w", strings.Join(append(path[:len(path):len(path)], key), "."), err)
+		}
+		buf.WriteString(formatTomlKey(key))
+		buf.WriteString(" = ")
+		buf.WriteString(text)
+		buf.WriteByte('\n')
+	}
+
+	for _, key := range table.keys {
+		value := table.values[key]
+		if !isTomlTable(value) {
+			continue
+		}
+
+		sub := append(path[:len(path):len(path)], key)
+		names := make([]string, len(sub))
+		for i, k := range sub {
+			names[i] = formatTomlKey(k)
+		}
+		name := strings.Join(names, ".")
+
+		var tables []*configTable
+		header := "[" + name + "]\n"
+		if t, ok := value.(*configTable); ok {
+			tables = []*configTable{t}
+		} else {
+			for _, item := range value.([]any) {
+				tables = append(tables, item.(*configTable))
+			}
+			header = "[[" + name + "]]\n"
+		}
+
+		for _, t := range tables {
+			if buf.Len() > 0 {
+				buf.WriteByte('\n')
+			}
+			buf.WriteString(header)
+			if err := writeTomlTable(buf, t, sub); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isTomlTable checks if the value is written as [table] or [[array of tables]].
+func isTomlTable(value any) bool {
+	switch v := value.(type) {
+	case *configTable:
+		return true
+	case []any:
+		if len(v) == 0 {
+			return false
+		}
+		for _, item := range v {
+			if _, ok := item.(*configTable); !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// formatTomlValue formats the value in one line, the tables are written as inline tables.
+func formatTomlValue(value any) (string, error) {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return "", fmt.Errorf("integer %d is out of range", v)
+		}
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "nan", nil
+		case math.IsInf(v, 1):
+			return "inf", nil
+		case math.IsInf(v, -1):
+			return "-inf", nil
+		}
+		return formatConfigFloat(v), nil
+	case string:
+		return formatTomlString(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			if item == nil {
+				return "", fmt.Errorf("null in array is not supported")
+			}
+			text, err := formatTomlValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = text
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case *configTable:
+		var items []string
+		for _, key := range v.keys {
+			if v.values[key] == nil {
+				continue
+			}
+			text, err := formatTomlValue(v.values[key])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formatTomlKey(key)+" = "+text)
+		}
+		if len(items) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	}
+
+	return "", fmt.Errorf("unsupported value %v", value)
+}
+
+func formatTomlKey(key string) string {
+	if tomlBareKeyPattern.MatchString(key) {
+		return key
+	}
+
+	return formatTomlString(key)
+}
+
+// formatTomlString formats s as basic string.
+func formatTomlString(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,1069 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package fileutil
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// yamlScalar is a scalar of yaml. The plain scalars are resolved by the target type, eg: 8080 is decoded as
+// "8080" into a string, and the quoted and block scalars are always strings.
+type yamlScalar struct {
+	value string
+	plain bool
+}
+
+var (
+	yamlIntPattern   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlOctPattern   = regexp.MustCompile(`^0o[0-7]+$`)
+	yamlHexPattern   = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+	yamlFloatPattern = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// resolveYamlScalar resolves the plain scalar by the core schema of yaml 1.2, it returns nil, bool, int, int64,
+// uint64, float64 or the string itself.
+func resolveYamlScalar(s string) any {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+
+	digits, base := s, 10
+	switch {
+	case yamlOctPattern.MatchString(s):
+		digits, base = s[2:], 8
+	case yamlHexPattern.MatchString(s):
+		digits, base = s[2:], 16
+	case !yamlIntPattern.MatchString(s):
+		if yamlFloatPattern.MatchString(s) {
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return f
+			}
+		}
+		return s
+	}
+
+	if n, err := strconv.ParseInt(digits, base, 64); err == nil {
+		if int64(int(n)) == n {
+			return int(n)
+		}
+		return n
+	}
+	if n, err := strconv.ParseUint(strings.TrimPrefix(digits, "+"), base, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && base == 10 {
+		return f
+	}
+
+	return s
+}
+
+// yamlLine is a line of yaml document.
+type yamlLine struct {
+	// num is the line number starting from 1
+	num    int
+	indent int
+	// text is the line without indentation, the comments aren't stripped
+	text string
+}
+
+// blank checks if the line is empty or a comment line.
+func (l yamlLine) blank() bool {
+	text := strings.TrimLeft(l.text, "\t")
+	return text == "" || text[0] == '#'
+}
+
+// parseYaml parses the yaml documents into *configTable, []any, yamlScalar and nil.
+func parseYaml(data []byte) ([]any, error) {
+	content := strings.TrimPrefix(string(data), "\ufeff")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	var (
+		docs     []any
+		lines    []yamlLine
+		explicit bool
+		started  bool
+	)
+
+	finish := func() error {
+		p := &yamlParser{lines: lines}
+		p.skipBlank()
+		if p.eof() {
+			if explicit {
+				docs = append(docs, nil)
+			}
+		} else {
+			doc, err := p.parseBlock(-1)
+			if err != nil {
+				return err
+			}
+			p.skipBlank()
+			if !p.eof() {
+				return p.errorf("unexpected content %q", p.cur().text)
+			}
+			docs = append(docs, doc)
+		}
+		lines, explicit, started = nil, false, false
+		return nil
+	}
+
+	for i, raw := range strings.Split(content, "\n") {
+		num := i + 1
+
+		switch {
+		case raw == "---" || strings.HasPrefix(raw, "--- ") || strings.HasPrefix(raw, "---\t"):
+			if started || explicit {
+				if err := finish(); err != nil {
+					return nil, err
+				}
+			}
+			explicit, started = true, true
+			if rest := strings.TrimSpace(raw[3:]); rest != "" && rest[0] != '#' {
+				lines = append(lines, yamlLine{num: num, indent: 0, text: rest})
+			}
+			continue
+		case raw == "..." || strings.HasPrefix(raw, "... "):
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			continue
+		case strings.HasPrefix(raw, "%") && !started:
+			// directives, eg: %YAML 1.2
+			continue
+		}
+
+		text := strings.TrimLeft(raw, " ")
+		line := yamlLine{num: num, indent: len(raw) - len(text), text: strings.TrimRight(text, " \t")}
+		if !line.blank() {
+			started = true
+		}
+		lines = append(lines, line)
+	}
+
+	if started || explicit {
+		if err := finish(); err != nil {
+			return nil, err
+		}
+	}
+
+	return docs, nil
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) eof() bool {
+	return p.pos >= len(p.lines)
+}
+
+func (p *yamlParser) cur() yamlLine {
+	return p.lines[p.pos]
+}
+
+// errorf returns the error at current line.
+func (p *yamlParser) errorf(format string, args ...any) error {
+	num := 0
+	if p.pos < len(p.lines) {
+		num = p.lines[p.pos].num
+	} else if len(p.lines) > 0 {
+		num = p.lines[len(p.lines)-1].num
+	}
+
+	return p.errorAt(num, format, args...)
+}
+
+// errorAt returns the error at line num.
+func (p *yamlParser) errorAt(num int, format string, args ...any) error {
+	return fmt.Errorf("fileutil: yaml: line %d: %s", num, fmt.Sprintf(format, args...))
+}
+
+// valueLine returns the line number of the value being parsed, which is the line before current one.
+func (p *yamlParser) valueLine() int {
+	return p.lines[p.pos-1].num
+}
+
+// skipBlank skips the blank lines and comment lines.
+func (p *yamlParser) skipBlank() {
+	for !p.eof() && p.cur().blank() {
+		p.pos++
+	}
+}
+
+// parseBlock parses the node at current line, which is indented more than parent.
+func (p *yamlParser) parseBlock(parent int) (any, error) {
+	line := p.cur()
+	if line.text[0] == '\t' {
+		return nil, p.errorf("tabs are not allowed for indentation")
+	}
+
+	if isYamlSeqItem(line.text) {
+		return p.parseSeq(line.indent)
+	}
+
+	if _, _, ok, err := splitYamlKey(line.text); err != nil {
+		return nil, p.errorf("%v", err)
+	} else if ok {
+		return p.parseMap(line.indent)
+	}
+
+	p.pos++
+	return p.parseValue(line.text, parent)
+}
+
+// parseNested parses the node in the following lines, it's null if they are not indented more than parent.
+func (p *yamlParser) parseNested(parent int) (any, error) {
+	p.skipBlank()
+	if p.eof() || p.cur().indent <= parent {
+		return nil, nil
+	}
+
+	return p.parseBlock(parent)
+}
+
+func (p *yamlParser) parseSeq(indent int) (any, error) {
+	items := []any{}
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			break
+		}
+		line := p.cur()
+		if line.indent < indent || (line.indent == indent && !isYamlSeqItem(line.text)) {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf("bad indentation of sequence item")
+		}
+
+		rest := strings.TrimLeft(line.text[1:], " ")
+		var (
+			item any
+			err  error
+		)
+		if rest == "" || rest[0] == '#' {
+			p.pos++
+			item, err = p.parseNested(indent)
+		} else {
+			// parse the content after "- " as a node at its column, eg: the compact mapping "- key: value"
+			p.lines[p.pos] = yamlLine{num: line.num, indent: indent + len(line.text) - len(rest), text: rest}
+			item, err = p.parseBlock(indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (p *yamlParser) parseMap(indent int) (any, error) {
+	table := newConfigTable()
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			break
+		}
+		line := p.cur()
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf("bad indentation of mapping entry")
+		}
+
+		key, rest, ok, err := splitYamlKey(line.text)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		if !ok {
+			return nil, p.errorf("expected a mapping key, but got %q", line.text)
+		}
+		if _, exists := table.get(key); exists {
+			return nil, p.errorf("duplicated key %q", key)
+		}
+		p.pos++
+
+		var value any
+		if rest == "" || rest[0] == '#' {
+			p.skipBlank()
+			if !p.eof() && p.cur().indent == indent && isYamlSeqItem(p.cur().text) {
+				// the sequence could be at the same indentation as its key
+				value, err = p.parseSeq(indent)
+			} else {
+				value, err = p.parseNested(indent)
+			}
+		} else {
+			value, err = p.parseValue(rest, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		table.set(key, value)
+	}
+
+	return table, nil
+}
+
+// parseValue parses the value in text, and the following lines indented more than parent if it spans lines.
+func (p *yamlParser) parseValue(text string, parent int) (any, error) {
+	num := p.valueLine()
+
+	switch text[0] {
+	case '|', '>':
+		return p.parseBlockScalar(text, parent)
+	case '&', '*', '!':
+		return nil, p.errorAt(num, "anchors, aliases and tags are not supported")
+	case '"', '\'':
+		return p.parseQuoted(text, parent)
+	case '[', '{':
+		return p.parseFlow(text, parent)
+	}
+
+	value := strings.TrimRight(stripYamlComment(text), " \t")
+
+	// the multi-line plain scalar, the line breaks are folded into spaces
+	var b strings.Builder
+	b.WriteString(value)
+	breaks := 0
+	for i := p.pos; i < len(p.lines); i++ {
+		line := p.lines[i]
+		if line.text == "" {
+			breaks++
+			continue
+		}
+		if line.text[0] == '#' || line.indent <= parent {
+			break
+		}
+		if breaks == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteString(strings.Repeat("\n", breaks))
+		}
+		breaks = 0
+		b.WriteString(strings.TrimRight(stripYamlComment(line.text), " \t"))
+		p.pos = i + 1
+	}
+
+	value = b.String()
+	if strings.Contains(value, ": ") || strings.HasSuffix(value, ":") {
+		return nil, p.errorAt(num, "mapping values are not allowed in plain scalar %q", value)
+	}
+
+	return yamlScalar{value: value, plain: true}, nil
+}
+
+func (p *yamlParser) parseQuoted(text string, parent int) (any, error) {
+	num := p.valueLine()
+	quote := text[0]
+	src := text
+	for {
+		end := scanYamlQuoted(src, quote)
+		if end >= 0 {
+			if rest := strings.TrimSpace(src[end+1:]); rest != "" && rest[0] != '#' {
+				return nil, p.errorAt(num, "unexpected content %q after quoted scalar", rest)
+			}
+			value, err := unquoteYaml(src[:end+1])
+			if err != nil {
+				return nil, p.errorAt(num, "%v", err)
+			}
+			return yamlScalar{value: value}, nil
+		}
+
+		if p.eof() {
+			return nil, p.errorf("unclosed quoted scalar")
+		}
+		line := p.cur()
+		if line.text != "" && line.indent <= parent {
+			return nil, p.errorf("unclosed quoted scalar")
+		}
+		src += "\n" + line.text
+		p.pos++
+	}
+}
+
+func (p *yamlParser) parseFlow(text string, parent int) (any, error) {
+	num := p.valueLine()
+	src := stripYamlComment(text)
+	for !yamlFlowClosed(src) {
+		if p.eof() {
+			return nil, p.errorf("unclosed flow collection")
+		}
+		line := p.cur()
+		if line.text != "" && line.indent <= parent && line.text[0] != ']' && line.text[0] != '}' {
+			return nil, p.errorf("unclosed flow collection")
+		}
+		src += "\n" + stripYamlComment(line.text)
+		p.pos++
+	}
+
+	f := &yamlFlowParser{src: src}
+	value, err := f.parseValue()
+	if err != nil {
+		return nil, p.errorAt(num, "%v", err)
+	}
+	f.skipSpace()
+	if f.pos < len(f.src) {
+		return nil, p.errorAt(num, "unexpected content %q after flow collection", f.src[f.pos:])
+	}
+
+	return value, nil
+}
+
+func (p *yamlParser) parseBlockScalar(header string, parent int) (any, error) {
+	literal := header[0] == '|'
+	chomp := byte(0)
+	explicitIndent := 0
+
+	rest := header[1:]
+	for len(rest) > 0 && rest[0] != ' ' && rest[0] != '\t' && rest[0] != '#' {
+		switch c := rest[0]; {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = c
+		case c >= '1' && c <= '9' && explicitIndent == 0:
+			explicitIndent = int(c - '0')
+		default:
+			return nil, p.errorAt(p.valueLine(), "invalid block scalar header %q", header)
+		}
+		rest = rest[1:]
+	}
+	if rest = strings.TrimSpace(rest); rest != "" && rest[0] != '#' {
+		return nil, p.errorAt(p.valueLine(), "unexpected content %q after block scalar header", rest)
+	}
+
+	indent := -1
+	if explicitIndent > 0 {
+		indent = parent + explicitIndent
+		if parent < 0 {
+			indent = explicitIndent
+		}
+	}
+
+	var lines []string
+	for !p.eof() {
+		line := p.cur()
+		if line.text == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+		if indent < 0 {
+			if line.indent <= parent {
+				break
+			}
+			indent = line.indent
+		}
+		if line.indent < indent {
+			break
+		}
+		lines = append(lines, strings.Repeat(" ", line.indent-indent)+line.text)
+		p.pos++
+	}
+
+	// the trailing blank lines are kept by chomping
+	n := len(lines)
+	for n > 0 && lines[n-1] == "" {
+		n--
+	}
+	trailing := len(lines) - n
+	lines = lines[:n]
+
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			prev := lines[i-1]
+			switch {
+			case literal:
+				b.WriteByte('\n')
+			case line == "":
+				if prev == "" {
+					b.WriteByte('\n')
+				}
+			case prev == "":
+				b.WriteByte('\n')
+			case line[0] == ' ' || prev[0] == ' ':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(line)
+	}
+
+	value := b.String()
+	switch {
+	case n == 0 && chomp != '+':
+		value = ""
+	case chomp == '-':
+	case chomp == '+':
+		value += "\n" + strings.Repeat("\n", trailing)
+		if n == 0 {
+			value = strings.Repeat("\n", trailing)
+		}
+	default:
+		value += "\n"
+	}
+
+	return yamlScalar{value: value}, nil
+}
+
+func isYamlSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ") || strings.HasPrefix(text, "-\t")
+}
+
+// splitYamlKey splits the mapping entry "key: value", ok is false if text is not a mapping entry.
+func splitYamlKey(text string) (key, rest string, ok bool, err error) {
+	switch text[0] {
+	case '[', '{', '|', '>', '#', '&', '*', '!':
+		return "", "", false, nil
+	case '?':
+		if len(text) == 1 || text[1] == ' ' {
+			return "", "", false, fmt.Errorf("complex mapping keys are not supported")
+		}
+	case '"', '\'':
+		end := scanYamlQuoted(text, text[0])
+		if end < 0 {
+			return "", "", false, nil
+		}
+		after := strings.TrimLeft(text[end+1:], " ")
+		if after == "" || after[0] != ':' || (len(after) > 1 && after[1] != ' ' && after[1] != '\t') {
+			return "", "", false, nil
+		}
+		key, err := unquoteYaml(text[:end+1])
+		if err != nil {
+			return "", "", false, err
+		}
+		return key, strings.TrimLeft(after[1:], " \t"), true, nil
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '#':
+			if i > 0 && (text[i-1] == ' ' || text[i-1] == '\t') {
+				return "", "", false, nil
+			}
+		case ':':
+			if i == len(text)-1 || text[i+1] == ' ' || text[i+1] == '\t' {
+				return strings.TrimRight(text[:i], " \t"), strings.TrimLeft(text[i+1:], " \t"), true, nil
+			}
+		}
+	}
+
+	return "", "", false, nil
+}
+
+// stripYamlComment removes the comment from text, the '#' in quoted scalars is not a comment.
+func stripYamlComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				if i+1 < len(text) && text[i+1] == '\'' {
+					i++
+				} else {
+					quote = 0
+				}
+			}
+		case quote == '"':
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				quote = 0
+			}
+		case c == '#':
+			if i == 0 || text[i-1] == ' ' || text[i-1] == '\t' {
+				return text[:i]
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.IndexByte(" \t,[{:", text[i-1]) >= 0 {
+				quote = c
+			}
+		}
+	}
+
+	return text
+}
+
+// scanYamlQuoted returns the index of closing quote of the quoted scalar at the start of src, or -1 if not closed.
+func scanYamlQuoted(src string, quote byte) int {
+	for i := 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			if quote == '\'' && i+1 < len(src) && src[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+
+	return -1
+}
+
+// unquoteYaml unquotes the single or double quoted scalar, the line breaks in it are folded.
+func unquoteYaml(src string) (string, error) {
+	quote := src[0]
+	body := src[1 : len(src)-1]
+
+	var b strings.Builder
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\n':
+			// fold the line break into a space, and keep the line breaks of blank lines
+			s := strings.TrimRight(b.String(), " \t")
+			b.Reset()
+			b.WriteString(s)
+			breaks := 0
+			for i < len(body) && (body[i] == '\n' || body[i] == ' ' || body[i] == '\t') {
+				if body[i] == '\n' {
+					breaks++
+				}
+				i++
+			}
+			i--
+			if breaks == 1 {
+				b.WriteByte(' ')
+			} else {
+				b.WriteString(strings.Repeat("\n", breaks-1))
+			}
+		case quote == '\'' && c == '\'':
+			b.WriteByte('\'')
+			i++
+		case quote == '"' && c == '\\':
+			i++
+			if i >= len(body) {
+				return "", fmt.Errorf("invalid escape at the end of %s", src)
+			}
+			n, err := writeYamlEscape(&b, body[i:])
+			if err != nil {
+				return "", err
+			}
+			i += n - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// writeYamlEscape writes the escape sequence at the start of s (after '\'), and returns its length.
+func writeYamlEscape(b *strings.Builder, s string) (int, error) {
+	simple := map[byte]string{
+		'0': "\x00", 'a': "\a", 'b': "\b", 't': "\t", '\t': "\t", 'n': "\n", 'v': "\v", 'f': "\f", 'r': "\r",
+		'e': "\x1b", ' ': " ", '"': "\"", '/': "/", '\\': "\\", 'N': "\u0085", '_': "\u00a0", 'L': "\u2028",
+		'P': "\u2029",
+	}
+	if r, ok := simple[s[0]]; ok {
+		b.WriteString(r)
+		return 1, nil
+	}
+
+	if s[0] == '\n' {
+		// the escaped line break is removed with the leading spaces of next line
+		n := 1
+		for n < len(s) && (s[n] == ' ' || s[n] == '\t') {
+			n++
+		}
+		return n, nil
+	}
+
+	size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[s[0]]
+	if size == 0 || len(s) < size+1 {
+		return 0, fmt.Errorf("invalid escape \\%c", s[0])
+	}
+	code, err := strconv.ParseUint(s[1:size+1], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, fmt.Errorf("invalid escape \\%s", s[:size+1])
+	}
+	b.WriteRune(rune(code))
+
+	return size + 1, nil
+}
+
+// yamlFlowClosed checks if the brackets of flow collection in src are closed.
+func yamlFlowClosed(src string) bool {
+	depth := 0
+	for i := 0; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+			if depth == 0 {
+				return true
+			}
+		case '"', '\'':
+			if i == 0 || strings.IndexByte(" \t\n,[{:", src[i-1]) >= 0 {
+				end := scanYamlQuoted(src[i:], c)
+				if end < 0 {
+					return false
+				}
+				i += end
+			}
+		}
+	}
+
+	return depth <= 0
+}
+
+// yamlFlowParser parses the flow collections, eg: [a, b] and {a: 1}.
+type yamlFlowParser struct {
+	src string
+	pos int
+}
+
+func (f *yamlFlowParser) skipSpace() {
+	for f.pos < len(f.src) && strings.IndexByte(" \t\n", f.src[f.pos]) >= 0 {
+		f.pos++
+	}
+}
+
+func (f *yamlFlowParser) parseValue() (any, error) {
+	f.skipSpace()
+	if f.pos >= len(f.src) {
+		return nil, fmt.Errorf("unexpected end of flow collection")
+	}
+
+	switch c := f.src[f.pos]; c {
+	case '[':
+		return f.parseSeq()
+	case '{':
+		return f.parseMap()
+	case '"', '\'':
+		end := scanYamlQuoted(f.src[f.pos:], c)
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed quoted scalar")
+		}
+		value, err := unquoteYaml(f.src[f.pos : f.pos+end+1])
+		if err != nil {
+			return nil, err
+		}
+		f.pos += end + 1
+		return yamlScalar{value: value}, nil
+	case '&', '*', '!':
+		return nil, fmt.Errorf("anchors, aliases and tags are not supported")
+	}
+
+	start := f.pos
+	for f.pos < len(f.src) {
+		c := f.src[f.pos]
+		if strings.IndexByte(",[]{}", c) >= 0 {
+			break
+		}
+		if c == ':' && (f.pos+1 == len(f.src) || strings.IndexByte(" \t\n,[]{}", f.src[f.pos+1]) >= 0) {
+			break
+		}
+		f.pos++
+	}
+
+	value := strings.Join(strings.Fields(f.src[start:f.pos]), " ")
+
+	return yamlScalar{value: value, plain: true}, nil
+}
+
+func (f *yamlFlowParser) parseSeq() (any, error) {
+	f.pos++
+	items := []any{}
+
+	for {
+		f.skipSpace()
+		if f.pos < len(f.src) && f.src[f.pos] == ']' {
+			f.pos++
+			return items, nil
+		}
+
+		item, err := f.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		f.skipSpace()
+		if f.pos >= len(f.src) {
+			return nil, fmt.Errorf("unclosed flow sequence")
+		}
+		switch f.src[f.pos] {
+		case ',':
+			f.pos++
+		case ']':
+		default:
+			return nil, fmt.Errorf("expected ',' or ']' in flow sequence, but got %q", f.src[f.pos:])
+		}
+	}
+}
+
+func (f *yamlFlowParser) parseMap() (any, error) {
+	f.pos++
+	table := newConfigTable()
+
+	for {
+		f.skipSpace()
+		if f.pos < len(f.src) && f.src[f.pos] == '}' {
+			f.pos++
+			return table, nil
+		}
+
+		keyNode, err := f.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		k, ok := keyNode.(yamlScalar)
+		if !ok {
+			return nil, fmt.Errorf("complex mapping keys are not supported")
+		}
+		if _, exists := table.get(k.value); exists {
+			return nil, fmt.Errorf("duplicated key %q", k.value)
+		}
+
+		var value any
+		f.skipSpace()
+		if f.pos < len(f.src) && f.src[f.pos] == ':' {
+			f.pos++
+			f.skipSpace()
+			if f.pos < len(f.src) && f.src[f.pos] != ',' && f.src[f.pos] != '}' {
+				if value, err = f.parseValue(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		table.set(k.value, value)
+
+		f.skipSpace()
+		if f.pos >= len(f.src) {
+			return nil, fmt.Errorf("unclosed flow mapping")
+		}
+		switch f.src[f.pos] {
+		case ',':
+			f.pos++
+		case '}':
+		default:
+			return nil, fmt.Errorf("expected ',' or '}' in flow mapping, but got %q", f.src[f.pos:])
+		}
+	}
+}
+
+// writeYamlDocument writes the encoded value as a yaml document in block style with 2 spaces indentation.
+func writeYamlDocument(buf *bytes.Buffer, node any) {
+	switch v := node.(type) {
+	case *configTable:
+		if len(v.keys) == 0 {
+			buf.WriteString("{}\n")
+			return
+		}
+		writeYamlTable(buf, v, 0)
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString("[]\n")
+			return
+		}
+		writeYamlSeq(buf, v, 0)
+	default:
+		buf.WriteString(formatYamlScalar(v))
+		buf.WriteByte('\n')
+	}
+}
+
+func writeYamlTable(buf *bytes.Buffer, table *configTable, indent int) {
+	for _, key := range table.keys {
+		buf.WriteString(strings.Repeat(" ", indent))
+		buf.WriteString(formatYamlString(key))
+		buf.WriteByte(':')
+		writeYamlValue(buf, table.values[key], indent)
+	}
+}
+
+func writeYamlSeq(buf *bytes.Buffer, items []any, indent int) {
+	for _, item := range items {
+		buf.WriteString(strings.Repeat(" ", indent))
+		buf.WriteByte('-')
+
+		// the nested collection starts at the line of "- "
+		var nested bytes.Buffer
+		switch v := item.(type) {
+		case *configTable:
+			if len(v.keys) > 0 {
+				writeYamlTable(&nested, v, indent+2)
+			}
+		case []any:
+			if len(v) > 0 {
+				writeYamlSeq(&nested, v, indent+2)
+			}
+		}
+		if nested.Len() > 0 {
+			buf.WriteByte(' ')
+			buf.Write(nested.Bytes()[indent+2:])
+			continue
+		}
+
+		writeYamlValue(buf, item, indent)
+	}
+}
+
+// writeYamlValue writes the value after "key:" or "-", the nested collections are in the following lines.
+func writeYamlValue(buf *bytes.Buffer, value any, indent int) {
+	switch v := value.(type) {
+	case *configTable:
+		if len(v.keys) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+		buf.WriteByte('\n')
+		writeYamlTable(buf, v, indent+2)
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+		buf.WriteByte('\n')
+		writeYamlSeq(buf, v, indent+2)
+	case string:
+		if isYamlLiteral(v) {
+			writeYamlLiteral(buf, v, indent+2)
+			return
+		}
+		buf.WriteByte(' ')
+		buf.WriteString(formatYamlString(v))
+		buf.WriteByte('\n')
+	default:
+		buf.WriteByte(' ')
+		buf.WriteString(formatYamlScalar(v))
+		buf.WriteByte('\n')
+	}
+}
+
+// isYamlLiteral checks if the multi-line string could be written as literal block scalar and read back as it is.
+func isYamlLiteral(s string) bool {
+	body := strings.TrimRight(s, "\n")
+	if !strings.Contains(body, "\n") || body[0] == ' ' {
+		return false
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if line != strings.TrimRight(line, " ") {
+			return false
+		}
+		for _, r := range line {
+			if !unicode.IsPrint(r) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// writeYamlLiteral writes s as literal block scalar, the chomping indicator keeps its trailing line breaks.
+func writeYamlLiteral(buf *bytes.Buffer, s string, indent int) {
+	body := strings.TrimRight(s, "\n")
+	trailing := len(s) - len(body)
+	switch trailing {
+	case 0:
+		buf.WriteString(" |-\n")
+	case 1:
+		buf.WriteString(" |\n")
+	default:
+		buf.WriteString(" |+\n")
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		if line != "" {
+			buf.WriteString(strings.Repeat(" ", indent))
+			buf.WriteString(line)
+		}
+		buf.WriteByte('\n')
+	}
+	if trailing > 1 {
+		buf.WriteString(strings.Repeat("\n", trailing-1))
+	}
+}
+
+func formatYamlScalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		switch {
+		case math.IsInf(v, 1):
+			return ".inf"
+		case math.IsInf(v, -1):
+			return "-.inf"
+		case math.IsNaN(v):
+			return ".nan"
+		}
+		return formatConfigFloat(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case string:
+		return formatYamlString(v)
+	}
+
+	panic(fmt.Sprintf("programming error: unexpected yaml value %T", value))
+}
+
+// formatYamlString returns s as plain scalar if it's read back as the same string, or double quoted scalar.
+func formatYamlString(s string) string {
+	if isYamlPlain(s) {
+		return s
+	}
+
+	return strconv.Quote(s)
+}
+
+func isYamlPlain(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) || strings.HasPrefix(s, "...") {
+		return false
+	}
+	if _, ok := resolveYamlScalar(s).(string); !ok {
+		return false
+	}
+	if strings.IndexByte("-?:,[]{}#&*!|>'\"%@`", s[0]) >= 0 {
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return true
+}
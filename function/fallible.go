@@ -0,0 +1,98 @@
+package function
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// ErrTimeout is returned by the function wrapped by WithTimeout, if it doesn't return in time.
+var ErrTimeout = errors.New("function: timeout")
+
+// AndThen returns a function which calls fn, then passes its result to next if fn succeeds.
+// The error of fn is returned without calling next.
+func AndThen[T any, R any](fn func() (T, error), next func(T) (R, error)) func() (R, error) {
+	return func() (R, error) {
+		value, err := fn()
+		if err != nil {
+			var zero R
+			return zero, err
+		}
+
+		return next(value)
+	}
+}
+
+// Fallback returns a function which calls primary, and calls secondary if primary fails.
+// If both fail, the errors of them are joined.
+func Fallback[T any](primary, secondary func() (T, error)) func() (T, error) {
+	return FirstSuccessful(primary, secondary)
+}
+
+// FirstSuccessful returns a function which calls fns in order, and returns the first result without error.
+// If all of them fail, the errors of them are joined, and the zero value is returned.
+func FirstSuccessful[T any](fns ...func() (T, error)) func() (T, error) {
+	return func() (T, error) {
+		errs := make([]error, 0, len(fns))
+
+		for _, fn := range fns {
+			value, err := fn()
+			if err == nil {
+				return value, nil
+			}
+			errs = append(errs, err)
+		}
+
+		var zero T
+		if len(errs) == 0 {
+			return zero, errors.New("function: no function to call")
+		}
+
+		return zero, internal.JoinError(errs...)
+	}
+}
+
+// timeoutResult is the result of fn called by WithTimeout.
+type timeoutResult[T any] struct {
+	value T
+	err   error
+}
+
+// WithTimeout returns a function which runs fn in a new goroutine, and returns ErrTimeout if fn doesn't return
+// in d. The timed out fn is abandoned rather than stopped, so it should not hold resources forever.
+// The panic of fn is returned as error.
+func WithTimeout[T any](fn func() (T, error), d time.Duration) func() (T, error) {
+	if d <= 0 {
+		panic("programming error: timeout must be greater than 0")
+	}
+
+	return func() (T, error) {
+		// buffered, so the abandoned goroutine could exit
+		done := make(chan timeoutResult[T], 1)
+
+		go func() {
+			var r timeoutResult[T]
+			defer func() {
+				if p := recover(); p != nil {
+					r.err = fmt.Errorf("function: fn panicked: %v", p)
+				}
+				done <- r
+			}()
+
+			r.value, r.err = fn()
+		}()
+
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case r := <-done:
+			return r.value, r.err
+		case <-timer.C:
+			var zero T
+			return zero, ErrTimeout
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package function
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func ExampleAndThen() {
+	read := func() (string, error) {
+		return "42", nil
+	}
+
+	result, err := AndThen(read, strconv.Atoi)()
+
+	fmt.Println(result, err)
+
+	// Output:
+	// 42 <nil>
+}
+
+func ExampleFallback() {
+	fromRemote := func() (string, error) {
+		return "", errors.New("connection refused")
+	}
+	fromCache := func() (string, error) {
+		return "cached", nil
+	}
+
+	result, err := Fallback(fromRemote, fromCache)()
+
+	fmt.Println(result, err)
+
+	// Output:
+	// cached <nil>
+}
+
+func ExampleFirstSuccessful() {
+	fromEnv := func() (int, error) {
+		return 0, errors.New("not set in env")
+	}
+	fromFile := func() (int, error) {
+		return 0, errors.New("not set in file")
+	}
+
+	_, err := FirstSuccessful(fromEnv, fromFile)()
+
+	fmt.Println(err)
+
+	// Output:
+	// not set in env
+	// not set in file
+}
+
+func ExampleWithTimeout() {
+	slow := func() (string, error) {
+		time.Sleep(time.Second)
+		return "done", nil
+	}
+
+	_, err := WithTimeout(slow, 10*time.Millisecond)()
+
+	fmt.Println(err)
+
+	// Output:
+	// function: timeout
+}
@@ -0,0 +1,135 @@
+package function
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestAndThen(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAndThen")
+
+	parse := AndThen(func() (string, error) {
+		return "42", nil
+	}, strconv.Atoi)
+
+	n, err := parse()
+	assert.IsNil(err)
+	assert.Equal(42, n)
+
+	errRead := errors.New("read failed")
+	called := false
+	failed := AndThen(func() (string, error) {
+		return "", errRead
+	}, func(s string) (int, error) {
+		called = true
+		return 0, nil
+	})
+
+	n, err = failed()
+	assert.Equal(errRead, err)
+	assert.Equal(0, n)
+	assert.Equal(false, called)
+}
+
+func TestFallback(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFallback")
+
+	errPrimary, errSecondary := errors.New("primary"), errors.New("secondary")
+	ok := func(s string) func() (string, error) {
+		return func() (string, error) { return s, nil }
+	}
+	fail := func(err error) func() (string, error) {
+		return func() (string, error) { return "", err }
+	}
+
+	result, err := Fallback(ok("a"), ok("b"))()
+	assert.IsNil(err)
+	assert.Equal("a", result)
+
+	result, err = Fallback(fail(errPrimary), ok("b"))()
+	assert.IsNil(err)
+	assert.Equal("b", result)
+
+	result, err = Fallback(fail(errPrimary), fail(errSecondary))()
+	assert.Equal("primary\nsecondary", err.Error())
+	assert.Equal("", result)
+}
+
+func TestFirstSuccessful(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFirstSuccessful")
+
+	var calls []int
+	call := func(i int, err error) func() (int, error) {
+		return func() (int, error) {
+			calls = append(calls, i)
+			return i, err
+		}
+	}
+
+	result, err := FirstSuccessful(
+		call(1, errors.New("1")),
+		call(2, nil),
+		call(3, nil),
+	)()
+	assert.IsNil(err)
+	assert.Equal(2, result)
+	assert.Equal([]int{1, 2}, calls)
+
+	result, err = FirstSuccessful(call(4, errors.New("4")), call(5, errors.New("5")))()
+	assert.Equal("4\n5", err.Error())
+	assert.Equal(0, result)
+
+	_, err = FirstSuccessful[int]()()
+	assert.IsNotNil(err)
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWithTimeout")
+
+	fast := WithTimeout(func() (int, error) {
+		return 1, nil
+	}, time.Second)
+
+	result, err := fast()
+	assert.IsNil(err)
+	assert.Equal(1, result)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	slow := WithTimeout(func() (int, error) {
+		<-release
+		return 2, nil
+	}, 10*time.Millisecond)
+
+	start := time.Now()
+	result, err = slow()
+	assert.Equal(ErrTimeout, err)
+	assert.Equal(0, result)
+	assert.Greater(500*time.Millisecond, time.Since(start))
+
+	panicked := WithTimeout(func() (int, error) {
+		panic("boom")
+	}, time.Second)
+
+	_, err = panicked()
+	assert.Equal("function: fn panicked: boom", err.Error())
+
+	// fallback to the cached value if the remote call is timeout
+	cached := Fallback(slow, func() (int, error) { return 3, nil })
+	result, err = cached()
+	assert.IsNil(err)
+	assert.Equal(3, result)
+}
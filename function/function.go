@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"reflect"
 	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
 )
 
 // After creates a function that invokes func once it's called n or more times.
@@ -105,6 +107,11 @@ func Debounced(fn func(), duration time.Duration) func() {
 // Schedule invoke function every duration time, util close the returned bool channel.
 // Play: https://go.dev/play/p/hbON-Xeyn5N
 func Schedule(d time.Duration, fn any, args ...any) chan bool {
+	return ScheduleWithClock(datetime.SystemClock, d, fn, args...)
+}
+
+// ScheduleWithClock is like Schedule, but the duration is measured by clock, eg: a datetime.FakeClock in tests.
+func ScheduleWithClock(clock datetime.Clock, d time.Duration, fn any, args ...any) chan bool {
 	// Catch programming error while constructing the closure
 	mustBeFunction(fn)
 
@@ -113,7 +120,7 @@ func Schedule(d time.Duration, fn any, args ...any) chan bool {
 		for {
 			unsafeInvokeFunc(fn, args...)
 			select {
-			case <-time.After(d):
+			case <-clock.After(d):
 			case <-quit:
 				return
 			}
@@ -1,6 +1,7 @@
 package function
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -174,3 +175,22 @@ func ExampleAcceptIf() {
 	// 0
 	// false
 }
+
+func ExampleContextPipeline() {
+	p := NewContextPipeline[string]().
+		Then("trim", func(ctx context.Context, s string) (string, error) {
+			return strings.TrimSpace(s), nil
+		}).
+		Then("upper", func(ctx context.Context, s string) (string, error) {
+			return strings.ToUpper(s), nil
+		})
+
+	result, err := p.Run(context.Background(), "  hello  ")
+
+	fmt.Println(result)
+	fmt.Println(err)
+
+	// Output:
+	// HELLO
+	// <nil>
+}
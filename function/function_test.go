@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/duke-git/lancet/v2/datetime"
 	"github.com/duke-git/lancet/v2/internal"
 )
 
@@ -145,6 +147,28 @@ func TestSchedule(t *testing.T) {
 	// assert.Equal(expected, res)
 }
 
+func TestScheduleWithClock(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestScheduleWithClock")
+
+	clock := datetime.NewFakeClock(time.Now())
+
+	var count int32
+	stop := ScheduleWithClock(clock, time.Second, func() {
+		atomic.AddInt32(&count, 1)
+	})
+
+	for i := 0; i < 4; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+	clock.BlockUntil(1)
+	close(stop)
+
+	assert.Equal(int32(5), atomic.LoadInt32(&count))
+}
+
 func TestPipeline(t *testing.T) {
 	assert := internal.NewAssert(t, "TestPipeline")
 
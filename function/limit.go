@@ -0,0 +1,78 @@
+package function
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// LimitConcurrency returns a function with the same signature of fn, at most n calls of the returned function
+// run at the same time, other calls wait until a running one returns.
+func LimitConcurrency[F any](fn F, n int) F {
+	// Catch programming error while constructing the closure
+	mustBeFunction(fn)
+
+	if n <= 0 {
+		panic("programming error: concurrency limit must be greater than 0")
+	}
+
+	sem := make(chan struct{}, n)
+
+	return wrapFunc(fn, func(call func() []reflect.Value) []reflect.Value {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+
+		return call()
+	})
+}
+
+// RateLimit returns a function with the same signature of fn, the returned function is invoked
+// at most rps times per second, calls exceeding the rate are delayed.
+func RateLimit[F any](fn F, rps float64) F {
+	// Catch programming error while constructing the closure
+	mustBeFunction(fn)
+
+	if rps <= 0 {
+		panic("programming error: rps must be greater than 0")
+	}
+
+	interval := time.Duration(float64(time.Second) / rps)
+
+	var (
+		mu   sync.Mutex
+		next time.Time
+	)
+
+	return wrapFunc(fn, func(call func() []reflect.Value) []reflect.Value {
+		mu.Lock()
+		now := time.Now()
+		if next.Before(now) {
+			next = now
+		}
+		wait := next.Sub(now)
+		next = next.Add(interval)
+		mu.Unlock()
+
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+
+		return call()
+	})
+}
+
+// wrapFunc makes a function of type F, which passes the call of fn to wrapper.
+func wrapFunc[F any](fn F, wrapper func(call func() []reflect.Value) []reflect.Value) F {
+	fv := reflect.ValueOf(fn)
+
+	wrapped := reflect.MakeFunc(fv.Type(), func(args []reflect.Value) []reflect.Value {
+		return wrapper(func() []reflect.Value {
+			if fv.Type().IsVariadic() {
+				return fv.CallSlice(args)
+			}
+			return fv.Call(args)
+		})
+	})
+
+	return wrapped.Interface().(F)
+}
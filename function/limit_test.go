@@ -0,0 +1,100 @@
+package function
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestLimitConcurrency(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLimitConcurrency")
+
+	var running, maxRunning int32
+
+	work := func(i int) string {
+		cur := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&maxRunning)
+			if cur <= old || atomic.CompareAndSwapInt32(&maxRunning, old, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return fmt.Sprint(i)
+	}
+
+	limited := LimitConcurrency(work, 2)
+
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = limited(i)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal([]string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, results)
+	assert.GreaterOrEqual(int32(2), atomic.LoadInt32(&maxRunning))
+}
+
+func TestLimitConcurrency_Variadic(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLimitConcurrency_Variadic")
+
+	sum := LimitConcurrency(func(base int, nums ...int) (int, error) {
+		for _, n := range nums {
+			base += n
+		}
+		return base, nil
+	}, 1)
+
+	result, err := sum(1, 2, 3)
+	assert.IsNil(err)
+	assert.Equal(6, result)
+
+	result, _ = sum(1)
+	assert.Equal(1, result)
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRateLimit")
+
+	var count int32
+	inc := RateLimit(func() {
+		atomic.AddInt32(&count, 1)
+	}, 100)
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		inc()
+	}
+	elapsed := time.Since(start)
+
+	assert.Equal(int32(6), atomic.LoadInt32(&count))
+	assert.GreaterOrEqual(elapsed, 50*time.Millisecond)
+}
+
+func TestLimit_Panic(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLimit_Panic")
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+
+	LimitConcurrency(1, 2)
+}
@@ -0,0 +1,106 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StageFunc is a stage of ContextPipeline, it receives the context passed to Run.
+type StageFunc[T any] func(ctx context.Context, value T) (T, error)
+
+// StageTrace is the tracing info of a finished stage.
+type StageTrace struct {
+	Name     string
+	Index    int
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+type stage[T any] struct {
+	name string
+	fn   StageFunc[T]
+}
+
+// ContextPipeline is a builder of multi-stage function flow. The context is bound lazily when Run is called,
+// so a pipeline could be built once and executed repeatedly, eg: for every request of a service.
+// It's like Pipeline, but stages receive context, could fail, and support tracing hooks for metrics.
+type ContextPipeline[T any] struct {
+	stages  []stage[T]
+	onStart []func(ctx context.Context, name string) context.Context
+	onDone  []func(ctx context.Context, trace StageTrace)
+}
+
+// NewContextPipeline creates a ContextPipeline instance.
+func NewContextPipeline[T any]() *ContextPipeline[T] {
+	return &ContextPipeline[T]{}
+}
+
+// Then appends a named stage to the pipeline.
+func (p *ContextPipeline[T]) Then(name string, fn StageFunc[T]) *ContextPipeline[T] {
+	if fn == nil {
+		panic("programming error: stage function must be not nil")
+	}
+
+	p.stages = append(p.stages, stage[T]{name: name, fn: fn})
+
+	return p
+}
+
+// OnStageStart registers a hook which is called before every stage runs, the returned context
+// is passed to the stage and OnStageDone hooks, so it could carry a tracing span.
+func (p *ContextPipeline[T]) OnStageStart(hook func(ctx context.Context, name string) context.Context) *ContextPipeline[T] {
+	p.onStart = append(p.onStart, hook)
+
+	return p
+}
+
+// OnStageDone registers a hook which is called after every stage finished, with the timing and error of the stage.
+func (p *ContextPipeline[T]) OnStageDone(hook func(ctx context.Context, trace StageTrace)) *ContextPipeline[T] {
+	p.onDone = append(p.onDone, hook)
+
+	return p
+}
+
+// Len returns the count of stages.
+func (p *ContextPipeline[T]) Len() int {
+	return len(p.stages)
+}
+
+// Run passes value into the stages one by one with ctx. It stops at the first failed stage,
+// or when ctx is done before a stage starts. The returned error is wrapped with the stage name.
+func (p *ContextPipeline[T]) Run(ctx context.Context, value T) (T, error) {
+	for i, s := range p.stages {
+		if err := ctx.Err(); err != nil {
+			return value, fmt.Errorf("pipeline stage %s: %w", s.name, err)
+		}
+
+		stageCtx := ctx
+		for _, hook := range p.onStart {
+			stageCtx = hook(stageCtx, s.name)
+		}
+
+		start := time.Now()
+		result, err := s.fn(stageCtx, value)
+
+		trace := StageTrace{
+			Name:     s.name,
+			Index:    i,
+			Start:    start,
+			Duration: time.Since(start),
+			Err:      err,
+		}
+		for _, hook := range p.onDone {
+			hook(stageCtx, trace)
+		}
+
+		if err != nil {
+			return value, fmt.Errorf("pipeline stage %s: %w", s.name, err)
+		}
+
+		value = result
+	}
+
+	return value, nil
+}
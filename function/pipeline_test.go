@@ -0,0 +1,90 @@
+package function
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestContextPipeline(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestContextPipeline")
+
+	type ctxKey struct{}
+
+	var traces []StageTrace
+
+	p := NewContextPipeline[int]().
+		Then("double", func(ctx context.Context, v int) (int, error) {
+			return v * 2, nil
+		}).
+		Then("addFromContext", func(ctx context.Context, v int) (int, error) {
+			return v + ctx.Value(ctxKey{}).(int), nil
+		}).
+		Then("sleep", func(ctx context.Context, v int) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return v, nil
+		}).
+		OnStageDone(func(ctx context.Context, trace StageTrace) {
+			traces = append(traces, trace)
+		})
+
+	assert.Equal(3, p.Len())
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, 1)
+	result, err := p.Run(ctx, 2)
+	assert.IsNil(err)
+	assert.Equal(5, result)
+
+	assert.Equal(3, len(traces))
+	assert.Equal("double", traces[0].Name)
+	assert.Equal(2, traces[2].Index)
+	assert.GreaterOrEqual(traces[2].Duration, 10*time.Millisecond)
+
+	// run again with another context
+	ctx = context.WithValue(context.Background(), ctxKey{}, 10)
+	result, err = p.Run(ctx, 3)
+	assert.IsNil(err)
+	assert.Equal(16, result)
+	assert.Equal(6, len(traces))
+}
+
+func TestContextPipeline_Error(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestContextPipeline_Error")
+
+	errStage := errors.New("stage failed")
+
+	var called []string
+	p := NewContextPipeline[string]().
+		Then("first", func(ctx context.Context, v string) (string, error) {
+			return v + "a", nil
+		}).
+		Then("second", func(ctx context.Context, v string) (string, error) {
+			return "", errStage
+		}).
+		Then("third", func(ctx context.Context, v string) (string, error) {
+			return v + "c", nil
+		}).
+		OnStageStart(func(ctx context.Context, name string) context.Context {
+			called = append(called, name)
+			return ctx
+		})
+
+	result, err := p.Run(context.Background(), "")
+	assert.Equal("a", result)
+	assert.Equal(true, errors.Is(err, errStage))
+	assert.Equal("pipeline stage second: stage failed", err.Error())
+	assert.Equal([]string{"first", "second"}, called)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = p.Run(ctx, "")
+	assert.Equal(true, errors.Is(err, context.Canceled))
+}
@@ -1,13 +1,48 @@
 package function
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
 
 // Watcher is used for record code excution time
 // Play: https://go.dev/play/p/l2yrOpCLd1I
 type Watcher struct {
-	startTime int64
-	stopTime  int64
-	excuting  bool
+	mu         sync.Mutex
+	startTime  int64
+	stopTime   int64
+	excuting   bool
+	lastLap    int64
+	laps       []Lap
+	scopeStack []string
+	scopes     map[string]*ScopeStat
+	scopeOrder []string
+	logf       func(format string, args ...any)
+}
+
+// Lap is a named lap recorded by Watcher.
+type Lap struct {
+	Name     string
+	Duration time.Duration // time since the previous lap, or the start of watcher for the first lap
+	Elapsed  time.Duration // time since the start of watcher
+}
+
+// ScopeStat is the statistics of a measured scope, nested scopes are named as "outer/inner".
+type ScopeStat struct {
+	Name  string
+	Depth int
+	Count int
+	Total time.Duration
+	Max   time.Duration
+}
+
+// WatcherReport is the report of Watcher, includes total elapsed time, laps and per-scope totals.
+type WatcherReport struct {
+	Elapsed time.Duration
+	Laps    []Lap
+	Scopes  []ScopeStat
 }
 
 // Start the watch timer.
@@ -17,18 +52,29 @@ func NewWatcher() *Watcher {
 
 // Start the watch timer.
 func (w *Watcher) Start() {
+	w.mu.Lock()
 	w.startTime = time.Now().UnixNano()
 	w.excuting = true
+	w.mu.Unlock()
 }
 
 // Stop the watch timer.
 func (w *Watcher) Stop() {
+	w.mu.Lock()
 	w.stopTime = time.Now().UnixNano()
 	w.excuting = false
+	w.mu.Unlock()
 }
 
 // GetElapsedTime get excute elapsed time.
 func (w *Watcher) GetElapsedTime() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.elapsed()
+}
+
+func (w *Watcher) elapsed() time.Duration {
 	if w.excuting {
 		return time.Duration(time.Now().UnixNano() - w.startTime)
 	}
@@ -37,7 +83,178 @@ func (w *Watcher) GetElapsedTime() time.Duration {
 
 // Reset the watch timer.
 func (w *Watcher) Reset() {
+	w.mu.Lock()
 	w.startTime = 0
 	w.stopTime = 0
 	w.excuting = false
+	w.lastLap = 0
+	w.laps = nil
+	w.scopeStack = nil
+	w.scopes = nil
+	w.scopeOrder = nil
+	w.mu.Unlock()
+}
+
+// SetLogger enables automatic logging, logf is called when a lap is recorded or a scope ends.
+// Pass nil to disable it. eg: w.SetLogger(log.Printf)
+func (w *Watcher) SetLogger(logf func(format string, args ...any)) *Watcher {
+	w.mu.Lock()
+	w.logf = logf
+	w.mu.Unlock()
+
+	return w
+}
+
+// Lap records a named lap and returns the time since the previous lap (or the start of watcher).
+// It returns 0 and records nothing if the watcher is not started.
+func (w *Watcher) Lap(name string) time.Duration {
+	now := time.Now().UnixNano()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.startTime == 0 {
+		return 0
+	}
+
+	prev := w.lastLap
+	if prev == 0 {
+		prev = w.startTime
+	}
+	w.lastLap = now
+
+	lap := Lap{
+		Name:     name,
+		Duration: time.Duration(now - prev),
+		Elapsed:  time.Duration(now - w.startTime),
+	}
+	w.laps = append(w.laps, lap)
+
+	if w.logf != nil {
+		w.logf("watcher lap %s: %v (elapsed %v)", lap.Name, lap.Duration, lap.Elapsed)
+	}
+
+	return lap.Duration
+}
+
+// Laps returns all the recorded laps.
+func (w *Watcher) Laps() []Lap {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]Lap(nil), w.laps...)
+}
+
+// Scope begins a named measurement scope, and returns a function to end it.
+// Scopes begun before the end of current scope are nested in it. The scope stack is shared by all the
+// goroutines using the watcher, so scopes of concurrent goroutines are nested into each other, use a
+// watcher per goroutine to measure them separately. eg:
+//
+//	defer w.Scope("handler")()
+func (w *Watcher) Scope(name string) func() {
+	start := time.Now()
+
+	w.mu.Lock()
+	w.scopeStack = append(w.scopeStack, name)
+	path := strings.Join(w.scopeStack, "/")
+	depth := len(w.scopeStack) - 1
+
+	// scopes are reported in the order they begin, so nested scopes follow their parent
+	if w.scopes == nil {
+		w.scopes = make(map[string]*ScopeStat)
+	}
+	if _, ok := w.scopes[path]; !ok {
+		w.scopes[path] = &ScopeStat{Name: path, Depth: depth}
+		w.scopeOrder = append(w.scopeOrder, path)
+	}
+	w.mu.Unlock()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			w.endScope(path, depth, time.Since(start))
+		})
+	}
+}
+
+// Measure runs fn in a named scope.
+func (w *Watcher) Measure(name string, fn func()) time.Duration {
+	start := time.Now()
+
+	end := w.Scope(name)
+	defer end()
+
+	fn()
+
+	return time.Since(start)
+}
+
+func (w *Watcher) endScope(path string, depth int, d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// pop the scope and the nested scopes which are not ended
+	if depth < len(w.scopeStack) {
+		w.scopeStack = w.scopeStack[:depth]
+	}
+
+	stat, ok := w.scopes[path]
+	if !ok {
+		// the watcher is reset before the scope ends
+		return
+	}
+	stat.Count++
+	stat.Total += d
+	if d > stat.Max {
+		stat.Max = d
+	}
+
+	if w.logf != nil {
+		w.logf("watcher scope %s: %v", path, d)
+	}
+}
+
+// Report returns the report of watcher.
+func (w *Watcher) Report() *WatcherReport {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	report := &WatcherReport{
+		Elapsed: w.elapsed(),
+		Laps:    append([]Lap(nil), w.laps...),
+		Scopes:  make([]ScopeStat, 0, len(w.scopeOrder)),
+	}
+	for _, path := range w.scopeOrder {
+		if stat := w.scopes[path]; stat.Count > 0 {
+			report.Scopes = append(report.Scopes, *stat)
+		}
+	}
+
+	return report
+}
+
+// String returns the report as readable text.
+func (r *WatcherReport) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("elapsed: %v\n", r.Elapsed))
+
+	if len(r.Laps) > 0 {
+		sb.WriteString("laps:\n")
+		for i, lap := range r.Laps {
+			sb.WriteString(fmt.Sprintf("  %d. %s: %v (elapsed %v)\n", i+1, lap.Name, lap.Duration, lap.Elapsed))
+		}
+	}
+
+	if len(r.Scopes) > 0 {
+		sb.WriteString("scopes:\n")
+		for _, s := range r.Scopes {
+			name := s.Name[strings.LastIndex(s.Name, "/")+1:]
+			sb.WriteString(fmt.Sprintf("  %s%s: total %v, count %d, max %v\n",
+				strings.Repeat("  ", s.Depth), name, s.Total, s.Count, s.Max))
+		}
+	}
+
+	return sb.String()
 }
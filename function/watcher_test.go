@@ -1,7 +1,10 @@
 package function
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/duke-git/lancet/v2/internal"
 )
@@ -38,3 +41,83 @@ func longRunningTask() []int64 {
 	}
 	return data
 }
+
+func TestWatcher_Lap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWatcher_Lap")
+
+	w := NewWatcher()
+	w.Start()
+
+	time.Sleep(10 * time.Millisecond)
+	d1 := w.Lap("first")
+	time.Sleep(10 * time.Millisecond)
+	d2 := w.Lap("second")
+
+	w.Stop()
+
+	laps := w.Laps()
+	assert.Equal(2, len(laps))
+	assert.Equal("first", laps[0].Name)
+	assert.Equal(d1, laps[0].Duration)
+	assert.Equal(d2, laps[1].Duration)
+	assert.GreaterOrEqual(d2, 10*time.Millisecond)
+	assert.GreaterOrEqual(laps[1].Elapsed, 20*time.Millisecond)
+
+	w.Reset()
+	assert.Equal(0, len(w.Laps()))
+
+	// the watcher is not started
+	assert.Equal(time.Duration(0), w.Lap("unstarted"))
+	assert.Equal(0, len(w.Laps()))
+}
+
+func TestWatcher_Scope(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWatcher_Scope")
+
+	var logs []string
+
+	w := NewWatcher().SetLogger(func(format string, args ...any) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	})
+	w.Start()
+
+	for i := 0; i < 2; i++ {
+		endOuter := w.Scope("outer")
+		w.Measure("inner", func() {
+			time.Sleep(5 * time.Millisecond)
+		})
+		endOuter()
+	}
+	w.Measure("other", func() {})
+
+	w.Stop()
+
+	report := w.Report()
+	assert.Equal(3, len(report.Scopes))
+
+	assert.Equal("outer", report.Scopes[0].Name)
+	assert.Equal(0, report.Scopes[0].Depth)
+	assert.Equal(2, report.Scopes[0].Count)
+
+	assert.Equal("outer/inner", report.Scopes[1].Name)
+	assert.Equal(1, report.Scopes[1].Depth)
+	assert.Equal(2, report.Scopes[1].Count)
+	assert.GreaterOrEqual(report.Scopes[1].Total, 10*time.Millisecond)
+	assert.GreaterOrEqual(report.Scopes[0].Total, report.Scopes[1].Total)
+
+	assert.Equal("other", report.Scopes[2].Name)
+	assert.Equal(0, report.Scopes[2].Depth)
+
+	assert.Equal(5, len(logs))
+	assert.Equal(true, strings.HasPrefix(logs[0], "watcher scope outer/inner: "))
+
+	text := report.String()
+	assert.Equal(true, strings.Contains(text, "scopes:\n"))
+	assert.Equal(true, strings.Contains(text, "scopes:\n  outer: total "))
+	assert.Equal(true, strings.Contains(text, "\n    inner: total "))
+	assert.Equal(true, strings.Contains(text, "\n  other: total "))
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package parallel implements the goroutine runners shared by the parallel functions of lancet.
+// do not use it outside lancet lib.
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Workers returns the count of goroutines to process n items, it's at most limit and n.
+// If limit <= 0, it's runtime.GOMAXPROCS(0).
+func Workers(n, limit int) int {
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	if limit > n {
+		limit = n
+	}
+
+	return limit
+}
+
+// Dispatch calls fn for index in [0, n) by workers goroutines, the indexes are dispatched in order until ctx is done,
+// it returns ctx.Err() if some indexes are not dispatched. The panic in fn is propagated to the calling goroutine
+// after all the goroutines return.
+func Dispatch(ctx context.Context, n, workers int, fn func(i int)) error {
+	if n == 0 {
+		return ctx.Err()
+	}
+
+	var p panicCatcher
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				p.run(func() {
+					fn(i)
+				})
+			}
+		}()
+	}
+
+	var ctxErr error
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+			break dispatch
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	p.repanic()
+
+	return ctxErr
+}
+
+// Chunks splits [0, n) into chunks contiguous parts of nearly the same size, and calls fn for each of them in
+// goroutines, the last chunk is run in the calling goroutine. The panic in fn is propagated to the calling goroutine
+// after all the goroutines return.
+func Chunks(n, chunks int, fn func(chunk, low, high int)) {
+	if chunks <= 0 {
+		return
+	}
+	if chunks == 1 {
+		fn(0, 0, n)
+		return
+	}
+
+	var (
+		p  panicCatcher
+		wg sync.WaitGroup
+	)
+
+	size, rest := n/chunks, n%chunks
+	low := 0
+	for chunk := 0; chunk < chunks; chunk++ {
+		high := low + size
+		if chunk < rest {
+			high++
+		}
+
+		if chunk == chunks-1 {
+			p.run(func() {
+				fn(chunk, low, high)
+			})
+		} else {
+			wg.Add(1)
+			go func(chunk, low, high int) {
+				defer wg.Done()
+				p.run(func() {
+					fn(chunk, low, high)
+				})
+			}(chunk, low, high)
+		}
+		low = high
+	}
+	wg.Wait()
+
+	p.repanic()
+}
+
+// panicCatcher keeps the first panic of the goroutines.
+type panicCatcher struct {
+	once     sync.Once
+	value    any
+	panicked bool
+}
+
+func (p *panicCatcher) run(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.once.Do(func() {
+				p.value, p.panicked = r, true
+			})
+		}
+	}()
+
+	fn()
+}
+
+// repanic panics with the kept panic value, it should be called after the goroutines return.
+func (p *panicCatcher) repanic() {
+	if p.panicked {
+		panic(p.value)
+	}
+}
@@ -0,0 +1,77 @@
+package parallel
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestWorkers(t *testing.T) {
+	assert := internal.NewAssert(t, "TestWorkers")
+
+	assert.Equal(4, Workers(10, 4))
+	assert.Equal(3, Workers(3, 4))
+	assert.Equal(0, Workers(0, 4))
+	assert.Equal(1, Workers(1, 0))
+}
+
+func TestDispatch(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDispatch")
+
+	result := make([]int, 100)
+	err := Dispatch(context.Background(), len(result), 4, func(i int) {
+		result[i] = i * 2
+	})
+	assert.IsNil(err)
+	for i, v := range result {
+		assert.Equal(i*2, v)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var count int32
+	err = Dispatch(ctx, 100, 1, func(i int) {
+		if atomic.AddInt32(&count, 1) == 3 {
+			cancel()
+		}
+	})
+	assert.Equal(context.Canceled, err)
+	assert.Equal(true, atomic.LoadInt32(&count) < 100)
+
+	assert.Equal(context.Canceled, Dispatch(ctx, 0, 4, func(i int) {}))
+
+	defer func() {
+		assert.Equal("boom", recover())
+	}()
+	Dispatch(context.Background(), 10, 4, func(i int) {
+		if i == 5 {
+			panic("boom")
+		}
+	})
+}
+
+func TestChunks(t *testing.T) {
+	assert := internal.NewAssert(t, "TestChunks")
+
+	bounds := make([][2]int, 3)
+	Chunks(10, 3, func(chunk, low, high int) {
+		bounds[chunk] = [2]int{low, high}
+	})
+	assert.Equal([][2]int{{0, 4}, {4, 7}, {7, 10}}, bounds)
+
+	called := false
+	Chunks(0, 0, func(_, _, _ int) {
+		called = true
+	})
+	assert.Equal(false, called)
+
+	defer func() {
+		assert.Equal("boom", recover())
+	}()
+	Chunks(10, 4, func(chunk, _, _ int) {
+		if chunk == 1 {
+			panic("boom")
+		}
+	})
+}
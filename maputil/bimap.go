@@ -0,0 +1,159 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package maputil
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrValueExists is returned by BiMap.Put if the value is already bound to another key.
+var ErrValueExists = errors.New("maputil: value is bound to another key")
+
+// BiMap is a bidirectional map (thread unsafe), both the keys and the values are unique, so the key could be
+// looked up by value in O(1).
+type BiMap[K comparable, V comparable] struct {
+	forward map[K]V
+	inverse map[V]K
+}
+
+// NewBiMap creates an empty BiMap pointer instance.
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		inverse: make(map[V]K),
+	}
+}
+
+// NewBiMapFromMap creates a BiMap from a regular map, it returns ErrValueExists if the values of m are not unique.
+func NewBiMapFromMap[K comparable, V comparable](m map[K]V) (*BiMap[K, V], error) {
+	bm := NewBiMap[K, V]()
+	for k, v := range m {
+		if err := bm.Put(k, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return bm, nil
+}
+
+// Put binds key and value, the old value of key is replaced. It returns ErrValueExists if value is bound to
+// another key, and the map is not changed.
+func (bm *BiMap[K, V]) Put(key K, value V) error {
+	if k, ok := bm.inverse[value]; ok && k != key {
+		return fmt.Errorf("%w: %v is bound to %v", ErrValueExists, value, k)
+	}
+
+	bm.ForcePut(key, value)
+
+	return nil
+}
+
+// ForcePut binds key and value, the old bindings of key and value are removed.
+func (bm *BiMap[K, V]) ForcePut(key K, value V) {
+	bm.DeleteKey(key)
+	bm.DeleteValue(value)
+
+	bm.forward[key] = value
+	bm.inverse[value] = key
+}
+
+// Get returns the value of key.
+func (bm *BiMap[K, V]) Get(key K) (V, bool) {
+	v, ok := bm.forward[key]
+	return v, ok
+}
+
+// GetKey returns the key of value.
+func (bm *BiMap[K, V]) GetKey(value V) (K, bool) {
+	k, ok := bm.inverse[value]
+	return k, ok
+}
+
+// DeleteKey removes the key and its value, returns false if the key is not present.
+func (bm *BiMap[K, V]) DeleteKey(key K) bool {
+	v, ok := bm.forward[key]
+	if !ok {
+		return false
+	}
+
+	delete(bm.forward, key)
+	delete(bm.inverse, v)
+
+	return true
+}
+
+// DeleteValue removes the value and its key, returns false if the value is not present.
+func (bm *BiMap[K, V]) DeleteValue(value V) bool {
+	k, ok := bm.inverse[value]
+	if !ok {
+		return false
+	}
+
+	delete(bm.inverse, value)
+	delete(bm.forward, k)
+
+	return true
+}
+
+// ContainKey checks if the key is present.
+func (bm *BiMap[K, V]) ContainKey(key K) bool {
+	_, ok := bm.forward[key]
+	return ok
+}
+
+// ContainValue checks if the value is present.
+func (bm *BiMap[K, V]) ContainValue(value V) bool {
+	_, ok := bm.inverse[value]
+	return ok
+}
+
+// Len returns the count of key and value pairs.
+func (bm *BiMap[K, V]) Len() int {
+	return len(bm.forward)
+}
+
+// Clear removes all the keys and values.
+func (bm *BiMap[K, V]) Clear() {
+	bm.forward = make(map[K]V)
+	bm.inverse = make(map[V]K)
+}
+
+// Inverse returns a new BiMap whose keys are the values of bm, and values are the keys of bm.
+func (bm *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return &BiMap[V, K]{
+		forward: bm.ToInverseMap(),
+		inverse: bm.ToMap(),
+	}
+}
+
+// Range calls iteratee for each key and value, the iteration stops if iteratee returns false.
+// The map should not be modified by iteratee.
+func (bm *BiMap[K, V]) Range(iteratee func(key K, value V) bool) {
+	for k, v := range bm.forward {
+		if !iteratee(k, v) {
+			return
+		}
+	}
+}
+
+// ToMap converts the BiMap to a regular map of keys to values.
+func (bm *BiMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, len(bm.forward))
+	for k, v := range bm.forward {
+		result[k] = v
+	}
+
+	return result
+}
+
+// ToInverseMap converts the BiMap to a regular map of values to keys.
+func (bm *BiMap[K, V]) ToInverseMap() map[V]K {
+	result := make(map[V]K, len(bm.inverse))
+	for v, k := range bm.inverse {
+		result[v] = k
+	}
+
+	return result
+}
@@ -0,0 +1,24 @@
+package maputil
+
+import (
+	"fmt"
+)
+
+func ExampleNewBiMap() {
+	codes := NewBiMap[string, int]()
+	codes.Put("OK", 200)
+	codes.Put("NotFound", 404)
+
+	code, _ := codes.Get("OK")
+	name, _ := codes.GetKey(404)
+	err := codes.Put("Missing", 404)
+
+	fmt.Println(code)
+	fmt.Println(name)
+	fmt.Println(err)
+
+	// Output:
+	// 200
+	// NotFound
+	// maputil: value is bound to another key: 404 is bound to NotFound
+}
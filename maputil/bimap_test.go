@@ -0,0 +1,89 @@
+package maputil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestBiMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBiMap")
+
+	bm := NewBiMap[string, int]()
+	assert.IsNil(bm.Put("a", 1))
+	assert.IsNil(bm.Put("b", 2))
+	// put the same pair again
+	assert.IsNil(bm.Put("a", 1))
+
+	v, ok := bm.Get("a")
+	assert.Equal(1, v)
+	assert.Equal(true, ok)
+
+	k, ok := bm.GetKey(2)
+	assert.Equal("b", k)
+	assert.Equal(true, ok)
+
+	_, ok = bm.GetKey(3)
+	assert.Equal(false, ok)
+
+	// the value is bound to another key
+	err := bm.Put("c", 1)
+	assert.Equal(true, errors.Is(err, ErrValueExists))
+	assert.Equal("maputil: value is bound to another key: 1 is bound to a", err.Error())
+	assert.Equal(false, bm.ContainKey("c"))
+
+	// the old value of key is replaced
+	assert.IsNil(bm.Put("a", 3))
+	assert.Equal(false, bm.ContainValue(1))
+	assert.Equal(map[string]int{"a": 3, "b": 2}, bm.ToMap())
+	assert.Equal(map[int]string{3: "a", 2: "b"}, bm.ToInverseMap())
+
+	// the old bindings of both key and value are removed
+	bm.ForcePut("a", 2)
+	assert.Equal(map[string]int{"a": 2}, bm.ToMap())
+	assert.Equal(map[int]string{2: "a"}, bm.ToInverseMap())
+	assert.Equal(1, bm.Len())
+
+	assert.IsNil(bm.Put("b", 4))
+	assert.Equal(true, bm.DeleteKey("a"))
+	assert.Equal(false, bm.DeleteKey("a"))
+	assert.Equal(false, bm.ContainValue(2))
+	assert.Equal(true, bm.DeleteValue(4))
+	assert.Equal(false, bm.DeleteValue(4))
+	assert.Equal(false, bm.ContainKey("b"))
+	assert.Equal(0, bm.Len())
+}
+
+func TestBiMap_Convert(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBiMap_Convert")
+
+	bm, err := NewBiMapFromMap(map[string]int{"a": 1, "b": 2})
+	assert.IsNil(err)
+
+	inverse := bm.Inverse()
+	k, _ := inverse.Get(2)
+	assert.Equal("b", k)
+
+	// the inverse is a copy
+	inverse.ForcePut(3, "c")
+	assert.Equal(false, bm.ContainKey("c"))
+
+	sum := 0
+	bm.Range(func(key string, value int) bool {
+		sum += value
+		return true
+	})
+	assert.Equal(3, sum)
+
+	_, err = NewBiMapFromMap(map[string]int{"a": 1, "b": 1})
+	assert.Equal(true, errors.Is(err, ErrValueExists))
+
+	bm.Clear()
+	assert.Equal(0, bm.Len())
+	assert.Equal(false, bm.ContainValue(1))
+}
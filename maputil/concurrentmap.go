@@ -138,12 +138,91 @@ func (cm *ConcurrentMap[K, V]) Range(iterator func(key K, value V) bool) {
 	}
 }
 
-// getShard get shard by a key.
+// Compute sets the value for a key to the result of fn atomically, fn is called with the existing value and
+// whether it's present, and the key is deleted if fn returns false for keep. It returns the new value and whether
+// the key is kept. fn should not access the map, since the shard of key is locked.
+func (cm *ConcurrentMap[K, V]) Compute(key K, fn func(value V, ok bool) (newValue V, keep bool)) (V, bool) {
+	shard := cm.getShard(key)
+
+	cm.locks[shard].Lock()
+	defer cm.locks[shard].Unlock()
+
+	value, ok := cm.maps[shard][key]
+	newValue, keep := fn(value, ok)
+	if keep {
+		cm.maps[shard][key] = newValue
+	} else {
+		delete(cm.maps[shard], key)
+	}
+
+	return newValue, keep
+}
+
+// Upsert sets the value for a key to the result of insertFn if the key is not present, or the result of updateFn
+// called with the existing value otherwise, atomically. It returns the new value.
+func (cm *ConcurrentMap[K, V]) Upsert(key K, insertFn func() V, updateFn func(existing V) V) V {
+	value, _ := cm.Compute(key, func(value V, ok bool) (V, bool) {
+		if ok {
+			return updateFn(value), true
+		}
+		return insertFn(), true
+	})
+
+	return value
+}
+
+// Len returns the count of keys in the map, the shards are counted one by one, so it's not a snapshot
+// if the map is being modified.
+func (cm *ConcurrentMap[K, V]) Len() int {
+	count := 0
+	for shard := range cm.locks {
+		cm.locks[shard].RLock()
+		count += len(cm.maps[shard])
+		cm.locks[shard].RUnlock()
+	}
+
+	return count
+}
+
+// getShard get shard by a key. The common key types are hashed directly, and the others are hashed by their
+// printed values.
 func (cm *ConcurrentMap[K, V]) getShard(key K) uint64 {
-	hash := fnv32(fmt.Sprintf("%v", key))
+	var hash uint32
+
+	switch k := any(key).(type) {
+	case string:
+		hash = fnv32(k)
+	case int:
+		hash = fnv32Uint64(uint64(k))
+	case int64:
+		hash = fnv32Uint64(uint64(k))
+	case int32:
+		hash = fnv32Uint64(uint64(k))
+	case uint:
+		hash = fnv32Uint64(uint64(k))
+	case uint64:
+		hash = fnv32Uint64(k)
+	case uint32:
+		hash = fnv32Uint64(uint64(k))
+	default:
+		hash = fnv32(fmt.Sprintf("%v", key))
+	}
+
 	return uint64(hash) % cm.shardCount
 }
 
+// fnv32Uint64 is fnv32 of the little endian bytes of x.
+func fnv32Uint64(x uint64) uint32 {
+	hash := uint32(2166136261)
+	const prime32 = uint32(16777619)
+	for i := 0; i < 8; i++ {
+		hash *= prime32
+		hash ^= uint32(x & 0xff)
+		x >>= 8
+	}
+	return hash
+}
+
 func fnv32(key string) uint32 {
 	hash := uint32(2166136261)
 	const prime32 = uint32(16777619)
@@ -172,3 +172,179 @@ func TestConcurrentMap_Range(t *testing.T) {
 		return true
 	})
 }
+
+func TestConcurrentMap_Compute(t *testing.T) {
+	assert := internal.NewAssert(t, "TestConcurrentMap_Compute")
+
+	cm := NewConcurrentMap[string, int](8)
+
+	var wg sync.WaitGroup
+	wg.Add(100)
+	for i := 0; i < 100; i++ {
+		go func() {
+			cm.Compute("counter", func(value int, ok bool) (int, bool) {
+				return value + 1, true
+			})
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	val, _ := cm.Get("counter")
+	assert.Equal(100, val)
+
+	val, keep := cm.Compute("counter", func(value int, ok bool) (int, bool) {
+		return 0, false
+	})
+	assert.Equal(0, val)
+	assert.Equal(false, keep)
+	assert.Equal(false, cm.Has("counter"))
+
+	cm.Compute("new", func(value int, ok bool) (int, bool) {
+		assert.Equal(false, ok)
+		return 1, true
+	})
+	val, ok := cm.Get("new")
+	assert.Equal(1, val)
+	assert.Equal(true, ok)
+}
+
+func TestConcurrentMap_Upsert(t *testing.T) {
+	assert := internal.NewAssert(t, "TestConcurrentMap_Upsert")
+
+	cm := NewConcurrentMap[int, []int](8)
+
+	var wg sync.WaitGroup
+	wg.Add(50)
+	for i := 0; i < 50; i++ {
+		go func(n int) {
+			cm.Upsert(n%5, func() []int {
+				return []int{n}
+			}, func(existing []int) []int {
+				return append(existing, n)
+			})
+			wg.Done()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(5, cm.Len())
+	cm.Range(func(key int, value []int) bool {
+		assert.Equal(10, len(value))
+		return true
+	})
+}
+
+func TestConcurrentMap_Len(t *testing.T) {
+	assert := internal.NewAssert(t, "TestConcurrentMap_Len")
+
+	cm := NewConcurrentMap[uint64, bool](16)
+	assert.Equal(0, cm.Len())
+
+	for i := uint64(0); i < 1000; i++ {
+		cm.Set(i, true)
+	}
+	assert.Equal(1000, cm.Len())
+
+	cm.Delete(1)
+	assert.Equal(999, cm.Len())
+}
+
+// the keys of benchmarks, which are shared by all goroutines, so there is contention
+const benchmarkMapKeys = 1 << 10
+
+// rwMutexMap is a map guarded by a single sync.RWMutex for comparison.
+type rwMutexMap struct {
+	mu sync.RWMutex
+	m  map[int]int
+}
+
+func BenchmarkConcurrentMap_Write(b *testing.B) {
+	cm := NewConcurrentMap[int, int](0)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cm.Set(i%benchmarkMapKeys, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMap_Write(b *testing.B) {
+	var m sync.Map
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Store(i%benchmarkMapKeys, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkRWMutexMap_Write(b *testing.B) {
+	m := &rwMutexMap{m: map[int]int{}}
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.mu.Lock()
+			m.m[i%benchmarkMapKeys] = i
+			m.mu.Unlock()
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentMap_Compute(b *testing.B) {
+	cm := NewConcurrentMap[int, int](0)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cm.Compute(i%benchmarkMapKeys, func(value int, ok bool) (int, bool) {
+				return value + 1, true
+			})
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentMap_ReadMostly(b *testing.B) {
+	cm := NewConcurrentMap[int, int](0)
+	for i := 0; i < benchmarkMapKeys; i++ {
+		cm.Set(i, i)
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%10 == 0 {
+				cm.Set(i%benchmarkMapKeys, i)
+			} else {
+				cm.Get(i % benchmarkMapKeys)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMap_ReadMostly(b *testing.B) {
+	var m sync.Map
+	for i := 0; i < benchmarkMapKeys; i++ {
+		m.Store(i, i)
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%10 == 0 {
+				m.Store(i%benchmarkMapKeys, i)
+			} else {
+				m.Load(i % benchmarkMapKeys)
+			}
+			i++
+		}
+	})
+}
@@ -309,6 +309,53 @@ func HasKey[K comparable, V any](m map[K]V, key K) bool {
 	return haskey
 }
 
+// GetOrSet returns the existing value for the key if present, ok is true.
+// Otherwise, it sets and returns the given value, ok is false.
+func GetOrSet[K comparable, V any](m map[K]V, key K, value V) (actual V, ok bool) {
+	if actual, ok = m[key]; ok {
+		return actual, true
+	}
+
+	m[key] = value
+
+	return value, false
+}
+
+// GetOrCompute is like GetOrSet, but the value is computed by compute only if the key is not present,
+// e.g. create the slice or nested map of a key lazily.
+func GetOrCompute[K comparable, V any](m map[K]V, key K, compute func() V) (actual V, ok bool) {
+	if actual, ok = m[key]; ok {
+		return actual, true
+	}
+
+	actual = compute()
+	m[key] = actual
+
+	return actual, false
+}
+
+// Upsert sets the value of key to the result of insertFn if the key is not present, or the result of updateFn
+// called with the existing value otherwise, the new value is returned.
+func Upsert[K comparable, V any](m map[K]V, key K, insertFn func() V, updateFn func(existing V) V) V {
+	var value V
+	if existing, ok := m[key]; ok {
+		value = updateFn(existing)
+	} else {
+		value = insertFn()
+	}
+
+	m[key] = value
+
+	return value
+}
+
+// Increment adds delta to the value of key, the value is zero if the key is not present, the new value is
+// returned, e.g. count the words by Increment(counts, word, 1).
+func Increment[K comparable, V constraints.Integer | constraints.Float](m map[K]V, key K, delta V) V {
+	m[key] += delta
+	return m[key]
+}
+
 // MapToStruct converts map to struct
 // Play: https://go.dev/play/p/7wYyVfX38Dp
 func MapToStruct(m map[string]any, structObj any) error {
@@ -452,6 +452,66 @@ func ExampleHasKey() {
 	// false
 }
 
+func ExampleGetOrSet() {
+	m := map[string]int{"a": 1}
+
+	result1, ok1 := GetOrSet(m, "a", 10)
+	result2, ok2 := GetOrSet(m, "b", 2)
+
+	fmt.Println(result1, ok1)
+	fmt.Println(result2, ok2)
+
+	// Output:
+	// 1 true
+	// 2 false
+}
+
+func ExampleGetOrCompute() {
+	groups := map[string][]int{}
+
+	for _, n := range []int{1, 2, 3, 4} {
+		key := "odd"
+		if n%2 == 0 {
+			key = "even"
+		}
+		group, _ := GetOrCompute(groups, key, func() []int { return make([]int, 0, 2) })
+		groups[key] = append(group, n)
+	}
+
+	fmt.Println(groups)
+
+	// Output:
+	// map[even:[2 4] odd:[1 3]]
+}
+
+func ExampleUpsert() {
+	m := map[string]string{}
+
+	insert := func() string { return "first" }
+	update := func(existing string) string { return existing + ",again" }
+
+	Upsert(m, "a", insert, update)
+	result := Upsert(m, "a", insert, update)
+
+	fmt.Println(result)
+
+	// Output:
+	// first,again
+}
+
+func ExampleIncrement() {
+	counts := map[string]int{}
+
+	for _, word := range []string{"go", "is", "go"} {
+		Increment(counts, word, 1)
+	}
+
+	fmt.Println(counts)
+
+	// Output:
+	// map[go:2 is:1]
+}
+
 func ExampleMapToStruct() {
 
 	personReqMap := map[string]any{
@@ -475,6 +475,82 @@ func TestHasKey(t *testing.T) {
 	assert.Equal(false, HasKey(m, "c"))
 }
 
+func TestGetOrSet(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGetOrSet")
+
+	m := map[string]int{"a": 1}
+
+	actual, ok := GetOrSet(m, "a", 10)
+	assert.Equal(1, actual)
+	assert.Equal(true, ok)
+
+	actual, ok = GetOrSet(m, "b", 2)
+	assert.Equal(2, actual)
+	assert.Equal(false, ok)
+
+	assert.Equal(map[string]int{"a": 1, "b": 2}, m)
+}
+
+func TestGetOrCompute(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGetOrCompute")
+
+	m := map[string][]int{"a": {1}}
+	calls := 0
+	compute := func() []int {
+		calls++
+		return []int{}
+	}
+
+	actual, ok := GetOrCompute(m, "a", compute)
+	assert.Equal([]int{1}, actual)
+	assert.Equal(true, ok)
+	assert.Equal(0, calls)
+
+	actual, ok = GetOrCompute(m, "b", compute)
+	assert.Equal([]int{}, actual)
+	assert.Equal(false, ok)
+	assert.Equal(1, calls)
+
+	_, ok = GetOrCompute(m, "b", compute)
+	assert.Equal(true, ok)
+	assert.Equal(1, calls)
+}
+
+func TestUpsert(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestUpsert")
+
+	m := map[string][]string{}
+	insert := func() []string { return []string{"new"} }
+	update := func(existing []string) []string { return append(existing, "updated") }
+
+	assert.Equal([]string{"new"}, Upsert(m, "a", insert, update))
+	assert.Equal([]string{"new", "updated"}, Upsert(m, "a", insert, update))
+	assert.Equal(map[string][]string{"a": {"new", "updated"}}, m)
+}
+
+func TestIncrement(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIncrement")
+
+	counts := map[string]int{}
+	for _, word := range []string{"a", "b", "a", "a"} {
+		Increment(counts, word, 1)
+	}
+	assert.Equal(map[string]int{"a": 3, "b": 1}, counts)
+	assert.Equal(1, Increment(counts, "a", -2))
+
+	totals := map[string]float64{}
+	assert.Equal(1.5, Increment(totals, "x", 1.5))
+	assert.Equal(2.0, Increment(totals, "x", 0.5))
+}
+
 func TestMapToStruct(t *testing.T) {
 	t.Parallel()
 
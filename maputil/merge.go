@@ -0,0 +1,150 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package maputil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrMergeTypeMismatch is returned by DeepMerge with MergeStrictTypes, if the values of a key have different types.
+var ErrMergeTypeMismatch = errors.New("maputil: merge type mismatch")
+
+// MergeConflict is the way DeepMerge resolves the key present in both maps, whose values are not both maps.
+type MergeConflict int
+
+const (
+	// MergeOverwrite replaces the value of dst with the value of src
+	MergeOverwrite MergeConflict = iota
+	// MergeKeep keeps the value of dst
+	MergeKeep
+)
+
+// DeepMergeConfig is config for DeepMerge.
+type DeepMergeConfig struct {
+	conflict     MergeConflict
+	appendSlices bool
+	strictTypes  bool
+}
+
+// DeepMergeOption is for adding DeepMerge config.
+type DeepMergeOption func(*DeepMergeConfig)
+
+// MergeStrategy sets the way to resolve the conflicts, the default is MergeOverwrite.
+func MergeStrategy(conflict MergeConflict) DeepMergeOption {
+	return func(dmc *DeepMergeConfig) {
+		dmc.conflict = conflict
+	}
+}
+
+// MergeAppendSlices makes the slices of the same type appended, the elements of dst are before the ones of src,
+// rather than resolved as conflicts.
+func MergeAppendSlices() DeepMergeOption {
+	return func(dmc *DeepMergeConfig) {
+		dmc.appendSlices = true
+	}
+}
+
+// MergeStrictTypes makes DeepMerge return ErrMergeTypeMismatch if the values of a key have different types,
+// e.g. a map and a string. The nil value matches any type.
+func MergeStrictTypes() DeepMergeOption {
+	return func(dmc *DeepMergeConfig) {
+		dmc.strictTypes = true
+	}
+}
+
+// DeepMerge merges src into dst recursively: the nested maps of the same key are merged rather than replaced,
+// and the other conflicts are resolved by options, so it's suitable for layering the configurations decoded from
+// JSON or YAML. The nested maps should be map[string]any. The maps and slices from src are copied, dst should not
+// be nil, and it's not changed if DeepMerge returns error.
+func DeepMerge(dst, src map[string]any, opts ...DeepMergeOption) error {
+	config := &DeepMergeConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	merged, err := deepMergeMap(dst, src, config, nil)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range merged {
+		dst[k] = v
+	}
+
+	return nil
+}
+
+// deepMergeMap returns the merged map of dst and src as a new map, path is the keys to dst used in error message.
+func deepMergeMap(dst, src map[string]any, config *DeepMergeConfig, path []pathSegment) (map[string]any, error) {
+	result := make(map[string]any, len(dst)+len(src))
+	for k, v := range dst {
+		result[k] = v
+	}
+
+	for k, srcValue := range src {
+		dstValue, ok := dst[k]
+		if !ok {
+			result[k] = deepCopyValue(srcValue)
+			continue
+		}
+
+		value, err := deepMergeValue(dstValue, srcValue, config, append(path[:len(path):len(path)], pathSegment{key: k}))
+		if err != nil {
+			return nil, err
+		}
+		result[k] = value
+	}
+
+	return result, nil
+}
+
+func deepMergeValue(dst, src any, config *DeepMergeConfig, path []pathSegment) (any, error) {
+	dstMap, dstIsMap := dst.(map[string]any)
+	srcMap, srcIsMap := src.(map[string]any)
+	if dstIsMap && srcIsMap {
+		return deepMergeMap(dstMap, srcMap, config, path)
+	}
+
+	dstType, srcType := reflect.TypeOf(dst), reflect.TypeOf(src)
+	if config.strictTypes && dst != nil && src != nil && dstType != srcType {
+		return nil, fmt.Errorf("%w: %s is %s and %s", ErrMergeTypeMismatch, formatPath(path), dstType, srcType)
+	}
+
+	if config.appendSlices && dstType == srcType && dstType != nil && dstType.Kind() == reflect.Slice {
+		dstSlice, srcSlice := reflect.ValueOf(dst), reflect.ValueOf(deepCopyValue(src))
+		result := reflect.MakeSlice(dstType, 0, dstSlice.Len()+srcSlice.Len())
+		return reflect.AppendSlice(reflect.AppendSlice(result, dstSlice), srcSlice).Interface(), nil
+	}
+
+	if config.conflict == MergeKeep {
+		return dst, nil
+	}
+
+	return deepCopyValue(src), nil
+}
+
+// deepCopyValue copies the nested map[string]any and []any of v, the other values are returned as is.
+func deepCopyValue(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for k, item := range value {
+			result[k] = deepCopyValue(item)
+		}
+		return result
+	case []any:
+		if value == nil {
+			return value
+		}
+		result := make([]any, len(value))
+		for i, item := range value {
+			result[i] = deepCopyValue(item)
+		}
+		return result
+	}
+
+	return v
+}
@@ -0,0 +1,25 @@
+package maputil
+
+import (
+	"fmt"
+)
+
+func ExampleDeepMerge() {
+	defaults := map[string]any{
+		"server":  map[string]any{"host": "localhost", "port": 80},
+		"plugins": []any{"log"},
+	}
+	override := map[string]any{
+		"server":  map[string]any{"port": 8080},
+		"plugins": []any{"auth"},
+	}
+
+	err := DeepMerge(defaults, override, MergeAppendSlices())
+
+	fmt.Println(err)
+	fmt.Println(defaults)
+
+	// Output:
+	// <nil>
+	// map[plugins:[log auth] server:map[host:localhost port:8080]]
+}
@@ -0,0 +1,125 @@
+package maputil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func newMergeTestMaps() (map[string]any, map[string]any) {
+	dst := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"host":  "localhost",
+			"port":  80,
+			"tls":   map[string]any{"enabled": false},
+			"hosts": []any{"a"},
+		},
+		"tags": []string{"x"},
+	}
+	src := map[string]any{
+		"server": map[string]any{
+			"port":  8080,
+			"tls":   map[string]any{"cert": "cert.pem"},
+			"hosts": []any{"b", "c"},
+		},
+		"tags":  []string{"y"},
+		"debug": true,
+	}
+
+	return dst, src
+}
+
+func TestDeepMerge(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeepMerge")
+
+	dst, src := newMergeTestMaps()
+	assert.IsNil(DeepMerge(dst, src))
+
+	assert.Equal(map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"host":  "localhost",
+			"port":  8080,
+			"tls":   map[string]any{"enabled": false, "cert": "cert.pem"},
+			"hosts": []any{"b", "c"},
+		},
+		"tags":  []string{"y"},
+		"debug": true,
+	}, dst)
+
+	// the maps and slices from src are copied
+	dst["server"].(map[string]any)["hosts"].([]any)[0] = "z"
+	dst["server"].(map[string]any)["tls"].(map[string]any)["cert"] = "other.pem"
+	assert.Equal([]any{"b", "c"}, src["server"].(map[string]any)["hosts"])
+	assert.Equal(map[string]any{"cert": "cert.pem"}, src["server"].(map[string]any)["tls"])
+}
+
+func TestDeepMerge_Keep(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeepMerge_Keep")
+
+	dst, src := newMergeTestMaps()
+	assert.IsNil(DeepMerge(dst, src, MergeStrategy(MergeKeep)))
+
+	assert.Equal(map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"host":  "localhost",
+			"port":  80,
+			"tls":   map[string]any{"enabled": false, "cert": "cert.pem"},
+			"hosts": []any{"a"},
+		},
+		"tags":  []string{"x"},
+		"debug": true,
+	}, dst)
+}
+
+func TestDeepMerge_AppendSlices(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeepMerge_AppendSlices")
+
+	dst, src := newMergeTestMaps()
+	dst["list"] = []any{1}
+	src["list"] = []int{2}
+
+	assert.IsNil(DeepMerge(dst, src, MergeAppendSlices(), MergeStrategy(MergeKeep)))
+
+	assert.Equal([]any{"a", "b", "c"}, dst["server"].(map[string]any)["hosts"])
+	assert.Equal([]string{"x", "y"}, dst["tags"])
+	// the slices of different types are conflicts
+	assert.Equal([]any{1}, dst["list"])
+}
+
+func TestDeepMerge_StrictTypes(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeepMerge_StrictTypes")
+
+	dst, src := newMergeTestMaps()
+	src["server"].(map[string]any)["tls"] = "on"
+
+	err := DeepMerge(dst, src, MergeStrictTypes())
+	assert.Equal(true, errors.Is(err, ErrMergeTypeMismatch))
+	assert.Equal("maputil: merge type mismatch: server.tls is map[string]interface {} and string", err.Error())
+
+	// dst is not changed
+	expected, _ := newMergeTestMaps()
+	assert.Equal(expected, dst)
+
+	// the nil value matches any type
+	dst = map[string]any{"a": nil, "b": 1}
+	assert.IsNil(DeepMerge(dst, map[string]any{"a": "x", "b": nil}, MergeStrictTypes()))
+	assert.Equal(map[string]any{"a": "x", "b": nil}, dst)
+
+	// the type mismatch is overwritten without MergeStrictTypes
+	dst, src = newMergeTestMaps()
+	src["server"] = "localhost:8080"
+	assert.IsNil(DeepMerge(dst, src))
+	assert.Equal("localhost:8080", dst["server"])
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package maputil
+
+// MultiMap is a map which maps one key to many values (thread unsafe), the values of a key are in the order of
+// adding, and may be duplicated. A key is removed when its last value is removed.
+type MultiMap[K comparable, V comparable] struct {
+	data map[K][]V
+	// size is the count of values of all keys
+	size int
+}
+
+// NewMultiMap creates an empty MultiMap pointer instance.
+func NewMultiMap[K comparable, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{data: make(map[K][]V)}
+}
+
+// NewMultiMapFromMap creates a MultiMap from the map of keys to the value slices, which are copied.
+func NewMultiMapFromMap[K comparable, V comparable](m map[K][]V) *MultiMap[K, V] {
+	mm := NewMultiMap[K, V]()
+	for k, values := range m {
+		mm.Add(k, values...)
+	}
+
+	return mm
+}
+
+// Add appends the values to the key.
+func (mm *MultiMap[K, V]) Add(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+
+	mm.data[key] = append(mm.data[key], values...)
+	mm.size += len(values)
+}
+
+// Remove removes the first occurrence of value from the key, returns false if it's not present.
+func (mm *MultiMap[K, V]) Remove(key K, value V) bool {
+	values := mm.data[key]
+	for i, v := range values {
+		if v != value {
+			continue
+		}
+
+		if len(values) == 1 {
+			delete(mm.data, key)
+		} else {
+			// copy, so the slices returned by Values are not changed
+			result := make([]V, 0, len(values)-1)
+			result = append(result, values[:i]...)
+			mm.data[key] = append(result, values[i+1:]...)
+		}
+		mm.size--
+
+		return true
+	}
+
+	return false
+}
+
+// RemoveAll removes the key, and returns its values.
+func (mm *MultiMap[K, V]) RemoveAll(key K) []V {
+	values := mm.data[key]
+	delete(mm.data, key)
+	mm.size -= len(values)
+
+	return values
+}
+
+// Values returns a copy of the values of key, or an empty slice if the key is not present.
+func (mm *MultiMap[K, V]) Values(key K) []V {
+	return append([]V{}, mm.data[key]...)
+}
+
+// Contain checks if the key is present.
+func (mm *MultiMap[K, V]) Contain(key K) bool {
+	_, ok := mm.data[key]
+	return ok
+}
+
+// ContainEntry checks if the value is present in the values of key.
+func (mm *MultiMap[K, V]) ContainEntry(key K, value V) bool {
+	for _, v := range mm.data[key] {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Keys returns the keys, the order is not specified like the keys of map.
+func (mm *MultiMap[K, V]) Keys() []K {
+	return Keys(mm.data)
+}
+
+// KeyLen returns the count of keys.
+func (mm *MultiMap[K, V]) KeyLen() int {
+	return len(mm.data)
+}
+
+// Len returns the count of values of all keys.
+func (mm *MultiMap[K, V]) Len() int {
+	return mm.size
+}
+
+// Clear removes all the keys.
+func (mm *MultiMap[K, V]) Clear() {
+	mm.data = make(map[K][]V)
+	mm.size = 0
+}
+
+// Range calls iteratee for each key and value pair, the values of a key are in order. The iteration stops if
+// iteratee returns false. The map should not be modified by iteratee.
+func (mm *MultiMap[K, V]) Range(iteratee func(key K, value V) bool) {
+	for k, values := range mm.data {
+		for _, v := range values {
+			if !iteratee(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// ToMap converts the multimap to a map of keys to the copies of value slices.
+func (mm *MultiMap[K, V]) ToMap() map[K][]V {
+	result := make(map[K][]V, len(mm.data))
+	for k, values := range mm.data {
+		result[k] = append([]V(nil), values...)
+	}
+
+	return result
+}
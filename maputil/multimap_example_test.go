@@ -0,0 +1,19 @@
+package maputil
+
+import (
+	"fmt"
+)
+
+func ExampleNewMultiMap() {
+	mm := NewMultiMap[string, string]()
+	mm.Add("fruit", "apple", "banana")
+	mm.Add("vegetable", "carrot")
+	mm.Remove("fruit", "apple")
+
+	fmt.Println(mm.Values("fruit"))
+	fmt.Println(mm.Len())
+
+	// Output:
+	// [banana]
+	// 2
+}
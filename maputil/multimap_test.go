@@ -0,0 +1,80 @@
+package maputil
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestMultiMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMultiMap")
+
+	mm := NewMultiMap[string, int]()
+	mm.Add("a", 1, 2)
+	mm.Add("b", 3)
+	mm.Add("a", 1)
+	mm.Add("c")
+
+	assert.Equal(4, mm.Len())
+	assert.Equal(2, mm.KeyLen())
+	assert.Equal([]int{1, 2, 1}, mm.Values("a"))
+	assert.Equal([]int{}, mm.Values("c"))
+	assert.Equal(true, mm.Contain("b"))
+	assert.Equal(false, mm.Contain("c"))
+	assert.Equal(true, mm.ContainEntry("a", 2))
+	assert.Equal(false, mm.ContainEntry("b", 2))
+
+	keys := mm.Keys()
+	sort.Strings(keys)
+	assert.Equal([]string{"a", "b"}, keys)
+
+	values := mm.Values("a")
+	assert.Equal(true, mm.Remove("a", 1))
+	assert.Equal(false, mm.Remove("a", 3))
+	assert.Equal([]int{2, 1}, mm.Values("a"))
+	// the returned values are not changed
+	assert.Equal([]int{1, 2, 1}, values)
+	assert.Equal(3, mm.Len())
+
+	// the key is removed with its last value
+	assert.Equal(true, mm.Remove("b", 3))
+	assert.Equal(false, mm.Contain("b"))
+
+	assert.Equal([]int{2, 1}, mm.RemoveAll("a"))
+	assert.Equal(0, mm.Len())
+	assert.Equal(0, mm.KeyLen())
+}
+
+func TestMultiMap_Convert(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMultiMap_Convert")
+
+	m := map[string][]int{"a": {1, 2}, "b": {3}, "c": {}}
+	mm := NewMultiMapFromMap(m)
+
+	m["a"][0] = 10
+	assert.Equal([]int{1, 2}, mm.Values("a"))
+	assert.Equal(map[string][]int{"a": {1, 2}, "b": {3}}, mm.ToMap())
+
+	sum := 0
+	mm.Range(func(key string, value int) bool {
+		sum += value
+		return true
+	})
+	assert.Equal(6, sum)
+
+	count := 0
+	mm.Range(func(key string, value int) bool {
+		count++
+		return false
+	})
+	assert.Equal(1, count)
+
+	mm.Clear()
+	assert.Equal(0, mm.Len())
+	assert.Equal(map[string][]int{}, mm.ToMap())
+}
@@ -0,0 +1,311 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package maputil
+
+import (
+	"bytes"
+	"container/list"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// OrderedMap is a map which keeps the insertion order of keys (thread unsafe), Get, Set and Delete are O(1).
+// It's marshaled into JSON object in the order of keys, the keys should be string, integer or implement
+// encoding.TextMarshaler like the keys of map for encoding/json. The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	data map[K]*list.Element
+	// the values of list are *Entry[K, V] in insertion order
+	list list.List
+}
+
+// NewOrderedMap creates an empty OrderedMap pointer instance.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		data: make(map[K]*list.Element),
+	}
+}
+
+// NewOrderedMapFromMap creates an OrderedMap from a regular map, the keys are ordered by less function since the
+// order of regular map is random.
+func NewOrderedMapFromMap[K comparable, V any](m map[K]V, less func(a, b K) bool) *OrderedMap[K, V] {
+	keys := Keys(m)
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+
+	om := NewOrderedMap[K, V]()
+	for _, k := range keys {
+		om.Set(k, m[k])
+	}
+
+	return om
+}
+
+// Set the value of key, a new key is appended to the end, and an existing key keeps its position.
+func (om *OrderedMap[K, V]) Set(key K, value V) {
+	if elem, ok := om.data[key]; ok {
+		elem.Value.(*Entry[K, V]).Value = value
+		return
+	}
+
+	if om.data == nil {
+		om.data = make(map[K]*list.Element)
+	}
+	om.data[key] = om.list.PushBack(&Entry[K, V]{Key: key, Value: value})
+}
+
+// Get the value of key.
+func (om *OrderedMap[K, V]) Get(key K) (V, bool) {
+	elem, ok := om.data[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	return elem.Value.(*Entry[K, V]).Value, true
+}
+
+// Delete the key, returns false if the key is not present.
+func (om *OrderedMap[K, V]) Delete(key K) bool {
+	elem, ok := om.data[key]
+	if !ok {
+		return false
+	}
+
+	om.list.Remove(elem)
+	delete(om.data, key)
+
+	return true
+}
+
+// Contain checks if the key is present.
+func (om *OrderedMap[K, V]) Contain(key K) bool {
+	_, ok := om.data[key]
+	return ok
+}
+
+// Len returns the count of keys.
+func (om *OrderedMap[K, V]) Len() int {
+	return len(om.data)
+}
+
+// Clear removes all the keys.
+func (om *OrderedMap[K, V]) Clear() {
+	om.data = make(map[K]*list.Element)
+	om.list.Init()
+}
+
+// Front returns the first entry in order.
+func (om *OrderedMap[K, V]) Front() (Entry[K, V], bool) {
+	if elem := om.list.Front(); elem != nil {
+		return *elem.Value.(*Entry[K, V]), true
+	}
+
+	return Entry[K, V]{}, false
+}
+
+// Back returns the last entry in order.
+func (om *OrderedMap[K, V]) Back() (Entry[K, V], bool) {
+	if elem := om.list.Back(); elem != nil {
+		return *elem.Value.(*Entry[K, V]), true
+	}
+
+	return Entry[K, V]{}, false
+}
+
+// Range calls iteratee for each key and value in order, the iteration stops if iteratee returns false.
+// The map should not be modified by iteratee.
+func (om *OrderedMap[K, V]) Range(iteratee func(key K, value V) bool) {
+	for elem := om.list.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*Entry[K, V])
+		if !iteratee(entry.Key, entry.Value) {
+			return
+		}
+	}
+}
+
+// Keys returns the keys in order.
+func (om *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, om.Len())
+	om.Range(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys
+}
+
+// Values returns the values in the order of keys.
+func (om *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, om.Len())
+	om.Range(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+
+	return values
+}
+
+// Entries returns the key/value pairs in order.
+func (om *OrderedMap[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, om.Len())
+	om.Range(func(key K, value V) bool {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+
+	return entries
+}
+
+// ToMap converts the ordered map to a regular map.
+func (om *OrderedMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, om.Len())
+	om.Range(func(key K, value V) bool {
+		result[key] = value
+		return true
+	})
+
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface, the keys of JSON object are in order.
+func (om *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	var err error
+	first := true
+	om.Range(func(key K, value V) bool {
+		var k string
+		if k, err = encodeOrderedMapKey(key); err != nil {
+			return false
+		}
+
+		var kb, vb []byte
+		if kb, err = json.Marshal(k); err != nil {
+			return false
+		}
+		if vb, err = json.Marshal(value); err != nil {
+			return false
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(vb)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, the keys are in the order of JSON object, and the
+// original keys of the map are removed. If a key is duplicated, the last value is kept in the first position.
+// JSON null is a no-op like encoding/json.
+func (om *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if token, err := dec.Token(); err != nil {
+		return err
+	} else if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("maputil: OrderedMap should be unmarshaled from JSON object")
+	}
+
+	om.Clear()
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key, err := decodeOrderedMapKey[K](token.(string))
+		if err != nil {
+			return err
+		}
+
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		om.Set(key, value)
+	}
+
+	// the closing '}'
+	_, err := dec.Token()
+
+	return err
+}
+
+// encodeOrderedMapKey converts key to the key of JSON object like encoding/json, the string kind is used
+// as it is even if it implements encoding.TextMarshaler.
+func encodeOrderedMapKey[K comparable](key K) (string, error) {
+	v := reflect.ValueOf(key)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+
+	return "", fmt.Errorf("maputil: unsupported key type %T of OrderedMap for JSON", key)
+}
+
+// decodeOrderedMapKey converts the key of JSON object to K like encoding/json.
+func decodeOrderedMapKey[K comparable](s string) (K, error) {
+	var key K
+
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(s))
+		return key, err
+	}
+
+	v := reflect.ValueOf(&key).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return key, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetInt(n)
+		return key, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetUint(n)
+		return key, nil
+	}
+
+	return key, fmt.Errorf("maputil: unsupported key type %T of OrderedMap for JSON", key)
+}
@@ -0,0 +1,57 @@
+package maputil
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func ExampleNewOrderedMap() {
+	om := NewOrderedMap[string, int]()
+	om.Set("b", 2)
+	om.Set("a", 1)
+	om.Set("c", 3)
+	om.Delete("a")
+
+	fmt.Println(om.Keys())
+	fmt.Println(om.Values())
+
+	// Output:
+	// [b c]
+	// [2 3]
+}
+
+func ExampleNewOrderedMapFromMap() {
+	om := NewOrderedMapFromMap(map[string]int{"b": 2, "a": 1, "c": 3}, func(a, b string) bool {
+		return a < b
+	})
+
+	fmt.Println(om.Entries())
+
+	// Output:
+	// [{a 1} {b 2} {c 3}]
+}
+
+func ExampleOrderedMap_MarshalJSON() {
+	om := NewOrderedMap[string, any]()
+	om.Set("name", "lancet")
+	om.Set("version", 2)
+	om.Set("go", "1.18")
+
+	data, _ := json.Marshal(om)
+
+	fmt.Println(string(data))
+
+	// Output:
+	// {"name":"lancet","version":2,"go":"1.18"}
+}
+
+func ExampleOrderedMap_UnmarshalJSON() {
+	om := NewOrderedMap[string, int]()
+
+	_ = json.Unmarshal([]byte(`{"z": 26, "a": 1, "m": 13}`), om)
+
+	fmt.Println(om.Keys())
+
+	// Output:
+	// [z a m]
+}
@@ -0,0 +1,203 @@
+package maputil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedMap")
+
+	om := NewOrderedMap[string, int]()
+	om.Set("c", 3)
+	om.Set("a", 1)
+	om.Set("b", 2)
+
+	assert.Equal(3, om.Len())
+	assert.Equal([]string{"c", "a", "b"}, om.Keys())
+	assert.Equal([]int{3, 1, 2}, om.Values())
+
+	// the existing key keeps its position
+	om.Set("c", 30)
+	assert.Equal([]Entry[string, int]{{"c", 30}, {"a", 1}, {"b", 2}}, om.Entries())
+
+	v, ok := om.Get("a")
+	assert.Equal(1, v)
+	assert.Equal(true, ok)
+
+	_, ok = om.Get("d")
+	assert.Equal(false, ok)
+	assert.Equal(true, om.Contain("b"))
+
+	assert.Equal(true, om.Delete("a"))
+	assert.Equal(false, om.Delete("a"))
+	assert.Equal([]string{"c", "b"}, om.Keys())
+
+	om.Set("a", 10)
+	assert.Equal([]string{"c", "b", "a"}, om.Keys())
+
+	front, ok := om.Front()
+	assert.Equal(Entry[string, int]{"c", 30}, front)
+	assert.Equal(true, ok)
+
+	back, ok := om.Back()
+	assert.Equal(Entry[string, int]{"a", 10}, back)
+	assert.Equal(true, ok)
+
+	assert.Equal(map[string]int{"a": 10, "b": 2, "c": 30}, om.ToMap())
+
+	om.Clear()
+	assert.Equal(0, om.Len())
+	assert.Equal([]string{}, om.Keys())
+
+	_, ok = om.Front()
+	assert.Equal(false, ok)
+	_, ok = om.Back()
+	assert.Equal(false, ok)
+}
+
+func TestOrderedMap_Range(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedMap_Range")
+
+	om := NewOrderedMapFromMap(map[int]string{3: "c", 1: "a", 2: "b"}, func(a, b int) bool {
+		return a < b
+	})
+	assert.Equal([]int{1, 2, 3}, om.Keys())
+
+	var keys []int
+	om.Range(func(key int, value string) bool {
+		keys = append(keys, key)
+		return key < 2
+	})
+	assert.Equal([]int{1, 2}, keys)
+}
+
+type orderedMapKey struct {
+	a, b string
+}
+
+func (k orderedMapKey) MarshalText() ([]byte, error) {
+	return []byte(k.a + "-" + k.b), nil
+}
+
+func (k *orderedMapKey) UnmarshalText(text []byte) error {
+	k.a, k.b = string(text[:1]), string(text[2:])
+	return nil
+}
+
+func TestOrderedMap_JSON(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedMap_JSON")
+
+	om := NewOrderedMap[string, any]()
+	om.Set("z", 1)
+	om.Set("a", []int{1, 2})
+	om.Set("m", map[string]string{"k": "v"})
+
+	data, err := json.Marshal(om)
+	assert.IsNil(err)
+	assert.Equal(`{"z":1,"a":[1,2],"m":{"k":"v"}}`, string(data))
+
+	result := NewOrderedMap[string, json.RawMessage]()
+	err = json.Unmarshal([]byte(`{"z": 1, "a": [1, 2], "m": {"k": "v"}, "z": 2}`), result)
+	assert.IsNil(err)
+	assert.Equal([]string{"z", "a", "m"}, result.Keys())
+
+	z, _ := result.Get("z")
+	assert.Equal("2", string(z))
+
+	// as a field of struct
+	type config struct {
+		Ports *OrderedMap[int, string] `json:"ports"`
+	}
+	var c config
+	err = json.Unmarshal([]byte(`{"ports": {"8080": "http", "22": "ssh"}}`), &c)
+	assert.IsNil(err)
+	assert.Equal([]int{8080, 22}, c.Ports.Keys())
+
+	data, err = json.Marshal(c)
+	assert.IsNil(err)
+	assert.Equal(`{"ports":{"8080":"http","22":"ssh"}}`, string(data))
+
+	keyed := NewOrderedMap[orderedMapKey, int]()
+	keyed.Set(orderedMapKey{"x", "y"}, 1)
+	data, err = json.Marshal(keyed)
+	assert.IsNil(err)
+	assert.Equal(`{"x-y":1}`, string(data))
+
+	keyed = NewOrderedMap[orderedMapKey, int]()
+	assert.IsNil(json.Unmarshal(data, keyed))
+	assert.Equal([]orderedMapKey{{"x", "y"}}, keyed.Keys())
+
+	empty, err := json.Marshal(NewOrderedMap[string, int]())
+	assert.IsNil(err)
+	assert.Equal(`{}`, string(empty))
+}
+
+func TestOrderedMap_JSONError(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedMap_JSONError")
+
+	om := NewOrderedMap[int, int]()
+
+	assert.IsNotNil(json.Unmarshal([]byte(`[1, 2]`), om))
+	assert.IsNotNil(json.Unmarshal([]byte(`{"a": 1}`), om))
+	assert.IsNotNil(json.Unmarshal([]byte(`{"1": "a"}`), om))
+
+	floatKeyed := NewOrderedMap[float64, int]()
+	floatKeyed.Set(1.5, 1)
+	_, err := json.Marshal(floatKeyed)
+	assert.IsNotNil(err)
+}
+
+// orderedMapStringKey is a string kind key with MarshalText, which is ignored by encoding/json.
+type orderedMapStringKey string
+
+func (k orderedMapStringKey) MarshalText() ([]byte, error) {
+	return []byte("text-" + string(k)), nil
+}
+
+func TestOrderedMap_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrderedMap_ZeroValue")
+
+	var om OrderedMap[string, int]
+	assert.Equal(0, om.Len())
+	assert.Equal([]string{}, om.Keys())
+	_, ok := om.Front()
+	assert.Equal(false, ok)
+	assert.Equal(false, om.Delete("a"))
+
+	om.Set("b", 2)
+	om.Set("a", 1)
+	assert.Equal([]string{"b", "a"}, om.Keys())
+
+	// null is a no-op
+	assert.IsNil(json.Unmarshal([]byte(`null`), &om))
+	assert.Equal([]string{"b", "a"}, om.Keys())
+
+	type config struct {
+		Ports OrderedMap[int, string] `json:"ports"`
+	}
+	var c config
+	assert.IsNil(json.Unmarshal([]byte(`{"ports": {"443": "https", "80": "http"}}`), &c))
+	assert.Equal([]int{443, 80}, c.Ports.Keys())
+	assert.IsNil(json.Unmarshal([]byte(`{"ports": null}`), &c))
+	assert.Equal([]int{443, 80}, c.Ports.Keys())
+
+	// the string kind is encoded as it is like encoding/json
+	keyed := NewOrderedMap[orderedMapStringKey, int]()
+	keyed.Set("a", 1)
+	data, err := json.Marshal(keyed)
+	assert.IsNil(err)
+	assert.Equal(`{"a":1}`, string(data))
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package maputil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPath is returned if the path can't be parsed.
+	ErrInvalidPath = errors.New("maputil: invalid path")
+	// ErrPathNotFound is returned if a key of path is not present, or an index of path is out of range.
+	ErrPathNotFound = errors.New("maputil: path not found")
+	// ErrPathTypeMismatch is returned if a value on the path is not a map for the key, or not a slice for the index.
+	ErrPathTypeMismatch = errors.New("maputil: path type mismatch")
+)
+
+// pathSegment is a key or an index of path.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// GetByPath returns the value of the nested map by path, e.g. "a.b[2].c" is m["a"]["b"][2]["c"]. The keys are
+// separated by '.', and the indices of slices are in brackets. A '.', '[', ']' or '\' in key is escaped by '\',
+// e.g. "a\.b" is the key "a.b". The nested values should be map[string]any and []any like the JSON or YAML
+// decoded into map[string]any.
+func GetByPath(m map[string]any, path string) (any, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var node any = m
+	for i, seg := range segments {
+		if node, err = getPathSegment(node, seg); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, formatPath(segments[:i+1]))
+		}
+	}
+
+	return node, nil
+}
+
+// SetByPath sets the value of the nested map by path, see GetByPath for the path syntax. The missing maps and
+// slices on the path are created, and a slice is extended with nil if the index is out of range. m is not changed
+// if it returns error, e.g. a value on the path is neither map nor slice.
+func SetByPath(m map[string]any, path string, value any) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return fmt.Errorf("%w: map is nil", ErrPathTypeMismatch)
+	}
+
+	_, err = setPath(m, segments, 0, value)
+
+	return err
+}
+
+// DeleteByPath deletes the key of map or the element of slice by path, see GetByPath for the path syntax.
+// The elements after the deleted one are shifted, and the slice is replaced by a new one in its parent.
+func DeleteByPath(m map[string]any, path string) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = deletePath(m, segments, 0)
+
+	return err
+}
+
+// parsePath splits path into segments, the first one is always a key.
+func parsePath(path string) ([]pathSegment, error) {
+	segments := []pathSegment{}
+
+	for i, expectKey := 0, true; ; {
+		if expectKey {
+			var key strings.Builder
+			for i < len(path) && path[i] != '.' && path[i] != '[' && path[i] != ']' {
+				if path[i] == '\\' {
+					if i+1 == len(path) {
+						return nil, fmt.Errorf("%w: %q ends with escape character", ErrInvalidPath, path)
+					}
+					i++
+				}
+				key.WriteByte(path[i])
+				i++
+			}
+			if key.Len() == 0 {
+				return nil, fmt.Errorf("%w: %q has empty key at %d", ErrInvalidPath, path, i)
+			}
+			segments = append(segments, pathSegment{key: key.String()})
+			expectKey = false
+		}
+
+		if i == len(path) {
+			return segments, nil
+		}
+
+		switch path[i] {
+		case '.':
+			i++
+			expectKey = true
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: %q has unclosed bracket at %d", ErrInvalidPath, path, i)
+			}
+			index, err := parseIndex(path[i+1 : i+end])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q has invalid index at %d", ErrInvalidPath, path, i)
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("%w: %q has unexpected %q at %d", ErrInvalidPath, path, path[i], i)
+		}
+	}
+}
+
+// parseIndex parses the non-negative decimal index, the sign is not allowed.
+func parseIndex(s string) (int, error) {
+	if s == "" || strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return 0, strconv.ErrSyntax
+	}
+
+	return strconv.Atoi(s)
+}
+
+// formatPath converts segments back to path, which is used in the error messages.
+func formatPath(segments []pathSegment) string {
+	var builder strings.Builder
+
+	for i, seg := range segments {
+		if seg.isIndex {
+			builder.WriteString("[" + strconv.Itoa(seg.index) + "]")
+			continue
+		}
+		if i > 0 {
+			builder.WriteByte('.')
+		}
+		for j := 0; j < len(seg.key); j++ {
+			if strings.IndexByte(`.[]\`, seg.key[j]) >= 0 {
+				builder.WriteByte('\\')
+			}
+			builder.WriteByte(seg.key[j])
+		}
+	}
+
+	return builder.String()
+}
+
+func getPathSegment(node any, seg pathSegment) (any, error) {
+	if seg.isIndex {
+		s, ok := node.([]any)
+		if !ok {
+			return nil, ErrPathTypeMismatch
+		}
+		if seg.index >= len(s) {
+			return nil, ErrPathNotFound
+		}
+		return s[seg.index], nil
+	}
+
+	m, ok := node.(map[string]any)
+	if !ok {
+		return nil, ErrPathTypeMismatch
+	}
+	value, ok := m[seg.key]
+	if !ok {
+		return nil, ErrPathNotFound
+	}
+
+	return value, nil
+}
+
+// setPath sets value into node by segments[i:], and returns the node which may be created or extended. The node
+// is only changed after the nested ones are set successfully.
+func setPath(node any, segments []pathSegment, i int, value any) (any, error) {
+	seg := segments[i]
+	last := i == len(segments)-1
+
+	if seg.isIndex {
+		s, ok := node.([]any)
+		if !ok && node != nil {
+			return nil, fmt.Errorf("%w: %s is %T", ErrPathTypeMismatch, formatPath(segments[:i]), node)
+		}
+		if seg.index >= len(s) {
+			s = append(s[:len(s):len(s)], make([]any, seg.index+1-len(s))...)
+		}
+
+		child := value
+		if !last {
+			var err error
+			if child, err = setPath(s[seg.index], segments, i+1, value); err != nil {
+				return nil, err
+			}
+		}
+		s[seg.index] = child
+
+		return s, nil
+	}
+
+	m, ok := node.(map[string]any)
+	if !ok && node != nil {
+		return nil, fmt.Errorf("%w: %s is %T", ErrPathTypeMismatch, formatPath(segments[:i]), node)
+	}
+	if m == nil {
+		m = make(map[string]any)
+	}
+
+	child := value
+	if !last {
+		var err error
+		if child, err = setPath(m[seg.key], segments, i+1, value); err != nil {
+			return nil, err
+		}
+	}
+	m[seg.key] = child
+
+	return m, nil
+}
+
+// deletePath deletes segments[i:] from node, and returns the node which may be a new slice.
+func deletePath(node any, segments []pathSegment, i int) (any, error) {
+	seg := segments[i]
+
+	if i < len(segments)-1 {
+		child, err := getPathSegment(node, seg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, formatPath(segments[:i+1]))
+		}
+		if child, err = deletePath(child, segments, i+1); err != nil {
+			return nil, err
+		}
+
+		// the child slice may be replaced
+		if seg.isIndex {
+			node.([]any)[seg.index] = child
+		} else {
+			node.(map[string]any)[seg.key] = child
+		}
+		return node, nil
+	}
+
+	if _, err := getPathSegment(node, seg); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, formatPath(segments))
+	}
+
+	if seg.isIndex {
+		s := node.([]any)
+		result := make([]any, 0, len(s)-1)
+		result = append(result, s[:seg.index]...)
+		return append(result, s[seg.index+1:]...), nil
+	}
+
+	delete(node.(map[string]any), seg.key)
+
+	return node, nil
+}
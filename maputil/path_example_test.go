@@ -0,0 +1,55 @@
+package maputil
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func ExampleGetByPath() {
+	var config map[string]any
+	json.Unmarshal([]byte(`{"servers": [{"host": "a.com", "ports": [80, 443]}], "log.level": "info"}`), &config)
+
+	host, _ := GetByPath(config, "servers[0].host")
+	port, _ := GetByPath(config, "servers[0].ports[1]")
+	level, _ := GetByPath(config, `log\.level`)
+	_, err := GetByPath(config, "servers[1].host")
+
+	fmt.Println(host)
+	fmt.Println(port)
+	fmt.Println(level)
+	fmt.Println(err)
+
+	// Output:
+	// a.com
+	// 443
+	// info
+	// maputil: path not found: servers[1]
+}
+
+func ExampleSetByPath() {
+	config := map[string]any{}
+
+	SetByPath(config, "db.hosts[1]", "db2")
+	SetByPath(config, "db.port", 5432)
+
+	result, _ := json.Marshal(config)
+
+	fmt.Println(string(result))
+
+	// Output:
+	// {"db":{"hosts":[null,"db2"],"port":5432}}
+}
+
+func ExampleDeleteByPath() {
+	config := map[string]any{
+		"db": map[string]any{"hosts": []any{"db1", "db2"}, "port": 5432},
+	}
+
+	DeleteByPath(config, "db.hosts[0]")
+	DeleteByPath(config, "db.port")
+
+	fmt.Println(config)
+
+	// Output:
+	// map[db:map[hosts:[db2]]]
+}
@@ -0,0 +1,196 @@
+package maputil
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func decodePathTestJSON(t *testing.T) map[string]any {
+	var m map[string]any
+	err := json.Unmarshal([]byte(`{
+		"server": {"host": "localhost", "ports": [80, 443]},
+		"users": [{"name": "a"}, {"name": "b", "tags": ["x", "y"]}],
+		"a.b": {"c[0]": 1}
+	}`), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestGetByPath(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGetByPath")
+
+	m := decodePathTestJSON(t)
+
+	tests := []struct {
+		path     string
+		expected any
+	}{
+		{"server.host", "localhost"},
+		{"server.ports[1]", float64(443)},
+		{"users[1].name", "b"},
+		{"users[1].tags[0]", "x"},
+		{`a\.b.c\[0\]`, float64(1)},
+		{"server.ports", []any{float64(80), float64(443)}},
+	}
+
+	for _, tt := range tests {
+		value, err := GetByPath(m, tt.path)
+		assert.IsNil(err)
+		assert.Equal(tt.expected, value)
+	}
+
+	errTests := []struct {
+		path string
+		err  error
+		msg  string
+	}{
+		{"server.name", ErrPathNotFound, "maputil: path not found: server.name"},
+		{"users[2].name", ErrPathNotFound, "maputil: path not found: users[2]"},
+		{"server.host.name", ErrPathTypeMismatch, "maputil: path type mismatch: server.host.name"},
+		{"server[0]", ErrPathTypeMismatch, "maputil: path type mismatch: server[0]"},
+		{`a\.b.x`, ErrPathNotFound, `maputil: path not found: a\.b.x`},
+	}
+
+	for _, tt := range errTests {
+		_, err := GetByPath(m, tt.path)
+		assert.Equal(true, errors.Is(err, tt.err))
+		assert.Equal(tt.msg, err.Error())
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestParsePath")
+
+	segments, err := parsePath(`a[0][12].b\\c\]`)
+	assert.IsNil(err)
+	assert.Equal([]pathSegment{
+		{key: "a"},
+		{index: 0, isIndex: true},
+		{index: 12, isIndex: true},
+		{key: `b\c]`},
+	}, segments)
+	assert.Equal(`a[0][12].b\\c\]`, formatPath(segments))
+
+	for _, path := range []string{"", ".a", "a.", "a..b", "[0]", "a[", "a[]", "a[-1]", "a[+1]", "a[x]", "a]", "a[0]b", `a\`} {
+		_, err := parsePath(path)
+		assert.Equal(true, errors.Is(err, ErrInvalidPath))
+	}
+}
+
+func TestSetByPath(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSetByPath")
+
+	m := decodePathTestJSON(t)
+
+	assert.IsNil(SetByPath(m, "server.host", "example.com"))
+	assert.IsNil(SetByPath(m, "server.ports[0]", 8080))
+	assert.IsNil(SetByPath(m, "server.ports[3]", 9090))
+	assert.IsNil(SetByPath(m, "users[0].tags[1]", "z"))
+	assert.IsNil(SetByPath(m, "db.primary.hosts[0].name", "db1"))
+	assert.IsNil(SetByPath(m, `a\.b.d`, 2))
+
+	for path, expected := range map[string]any{
+		"server.host":   "example.com",
+		"server.ports":  []any{8080, float64(443), nil, 9090},
+		"users[0].tags": []any{nil, "z"},
+		"users[0].name": "a",
+		"db":            map[string]any{"primary": map[string]any{"hosts": []any{map[string]any{"name": "db1"}}}},
+		`a\.b`:          map[string]any{"c[0]": float64(1), "d": 2},
+	} {
+		value, err := GetByPath(m, path)
+		assert.IsNil(err)
+		assert.Equal(expected, value)
+	}
+
+	// m is not changed if the path conflicts
+	err := SetByPath(m, "server.host.name", "x")
+	assert.Equal(true, errors.Is(err, ErrPathTypeMismatch))
+	assert.Equal("maputil: path type mismatch: server.host is string", err.Error())
+
+	err = SetByPath(m, "server.ports[0].x", "x")
+	assert.Equal(true, errors.Is(err, ErrPathTypeMismatch))
+	assert.Equal("maputil: path type mismatch: server.ports[0] is int", err.Error())
+
+	err = SetByPath(m, "users.name", "x")
+	assert.Equal(true, errors.Is(err, ErrPathTypeMismatch))
+
+	err = SetByPath(nil, "a", 1)
+	assert.Equal(true, errors.Is(err, ErrPathTypeMismatch))
+
+	err = SetByPath(m, "a..b", 1)
+	assert.Equal(true, errors.Is(err, ErrInvalidPath))
+}
+
+func TestSetByPath_Slice(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSetByPath_Slice")
+
+	m := map[string]any{"list": []any{1, "a"}}
+
+	err := SetByPath(m, "list[5].x", 1)
+	assert.IsNil(err)
+	assert.Equal([]any{1, "a", nil, nil, nil, map[string]any{"x": 1}}, m["list"])
+
+	err = SetByPath(m, "list[1].x", 1)
+	assert.Equal(true, errors.Is(err, ErrPathTypeMismatch))
+	assert.Equal(6, len(m["list"].([]any)))
+
+	err = SetByPath(m, "list[5].y[1]", 2)
+	assert.IsNil(err)
+	assert.Equal(map[string]any{"x": 1, "y": []any{nil, 2}}, m["list"].([]any)[5])
+}
+
+func TestDeleteByPath(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeleteByPath")
+
+	m := decodePathTestJSON(t)
+	ports := m["server"].(map[string]any)["ports"].([]any)
+
+	assert.IsNil(DeleteByPath(m, "server.ports[0]"))
+	assert.IsNil(DeleteByPath(m, "users[1].tags[1]"))
+	assert.IsNil(DeleteByPath(m, "users[0]"))
+	assert.IsNil(DeleteByPath(m, `a\.b.c\[0\]`))
+
+	value, _ := GetByPath(m, "server.ports")
+	assert.Equal([]any{float64(443)}, value)
+	// the original slice is not changed
+	assert.Equal([]any{float64(80), float64(443)}, ports)
+
+	value, _ = GetByPath(m, "users")
+	assert.Equal([]any{map[string]any{"name": "b", "tags": []any{"x"}}}, value)
+
+	value, _ = GetByPath(m, `a\.b`)
+	assert.Equal(map[string]any{}, value)
+
+	err := DeleteByPath(m, "server.name")
+	assert.Equal(true, errors.Is(err, ErrPathNotFound))
+	assert.Equal("maputil: path not found: server.name", err.Error())
+
+	err = DeleteByPath(m, "server.ports[1]")
+	assert.Equal(true, errors.Is(err, ErrPathNotFound))
+
+	err = DeleteByPath(m, "server.host.name")
+	assert.Equal(true, errors.Is(err, ErrPathTypeMismatch))
+
+	err = DeleteByPath(m, "db.host")
+	assert.Equal(true, errors.Is(err, ErrPathNotFound))
+	assert.Equal("maputil: path not found: db", err.Error())
+
+	err = DeleteByPath(m, "a[")
+	assert.Equal(true, errors.Is(err, ErrInvalidPath))
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+//go:build go1.23
+
+package maputil
+
+import "iter"
+
+// All returns an iterator of the key and value pairs like Range, works with the iterator based APIs of the
+// standard library.
+func (mm *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return mm.Range
+}
+
+// Groups returns an iterator of the keys and their values, the value slices should not be modified.
+func (mm *MultiMap[K, V]) Groups() iter.Seq2[K, []V] {
+	return func(yield func(key K, values []V) bool) {
+		for k, values := range mm.data {
+			if !yield(k, values) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator of the key and value pairs like Range, works with the iterator based APIs of the
+// standard library, e.g. maps.Collect(bm.All()).
+func (bm *BiMap[K, V]) All() iter.Seq2[K, V] {
+	return bm.Range
+}
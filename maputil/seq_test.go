@@ -0,0 +1,40 @@
+//go:build go1.23
+
+package maputil
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestMultiMap_Seq(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMultiMap_Seq")
+
+	mm := NewMultiMapFromMap(map[string][]int{"a": {1, 2}, "b": {3}})
+
+	sum := 0
+	for _, v := range mm.All() {
+		sum += v
+	}
+	assert.Equal(6, sum)
+
+	assert.Equal(map[string][]int{"a": {1, 2}, "b": {3}}, maps.Collect(mm.Groups()))
+
+	for range mm.Groups() {
+		break
+	}
+}
+
+func TestBiMap_Seq(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBiMap_Seq")
+
+	bm, _ := NewBiMapFromMap(map[string]int{"a": 1, "b": 2})
+
+	assert.Equal(map[string]int{"a": 1, "b": 2}, maps.Collect(bm.All()))
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package mathutil
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// BigFactorial calculate n! as big.Int, it never overflows.
+func BigFactorial(n uint) *big.Int {
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// Permutation calculate the number of ordered arrangements of k items from n items, n!/(n-k)!.
+// It's 0 if k < 0 or k > n. ok is false if the result overflows int64, use BigPermutation instead.
+func Permutation(n, k int) (result int64, ok bool) {
+	if n < 0 || k < 0 || k > n {
+		return 0, true
+	}
+
+	var r uint64 = 1
+	for i := n - k + 1; i <= n; i++ {
+		hi, lo := bits.Mul64(r, uint64(i))
+		if hi != 0 || lo > math.MaxInt64 {
+			return 0, false
+		}
+		r = lo
+	}
+
+	return int64(r), true
+}
+
+// BigPermutation calculate n!/(n-k)! as big.Int, it's 0 if k < 0 or k > n.
+func BigPermutation(n, k int) *big.Int {
+	if n < 0 || k < 0 || k > n {
+		return big.NewInt(0)
+	}
+
+	return new(big.Int).MulRange(int64(n-k+1), int64(n))
+}
+
+// Combination calculate the number of ways to choose k items from n items, n!/(k!(n-k)!).
+// It's 0 if k < 0 or k > n. ok is false if the result overflows int64, use BigCombination instead.
+func Combination(n, k int) (result int64, ok bool) {
+	if n < 0 || k < 0 || k > n {
+		return 0, true
+	}
+	if k > n-k {
+		k = n - k
+	}
+
+	// r is C(n-k+i, i) after the i-th iteration, which is always an integer
+	var r uint64 = 1
+	for i := 1; i <= k; i++ {
+		hi, lo := bits.Mul64(r, uint64(n-k+i))
+		if hi >= uint64(i) {
+			return 0, false
+		}
+		q, _ := bits.Div64(hi, lo, uint64(i))
+		if q > math.MaxInt64 {
+			return 0, false
+		}
+		r = q
+	}
+
+	return int64(r), true
+}
+
+// BigCombination calculate n!/(k!(n-k)!) as big.Int, it's 0 if k < 0 or k > n.
+func BigCombination(n, k int) *big.Int {
+	if n < 0 || k < 0 || k > n {
+		return big.NewInt(0)
+	}
+
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// BinomialPMF calculate the probability of exactly k successes in n independent trials, and the
+// probability of success of each trial is p. It returns NaN if p is not in [0, 1].
+func BinomialPMF(n, k int, p float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	if n < 0 || k < 0 || k > n {
+		return 0
+	}
+
+	switch p {
+	case 0:
+		if k == 0 {
+			return 1
+		}
+		return 0
+	case 1:
+		if k == n {
+			return 1
+		}
+		return 0
+	}
+
+	// calculate in log space, so large n doesn't overflow
+	lnN, _ := math.Lgamma(float64(n + 1))
+	lnK, _ := math.Lgamma(float64(k + 1))
+	lnNK, _ := math.Lgamma(float64(n - k + 1))
+
+	return math.Exp(lnN - lnK - lnNK + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+}
+
+// BinomialCDF calculate the probability of at most k successes in n independent trials, and the
+// probability of success of each trial is p. It returns NaN if p is not in [0, 1].
+func BinomialCDF(n, k int, p float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	if n < 0 || k < 0 {
+		return 0
+	}
+	if k >= n {
+		return 1
+	}
+
+	sum := 0.0
+	for i := 0; i <= k; i++ {
+		sum += BinomialPMF(n, i, p)
+	}
+
+	return math.Min(sum, 1)
+}
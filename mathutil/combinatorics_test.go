@@ -0,0 +1,113 @@
+package mathutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestBigFactorial(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBigFactorial")
+
+	assert.Equal("1", BigFactorial(0).String())
+	assert.Equal("120", BigFactorial(5).String())
+	assert.Equal("2432902008176640000", BigFactorial(20).String())
+	assert.Equal("51090942171709440000", BigFactorial(21).String())
+	assert.Equal("30414093201713378043612608166064768844377641568960512000000000000", BigFactorial(50).String())
+}
+
+func TestPermutation(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPermutation")
+
+	tests := []struct {
+		n, k     int
+		expected int64
+	}{
+		{5, 0, 1},
+		{5, 2, 20},
+		{5, 5, 120},
+		{20, 20, 2432902008176640000},
+		{3, 4, 0},
+		{3, -1, 0},
+	}
+	for _, tt := range tests {
+		r, ok := Permutation(tt.n, tt.k)
+		assert.Equal(true, ok)
+		assert.Equal(tt.expected, r)
+		assert.Equal(tt.expected, BigPermutation(tt.n, tt.k).Int64())
+	}
+
+	_, ok := Permutation(21, 21)
+	assert.Equal(false, ok)
+	assert.Equal("51090942171709440000", BigPermutation(21, 21).String())
+	assert.Equal("9900", BigPermutation(100, 2).String())
+}
+
+func TestCombination(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCombination")
+
+	tests := []struct {
+		n, k     int
+		expected int64
+	}{
+		{5, 0, 1},
+		{5, 2, 10},
+		{5, 5, 1},
+		{52, 5, 2598960},
+		{66, 33, 7219428434016265740},
+		{3, 4, 0},
+		{-1, 0, 0},
+	}
+	for _, tt := range tests {
+		r, ok := Combination(tt.n, tt.k)
+		assert.Equal(true, ok)
+		assert.Equal(tt.expected, r)
+		assert.Equal(tt.expected, BigCombination(tt.n, tt.k).Int64())
+	}
+
+	_, ok := Combination(68, 34)
+	assert.Equal(false, ok)
+	assert.Equal("28453041475240576740", BigCombination(68, 34).String())
+
+	// the result is checked against the big version
+	for n := 0; n <= 70; n++ {
+		for k := 0; k <= n; k++ {
+			r, ok := Combination(n, k)
+			big := BigCombination(n, k)
+			assert.Equal(big.IsInt64(), ok)
+			if ok {
+				assert.Equal(big.Int64(), r)
+			}
+		}
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestBinomial")
+
+	assert.Equal(0.3125, RoundToFloat(BinomialPMF(5, 2, 0.5), 10))
+	assert.Equal(0.5, RoundToFloat(BinomialCDF(5, 2, 0.5), 10))
+	assert.Equal(1.0, BinomialCDF(5, 5, 0.3))
+	assert.Equal(1.0, BinomialPMF(0, 0, 0.3))
+	assert.Equal(0.0, BinomialPMF(5, 6, 0.3))
+	assert.Equal(1.0, BinomialPMF(5, 0, 0))
+	assert.Equal(1.0, BinomialPMF(5, 5, 1))
+	assert.Equal(0.0, BinomialPMF(5, 4, 1))
+	assert.Equal(true, math.IsNaN(BinomialPMF(5, 2, 1.5)))
+	assert.Equal(true, math.IsNaN(BinomialCDF(5, 2, -0.1)))
+
+	sum := 0.0
+	for k := 0; k <= 1000; k++ {
+		sum += BinomialPMF(1000, k, 0.3)
+	}
+	assert.Equal(1.0, RoundToFloat(sum, 8))
+}
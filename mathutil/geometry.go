@@ -0,0 +1,82 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package mathutil
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// DegToRad converts degrees to radians, it's the generic version of AngleToRadian.
+func DegToRad[T constraints.Float](deg T) T {
+	return deg * (math.Pi / 180)
+}
+
+// RadToDeg converts radians to degrees, it's the generic version of RadianToAngle.
+func RadToDeg[T constraints.Float](rad T) T {
+	return rad * (180 / math.Pi)
+}
+
+// AngleBetween returns the angle in radians between vector (x1, y1) and vector (x2, y2), which is in [0, π].
+// It's 0 if either vector is zero.
+func AngleBetween[T constraints.Float](x1, y1, x2, y2 T) T {
+	cross := float64(x1)*float64(y2) - float64(y1)*float64(x2)
+	dot := float64(x1)*float64(x2) + float64(y1)*float64(y2)
+
+	// atan2 is more accurate than acos of the normalized dot product for small angles
+	return T(math.Atan2(math.Abs(cross), dot))
+}
+
+// Distance2D returns the euclidean distance between point (x1, y1) and point (x2, y2).
+func Distance2D[T constraints.Float](x1, y1, x2, y2 T) T {
+	return T(math.Hypot(float64(x2-x1), float64(y2-y1)))
+}
+
+// Distance3D returns the euclidean distance between point (x1, y1, z1) and point (x2, y2, z2).
+func Distance3D[T constraints.Float](x1, y1, z1, x2, y2, z2 T) T {
+	return T(math.Hypot(math.Hypot(float64(x2-x1), float64(y2-y1)), float64(z2-z1)))
+}
+
+// PointInRect checks if point (px, py) is in the axis-aligned rectangle with diagonal corners
+// (x1, y1) and (x2, y2), which could be in any order. Points on the edges are in the rectangle.
+func PointInRect[T constraints.Float](px, py, x1, y1, x2, y2 T) bool {
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+
+	return px >= x1 && px <= x2 && py >= y1 && py <= y2
+}
+
+// PointInCircle checks if point (px, py) is in the circle with center (cx, cy) and radius r.
+// Points on the circumference are in the circle.
+func PointInCircle[T constraints.Float](px, py, cx, cy, r T) bool {
+	if r < 0 {
+		return false
+	}
+
+	dx, dy := px-cx, py-cy
+
+	return dx*dx+dy*dy <= r*r
+}
+
+// LineIntersection returns the intersection point of the line through (x1, y1) and (x2, y2) and the
+// line through (x3, y3) and (x4, y4). ok is false if the lines are parallel, coincident or degenerate.
+func LineIntersection[T constraints.Float](x1, y1, x2, y2, x3, y3, x4, y4 T) (x, y T, ok bool) {
+	denominator := (x1-x2)*(y3-y4) - (y1-y2)*(x3-x4)
+	if denominator == 0 {
+		return 0, 0, false
+	}
+
+	a := x1*y2 - y1*x2
+	b := x3*y4 - y3*x4
+
+	x = (a*(x3-x4) - (x1-x2)*b) / denominator
+	y = (a*(y3-y4) - (y1-y2)*b) / denominator
+
+	return x, y, true
+}
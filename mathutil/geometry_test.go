@@ -0,0 +1,91 @@
+package mathutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestDegToRad(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDegToRad")
+
+	assert.Equal(math.Pi, DegToRad(180.0))
+	assert.Equal(float32(math.Pi/2), DegToRad(float32(90)))
+	assert.Equal(180.0, RadToDeg(math.Pi))
+	assert.Equal(45.0, RoundToFloat(RadToDeg(DegToRad(45.0)), 10))
+}
+
+func TestAngleBetween(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAngleBetween")
+
+	assert.Equal(math.Pi/2, AngleBetween(1.0, 0, 0, 1))
+	assert.Equal(math.Pi/2, AngleBetween(1.0, 0, 0, -1))
+	assert.Equal(math.Pi, AngleBetween(1.0, 0, -2, 0))
+	assert.Equal(0.0, AngleBetween(1.0, 1, 3, 3))
+	assert.Equal(math.Pi/4, AngleBetween(2.0, 0, 1, 1))
+	assert.Equal(0.0, AngleBetween(0.0, 0, 1, 1))
+}
+
+func TestDistance(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDistance")
+
+	assert.Equal(5.0, Distance2D(0.0, 0, 3, 4))
+	assert.Equal(float32(5), Distance2D(float32(1), 1, 4, 5))
+	assert.Equal(0.0, Distance2D(1.0, 1, 1, 1))
+	assert.Equal(3.0, Distance3D(0.0, 0, 0, 1, 2, 2))
+	assert.Equal(13.0, Distance3D(1.0, 1, 1, 4, 5, 13))
+}
+
+func TestPointInRect(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPointInRect")
+
+	assert.Equal(true, PointInRect(1.0, 1, 0, 0, 2, 2))
+	assert.Equal(true, PointInRect(1.0, 1, 2, 2, 0, 0))
+	assert.Equal(true, PointInRect(2.0, 0, 0, 0, 2, 2))
+	assert.Equal(false, PointInRect(2.1, 1, 0, 0, 2, 2))
+	assert.Equal(false, PointInRect(1.0, -1, 0, 2, 2, 0))
+}
+
+func TestPointInCircle(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPointInCircle")
+
+	assert.Equal(true, PointInCircle(0.0, 0, 0, 0, 1))
+	assert.Equal(true, PointInCircle(3.0, 4, 0, 0, 5))
+	assert.Equal(false, PointInCircle(3.0, 4.1, 0, 0, 5))
+	assert.Equal(true, PointInCircle(2.0, 2, 2, 2, 0))
+	assert.Equal(false, PointInCircle(2.0, 2, 2, 2, -1))
+}
+
+func TestLineIntersection(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLineIntersection")
+
+	x, y, ok := LineIntersection(0.0, 0, 2, 2, 0, 2, 2, 0)
+	assert.Equal(true, ok)
+	assert.Equal(1.0, x)
+	assert.Equal(1.0, y)
+
+	// the intersection could be outside the segments
+	x, y, ok = LineIntersection(0.0, 0, 1, 0, 3, 1, 3, 2)
+	assert.Equal(true, ok)
+	assert.Equal(3.0, x)
+	assert.Equal(0.0, y)
+
+	_, _, ok = LineIntersection(0.0, 0, 1, 1, 0, 1, 1, 2)
+	assert.Equal(false, ok)
+
+	_, _, ok = LineIntersection(0.0, 0, 0, 0, 0, 1, 1, 2)
+	assert.Equal(false, ok)
+}
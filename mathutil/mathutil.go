@@ -395,3 +395,112 @@ func Abs[T constraints.Integer | constraints.Float](x T) T {
 func Div[T constraints.Float | constraints.Integer](x T, y T) float64 {
 	return float64(x) / float64(y)
 }
+
+// RoundingMode is the rounding mode of RoundWithMode.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to nearest, and half away from zero, e.g. 2.5 -> 3, -2.5 -> -3.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfDown rounds to nearest, and half towards zero, e.g. 2.5 -> 2, -2.5 -> -2.
+	RoundHalfDown
+	// RoundHalfEven rounds to nearest, and half to even, also known as banker's rounding, e.g. 2.5 -> 2, 3.5 -> 4.
+	RoundHalfEven
+	// RoundCeiling rounds towards positive infinity, e.g. 2.1 -> 3, -2.9 -> -2.
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity, e.g. 2.9 -> 2, -2.1 -> -3.
+	RoundFloor
+	// RoundTruncate rounds towards zero, e.g. 2.9 -> 2, -2.9 -> -2.
+	RoundTruncate
+)
+
+// RoundWithMode round off x to n decimal places with rounding mode, n could be negative to round to
+// tens, hundreds and etc. The rounding is done on the shortest decimal representation of x,
+// so RoundWithMode(2.675, 2, RoundHalfUp) is 2.68, although 2.675 is 2.67499999... in binary.
+func RoundWithMode[T constraints.Float | constraints.Integer](x T, n int, mode RoundingMode) float64 {
+	bitSize := 64
+	if _, ok := any(x).(float32); ok {
+		bitSize = 32
+	}
+
+	f := float64(x)
+	if math.IsNaN(f) || math.IsInf(f, 0) || f == 0 {
+		return f
+	}
+
+	neg := f < 0
+	s := strconv.FormatFloat(math.Abs(f), 'f', -1, bitSize)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+
+	digits := intPart + fracPart
+	point := len(intPart)
+	keep := point + n
+	if keep >= len(digits) {
+		return f
+	}
+	if keep < 0 {
+		digits = strings.Repeat("0", -keep) + digits
+		point -= keep
+		keep = 0
+	}
+
+	retained, dropped := []byte(digits[:keep]), digits[keep:]
+	if strings.Trim(dropped, "0") == "" {
+		return f
+	}
+
+	exactHalf := dropped[0] == '5' && strings.Trim(dropped[1:], "0") == ""
+	aboveHalf := dropped[0] > '5' || (dropped[0] == '5' && !exactHalf)
+
+	var increase bool
+	switch mode {
+	case RoundHalfUp:
+		increase = dropped[0] >= '5'
+	case RoundHalfDown:
+		increase = aboveHalf
+	case RoundHalfEven:
+		lastOdd := len(retained) > 0 && (retained[len(retained)-1]-'0')%2 == 1
+		increase = aboveHalf || (exactHalf && lastOdd)
+	case RoundCeiling:
+		increase = !neg
+	case RoundFloor:
+		increase = neg
+	case RoundTruncate:
+		increase = false
+	}
+
+	if increase {
+		i := len(retained) - 1
+		for ; i >= 0; i-- {
+			if retained[i] < '9' {
+				retained[i]++
+				break
+			}
+			retained[i] = '0'
+		}
+		if i < 0 {
+			retained = append([]byte{'1'}, retained...)
+			point++
+		}
+	}
+
+	var result string
+	if n >= 0 {
+		result = string(retained[:point]) + "." + string(retained[point:])
+	} else {
+		result = string(retained) + strings.Repeat("0", -n)
+	}
+	if result == "" || result == "." {
+		return 0
+	}
+
+	r, _ := strconv.ParseFloat(result, 64)
+	if r == 0 {
+		return 0
+	}
+	if neg {
+		return -r
+	}
+
+	return r
+}
@@ -478,3 +478,95 @@ func ExampleDiv() {
 	// 0.5
 	// 0
 }
+
+func ExampleRoundWithMode() {
+	result1 := RoundWithMode(2.5, 0, RoundHalfEven)
+	result2 := RoundWithMode(3.5, 0, RoundHalfEven)
+	result3 := RoundWithMode(2.675, 2, RoundHalfUp)
+	result4 := RoundWithMode(-2.1, 0, RoundFloor)
+	result5 := RoundWithMode(2.99, 1, RoundTruncate)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(result3)
+	fmt.Println(result4)
+	fmt.Println(result5)
+
+	// Output:
+	// 2
+	// 4
+	// 2.68
+	// -3
+	// 2.9
+}
+
+func ExampleCombination() {
+	result1, ok1 := Combination(52, 5)
+	_, ok2 := Combination(100, 50)
+	result3 := BigCombination(100, 50)
+
+	fmt.Println(result1, ok1)
+	fmt.Println(ok2)
+	fmt.Println(result3)
+
+	// Output:
+	// 2598960 true
+	// false
+	// 100891344545564193334812497256
+}
+
+func ExampleSMA() {
+	result1 := SMA([]int{1, 2, 3, 4, 5}, 3)
+	result2 := SMA([]int{1, 2, 3}, 4)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// [2 3 4]
+	// []
+}
+
+func ExampleEMA() {
+	result := EMA([]int{2, 4, 7, 8}, 3)
+
+	fmt.Println(result)
+
+	// Output:
+	// [2 3 5 6.5]
+}
+
+func ExampleRollingMax() {
+	numbers := []int{1, 3, -1, -3, 5, 3, 6, 7}
+
+	fmt.Println(RollingMax(numbers, 3))
+	fmt.Println(RollingMin(numbers, 3))
+
+	// Output:
+	// [3 3 5 5 6 7]
+	// [-1 -3 -3 -3 3 3]
+}
+
+func ExampleDistance2D() {
+	result1 := Distance2D(0.0, 0, 3, 4)
+	result2 := Distance3D(0.0, 0, 0, 1, 2, 2)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// 5
+	// 3
+}
+
+func ExampleLineIntersection() {
+	x, y, ok := LineIntersection(0.0, 0, 2, 2, 0, 2, 2, 0)
+	_, _, parallel := LineIntersection(0.0, 0, 1, 1, 0, 1, 1, 2)
+
+	fmt.Println(x, y, ok)
+	fmt.Println(parallel)
+
+	// Output:
+	// 1 1 true
+	// false
+}
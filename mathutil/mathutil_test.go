@@ -413,3 +413,56 @@ func TestDiv(t *testing.T) {
 	assert.Equal(math.Inf(-1), Div(-8, 0))
 	assert.Equal(true, math.IsNaN(Div(0, 0)))
 }
+
+func TestRoundWithMode(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRoundWithMode")
+
+	tests := []struct {
+		x        float64
+		n        int
+		mode     RoundingMode
+		expected float64
+	}{
+		{2.5, 0, RoundHalfUp, 3},
+		{-2.5, 0, RoundHalfUp, -3},
+		{2.675, 2, RoundHalfUp, 2.68},
+		{1.005, 2, RoundHalfUp, 1.01},
+		{2.5, 0, RoundHalfDown, 2},
+		{-2.5, 0, RoundHalfDown, -2},
+		{2.51, 0, RoundHalfDown, 3},
+		{2.5, 0, RoundHalfEven, 2},
+		{3.5, 0, RoundHalfEven, 4},
+		{-2.5, 0, RoundHalfEven, -2},
+		{0.125, 2, RoundHalfEven, 0.12},
+		{0.135, 2, RoundHalfEven, 0.14},
+		{0.1251, 2, RoundHalfEven, 0.13},
+		{0.5, 0, RoundHalfEven, 0},
+		{2.1, 0, RoundCeiling, 3},
+		{-2.9, 0, RoundCeiling, -2},
+		{-0.1, 0, RoundCeiling, 0},
+		{2.9, 0, RoundFloor, 2},
+		{-2.1, 0, RoundFloor, -3},
+		{2.99, 1, RoundTruncate, 2.9},
+		{-2.99, 1, RoundTruncate, -2.9},
+		{9.99, 1, RoundHalfUp, 10},
+		{99.5, 0, RoundCeiling, 100},
+		{1234.5, -2, RoundHalfUp, 1200},
+		{1250, -2, RoundHalfEven, 1200},
+		{1350, -2, RoundHalfEven, 1400},
+		{42, -3, RoundCeiling, 1000},
+		{42, -3, RoundHalfUp, 0},
+		{1.23, 5, RoundHalfUp, 1.23},
+		{0, 2, RoundCeiling, 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, RoundWithMode(tt.x, tt.n, tt.mode))
+	}
+
+	assert.Equal(2.68, RoundWithMode(float32(2.675), 2, RoundHalfUp))
+	assert.Equal(float64(120), RoundWithMode(125, -1, RoundHalfEven))
+	assert.Equal(true, math.IsNaN(RoundWithMode(math.NaN(), 2, RoundHalfUp)))
+	assert.Equal(true, math.IsInf(RoundWithMode(math.Inf(1), 2, RoundHalfUp), 1))
+}
@@ -0,0 +1,173 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package mathutil
+
+import "golang.org/x/exp/constraints"
+
+// SMA calculate the simple moving average of numbers over window, the i-th result is the average of
+// numbers[i:i+window], so the length of result is len(numbers)-window+1. It returns empty slice if
+// window is not in [1, len(numbers)].
+func SMA[T constraints.Integer | constraints.Float](numbers []T, window int) []float64 {
+	if window <= 0 || window > len(numbers) {
+		return []float64{}
+	}
+
+	result := make([]float64, 0, len(numbers)-window+1)
+
+	var sum float64
+	for i, v := range numbers {
+		sum += float64(v)
+		if i >= window {
+			sum -= float64(numbers[i-window])
+		}
+		if i >= window-1 {
+			result = append(result, sum/float64(window))
+		}
+	}
+
+	return result
+}
+
+// WMA calculate the linearly weighted moving average of numbers over window, in each window the weight
+// of the oldest number is 1 and the latest is window. The length of result is len(numbers)-window+1,
+// it returns empty slice if window is not in [1, len(numbers)].
+func WMA[T constraints.Integer | constraints.Float](numbers []T, window int) []float64 {
+	if window <= 0 || window > len(numbers) {
+		return []float64{}
+	}
+
+	result := make([]float64, 0, len(numbers)-window+1)
+	denominator := float64(window*(window+1)) / 2
+
+	// sum is the plain sum of the window and weighted is the weighted sum, when the window slides,
+	// the weight of every number in it decreases by 1, so weighted decreases by sum.
+	var sum, weighted float64
+	for i, v := range numbers {
+		if i < window {
+			sum += float64(v)
+			weighted += float64(i+1) * float64(v)
+		} else {
+			weighted += float64(window)*float64(v) - sum
+			sum += float64(v) - float64(numbers[i-window])
+		}
+		if i >= window-1 {
+			result = append(result, weighted/denominator)
+		}
+	}
+
+	return result
+}
+
+// EMA calculate the exponential moving average of numbers with smoothing factor 2/(period+1), the first
+// result is the first number. The length of result is len(numbers), it returns empty slice if period
+// is not positive.
+func EMA[T constraints.Integer | constraints.Float](numbers []T, period int) []float64 {
+	if period <= 0 {
+		return []float64{}
+	}
+
+	result := make([]float64, len(numbers))
+
+	ema := NewStreamEMA(period)
+	for i, v := range numbers {
+		result[i] = ema.Add(float64(v))
+	}
+
+	return result
+}
+
+// RollingMax returns the max value of each window of numbers, the i-th result is the max of
+// numbers[i:i+window]. It takes O(n) time by monotonic deque, and returns empty slice if window is not
+// in [1, len(numbers)].
+func RollingMax[T constraints.Integer | constraints.Float](numbers []T, window int) []T {
+	return rolling(numbers, window, func(a, b T) bool { return a >= b })
+}
+
+// RollingMin returns the min value of each window of numbers, the i-th result is the min of
+// numbers[i:i+window]. It takes O(n) time by monotonic deque, and returns empty slice if window is not
+// in [1, len(numbers)].
+func RollingMin[T constraints.Integer | constraints.Float](numbers []T, window int) []T {
+	return rolling(numbers, window, func(a, b T) bool { return a <= b })
+}
+
+// rolling keeps the indexes of numbers in deque, the numbers of which are monotonic by keep,
+// so the head of deque is the result of the window.
+func rolling[T constraints.Integer | constraints.Float](numbers []T, window int, keep func(a, b T) bool) []T {
+	if window <= 0 || window > len(numbers) {
+		return []T{}
+	}
+
+	result := make([]T, 0, len(numbers)-window+1)
+	deque := make([]int, 0, window)
+
+	for i, v := range numbers {
+		if len(deque) > 0 && deque[0] <= i-window {
+			deque = deque[1:]
+		}
+		for len(deque) > 0 && !keep(numbers[deque[len(deque)-1]], v) {
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, i)
+
+		if i >= window-1 {
+			result = append(result, numbers[deque[0]])
+		}
+	}
+
+	return result
+}
+
+// StreamEMA calculates the exponential moving average of a stream of numbers, e.g. metrics sampled
+// periodically. It's not safe for concurrent use.
+type StreamEMA struct {
+	alpha float64
+	value float64
+	count int
+}
+
+// NewStreamEMA creates a StreamEMA with smoothing factor 2/(period+1), period should be positive.
+func NewStreamEMA(period int) *StreamEMA {
+	if period <= 0 {
+		panic("mathutil: period of ema should be positive")
+	}
+
+	return NewStreamEMAWithAlpha(2 / float64(period+1))
+}
+
+// NewStreamEMAWithAlpha creates a StreamEMA with smoothing factor alpha, which should be in (0, 1].
+func NewStreamEMAWithAlpha(alpha float64) *StreamEMA {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("mathutil: alpha of ema should be in (0, 1]")
+	}
+
+	return &StreamEMA{alpha: alpha}
+}
+
+// Add adds a number to the stream and returns the current average, the first number is the initial average.
+func (e *StreamEMA) Add(x float64) float64 {
+	if e.count == 0 {
+		e.value = x
+	} else {
+		e.value += e.alpha * (x - e.value)
+	}
+	e.count++
+
+	return e.value
+}
+
+// Value returns the current average, it's 0 if no number is added.
+func (e *StreamEMA) Value() float64 {
+	return e.value
+}
+
+// Count returns the count of numbers added.
+func (e *StreamEMA) Count() int {
+	return e.count
+}
+
+// Reset clears the stream, the alpha is kept.
+func (e *StreamEMA) Reset() {
+	e.value = 0
+	e.count = 0
+}
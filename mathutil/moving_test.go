@@ -0,0 +1,102 @@
+package mathutil
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestSMA(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSMA")
+
+	assert.Equal([]float64{2, 3, 4}, SMA([]int{1, 2, 3, 4, 5}, 3))
+	assert.Equal([]float64{1.5, 2.5}, SMA([]float64{1, 2, 3}, 2))
+	assert.Equal([]float64{3}, SMA([]int{1, 2, 3, 4, 5}, 5))
+	assert.Equal([]float64{}, SMA([]int{1, 2, 3}, 4))
+	assert.Equal([]float64{}, SMA([]int{1, 2, 3}, 0))
+	assert.Equal([]float64{}, SMA([]int{}, 1))
+}
+
+func TestWMA(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWMA")
+
+	// (1*1+2*2+3*3)/6, (1*2+2*3+3*4)/6, (1*3+2*4+3*5)/6
+	result := WMA([]int{1, 2, 3, 4, 5}, 3)
+	assert.Equal(3, len(result))
+	assert.Equal(2.333333, RoundToFloat(result[0], 6))
+	assert.Equal(3.333333, RoundToFloat(result[1], 6))
+	assert.Equal(4.333333, RoundToFloat(result[2], 6))
+
+	assert.Equal([]float64{5, 1}, WMA([]int{5, 1}, 1))
+	assert.Equal([]float64{}, WMA([]int{1}, 2))
+}
+
+func TestEMA(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEMA")
+
+	// alpha is 0.5
+	assert.Equal([]float64{2, 3, 5, 6.5}, EMA([]int{2, 4, 7, 8}, 3))
+	assert.Equal([]float64{1, 2, 3}, EMA([]float64{1, 2, 3}, 1))
+	assert.Equal([]float64{}, EMA([]int{}, 3))
+	assert.Equal([]float64{}, EMA([]int{1, 2}, 0))
+}
+
+func TestRollingMinMax(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRollingMinMax")
+
+	numbers := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	assert.Equal([]int{3, 3, 5, 5, 6, 7}, RollingMax(numbers, 3))
+	assert.Equal([]int{-1, -3, -3, -3, 3, 3}, RollingMin(numbers, 3))
+	assert.Equal(numbers, RollingMax(numbers, 1))
+	assert.Equal([]float64{2.5}, RollingMin([]float64{3, 2.5, 4}, 3))
+	assert.Equal([]int{}, RollingMax(numbers, 9))
+
+	// compare with brute force
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 200)
+	for i := range data {
+		data[i] = r.Intn(20)
+	}
+	for _, window := range []int{1, 2, 5, 17, 200} {
+		maxs, mins := RollingMax(data, window), RollingMin(data, window)
+		for i := 0; i+window <= len(data); i++ {
+			assert.Equal(Max(data[i:i+window]...), maxs[i])
+			assert.Equal(Min(data[i:i+window]...), mins[i])
+		}
+	}
+}
+
+func TestStreamEMA(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStreamEMA")
+
+	ema := NewStreamEMA(3)
+	assert.Equal(0.0, ema.Value())
+	assert.Equal(2.0, ema.Add(2))
+	assert.Equal(3.0, ema.Add(4))
+	assert.Equal(3.0, ema.Value())
+	assert.Equal(2, ema.Count())
+
+	ema.Reset()
+	assert.Equal(0, ema.Count())
+	assert.Equal(10.0, ema.Add(10))
+
+	ema = NewStreamEMAWithAlpha(0.1)
+	ema.Add(0)
+	assert.Equal(1.0, ema.Add(10))
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	NewStreamEMAWithAlpha(0)
+}
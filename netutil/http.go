@@ -109,6 +109,8 @@ type HttpClientConfig struct {
 	ResponseTimeout  time.Duration
 	Verbose          bool
 	Proxy            *url.URL
+	// Cache caches the responses by CachingTransport if it's not nil.
+	Cache HttpCache
 }
 
 // defaultHttpClientConfig defalut client config.
@@ -162,6 +164,8 @@ func NewHttpClientWithConfig(config *HttpClientConfig) *HttpClient {
 
 	if config.SSLEnabled {
 		client.TLS = config.TLSConfig
+		transport := client.Client.Transport.(*http.Transport)
+		transport.TLSClientConfig = config.TLSConfig
 	}
 
 	if config.Proxy != nil {
@@ -169,6 +173,10 @@ func NewHttpClientWithConfig(config *HttpClientConfig) *HttpClient {
 		transport.Proxy = http.ProxyURL(config.Proxy)
 	}
 
+	if config.Cache != nil {
+		client.Client.Transport = NewCachingTransport(client.Client.Transport, config.Cache)
+	}
+
 	return client
 }
 
@@ -231,7 +239,11 @@ func (client *HttpClient) DecodeResponse(resp *http.Response, target any) error
 // setTLS set http client transport TLSClientConfig
 func (client *HttpClient) setTLS(rawUrl string) {
 	if strings.HasPrefix(rawUrl, "https") {
-		if transport, ok := client.Client.Transport.(*http.Transport); ok {
+		roundTripper := client.Client.Transport
+		if caching, ok := roundTripper.(*CachingTransport); ok {
+			roundTripper = caching.Transport
+		}
+		if transport, ok := roundTripper.(*http.Transport); ok {
 			transport.TLSClientConfig = client.TLS
 		}
 	}
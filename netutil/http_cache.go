@@ -0,0 +1,444 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package netutil
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
+)
+
+const (
+	// HttpCacheHeader is set to "1" in the responses served from cache, including the revalidated ones.
+	HttpCacheHeader = "X-From-Cache"
+
+	// cacheStoredAtHeader keeps the time the response is stored, it's removed from the served responses.
+	cacheStoredAtHeader = "X-Netutil-Cache-Stored-At"
+	// cacheVaryPrefix keeps the request headers listed by Vary, it's removed from the served responses.
+	cacheVaryPrefix = "X-Netutil-Cache-Vary-"
+)
+
+// HttpCache is the storage of CachingTransport, the values are the serialized responses. It should be safe for
+// concurrent use.
+type HttpCache interface {
+	// Get returns the value of key, ok is false if it doesn't exist.
+	Get(key string) (value []byte, ok bool)
+	// Set sets the value of key.
+	Set(key string, value []byte)
+	// Delete removes the value of key.
+	Delete(key string)
+}
+
+// MemoryHttpCache is a HttpCache in memory.
+type MemoryHttpCache struct {
+	mu    sync.RWMutex
+	items map[string][]byte
+}
+
+// NewMemoryHttpCache creates a MemoryHttpCache pointer instance.
+func NewMemoryHttpCache() *MemoryHttpCache {
+	return &MemoryHttpCache{items: make(map[string][]byte)}
+}
+
+// Get returns the value of key, ok is false if it doesn't exist.
+func (c *MemoryHttpCache) Get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, ok := c.items[key]
+
+	return value, ok
+}
+
+// Set sets the value of key.
+func (c *MemoryHttpCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[key] = value
+}
+
+// Delete removes the value of key.
+func (c *MemoryHttpCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.items, key)
+}
+
+// DirHttpCache is a HttpCache storing the values as files in a directory, so the cache is kept after restart.
+// The file name is the sha256 of key.
+type DirHttpCache struct {
+	dir string
+}
+
+// NewDirHttpCache creates a DirHttpCache pointer instance, the directory is created if it doesn't exist.
+func NewDirHttpCache(dir string) (*DirHttpCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &DirHttpCache{dir: dir}, nil
+}
+
+// Get returns the value of key, ok is false if it doesn't exist or can't be read.
+func (c *DirHttpCache) Get(key string) ([]byte, bool) {
+	value, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Set sets the value of key, the file is replaced atomically. The cache is best effort, the errors are ignored.
+func (c *DirHttpCache) Set(key string, value []byte) {
+	f, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+
+	_, err = f.Write(value)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// Delete removes the value of key.
+func (c *DirHttpCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+func (c *DirHttpCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// CachingTransport is a http.RoundTripper caching the responses of GET requests as a private cache, eg: the
+// browser cache. It respects the Cache-Control (max-age, no-cache, no-store), Expires and Vary headers, the fresh
+// responses are served from cache without sending request, and the stale responses are revalidated with
+// If-None-Match (ETag) and If-Modified-Since (Last-Modified), so the unchanged responses are not downloaded again.
+// The responses without freshness or validators are not cached. The successful POST, PUT, PATCH and DELETE requests
+// invalidate the cache of their urls.
+type CachingTransport struct {
+	// Transport sends the requests, http.DefaultTransport is used if it's nil.
+	Transport http.RoundTripper
+	// Cache stores the responses.
+	Cache HttpCache
+	// Clock computes the age and freshness of the cached responses, datetime.SystemClock is used if it's nil.
+	Clock datetime.Clock
+}
+
+// NewCachingTransport creates a CachingTransport pointer instance, it could be used as the transport of
+// http.Client, or the HttpClient by WithHttpCache.
+func NewCachingTransport(transport http.RoundTripper, cache HttpCache) *CachingTransport {
+	return &CachingTransport{
+		Transport: transport,
+		Cache:     cache,
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		resp, err := t.transport().RoundTrip(req)
+		if err == nil && isUnsafeMethod(req.Method) && resp.StatusCode < 400 {
+			t.Cache.Delete(cacheKey(req))
+		}
+		return resp, err
+	}
+
+	reqControl := parseCacheControl(req.Header)
+	if req.Method == http.MethodHead || req.Header.Get("Range") != "" || hasConditional(req) {
+		return t.transport().RoundTrip(req)
+	}
+	if _, ok := reqControl["no-store"]; ok {
+		return t.transport().RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached := t.load(key, req)
+	if cached != nil {
+		_, noCache := reqControl["no-cache"]
+		if !noCache && reqControl["max-age"] != "0" && t.isFresh(cached) {
+			return t.serve(cached, req), nil
+		}
+
+		etag, lastModified := cached.Header.Get("ETag"), cached.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			req = req.Clone(req.Context())
+			if etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if lastModified != "" {
+				req.Header.Set("If-Modified-Since", lastModified)
+			}
+		}
+	}
+
+	now := t.now()
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+
+		// the headers of 304 response update the cached response
+		for _, name := range []string{"Cache-Control", "Expires", "Date", "ETag", "Last-Modified", "Age"} {
+			if values, ok := resp.Header[name]; ok {
+				cached.Header[name] = values
+			}
+		}
+		cached.Header.Set(cacheStoredAtHeader, strconv.FormatInt(now.UnixNano(), 10))
+		t.save(key, req, cached)
+
+		return t.serve(cached, req), nil
+	}
+
+	if !isCacheable(resp) {
+		if cached != nil && resp.StatusCode < 500 {
+			t.Cache.Delete(key)
+		}
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	stored := *resp
+	stored.Header = resp.Header.Clone()
+	stored.Header.Set(cacheStoredAtHeader, strconv.FormatInt(now.UnixNano(), 10))
+	stored.Body = io.NopCloser(bytes.NewReader(body))
+	t.save(key, req, &stored)
+
+	return resp, nil
+}
+
+func (t *CachingTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+
+	return http.DefaultTransport
+}
+
+func (t *CachingTransport) now() time.Time {
+	if t.Clock != nil {
+		return t.Clock.Now()
+	}
+
+	return datetime.SystemClock.Now()
+}
+
+// load returns the cached response of key, it's nil if it doesn't exist or the Vary headers don't match.
+func (t *CachingTransport) load(key string, req *http.Request) *http.Response {
+	data, ok := t.Cache.Get(key)
+	if !ok {
+		return nil
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	for _, name := range varyHeaders(resp.Header) {
+		if resp.Header.Get(cacheVaryPrefix+name) != req.Header.Get(name) {
+			return nil
+		}
+	}
+
+	return resp
+}
+
+// save stores resp with the request headers listed by Vary.
+func (t *CachingTransport) save(key string, req *http.Request, resp *http.Response) {
+	for _, name := range varyHeaders(resp.Header) {
+		resp.Header.Set(cacheVaryPrefix+name, req.Header.Get(name))
+	}
+
+	data, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return
+	}
+
+	t.Cache.Set(key, data)
+}
+
+// serve returns the cached response without the internal headers.
+func (t *CachingTransport) serve(cached *http.Response, req *http.Request) *http.Response {
+	age := t.age(cached)
+
+	for name := range cached.Header {
+		if strings.HasPrefix(name, cacheVaryPrefix) || name == cacheStoredAtHeader {
+			cached.Header.Del(name)
+		}
+	}
+	cached.Header.Set("Age", strconv.Itoa(int(age/time.Second)))
+	cached.Header.Set(HttpCacheHeader, "1")
+	cached.Request = req
+
+	return cached
+}
+
+// isFresh checks if the cached response could be served without revalidation.
+func (t *CachingTransport) isFresh(resp *http.Response) bool {
+	control := parseCacheControl(resp.Header)
+	if _, ok := control["no-cache"]; ok {
+		return false
+	}
+
+	return t.age(resp) < freshnessLifetime(resp.Header, control)
+}
+
+// age returns the age of cached response, including the Age header from the server.
+func (t *CachingTransport) age(resp *http.Response) time.Duration {
+	storedAt, err := strconv.ParseInt(resp.Header.Get(cacheStoredAtHeader), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	age := t.now().Sub(time.Unix(0, storedAt))
+	if seconds, err := strconv.Atoi(resp.Header.Get("Age")); err == nil && seconds > 0 {
+		age += time.Duration(seconds) * time.Second
+	}
+
+	return age
+}
+
+// freshnessLifetime returns the lifetime of response by max-age or Expires, it's 0 if neither exists.
+func freshnessLifetime(header http.Header, control map[string]string) time.Duration {
+	if maxAge, ok := control["max-age"]; ok {
+		seconds, err := strconv.Atoi(maxAge)
+		if err != nil || seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if expires := header.Get("Expires"); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		if err != nil {
+			return 0
+		}
+
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			return 0
+		}
+
+		return expiresAt.Sub(date)
+	}
+
+	return 0
+}
+
+// isCacheable checks if the response could be stored, it should have freshness or validators.
+func isCacheable(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNonAuthoritativeInfo, http.StatusMovedPermanently, http.StatusNotFound, http.StatusGone:
+	default:
+		return false
+	}
+
+	control := parseCacheControl(resp.Header)
+	if _, ok := control["no-store"]; ok {
+		return false
+	}
+	if resp.Header.Get("Vary") == "*" {
+		return false
+	}
+
+	_, hasMaxAge := control["max-age"]
+
+	return hasMaxAge || resp.Header.Get("Expires") != "" || resp.Header.Get("ETag") != "" ||
+		resp.Header.Get("Last-Modified") != ""
+}
+
+// parseCacheControl parses the Cache-Control header into directives, eg: "max-age=60, no-cache" to
+// {"max-age": "60", "no-cache": ""}.
+func parseCacheControl(header http.Header) map[string]string {
+	control := make(map[string]string)
+
+	for _, value := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+
+			name, arg := part, ""
+			if i := strings.IndexByte(part, '='); i >= 0 {
+				name, arg = part[:i], strings.Trim(strings.TrimSpace(part[i+1:]), `"`)
+			}
+			control[strings.ToLower(strings.TrimSpace(name))] = arg
+		}
+	}
+
+	return control
+}
+
+func varyHeaders(header http.Header) []string {
+	var names []string
+
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+
+	return names
+}
+
+// hasConditional checks if the request is conditional already, then it's passed through.
+func hasConditional(req *http.Request) bool {
+	return req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+}
+
+func isUnsafeMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func cacheKey(req *http.Request) string {
+	return req.URL.String()
+}
@@ -0,0 +1,288 @@
+package netutil
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// cachingTestClient returns a http.Client with CachingTransport using a FakeClock.
+func cachingTestClient(cache HttpCache) (*http.Client, *datetime.FakeClock) {
+	clock := datetime.NewFakeClock(time.Now())
+	transport := NewCachingTransport(nil, cache)
+	transport.Clock = clock
+
+	return &http.Client{Transport: transport}, clock
+}
+
+func getBody(t *testing.T, client *http.Client, req *http.Request) (*http.Response, string) {
+	t.Helper()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	return resp, string(body)
+}
+
+func TestCachingTransportMaxAge(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCachingTransportMaxAge")
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprintf(w, "response %d", n)
+	}))
+	defer server.Close()
+
+	client, clock := cachingTestClient(NewMemoryHttpCache())
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	resp, body := getBody(t, client, req)
+	assert.Equal("response 1", body)
+	assert.Equal("", resp.Header.Get(HttpCacheHeader))
+
+	clock.Advance(30 * time.Second)
+	resp, body = getBody(t, client, req)
+	assert.Equal("response 1", body)
+	assert.Equal("1", resp.Header.Get(HttpCacheHeader))
+	assert.Equal("30", resp.Header.Get("Age"))
+	assert.Equal("", resp.Header.Get(cacheStoredAtHeader))
+	assert.Equal(http.StatusOK, resp.StatusCode)
+
+	// request no-cache
+	noCache, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	noCache.Header.Set("Cache-Control", "no-cache")
+	_, body = getBody(t, client, noCache)
+	assert.Equal("response 2", body)
+
+	// stale
+	clock.Advance(61 * time.Second)
+	_, body = getBody(t, client, req)
+	assert.Equal("response 3", body)
+	assert.Equal(int32(3), atomic.LoadInt32(&hits))
+}
+
+func TestCachingTransportETag(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCachingTransportETag")
+
+	var hits, notModified int32
+	var version atomic.Value
+	version.Store("v1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+
+		v := version.Load().(string)
+		etag := `"` + v + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "no-cache")
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, "data "+v)
+	}))
+	defer server.Close()
+
+	client, _ := cachingTestClient(NewMemoryHttpCache())
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	_, body := getBody(t, client, req)
+	assert.Equal("data v1", body)
+
+	resp, body := getBody(t, client, req)
+	assert.Equal("data v1", body)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("1", resp.Header.Get(HttpCacheHeader))
+	assert.Equal(int32(1), atomic.LoadInt32(&notModified))
+
+	// the request passed to transport is not modified
+	assert.Equal("", req.Header.Get("If-None-Match"))
+
+	version.Store("v2")
+	resp, body = getBody(t, client, req)
+	assert.Equal("data v2", body)
+	assert.Equal("", resp.Header.Get(HttpCacheHeader))
+	assert.Equal(int32(3), atomic.LoadInt32(&hits))
+}
+
+func TestCachingTransportLastModified(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCachingTransportLastModified")
+
+	modified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		http.ServeContent(w, r, "data.txt", modified, strings.NewReader("content"))
+	}))
+	defer server.Close()
+
+	client, _ := cachingTestClient(NewMemoryHttpCache())
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	_, body := getBody(t, client, req)
+	assert.Equal("content", body)
+
+	resp, body := getBody(t, client, req)
+	assert.Equal("content", body)
+	assert.Equal("1", resp.Header.Get(HttpCacheHeader))
+	assert.Equal(int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestCachingTransportNotCached(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCachingTransportNotCached")
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		switch r.URL.Path {
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store, max-age=60")
+		case "/error":
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/vary":
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "Accept-Language")
+			fmt.Fprint(w, r.Header.Get("Accept-Language"))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := cachingTestClient(NewMemoryHttpCache())
+
+	for _, path := range []string{"/no-store", "/error", "/plain"} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		getBody(t, client, req)
+		resp, _ := getBody(t, client, req)
+		assert.Equal("", resp.Header.Get(HttpCacheHeader))
+	}
+	assert.Equal(int32(6), atomic.LoadInt32(&hits))
+
+	// the cached response of different Vary headers is not used
+	en, _ := http.NewRequest(http.MethodGet, server.URL+"/vary", nil)
+	en.Header.Set("Accept-Language", "en")
+	zh, _ := http.NewRequest(http.MethodGet, server.URL+"/vary", nil)
+	zh.Header.Set("Accept-Language", "zh")
+
+	_, body := getBody(t, client, en)
+	assert.Equal("en", body)
+	_, body = getBody(t, client, zh)
+	assert.Equal("zh", body)
+	resp, body := getBody(t, client, zh)
+	assert.Equal("zh", body)
+	assert.Equal("1", resp.Header.Get(HttpCacheHeader))
+}
+
+func TestCachingTransportInvalidate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCachingTransportInvalidate")
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+	}))
+	defer server.Close()
+
+	cache := NewMemoryHttpCache()
+	client, _ := cachingTestClient(cache)
+
+	get, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	getBody(t, client, get)
+	_, ok := cache.Get(server.URL)
+	assert.Equal(true, ok)
+
+	post, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("x"))
+	getBody(t, client, post)
+	_, ok = cache.Get(server.URL)
+	assert.Equal(false, ok)
+
+	resp, _ := getBody(t, client, get)
+	assert.Equal("", resp.Header.Get(HttpCacheHeader))
+	assert.Equal(int32(3), atomic.LoadInt32(&hits))
+}
+
+func TestDirHttpCache(t *testing.T) {
+	assert := internal.NewAssert(t, "TestDirHttpCache")
+
+	dir := t.TempDir() + "/cache"
+	cache, err := NewDirHttpCache(dir)
+	assert.IsNil(err)
+
+	_, ok := cache.Get("a")
+	assert.Equal(false, ok)
+
+	cache.Set("a", []byte("value"))
+	value, ok := cache.Get("a")
+	assert.Equal(true, ok)
+	assert.Equal("value", string(value))
+
+	// the cache is kept by the directory
+	cache2, _ := NewDirHttpCache(dir)
+	value, ok = cache2.Get("a")
+	assert.Equal(true, ok)
+	assert.Equal("value", string(value))
+
+	cache.Delete("a")
+	_, ok = cache2.Get("a")
+	assert.Equal(false, ok)
+
+	// works as the cache of transport
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, "hello")
+	}))
+	defer server.Close()
+
+	client, _ := cachingTestClient(cache)
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	getBody(t, client, req)
+	resp, body := getBody(t, client, req)
+	assert.Equal("hello", body)
+	assert.Equal("1", resp.Header.Get(HttpCacheHeader))
+	assert.Equal(int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestHttpClientWithHttpCache(t *testing.T) {
+	assert := internal.NewAssert(t, "TestHttpClientWithHttpCache")
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, `{"id":1,"title":"lancet"}`)
+	}))
+	defer server.Close()
+
+	_, err := NewHttpClientWithOptions(WithHttpCache(nil))
+	assert.IsNotNil(err)
+
+	client, err := NewHttpClientWithOptions(WithHttpCache(NewMemoryHttpCache()))
+	assert.IsNil(err)
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.SendRequest(&HttpRequest{RawURL: server.URL, Method: http.MethodGet})
+		assert.IsNil(err)
+
+		var todo restTodo
+		assert.IsNil(client.DecodeResponse(resp, &todo))
+		assert.Equal("lancet", todo.Title)
+	}
+	assert.Equal(int32(1), atomic.LoadInt32(&hits))
+}
@@ -0,0 +1,178 @@
+package netutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestHttpClientWithRootCAs(t *testing.T) {
+	assert := internal.NewAssert(t, "TestHttpClientWithRootCAs")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	request := &HttpRequest{RawURL: server.URL, Method: "GET"}
+
+	// server certificate is not trusted
+	client := NewHttpClient()
+	_, err := client.SendRequest(request)
+	assert.IsNotNil(err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	client, err = NewHttpClientWithOptions(WithRootCAs(pool), WithTLSMinVersion(tls.VersionTLS12))
+	assert.IsNil(err)
+
+	resp, err := client.SendRequest(request)
+	assert.IsNil(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal("ok", string(body))
+
+	// the embedded http.Client could be used directly
+	resp, err = client.Client.Get(server.URL)
+	assert.IsNil(err)
+	resp.Body.Close()
+
+	client, err = NewHttpClientWithOptions(WithInsecureSkipVerify())
+	assert.IsNil(err)
+	resp, err = client.SendRequest(request)
+	assert.IsNil(err)
+	resp.Body.Close()
+
+	certFile := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0644)
+
+	client, err = NewHttpClientWithOptions(WithRootCAFile(certFile))
+	assert.IsNil(err)
+	resp, err = client.SendRequest(request)
+	assert.IsNil(err)
+	resp.Body.Close()
+
+	_, err = NewHttpClientWithOptions(WithRootCAFile("./notexist.pem"))
+	assert.IsNotNil(err)
+
+	_, err = NewHttpClientWithOptions(WithTLSMinVersion(0x0123))
+	assert.IsNotNil(err)
+}
+
+func TestHttpClientWithClientCertificate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestHttpClientWithClientCertificate")
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+	clientCert := writeTestCertificate(t, certFile, keyFile)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	request := &HttpRequest{RawURL: server.URL, Method: "GET"}
+
+	client, err := NewHttpClientWithOptions(WithInsecureSkipVerify())
+	assert.IsNil(err)
+	_, err = client.SendRequest(request)
+	assert.IsNotNil(err)
+
+	client, err = NewHttpClientWithOptions(WithInsecureSkipVerify(), WithClientCertificate(certFile, keyFile))
+	assert.IsNil(err)
+
+	resp, err := client.SendRequest(request)
+	assert.IsNil(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal("lancet client", string(body))
+
+	_, err = NewHttpClientWithOptions(WithClientCertificate(keyFile, certFile))
+	assert.IsNotNil(err)
+}
+
+func TestHttpClientWithProxy(t *testing.T) {
+	assert := internal.NewAssert(t, "TestHttpClientWithProxy")
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the proxy receives the absolute url of target
+		w.Write([]byte("proxied " + r.URL.String()))
+	}))
+	defer proxy.Close()
+
+	client, err := NewHttpClientWithOptions(WithProxy(proxy.URL))
+	assert.IsNil(err)
+
+	resp, err := client.SendRequest(&HttpRequest{RawURL: "http://example.com/todos", Method: "GET"})
+	assert.IsNil(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal("proxied http://example.com/todos", string(body))
+
+	_, err = NewHttpClientWithOptions(WithProxy("socks5://127.0.0.1:1080"))
+	assert.IsNil(err)
+
+	_, err = NewHttpClientWithOptions(WithProxy("ftp://127.0.0.1:21"))
+	assert.IsNotNil(err)
+
+	_, err = NewHttpClientWithOptions(WithProxy("127.0.0.1:8080"))
+	assert.IsNotNil(err)
+}
+
+// writeTestCertificate writes a self signed certificate and its key into files.
+func writeTestCertificate(t *testing.T, certFile, keyFile string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "lancet client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
@@ -160,6 +160,28 @@ func IsPublicIP(IP net.IP) bool {
 	return false
 }
 
+// IsPrivateIP verify an ip is private or not, the private ranges are 10.0.0.0/8, 172.16.0.0/12,
+// 192.168.0.0/16 (RFC 1918) and fc00::/7 (RFC 4193).
+func IsPrivateIP(IP net.IP) bool {
+	return IP.IsPrivate()
+}
+
+// IsSharedAddress verify an ip is in the shared address space 100.64.0.0/10 (RFC 6598) or not,
+// which is used by carrier-grade NAT.
+func IsSharedAddress(IP net.IP) bool {
+	if ip4 := IP.To4(); ip4 != nil {
+		return ip4[0] == 100 && ip4[1]&0xc0 == 64
+	}
+	return false
+}
+
+// IsGlobalIP verify an ip is a public global unicast address or not, both IPv4 and IPv6 are supported.
+// Unlike IsPublicIP, which only excludes the private ranges of IPv4, the unspecified, multicast, shared
+// and IPv6 private addresses are not global, and the IPv6 global unicast addresses are global.
+func IsGlobalIP(IP net.IP) bool {
+	return IP.IsGlobalUnicast() && !IsPrivateIP(IP) && !IsSharedAddress(IP)
+}
+
 // IsInternalIP verify an ip is intranet or not.
 // Play: https://go.dev/play/p/sYGhXbgO4Cb
 func IsInternalIP(IP net.IP) bool {
@@ -278,3 +300,119 @@ func IsTelnetConnected(host string, port string) bool {
 
 	return true
 }
+
+// InterfaceInfo is the information of a network interface.
+type InterfaceInfo struct {
+	Index      int
+	Name       string
+	MTU        int
+	MAC        string
+	Flags      string
+	IsUp       bool
+	IsLoopback bool
+	// Addrs are the addresses in CIDR notation, e.g. "192.168.1.10/24".
+	Addrs []string
+	IPv4  []string
+	IPv6  []string
+}
+
+// GetAllInterfaces returns the information of all network interfaces.
+func GetAllInterfaces() ([]InterfaceInfo, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]InterfaceInfo, 0, len(interfaces))
+	for _, iface := range interfaces {
+		info := InterfaceInfo{
+			Index:      iface.Index,
+			Name:       iface.Name,
+			MTU:        iface.MTU,
+			MAC:        iface.HardwareAddr.String(),
+			Flags:      iface.Flags.String(),
+			IsUp:       iface.Flags&net.FlagUp != 0,
+			IsLoopback: iface.Flags&net.FlagLoopback != 0,
+			Addrs:      []string{},
+			IPv4:       []string{},
+			IPv6:       []string{},
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			info.Addrs = append(info.Addrs, ipNet.String())
+			if ipNet.IP.To4() != nil {
+				info.IPv4 = append(info.IPv4, ipNet.IP.String())
+			} else {
+				info.IPv6 = append(info.IPv6, ipNet.IP.String())
+			}
+		}
+
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// GetFreePort returns a free tcp port of localhost.
+func GetFreePort() (int, error) {
+	ports, err := GetFreePorts(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return ports[0], nil
+}
+
+// GetFreePorts returns n distinct free tcp ports of localhost.
+// Note the ports may be taken by other processes after returned.
+func GetFreePorts(n int) ([]int, error) {
+	if n <= 0 {
+		return []int{}, nil
+	}
+
+	ports := make([]int, 0, n)
+	listeners := make([]net.Listener, 0, n)
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+
+	// hold all listeners until the end, so the ports are distinct
+	for i := 0; i < n; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, l)
+		ports = append(ports, l.Addr().(*net.TCPAddr).Port)
+	}
+
+	return ports, nil
+}
+
+// GetOutboundIP returns the local ip used to connect to targetHint, which is "host:port"
+// of a remote address, default is "8.8.8.8:80". No packet is sent, it only asks the system
+// for the route to target, so the target doesn't need to be reachable.
+func GetOutboundIP(targetHint ...string) (net.IP, error) {
+	target := "8.8.8.8:80"
+	if len(targetHint) > 0 && targetHint[0] != "" {
+		target = targetHint[0]
+	}
+
+	conn, err := net.Dial("udp", target)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
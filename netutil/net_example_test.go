@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 )
 
 func ExampleGetInternalIp() {
@@ -201,3 +203,28 @@ func ExampleIsTelnetConnected() {
 	// true
 	// false
 }
+
+func ExampleNewCachingTransport() {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprint(w, "config")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewCachingTransport(nil, NewMemoryHttpCache())}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL + "/config")
+		if err != nil {
+			return
+		}
+		resp.Body.Close()
+	}
+
+	fmt.Println(atomic.LoadInt32(&hits))
+
+	// Output:
+	// 1
+}
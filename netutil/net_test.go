@@ -1,6 +1,7 @@
 package netutil
 
 import (
+	"fmt"
 	"net"
 	"net/http"
 	"testing"
@@ -142,3 +143,97 @@ func TestTelnetConnected(t *testing.T) {
 	result2 := IsTelnetConnected("www.baidu.com", "123")
 	assert.Equal(false, result2)
 }
+
+func TestIsPrivateIP(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIsPrivateIP")
+
+	tests := []struct {
+		ip        string
+		isPrivate bool
+		isShared  bool
+		isPublic  bool
+		isGlobal  bool
+	}{
+		{"127.0.0.1", false, false, false, false},
+		{"192.168.0.1", true, false, false, false},
+		{"10.91.210.131", true, false, false, false},
+		{"172.20.16.1", true, false, false, false},
+		{"172.32.16.1", false, false, true, true},
+		{"100.64.0.1", false, true, true, false},
+		{"100.127.255.254", false, true, true, false},
+		{"100.128.0.1", false, false, true, true},
+		{"169.254.1.1", false, false, false, false},
+		{"224.0.0.1", false, false, false, false},
+		{"239.1.1.1", false, false, true, false},
+		{"0.0.0.0", false, false, true, false},
+		{"36.112.24.10", false, false, true, true},
+		{"fd00::1", true, false, false, false},
+		{"fe80::1", false, false, false, false},
+		{"ff02::1", false, false, false, false},
+		{"::1", false, false, false, false},
+		{"2001:4860:4860::8888", false, false, false, true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.isPrivate, IsPrivateIP(net.ParseIP(tt.ip)))
+		assert.Equal(tt.isShared, IsSharedAddress(net.ParseIP(tt.ip)))
+		assert.Equal(tt.isPublic, IsPublicIP(net.ParseIP(tt.ip)))
+		assert.Equal(tt.isGlobal, IsGlobalIP(net.ParseIP(tt.ip)))
+	}
+}
+
+func TestGetAllInterfaces(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGetAllInterfaces")
+
+	interfaces, err := GetAllInterfaces()
+	assert.IsNil(err)
+
+	var loopback *InterfaceInfo
+	for i := range interfaces {
+		if interfaces[i].IsLoopback {
+			loopback = &interfaces[i]
+		}
+	}
+	if loopback == nil {
+		t.Skip("no loopback interface")
+	}
+
+	assert.Equal(true, loopback.IsUp)
+	assert.Equal(true, loopback.MTU > 0)
+	assert.Equal(true, len(loopback.IPv4) > 0 || len(loopback.IPv6) > 0)
+}
+
+func TestGetFreePorts(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGetFreePorts")
+
+	port, err := GetFreePort()
+	assert.IsNil(err)
+	assert.Equal(true, port > 0)
+
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	assert.IsNil(err)
+	l.Close()
+
+	ports, err := GetFreePorts(5)
+	assert.IsNil(err)
+	assert.Equal(5, len(ports))
+
+	seen := make(map[int]bool)
+	for _, p := range ports {
+		seen[p] = true
+	}
+	assert.Equal(5, len(seen))
+}
+
+func TestGetOutboundIP(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGetOutboundIP")
+
+	ip, err := GetOutboundIP("127.0.0.1:80")
+	assert.IsNil(err)
+	assert.Equal("127.0.0.1", ip.String())
+
+	_, err = GetOutboundIP("invalid")
+	assert.IsNotNil(err)
+}
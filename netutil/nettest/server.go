@@ -0,0 +1,326 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+// Package nettest implements a local http server for testing the http clients.
+package nettest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TestingT is the subset of testing.TB used by the assertion helpers of Server.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// CapturedRequest is a request received by Server.
+type CapturedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+	// Time is the time the request is received
+	Time time.Time
+}
+
+// ServerOption is the option of NewServer.
+type ServerOption func(*serverConfig)
+
+type serverConfig struct {
+	latency    time.Duration
+	jitter     time.Duration
+	failures   int
+	failStatus int
+	faultRate  float64
+	faultCode  int
+}
+
+// WithLatency delays every response by latency plus a random duration in [0, jitter), the delay ends early
+// if the client cancels the request.
+func WithLatency(latency, jitter time.Duration) ServerOption {
+	return func(c *serverConfig) {
+		c.latency = latency
+		c.jitter = jitter
+	}
+}
+
+// WithFailures makes the first n requests fail with the status code, eg: for testing the retries.
+// If status is 0, the connection is closed without response.
+func WithFailures(n int, status int) ServerOption {
+	return func(c *serverConfig) {
+		c.failures = n
+		c.failStatus = status
+	}
+}
+
+// WithFaultRate makes the requests fail randomly with the probability rate in [0, 1] and the status code.
+// If status is 0, the connection is closed without response.
+func WithFaultRate(rate float64, status int) ServerOption {
+	return func(c *serverConfig) {
+		c.faultRate = rate
+		c.faultCode = status
+	}
+}
+
+// Server is a local http server for testing the http clients, it routes the requests by method and path, and
+// records all the requests for assertions. It should be closed by Close after testing.
+type Server struct {
+	*httptest.Server
+
+	config serverConfig
+	// routes is keyed by "METHOD /path" or "/path" for any method
+	routes map[string]http.Handler
+
+	mu       sync.Mutex
+	requests []CapturedRequest
+	rand     *rand.Rand
+}
+
+// NewServer starts a Server, the keys of routes are "METHOD /path" or "/path" which matches any method,
+// eg: "GET /users". The paths are matched exactly, it responds 405 if the path matches but the method doesn't,
+// and 404 if no path matches.
+func NewServer(routes map[string]http.Handler, opts ...ServerOption) *Server {
+	s := &Server{
+		routes: make(map[string]http.Handler, len(routes)),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+
+	for pattern, handler := range routes {
+		method, path := parseRoutePattern(pattern)
+		s.routes[routeKey(method, path)] = handler
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Requests returns the requests received in order.
+func (s *Server) Requests() []CapturedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]CapturedRequest{}, s.requests...)
+}
+
+// LastRequest returns the last request received, ok is false if no request is received.
+func (s *Server) LastRequest() (request CapturedRequest, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.requests) == 0 {
+		return CapturedRequest{}, false
+	}
+
+	return s.requests[len(s.requests)-1], true
+}
+
+// RequestCount returns the number of requests received with method and path, the empty method or path matches all.
+func (s *Server) RequestCount(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, r := range s.requests {
+		if (method == "" || r.Method == method) && (path == "" || r.Path == path) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Reset clears the recorded requests, and restarts the counting of WithFailures.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
+
+// AssertCalled reports an error to t if no request is received with method and path.
+func (s *Server) AssertCalled(t TestingT, method, path string) bool {
+	t.Helper()
+
+	if s.RequestCount(method, path) == 0 {
+		t.Errorf("nettest: expected request %s %s, but not received, received: %s", method, path, s.describeRequests())
+		return false
+	}
+
+	return true
+}
+
+// AssertNotCalled reports an error to t if any request is received with method and path.
+func (s *Server) AssertNotCalled(t TestingT, method, path string) bool {
+	t.Helper()
+
+	if count := s.RequestCount(method, path); count > 0 {
+		t.Errorf("nettest: expected no request %s %s, but received %d", method, path, count)
+		return false
+	}
+
+	return true
+}
+
+// AssertRequestCount reports an error to t if the number of requests received with method and path is not n,
+// the empty method or path matches all.
+func (s *Server) AssertRequestCount(t TestingT, method, path string, n int) bool {
+	t.Helper()
+
+	if count := s.RequestCount(method, path); count != n {
+		t.Errorf("nettest: expected %d requests %s %s, but received %d", n, method, path, count)
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, CapturedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+		Time:   time.Now(),
+	})
+	seq := len(s.requests)
+
+	delay := s.config.latency
+	if s.config.jitter > 0 {
+		delay += time.Duration(s.rand.Int63n(int64(s.config.jitter)))
+	}
+
+	fault, status := false, 0
+	switch {
+	case seq <= s.config.failures:
+		fault, status = true, s.config.failStatus
+	case s.config.faultRate > 0 && s.rand.Float64() < s.config.faultRate:
+		fault, status = true, s.config.faultCode
+	}
+	s.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+
+	if fault {
+		writeFault(w, status)
+		return
+	}
+
+	s.route(r).ServeHTTP(w, r)
+}
+
+// route finds the handler of request.
+func (s *Server) route(r *http.Request) http.Handler {
+	if h, ok := s.routes[routeKey(r.Method, r.URL.Path)]; ok {
+		return h
+	}
+	if h, ok := s.routes[routeKey("", r.URL.Path)]; ok {
+		return h
+	}
+
+	for key := range s.routes {
+		if _, path := parseRoutePattern(key); path == r.URL.Path {
+			return StatusHandler(http.StatusMethodNotAllowed)
+		}
+	}
+
+	return http.NotFoundHandler()
+}
+
+// writeFault responds the status code, or closes the connection if status is 0.
+func writeFault(w http.ResponseWriter, status int) {
+	if status != 0 {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if hijacker, ok := w.(http.Hijacker); ok {
+		if conn, _, err := hijacker.Hijack(); err == nil {
+			conn.Close()
+			return
+		}
+	}
+
+	panic(http.ErrAbortHandler)
+}
+
+func parseRoutePattern(pattern string) (method, path string) {
+	pattern = strings.TrimSpace(pattern)
+	if i := strings.IndexByte(pattern, ' '); i >= 0 {
+		return strings.ToUpper(pattern[:i]), strings.TrimSpace(pattern[i+1:])
+	}
+
+	return "", pattern
+}
+
+func routeKey(method, path string) string {
+	if method == "" {
+		return path
+	}
+
+	return method + " " + path
+}
+
+// StatusHandler returns a http.Handler responding the status code with its status text.
+func StatusHandler(status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(status), status)
+	})
+}
+
+// JSONHandler returns a http.Handler responding the status code and v encoded as JSON.
+func JSONHandler(status int, v any) http.Handler {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("programming error: can't encode JSON response: %v", err))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(data)
+	})
+}
+
+// describeRequests returns the method and path of requests received, for the assertion messages.
+func (s *Server) describeRequests() string {
+	requests := s.Requests()
+	if len(requests) == 0 {
+		return "none"
+	}
+
+	items := make([]string, len(requests))
+	for i, r := range requests {
+		items[i] = r.Method + " " + r.Path
+	}
+
+	return strings.Join(items, ", ")
+}
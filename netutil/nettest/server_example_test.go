@@ -0,0 +1,28 @@
+package nettest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/duke-git/lancet/v2/netutil"
+)
+
+func ExampleNewServer() {
+	server := NewServer(map[string]http.Handler{
+		"GET /todos/1": JSONHandler(http.StatusOK, map[string]any{"id": 1, "title": "lancet"}),
+	})
+	defer server.Close()
+
+	todo, err := netutil.GetJSON[map[string]any](context.Background(), server.URL+"/todos/1")
+	if err != nil {
+		return
+	}
+
+	fmt.Println(todo["title"])
+	fmt.Println(server.RequestCount(http.MethodGet, "/todos/1"))
+
+	// Output:
+	// lancet
+	// 1
+}
@@ -0,0 +1,176 @@
+package nettest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/netutil"
+)
+
+type testTodo struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// recordingT records the errors of assertion helpers.
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestServerRoutes(t *testing.T) {
+	assert := internal.NewAssert(t, "TestServerRoutes")
+
+	server := NewServer(map[string]http.Handler{
+		"GET /todos/1": JSONHandler(http.StatusOK, testTodo{ID: 1, Title: "lancet"}),
+		"post /todos":  JSONHandler(http.StatusCreated, testTodo{ID: 2, Title: "new"}),
+		"/health":      StatusHandler(http.StatusNoContent),
+	})
+	defer server.Close()
+
+	todo, err := netutil.GetJSON[testTodo](context.Background(), server.URL+"/todos/1?a=x",
+		netutil.WithRestHeader("Authorization", "token"))
+	assert.IsNil(err)
+	assert.Equal(testTodo{ID: 1, Title: "lancet"}, todo)
+
+	todo, err = netutil.PostJSON[testTodo, testTodo](context.Background(), server.URL+"/todos", testTodo{Title: "new"})
+	assert.IsNil(err)
+	assert.Equal(2, todo.ID)
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		req, _ := http.NewRequest(method, server.URL+"/health", nil)
+		resp, err := http.DefaultClient.Do(req)
+		assert.IsNil(err)
+		resp.Body.Close()
+		assert.Equal(http.StatusNoContent, resp.StatusCode)
+	}
+
+	resp, err := http.Get(server.URL + "/todos")
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/missing")
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+
+	// captured requests
+	requests := server.Requests()
+	assert.Equal(6, len(requests))
+	assert.Equal("/todos/1", requests[0].Path)
+	assert.Equal("x", requests[0].Query.Get("a"))
+	assert.Equal("token", requests[0].Header.Get("Authorization"))
+	assert.Equal(`{"id":0,"title":"new"}`, string(requests[1].Body))
+
+	last, ok := server.LastRequest()
+	assert.Equal(true, ok)
+	assert.Equal("/missing", last.Path)
+
+	assert.Equal(2, server.RequestCount("", "/health"))
+	assert.Equal(1, server.RequestCount(http.MethodDelete, ""))
+
+	server.Reset()
+	assert.Equal(0, len(server.Requests()))
+	_, ok = server.LastRequest()
+	assert.Equal(false, ok)
+}
+
+func TestServerAssertions(t *testing.T) {
+	assert := internal.NewAssert(t, "TestServerAssertions")
+
+	server := NewServer(map[string]http.Handler{
+		"/": StatusHandler(http.StatusOK),
+	})
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/", "text/plain", strings.NewReader("hello"))
+	assert.IsNil(err)
+	resp.Body.Close()
+
+	rt := &recordingT{}
+	assert.Equal(true, server.AssertCalled(rt, http.MethodPost, "/"))
+	assert.Equal(true, server.AssertNotCalled(rt, http.MethodGet, "/"))
+	assert.Equal(true, server.AssertRequestCount(rt, "", "", 1))
+	assert.Equal(0, len(rt.errors))
+
+	assert.Equal(false, server.AssertCalled(rt, http.MethodGet, "/"))
+	assert.Equal(false, server.AssertNotCalled(rt, http.MethodPost, "/"))
+	assert.Equal(false, server.AssertRequestCount(rt, http.MethodPost, "/", 2))
+	assert.Equal(3, len(rt.errors))
+	assert.Equal(true, strings.Contains(rt.errors[0], "received: POST /"))
+}
+
+func TestServerFailures(t *testing.T) {
+	assert := internal.NewAssert(t, "TestServerFailures")
+
+	server := NewServer(map[string]http.Handler{
+		"GET /todo": JSONHandler(http.StatusOK, testTodo{ID: 1}),
+	}, WithFailures(2, http.StatusServiceUnavailable))
+	defer server.Close()
+
+	// the rest helpers retry the failed requests
+	todo, err := netutil.GetJSON[testTodo](context.Background(), server.URL+"/todo", netutil.WithRestRetry(3, time.Millisecond))
+	assert.IsNil(err)
+	assert.Equal(1, todo.ID)
+	server.AssertRequestCount(t, http.MethodGet, "/todo", 3)
+
+	// the connection is closed
+	closing := NewServer(nil, WithFailures(1, 0))
+	defer closing.Close()
+
+	_, err = http.Get(closing.URL)
+	assert.IsNotNil(err)
+
+	resp, err := http.Get(closing.URL)
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServerFaultRate(t *testing.T) {
+	assert := internal.NewAssert(t, "TestServerFaultRate")
+
+	server := NewServer(map[string]http.Handler{
+		"/": StatusHandler(http.StatusOK),
+	}, WithFaultRate(1, http.StatusInternalServerError))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/")
+	assert.IsNil(err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal("Internal Server Error\n", string(body))
+}
+
+func TestServerLatency(t *testing.T) {
+	assert := internal.NewAssert(t, "TestServerLatency")
+
+	server := NewServer(map[string]http.Handler{
+		"/": StatusHandler(http.StatusOK),
+	}, WithLatency(50*time.Millisecond, 10*time.Millisecond))
+	defer server.Close()
+
+	start := time.Now()
+	resp, err := http.Get(server.URL + "/")
+	assert.IsNil(err)
+	resp.Body.Close()
+	assert.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+
+	// the client timeout
+	_, err = netutil.GetJSON[testTodo](context.Background(), server.URL+"/", netutil.WithRestTimeout(10*time.Millisecond),
+		netutil.WithRestRetry(1, 0))
+	assert.IsNotNil(err)
+}
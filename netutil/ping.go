@@ -0,0 +1,317 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package netutil
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"time"
+)
+
+const (
+	icmpEchoReply       = 0
+	icmpDestUnreachable = 3
+	icmpEchoRequest     = 8
+	icmpTimeExceeded    = 11
+)
+
+// PingResult is the statistics of Ping.
+type PingResult struct {
+	Host     string
+	Addr     string
+	Sent     int
+	Received int
+	// Loss is the packet loss ratio in percent.
+	Loss float64
+	// RTTs are the round trip times of received replies.
+	RTTs   []time.Duration
+	MinRTT time.Duration
+	MaxRTT time.Duration
+	AvgRTT time.Duration
+	StdDev time.Duration
+}
+
+// TraceHop is a hop of Traceroute.
+type TraceHop struct {
+	TTL int
+	// Addr is the address of the router which replied, it's empty if timeout.
+	Addr    string
+	RTT     time.Duration
+	Timeout bool
+	// Reached reports whether the hop is the destination.
+	Reached bool
+}
+
+// Ping sends count ICMP echo requests to host one after another, and returns the statistics
+// of replies. Every request waits at most timeout for its reply. Only IPv4 is supported.
+// It uses a raw ICMP socket when running as a privileged user, otherwise an unprivileged ICMP
+// datagram socket (linux and darwin, on linux the group should be in net.ipv4.ping_group_range).
+func Ping(host string, count int, timeout time.Duration) (*PingResult, error) {
+	if count <= 0 {
+		return nil, errors.New("netutil: ping count should be positive")
+	}
+
+	ip, err := resolveIPv4(host)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, privileged, err := listenICMP()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var dst net.Addr = &net.UDPAddr{IP: ip}
+	if privileged {
+		dst = &net.IPAddr{IP: ip}
+	}
+
+	id := os.Getpid() & 0xffff
+	result := &PingResult{Host: host, Addr: ip.String(), RTTs: []time.Duration{}}
+
+	for seq := 1; seq <= count; seq++ {
+		start := time.Now()
+		if _, err := conn.WriteTo(newEchoRequest(id, seq), dst); err != nil {
+			return nil, err
+		}
+		result.Sent++
+
+		reply, err := readICMP(conn, start.Add(timeout), func(msg *icmpMessage) bool {
+			// the kernel rewrites id of unprivileged datagram socket, so only check the seq
+			return msg.typ == icmpEchoReply && msg.seq == seq && (!privileged || msg.id == id) && msg.from.Equal(ip)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if reply != nil {
+			result.RTTs = append(result.RTTs, time.Since(start))
+		}
+	}
+
+	result.Received = len(result.RTTs)
+	result.Loss = float64(result.Sent-result.Received) / float64(result.Sent) * 100
+	result.calculateRTT()
+
+	return result, nil
+}
+
+func (r *PingResult) calculateRTT() {
+	if len(r.RTTs) == 0 {
+		return
+	}
+
+	r.MinRTT, r.MaxRTT = r.RTTs[0], r.RTTs[0]
+
+	var sum time.Duration
+	for _, rtt := range r.RTTs {
+		sum += rtt
+		if rtt < r.MinRTT {
+			r.MinRTT = rtt
+		}
+		if rtt > r.MaxRTT {
+			r.MaxRTT = rtt
+		}
+	}
+	r.AvgRTT = sum / time.Duration(len(r.RTTs))
+
+	var variance float64
+	for _, rtt := range r.RTTs {
+		d := float64(rtt - r.AvgRTT)
+		variance += d * d
+	}
+	r.StdDev = time.Duration(math.Sqrt(variance / float64(len(r.RTTs))))
+}
+
+// Traceroute traces the route to host, and returns the hops until the destination is reached or
+// maxHops is exceeded, every hop waits at most timeout for its reply. Only IPv4 is supported.
+// If privileged is true, it sends ICMP echo requests on a raw socket which requires root or
+// administrator permission. Otherwise it sends UDP probes and reads the ICMP errors from socket
+// error queue, which needs no permission but is only supported on linux.
+func Traceroute(host string, maxHops int, timeout time.Duration, privileged bool) ([]TraceHop, error) {
+	if maxHops <= 0 {
+		return nil, errors.New("netutil: max hops should be positive")
+	}
+
+	ip, err := resolveIPv4(host)
+	if err != nil {
+		return nil, err
+	}
+
+	if !privileged {
+		return udpTraceroute(ip, maxHops, timeout)
+	}
+
+	conn, err := net.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	id := os.Getpid() & 0xffff
+	hops := make([]TraceHop, 0, maxHops)
+
+	for ttl := 1; ttl <= maxHops; ttl++ {
+		if err := setTTL(conn, ttl); err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		if _, err := conn.WriteTo(newEchoRequest(id, ttl), &net.IPAddr{IP: ip}); err != nil {
+			return nil, err
+		}
+
+		reply, err := readICMP(conn, start.Add(timeout), func(msg *icmpMessage) bool {
+			return msg.id == id && msg.seq == ttl
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		hop := TraceHop{TTL: ttl, Timeout: reply == nil}
+		if reply != nil {
+			hop.Addr = reply.from.String()
+			hop.RTT = time.Since(start)
+			hop.Reached = reply.typ == icmpEchoReply || reply.typ == icmpDestUnreachable
+		}
+		hops = append(hops, hop)
+
+		if hop.Reached {
+			break
+		}
+	}
+
+	return hops, nil
+}
+
+func resolveIPv4(host string) (net.IP, error) {
+	addr, err := net.ResolveIPAddr("ip4", host)
+	if err != nil {
+		return nil, err
+	}
+
+	return addr.IP.To4(), nil
+}
+
+// listenICMP listens on a raw ICMP socket, or an unprivileged ICMP datagram socket if permission denied.
+func listenICMP() (net.PacketConn, bool, error) {
+	conn, err := net.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err == nil {
+		return conn, true, nil
+	}
+
+	conn, dgramErr := listenUnprivilegedICMP()
+	if dgramErr != nil {
+		return nil, false, fmt.Errorf("netutil: listen icmp: %v, %v", err, dgramErr)
+	}
+
+	return conn, false, nil
+}
+
+// icmpMessage is a received ICMP message. For error messages like time exceeded,
+// id and seq are of the original echo request.
+type icmpMessage struct {
+	from net.IP
+	typ  int
+	id   int
+	seq  int
+}
+
+// readICMP reads ICMP messages until one matches, returns nil message if deadline exceeded.
+func readICMP(conn net.PacketConn, deadline time.Time, match func(msg *icmpMessage) bool) (*icmpMessage, error) {
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		msg := parseICMP(buf[:n])
+		if msg == nil {
+			continue
+		}
+
+		switch a := addr.(type) {
+		case *net.IPAddr:
+			msg.from = a.IP
+		case *net.UDPAddr:
+			msg.from = a.IP
+		}
+
+		if match(msg) {
+			return msg, nil
+		}
+	}
+}
+
+func newEchoRequest(id, seq int) []byte {
+	msg := make([]byte, 16)
+	msg[0] = icmpEchoRequest
+	binary.BigEndian.PutUint16(msg[4:], uint16(id))
+	binary.BigEndian.PutUint16(msg[6:], uint16(seq))
+	binary.BigEndian.PutUint64(msg[8:], uint64(time.Now().UnixNano()))
+	binary.BigEndian.PutUint16(msg[2:], icmpChecksum(msg))
+
+	return msg
+}
+
+func icmpChecksum(b []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+
+	return ^uint16(sum)
+}
+
+func parseICMP(b []byte) *icmpMessage {
+	// some systems return the IPv4 header with datagram socket
+	if len(b) >= 20 && b[0]>>4 == 4 {
+		b = b[int(b[0]&0x0f)*4:]
+	}
+	if len(b) < 8 {
+		return nil
+	}
+
+	msg := &icmpMessage{typ: int(b[0])}
+
+	switch msg.typ {
+	case icmpEchoReply:
+		msg.id = int(binary.BigEndian.Uint16(b[4:]))
+		msg.seq = int(binary.BigEndian.Uint16(b[6:]))
+	case icmpTimeExceeded, icmpDestUnreachable:
+		// the body is the original IPv4 header and the first 8 bytes of original ICMP message
+		inner := b[8:]
+		if len(inner) < 20 {
+			return nil
+		}
+		ihl := int(inner[0]&0x0f) * 4
+		if len(inner) < ihl+8 || inner[ihl] != icmpEchoRequest {
+			return nil
+		}
+		msg.id = int(binary.BigEndian.Uint16(inner[ihl+4:]))
+		msg.seq = int(binary.BigEndian.Uint16(inner[ihl+6:]))
+	default:
+		return nil
+	}
+
+	return msg
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly && !windows
+
+package netutil
+
+import (
+	"errors"
+	"net"
+	"runtime"
+)
+
+func setTTL(conn net.PacketConn, ttl int) error {
+	return errors.New("netutil: setting ttl is not supported on " + runtime.GOOS)
+}
+
+func listenUnprivilegedICMP() (net.PacketConn, error) {
+	return nil, errors.New("netutil: unprivileged icmp is not supported on " + runtime.GOOS)
+}
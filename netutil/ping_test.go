@@ -0,0 +1,68 @@
+package netutil
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func skipIfNoPermission(t *testing.T, err error) {
+	if err != nil && (errors.Is(err, os.ErrPermission) || strings.Contains(err.Error(), "not permitted") ||
+		strings.Contains(err.Error(), "permission denied") || strings.Contains(err.Error(), "not supported")) {
+		t.Skip("skip for no permission: " + err.Error())
+	}
+}
+
+func TestPing(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPing")
+
+	result, err := Ping("127.0.0.1", 3, time.Second)
+	skipIfNoPermission(t, err)
+	assert.IsNil(err)
+
+	assert.Equal("127.0.0.1", result.Addr)
+	assert.Equal(3, result.Sent)
+	assert.Equal(3, result.Received)
+	assert.Equal(float64(0), result.Loss)
+	assert.Equal(3, len(result.RTTs))
+	assert.Equal(true, result.MinRTT <= result.AvgRTT && result.AvgRTT <= result.MaxRTT)
+
+	_, err = Ping("127.0.0.1", 0, time.Second)
+	assert.IsNotNil(err)
+}
+
+func TestTraceroute(t *testing.T) {
+	assert := internal.NewAssert(t, "TestTraceroute")
+
+	for _, privileged := range []bool{true, false} {
+		hops, err := Traceroute("127.0.0.1", 5, time.Second, privileged)
+		if err != nil {
+			skipIfNoPermission(t, err)
+		}
+		assert.IsNil(err)
+
+		assert.Equal(1, len(hops))
+		assert.Equal(1, hops[0].TTL)
+		assert.Equal("127.0.0.1", hops[0].Addr)
+		assert.Equal(true, hops[0].Reached)
+		assert.Equal(false, hops[0].Timeout)
+	}
+
+	_, err := Traceroute("127.0.0.1", 0, time.Second, false)
+	assert.IsNotNil(err)
+}
+
+func TestIcmpChecksum(t *testing.T) {
+	assert := internal.NewAssert(t, "TestIcmpChecksum")
+
+	msg := newEchoRequest(1, 2)
+	assert.Equal(uint16(0), icmpChecksum(msg))
+
+	parsed := parseICMP(append([]byte{icmpEchoReply}, msg[1:]...))
+	assert.Equal(1, parsed.id)
+	assert.Equal(2, parsed.seq)
+}
@@ -0,0 +1,35 @@
+//go:build windows
+
+package netutil
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+func setTTL(conn net.PacketConn, ttl int) error {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return errors.New("netutil: connection doesn't support setting ttl")
+	}
+
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var sockErr error
+	err = raw.Control(func(fd uintptr) {
+		sockErr = syscall.SetsockoptInt(syscall.Handle(fd), syscall.IPPROTO_IP, syscall.IP_TTL, ttl)
+	})
+	if err != nil {
+		return err
+	}
+
+	return sockErr
+}
+
+func listenUnprivilegedICMP() (net.PacketConn, error) {
+	return nil, errors.New("netutil: unprivileged icmp is not supported on windows")
+}
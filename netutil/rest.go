@@ -0,0 +1,275 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package netutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRestTimeout     = 30 * time.Second
+	defaultRestMaxAttempts = 3
+	defaultRestBackoff     = 200 * time.Millisecond
+	maxErrorBodySize       = 64 * 1024
+)
+
+// HTTPError is returned by the rest helpers when the response status code is not 2xx.
+type HTTPError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Body is the response body, at most 64KB are kept.
+	Body []byte
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("netutil: %s %s: %s", e.Method, e.URL, e.Status)
+	if len(e.Body) > 0 {
+		msg += ": " + strings.TrimSpace(string(e.Body))
+	}
+
+	return msg
+}
+
+// RestOption is the option of GetJSON, PostJSON, PutJSON, PatchJSON and DeleteJSON.
+type RestOption func(*restConfig)
+
+type restConfig struct {
+	client      *http.Client
+	timeout     time.Duration
+	header      http.Header
+	query       url.Values
+	maxAttempts int
+	backoff     time.Duration
+	retryAll    bool
+}
+
+// WithRestClient sets the http client to send request, http.DefaultClient is used by default.
+func WithRestClient(client *http.Client) RestOption {
+	return func(c *restConfig) {
+		c.client = client
+	}
+}
+
+// WithRestTimeout sets the timeout of the whole call including retries, default is 30 seconds.
+// The timeout is not applied if it's not positive, then only the context controls the deadline.
+func WithRestTimeout(timeout time.Duration) RestOption {
+	return func(c *restConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithRestHeader adds a request header.
+func WithRestHeader(key, value string) RestOption {
+	return func(c *restConfig) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithRestQuery adds a query param to the url.
+func WithRestQuery(key, value string) RestOption {
+	return func(c *restConfig) {
+		c.query.Add(key, value)
+	}
+}
+
+// WithRestRetry sets the max attempts and the initial backoff between attempts, the backoff doubles
+// after each attempt. Only idempotent methods (GET, HEAD, PUT, DELETE, OPTIONS) are retried, on
+// network errors, 429 and 5xx status codes. Default is 3 attempts with 200ms backoff, pass 1 to disable retry.
+func WithRestRetry(maxAttempts int, backoff time.Duration) RestOption {
+	return func(c *restConfig) {
+		c.maxAttempts = maxAttempts
+		c.backoff = backoff
+	}
+}
+
+// WithRestRetryNonIdempotent makes the POST and PATCH requests be retried too,
+// use it only when the server handles duplicated requests.
+func WithRestRetryNonIdempotent() RestOption {
+	return func(c *restConfig) {
+		c.retryAll = true
+	}
+}
+
+// GetJSON sends a GET request and decodes the JSON response into T.
+func GetJSON[T any](ctx context.Context, url string, opts ...RestOption) (T, error) {
+	var result T
+	err := doRest(ctx, http.MethodGet, url, nil, &result, opts)
+
+	return result, err
+}
+
+// PostJSON sends a POST request with body encoded as JSON, and decodes the JSON response into Resp.
+func PostJSON[Req any, Resp any](ctx context.Context, url string, body Req, opts ...RestOption) (Resp, error) {
+	return sendJSON[Req, Resp](ctx, http.MethodPost, url, body, opts)
+}
+
+// PutJSON sends a PUT request with body encoded as JSON, and decodes the JSON response into Resp.
+func PutJSON[Req any, Resp any](ctx context.Context, url string, body Req, opts ...RestOption) (Resp, error) {
+	return sendJSON[Req, Resp](ctx, http.MethodPut, url, body, opts)
+}
+
+// PatchJSON sends a PATCH request with body encoded as JSON, and decodes the JSON response into Resp.
+func PatchJSON[Req any, Resp any](ctx context.Context, url string, body Req, opts ...RestOption) (Resp, error) {
+	return sendJSON[Req, Resp](ctx, http.MethodPatch, url, body, opts)
+}
+
+// DeleteJSON sends a DELETE request and decodes the JSON response into T.
+func DeleteJSON[T any](ctx context.Context, url string, opts ...RestOption) (T, error) {
+	var result T
+	err := doRest(ctx, http.MethodDelete, url, nil, &result, opts)
+
+	return result, err
+}
+
+func sendJSON[Req any, Resp any](ctx context.Context, method, url string, body Req, opts []RestOption) (Resp, error) {
+	var result Resp
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return result, err
+	}
+
+	err = doRest(ctx, method, url, data, &result, opts)
+
+	return result, err
+}
+
+func doRest(ctx context.Context, method, rawUrl string, body []byte, result any, opts []RestOption) error {
+	config := &restConfig{
+		client:      http.DefaultClient,
+		timeout:     defaultRestTimeout,
+		header:      make(http.Header),
+		query:       make(url.Values),
+		maxAttempts: defaultRestMaxAttempts,
+		backoff:     defaultRestBackoff,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.timeout)
+		defer cancel()
+	}
+
+	reqUrl, err := appendQuery(rawUrl, config.query)
+	if err != nil {
+		return err
+	}
+
+	attempts := config.maxAttempts
+	if attempts < 1 || (!isIdempotent(method) && !config.retryAll) {
+		attempts = 1
+	}
+
+	backoff := config.backoff
+	for i := 1; ; i++ {
+		retryable, err := sendRest(ctx, config, method, reqUrl, body, result)
+		if err == nil || !retryable || i >= attempts {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// sendRest sends one request, and returns whether the error is retryable.
+func sendRest(ctx context.Context, config *restConfig, method, reqUrl string, body []byte, result any) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqUrl, reader)
+	if err != nil {
+		return false, err
+	}
+
+	for k, v := range config.header {
+		req.Header[k] = v
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := config.client.Do(req)
+	if err != nil {
+		// don't retry when the context is done
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		httpErr := &HTTPError{
+			Method:     method,
+			URL:        reqUrl,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header,
+			Body:       data,
+		}
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, httpErr
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if errors.Is(err, io.EOF) {
+		// empty body, e.g. 204 No Content
+		err = nil
+	}
+
+	return false, err
+}
+
+func appendQuery(rawUrl string, query url.Values) (string, error) {
+	if len(query) == 0 {
+		return rawUrl, nil
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+
+	values := u.Query()
+	for k, vs := range query {
+		for _, v := range vs {
+			values.Add(k, v)
+		}
+	}
+	u.RawQuery = values.Encode()
+
+	return u.String(), nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package netutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type restTodo struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+func TestGetJSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestGetJSON")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodGet, r.Method)
+		assert.Equal("application/json", r.Header.Get("Accept"))
+		assert.Equal("token", r.Header.Get("Authorization"))
+		assert.Equal("1", r.URL.Query().Get("id"))
+		assert.Equal("x", r.URL.Query().Get("a"))
+
+		json.NewEncoder(w).Encode(restTodo{ID: 1, Title: "lancet"})
+	}))
+	defer server.Close()
+
+	todo, err := GetJSON[restTodo](context.Background(), server.URL+"?a=x",
+		WithRestHeader("Authorization", "token"), WithRestQuery("id", "1"))
+	assert.IsNil(err)
+	assert.Equal(restTodo{ID: 1, Title: "lancet"}, todo)
+}
+
+func TestPostJSON(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPostJSON")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal("application/json", r.Header.Get("Content-Type"))
+
+		var todo restTodo
+		json.NewDecoder(r.Body).Decode(&todo)
+		todo.ID = 100
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(todo)
+	}))
+	defer server.Close()
+
+	todo, err := PostJSON[restTodo, restTodo](context.Background(), server.URL, restTodo{Title: "new"})
+	assert.IsNil(err)
+	assert.Equal(restTodo{ID: 100, Title: "new"}, todo)
+}
+
+func TestRestNoContent(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRestNoContent")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, err := DeleteJSON[struct{}](context.Background(), server.URL)
+	assert.IsNil(err)
+}
+
+func TestRestHTTPError(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRestHTTPError")
+
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := GetJSON[restTodo](context.Background(), server.URL)
+
+	var httpErr *HTTPError
+	assert.Equal(true, errors.As(err, &httpErr))
+	assert.Equal(http.StatusNotFound, httpErr.StatusCode)
+	assert.Equal("not found\n", string(httpErr.Body))
+	// 4xx is not retried
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+}
+
+func TestRestRetry(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRestRetry")
+
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(restTodo{ID: 1})
+	}))
+	defer server.Close()
+
+	todo, err := GetJSON[restTodo](context.Background(), server.URL, WithRestRetry(3, time.Millisecond))
+	assert.IsNil(err)
+	assert.Equal(1, todo.ID)
+	assert.Equal(int32(3), atomic.LoadInt32(&count))
+
+	// POST is not retried by default
+	atomic.StoreInt32(&count, 0)
+	_, err = PostJSON[restTodo, restTodo](context.Background(), server.URL, restTodo{}, WithRestRetry(3, time.Millisecond))
+	assert.IsNotNil(err)
+	assert.Equal(int32(1), atomic.LoadInt32(&count))
+
+	atomic.StoreInt32(&count, 0)
+	_, err = PostJSON[restTodo, restTodo](context.Background(), server.URL, restTodo{},
+		WithRestRetry(3, time.Millisecond), WithRestRetryNonIdempotent())
+	assert.IsNil(err)
+	assert.Equal(int32(3), atomic.LoadInt32(&count))
+}
+
+func TestRestTimeout(t *testing.T) {
+	assert := internal.NewAssert(t, "TestRestTimeout")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	_, err := GetJSON[restTodo](context.Background(), server.URL, WithRestTimeout(50*time.Millisecond))
+	assert.Equal(true, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(true, time.Since(start) < 500*time.Millisecond)
+}
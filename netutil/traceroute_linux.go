@@ -0,0 +1,149 @@
+//go:build linux
+
+package netutil
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+const (
+	tracerouteBasePort = 33434
+	soEeOriginICMP     = 2
+)
+
+// udpTraceroute sends UDP probes with increasing ttl, and reads the ICMP errors from the socket
+// error queue (IP_RECVERR) instead of a raw socket, so it needs no permission.
+func udpTraceroute(ip net.IP, maxHops int, timeout time.Duration) ([]TraceHop, error) {
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setsockopt(raw, syscall.IP_RECVERR, 1); err != nil {
+		return nil, err
+	}
+
+	hops := make([]TraceHop, 0, maxHops)
+
+	for ttl := 1; ttl <= maxHops; ttl++ {
+		if err := setsockopt(raw, syscall.IP_TTL, ttl); err != nil {
+			return nil, err
+		}
+
+		payload := make([]byte, 8)
+		binary.BigEndian.PutUint32(payload, uint32(os.Getpid()))
+		binary.BigEndian.PutUint32(payload[4:], uint32(ttl))
+
+		start := time.Now()
+		dst := &net.UDPAddr{IP: ip, Port: tracerouteBasePort + ttl - 1}
+		if _, err := conn.WriteTo(payload, dst); err != nil {
+			return nil, err
+		}
+
+		if err := conn.SetReadDeadline(start.Add(timeout)); err != nil {
+			return nil, err
+		}
+
+		hop := TraceHop{TTL: ttl, Timeout: true}
+		for {
+			ee, err := readErrQueue(raw)
+			if err != nil {
+				if errors.Is(err, os.ErrDeadlineExceeded) {
+					break
+				}
+				return nil, err
+			}
+			if ee.origin != soEeOriginICMP || string(ee.payload) != string(payload) {
+				continue
+			}
+
+			hop.Timeout = false
+			hop.Addr = ee.offender.String()
+			hop.RTT = time.Since(start)
+			hop.Reached = ee.typ == icmpDestUnreachable
+			break
+		}
+		hops = append(hops, hop)
+
+		if hop.Reached {
+			break
+		}
+	}
+
+	return hops, nil
+}
+
+func setsockopt(raw syscall.RawConn, opt, value int) error {
+	var sockErr error
+	err := raw.Control(func(fd uintptr) {
+		sockErr = syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IP, opt, value)
+	})
+	if err != nil {
+		return err
+	}
+
+	return sockErr
+}
+
+// sockExtendedErr is the struct sock_extended_err with the offender address and original payload.
+type sockExtendedErr struct {
+	origin   int
+	typ      int
+	offender net.IP
+	payload  []byte
+}
+
+func readErrQueue(raw syscall.RawConn) (*sockExtendedErr, error) {
+	buf := make([]byte, 512)
+	oob := make([]byte, 512)
+
+	var n, oobn int
+	var recvErr error
+
+	err := raw.Read(func(fd uintptr) bool {
+		n, oobn, _, _, recvErr = syscall.Recvmsg(int(fd), buf, oob, syscall.MSG_ERRQUEUE)
+		return recvErr != syscall.EAGAIN
+	})
+	if err != nil {
+		return nil, err
+	}
+	if recvErr != nil {
+		return nil, os.NewSyscallError("recvmsg", recvErr)
+	}
+
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range msgs {
+		if m.Header.Level != syscall.SOL_IP || m.Header.Type != syscall.IP_RECVERR {
+			continue
+		}
+		// ee_errno(4) ee_origin(1) ee_type(1) ee_code(1) ee_pad(1) ee_info(4) ee_data(4),
+		// followed by the offender sockaddr_in
+		if len(m.Data) < 16+8 {
+			continue
+		}
+
+		return &sockExtendedErr{
+			origin:   int(m.Data[4]),
+			typ:      int(m.Data[5]),
+			offender: net.IPv4(m.Data[20], m.Data[21], m.Data[22], m.Data[23]),
+			payload:  buf[:n],
+		}, nil
+	}
+
+	return &sockExtendedErr{}, nil
+}
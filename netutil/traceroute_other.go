@@ -0,0 +1,14 @@
+//go:build !linux
+
+package netutil
+
+import (
+	"errors"
+	"net"
+	"runtime"
+	"time"
+)
+
+func udpTraceroute(ip net.IP, maxHops int, timeout time.Duration) ([]TraceHop, error) {
+	return nil, errors.New("netutil: unprivileged traceroute is not supported on " + runtime.GOOS)
+}
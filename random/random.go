@@ -5,7 +5,9 @@
 package random
 
 import (
+	"bufio"
 	crand "crypto/rand"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"math"
@@ -228,3 +230,157 @@ func RandFloats(n int, min, max float64, precision int) []float64 {
 
 	return nums
 }
+
+// RandTimeBetween generate random time between [start, end).
+func RandTimeBetween(start, end time.Time) time.Time {
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	d := end.Sub(start)
+	if d <= 0 {
+		return start
+	}
+
+	return start.Add(time.Duration(rand.Int63n(int64(d))))
+}
+
+// RandDate generate random date (the start of a day) between the dates of start and end, both inclusive,
+// in the location of start.
+func RandDate(start, end time.Time) time.Time {
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	loc := start.Location()
+	end = end.In(loc)
+	startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	endDate := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+
+	// count days in utc, so daylight saving time doesn't matter
+	utcStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	utcEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(utcEnd.Sub(utcStart) / (24 * time.Hour))
+
+	return startDate.AddDate(0, 0, rand.Intn(days+1))
+}
+
+// RandChoice returns a random element of slice, or the zero value if the slice is empty.
+func RandChoice[T any](s []T) T {
+	if len(s) == 0 {
+		var zero T
+		return zero
+	}
+
+	return s[rand.Intn(len(s))]
+}
+
+// RandChoices returns k random elements of slice. If withReplacement is false, every element is
+// picked at most once and at most len(s) elements are returned.
+func RandChoices[T any](s []T, k int, withReplacement bool) []T {
+	if k <= 0 || len(s) == 0 {
+		return []T{}
+	}
+
+	if withReplacement {
+		result := make([]T, k)
+		for i := range result {
+			result[i] = s[rand.Intn(len(s))]
+		}
+		return result
+	}
+
+	if k > len(s) {
+		k = len(s)
+	}
+
+	// partial fisher-yates on the indexes, only the swapped indexes are recorded
+	swapped := make(map[int]int, k)
+	indexOf := func(i int) int {
+		if j, ok := swapped[i]; ok {
+			return j
+		}
+		return i
+	}
+
+	result := make([]T, k)
+	for i := 0; i < k; i++ {
+		j := i + rand.Intn(len(s)-i)
+		vi, vj := indexOf(i), indexOf(j)
+		swapped[i], swapped[j] = vj, vi
+		result[i] = s[vj]
+	}
+
+	return result
+}
+
+// RandBool returns true with the probability, which is between [0, 1].
+func RandBool(probability float64) bool {
+	if probability <= 0 {
+		return false
+	}
+	if probability >= 1 {
+		return true
+	}
+
+	return rand.Float64() < probability
+}
+
+// SecureShuffle shuffles the slice in place with crypto/rand source, e.g. for lottery or card games which
+// should not be predictable. It returns the shuffled slice.
+func SecureShuffle[T any](s []T) ([]T, error) {
+	r := newSecureIntn()
+	for i := len(s) - 1; i > 0; i-- {
+		j, err := r.intn(i + 1)
+		if err != nil {
+			return s, err
+		}
+		s[i], s[j] = s[j], s[i]
+	}
+
+	return s, nil
+}
+
+// PartialShuffle shuffles only the first k positions of slice in place in O(k), and returns them,
+// they are a uniform random sample of the whole slice. k is limited to len(s).
+func PartialShuffle[T any](s []T, k int) []T {
+	if k <= 0 {
+		return s[:0]
+	}
+	if k > len(s) {
+		k = len(s)
+	}
+
+	for i := 0; i < k; i++ {
+		j := i + rand.Intn(len(s)-i)
+		s[i], s[j] = s[j], s[i]
+	}
+
+	return s[:k]
+}
+
+// secureIntn generates uniform random numbers from crypto/rand, the random bytes are buffered.
+type secureIntn struct {
+	r io.Reader
+}
+
+func newSecureIntn() *secureIntn {
+	return &secureIntn{r: bufio.NewReaderSize(crand.Reader, 256)}
+}
+
+// intn returns random int in [0, n) without modulo bias.
+func (s *secureIntn) intn(n int) (int, error) {
+	var buf [8]byte
+	max := uint64(n)
+	limit := math.MaxUint64 - math.MaxUint64%max
+
+	for {
+		if _, err := io.ReadFull(s.r, buf[:]); err != nil {
+			return 0, err
+		}
+		v := binary.BigEndian.Uint64(buf[:])
+		if v < limit {
+			return int(v % max), nil
+		}
+	}
+}
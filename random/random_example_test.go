@@ -185,3 +185,37 @@ func ExampleRandFloats() {
 	// true
 	// 5
 }
+
+func ExampleRandChoice() {
+	result := RandChoice([]string{"a", "b", "c"})
+
+	if result == "a" || result == "b" || result == "c" {
+		fmt.Println("ok")
+	}
+
+	// Output:
+	// ok
+}
+
+func ExampleRandChoices() {
+	result1 := RandChoices([]int{1, 2, 3}, 5, true)
+	result2 := RandChoices([]int{1, 2, 3}, 5, false)
+
+	fmt.Println(len(result1))
+	fmt.Println(len(result2))
+
+	// Output:
+	// 5
+	// 3
+}
+
+func ExamplePartialShuffle() {
+	nums := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	sample := PartialShuffle(nums, 3)
+
+	fmt.Println(len(sample))
+
+	// Output:
+	// 3
+}
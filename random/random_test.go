@@ -3,7 +3,9 @@ package random
 import (
 	"reflect"
 	"regexp"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/duke-git/lancet/v2/internal"
 )
@@ -196,3 +198,174 @@ func TestRandFloats(t *testing.T) {
 
 	assert.Equal(len(numbers), 5)
 }
+
+func TestRandTimeBetween(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRandTimeBetween")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	for i := 0; i < 100; i++ {
+		tm := RandTimeBetween(start, end)
+		assert.Equal(true, !tm.Before(start) && tm.Before(end))
+
+		tm = RandTimeBetween(end, start)
+		assert.Equal(true, !tm.Before(start) && tm.Before(end))
+	}
+
+	assert.Equal(start, RandTimeBetween(start, start))
+}
+
+func TestRandDate(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRandDate")
+
+	start := time.Date(2024, 2, 27, 15, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	seen := map[int]bool{}
+	for i := 0; i < 500; i++ {
+		date := RandDate(start, end)
+		assert.Equal(0, date.Hour()+date.Minute()+date.Second()+date.Nanosecond())
+		assert.Equal(true, !date.Before(start.Truncate(24*time.Hour)) && !date.After(end))
+		seen[date.Day()] = true
+	}
+	assert.Equal(map[int]bool{27: true, 28: true, 29: true, 1: true}, seen)
+
+	assert.Equal(time.Date(2024, 2, 27, 0, 0, 0, 0, time.UTC), RandDate(start, start))
+}
+
+func TestRandChoice(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRandChoice")
+
+	s := []string{"a", "b", "c"}
+	for i := 0; i < 20; i++ {
+		v := RandChoice(s)
+		assert.Equal(true, v == "a" || v == "b" || v == "c")
+	}
+
+	assert.Equal("", RandChoice([]string{}))
+	assert.Equal(0, RandChoice[int](nil))
+}
+
+func TestRandChoices(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRandChoices")
+
+	s := []int{1, 2, 3, 4, 5}
+
+	result := RandChoices(s, 10, true)
+	assert.Equal(10, len(result))
+	for _, v := range result {
+		assert.Equal(true, v >= 1 && v <= 5)
+	}
+
+	for i := 0; i < 50; i++ {
+		result = RandChoices(s, 3, false)
+		assert.Equal(3, len(result))
+
+		used := map[int]bool{}
+		for _, v := range result {
+			assert.Equal(true, v >= 1 && v <= 5)
+			assert.Equal(false, used[v])
+			used[v] = true
+		}
+	}
+
+	all := RandChoices(s, 10, false)
+	assert.Equal(5, len(all))
+	sort.Ints(all)
+	assert.Equal(s, all)
+	assert.Equal([]int{1, 2, 3, 4, 5}, s)
+
+	assert.Equal([]int{}, RandChoices(s, 0, false))
+	assert.Equal([]int{}, RandChoices([]int{}, 3, true))
+}
+
+func TestRandBool(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRandBool")
+
+	trueCount := 0
+	for i := 0; i < 10000; i++ {
+		assert.Equal(false, RandBool(0))
+		assert.Equal(true, RandBool(1))
+		if RandBool(0.3) {
+			trueCount++
+		}
+	}
+	assert.Equal(true, trueCount > 2500 && trueCount < 3500)
+}
+
+func TestSecureShuffle(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSecureShuffle")
+
+	s := make([]int, 100)
+	for i := range s {
+		s[i] = i
+	}
+
+	shuffled, err := SecureShuffle(append([]int{}, s...))
+	assert.IsNil(err)
+	assert.Equal(100, len(shuffled))
+	assert.NotEqual(s, shuffled)
+
+	sort.Ints(shuffled)
+	assert.Equal(s, shuffled)
+
+	empty, err := SecureShuffle([]int{})
+	assert.IsNil(err)
+	assert.Equal([]int{}, empty)
+
+	// every position should get every value with close probability
+	counts := [3][3]int{}
+	for i := 0; i < 3000; i++ {
+		r, _ := SecureShuffle([]int{0, 1, 2})
+		for pos, v := range r {
+			counts[pos][v]++
+		}
+	}
+	for _, row := range counts {
+		for _, c := range row {
+			assert.Equal(true, c > 800 && c < 1200)
+		}
+	}
+}
+
+func TestPartialShuffle(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPartialShuffle")
+
+	s := make([]int, 1000)
+	for i := range s {
+		s[i] = i
+	}
+
+	sample := PartialShuffle(s, 10)
+	assert.Equal(10, len(sample))
+
+	used := map[int]bool{}
+	for _, v := range sample {
+		assert.Equal(false, used[v])
+		used[v] = true
+	}
+
+	sorted := append([]int{}, s...)
+	sort.Ints(sorted)
+	for i, v := range sorted {
+		assert.Equal(i, v)
+	}
+
+	assert.Equal(1000, len(PartialShuffle(s, 2000)))
+	assert.Equal(0, len(PartialShuffle(s, 0)))
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package retry
+
+import "time"
+
+// Policy is a reusable set of retry options. It could be stored, shared and composed with overrides,
+// so the same options don't need to be re-specified at every call site.
+// Policy is immutable, With and Compose always return a new Policy.
+type Policy struct {
+	options []Option
+}
+
+var (
+	// Quick retries 3 times with a short linear backoff of 100ms, for cheap local operations.
+	Quick = NewPolicy(
+		RetryTimes(3),
+		RetryWithLinearBackoff(100*time.Millisecond),
+	)
+
+	// NetworkDefault retries 5 times with exponential backoff starting at 200ms and up to 100ms jitter,
+	// for remote calls like http requests.
+	NetworkDefault = NewPolicy(
+		RetryTimes(5),
+		RetryWithExponentialWithJitterBackoff(200*time.Millisecond, 2, 100*time.Millisecond),
+	)
+
+	// Database retries 4 times with exponential backoff starting at 500ms and up to 250ms jitter,
+	// which gives the database some time to recover from transient failures.
+	Database = NewPolicy(
+		RetryTimes(4),
+		RetryWithExponentialWithJitterBackoff(500*time.Millisecond, 2, 250*time.Millisecond),
+	)
+)
+
+// NewPolicy creates a Policy with options.
+func NewPolicy(opts ...Option) Policy {
+	return Policy{options: append([]Option(nil), opts...)}
+}
+
+// With returns a new Policy with opts applied after the options of p, so they override the same settings.
+func (p Policy) With(opts ...Option) Policy {
+	options := make([]Option, 0, len(p.options)+len(opts))
+	options = append(options, p.options...)
+	options = append(options, opts...)
+
+	return Policy{options: options}
+}
+
+// Options returns a copy of the options of the policy, so it could be passed to Retry with other options.
+func (p Policy) Options() []Option {
+	return append([]Option(nil), p.options...)
+}
+
+// Retry executes retryFunc with the options of the policy, opts override the policy options for this call only.
+func (p Policy) Retry(retryFunc RetryFunc, opts ...Option) error {
+	return Retry(retryFunc, p.With(opts...).options...)
+}
+
+// Compose merges policies into one, the options of later policy override the earlier ones.
+func Compose(policies ...Policy) Policy {
+	var result Policy
+	for _, p := range policies {
+		result = result.With(p.options...)
+	}
+
+	return result
+}
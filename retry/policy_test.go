@@ -0,0 +1,109 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestPolicy_Retry(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPolicy_Retry")
+
+	policy := NewPolicy(RetryTimes(3), RetryWithLinearBackoff(time.Microsecond*50))
+
+	var number int
+	increaseNumber := func() error {
+		number++
+		return errors.New("error occurs")
+	}
+
+	err := policy.Retry(increaseNumber)
+	assert.IsNotNil(err)
+	assert.Equal(3, number)
+
+	// policy is reusable
+	number = 0
+	err = policy.Retry(increaseNumber)
+	assert.IsNotNil(err)
+	assert.Equal(3, number)
+
+	// override for single call
+	number = 0
+	err = policy.Retry(increaseNumber, RetryTimes(2))
+	assert.IsNotNil(err)
+	assert.Equal(2, number)
+}
+
+func TestPolicy_With(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPolicy_With")
+
+	base := NewPolicy(RetryTimes(3), RetryWithLinearBackoff(time.Microsecond*50))
+	more := base.With(RetryTimes(6))
+
+	assert.Equal(2, len(base.Options()))
+	assert.Equal(3, len(more.Options()))
+
+	var number int
+	increaseNumber := func() error {
+		number++
+		return errors.New("error occurs")
+	}
+
+	_ = more.Retry(increaseNumber)
+	assert.Equal(6, number)
+
+	number = 0
+	_ = base.Retry(increaseNumber)
+	assert.Equal(3, number)
+}
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCompose")
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	policy := Compose(
+		Quick,
+		NewPolicy(RetryWithLinearBackoff(time.Microsecond*50)),
+		NewPolicy(Context(ctx)),
+	)
+
+	var number int
+	increaseNumber := func() error {
+		number++
+		if number == 2 {
+			return nil
+		}
+		return errors.New("error occurs")
+	}
+
+	err := policy.Retry(increaseNumber)
+	assert.IsNil(err)
+	assert.Equal(2, number)
+}
+
+func TestPresetPolicies(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPresetPolicies")
+
+	for _, p := range []Policy{Quick, NetworkDefault, Database} {
+		var number int
+		err := p.Retry(func() error {
+			number++
+			return nil
+		})
+		assert.IsNil(err)
+		assert.Equal(1, number)
+	}
+}
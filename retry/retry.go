@@ -14,6 +14,8 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/duke-git/lancet/v2/datetime"
 )
 
 const (
@@ -23,16 +25,42 @@ const (
 	DefaultRetryLinearInterval = time.Second * 3
 )
 
+// ErrWouldExceedDeadline is returned if the next attempt can't be started before the deadline of context after
+// backoff, when FailFastOnDeadline or TruncateBackoffToDeadline is set.
+var ErrWouldExceedDeadline = errors.New("retry: backoff would exceed the deadline")
+
+// deadlineStrategy is the way to handle the backoff interval passing the deadline of context.
+type deadlineStrategy int
+
+const (
+	// deadlineWait waits the backoff interval until the context is done
+	deadlineWait deadlineStrategy = iota
+	deadlineFailFast
+	deadlineTruncate
+)
+
+// attemptKey is the context key of the attempt number.
+type attemptKey struct{}
+
 // RetryConfig is config for retry
 type RetryConfig struct {
 	context         context.Context
 	retryTimes      uint
 	backoffStrategy BackoffStrategy
+	attemptTimeout  time.Duration
+	totalTimeout    time.Duration
+	clock           datetime.Clock
+
+	deadlineStrategy deadlineStrategy
+	deadlineReserve  time.Duration
 }
 
 // RetryFunc is function that retry executes
 type RetryFunc func() error
 
+// ContextRetryFunc is function that RetryWithContext executes, ctx is derived for every attempt.
+type ContextRetryFunc func(ctx context.Context) error
+
 // Option is for adding retry config
 type Option func(*RetryConfig)
 
@@ -108,13 +136,91 @@ func Context(ctx context.Context) Option {
 	}
 }
 
+// RetryWithClock set the clock used to wait for backoff intervals, eg: a datetime.FakeClock in tests.
+func RetryWithClock(clock datetime.Clock) Option {
+	if clock == nil {
+		panic("programming error: clock must be not nil")
+	}
+
+	return func(rc *RetryConfig) {
+		rc.clock = clock
+	}
+}
+
+// AttemptTimeout set the timeout of every single attempt. A hung attempt is abandoned after d,
+// and counted as a failed attempt, so retries continue until retry times or the total timeout is reached.
+func AttemptTimeout(d time.Duration) Option {
+	if d <= 0 {
+		panic("programming error: attempt timeout should not be lower or equal to 0")
+	}
+
+	return func(rc *RetryConfig) {
+		rc.attemptTimeout = d
+	}
+}
+
+// TotalTimeout set the overall deadline of all the attempts and backoff intervals.
+func TotalTimeout(d time.Duration) Option {
+	if d <= 0 {
+		panic("programming error: total timeout should not be lower or equal to 0")
+	}
+
+	return func(rc *RetryConfig) {
+		rc.totalTimeout = d
+	}
+}
+
+// FailFastOnDeadline makes retry return ErrWouldExceedDeadline at once, if the next backoff interval would pass
+// the deadline of context, rather than waiting until the context is done.
+func FailFastOnDeadline() Option {
+	return func(rc *RetryConfig) {
+		rc.deadlineStrategy = deadlineFailFast
+		rc.deadlineReserve = 0
+	}
+}
+
+// TruncateBackoffToDeadline truncates the backoff interval which would pass the deadline of context minus reserve,
+// so the last attempt has at least reserve time to run, eg: reserve is the expected duration of an attempt.
+// If the remaining time is not longer than reserve, retry returns ErrWouldExceedDeadline at once.
+func TruncateBackoffToDeadline(reserve time.Duration) Option {
+	if reserve < 0 {
+		panic("programming error: deadline reserve should not be lower to 0")
+	}
+
+	return func(rc *RetryConfig) {
+		rc.deadlineStrategy = deadlineTruncate
+		rc.deadlineReserve = reserve
+	}
+}
+
+// AttemptFromContext returns the number of the current attempt starting from 1, ctx is the context passed to
+// ContextRetryFunc. It returns false if ctx is not from retry.
+func AttemptFromContext(ctx context.Context) (uint, bool) {
+	attempt, ok := ctx.Value(attemptKey{}).(uint)
+	return attempt, ok
+}
+
 // Retry executes the retryFunc repeatedly until it was successful or canceled by the context
 // The default times of retries is 5 and the default duration between retries is 3 seconds.
 // Play: https://go.dev/play/p/nk2XRmagfVF
 func Retry(retryFunc RetryFunc, opts ...Option) error {
+	return retry(getFuncName(retryFunc), func(ctx context.Context) error {
+		return retryFunc()
+	}, opts...)
+}
+
+// RetryWithContext is like Retry, but retryFunc receives a context which is done when the attempt
+// timeout, the total timeout or the context set by Context option is reached. The attempt number could be got
+// from the context by AttemptFromContext.
+func RetryWithContext(retryFunc ContextRetryFunc, opts ...Option) error {
+	return retry(getFuncName(retryFunc), retryFunc, opts...)
+}
+
+func retry(funcName string, retryFunc ContextRetryFunc, opts ...Option) error {
 	config := &RetryConfig{
 		retryTimes: DefaultRetryTimes,
 		context:    context.TODO(),
+		clock:      datetime.SystemClock,
 	}
 
 	for _, opt := range opts {
@@ -127,13 +233,27 @@ func Retry(retryFunc RetryFunc, opts ...Option) error {
 		}
 	}
 
+	ctx := config.context
+	if config.totalTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.totalTimeout)
+		defer cancel()
+	}
+
 	var i uint
 	for i < config.retryTimes {
-		err := retryFunc()
+		err := runAttempt(context.WithValue(ctx, attemptKey{}, i+1), retryFunc, config)
 		if err != nil {
+			interval := config.backoffStrategy.CalculateInterval()
+			if i+1 < config.retryTimes {
+				if interval, err = fitDeadline(ctx, config, interval, err); err != nil {
+					return err
+				}
+			}
+
 			select {
-			case <-time.After(config.backoffStrategy.CalculateInterval()):
-			case <-config.context.Done():
+			case <-config.clock.After(interval):
+			case <-ctx.Done():
 				return errors.New("retry is cancelled")
 			}
 		} else {
@@ -142,11 +262,59 @@ func Retry(retryFunc RetryFunc, opts ...Option) error {
 		i++
 	}
 
-	funcPath := runtime.FuncForPC(reflect.ValueOf(retryFunc).Pointer()).Name()
+	return fmt.Errorf("function %s run failed after %d times retry", funcName, i)
+}
+
+// fitDeadline adjusts the backoff interval by the deadline strategy, lastErr is the error of the last attempt.
+func fitDeadline(ctx context.Context, config *RetryConfig, interval time.Duration, lastErr error) (time.Duration, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok || config.deadlineStrategy == deadlineWait {
+		return interval, nil
+	}
+
+	remaining := deadline.Sub(config.clock.Now())
+	if remaining-config.deadlineReserve >= interval {
+		return interval, nil
+	}
+
+	if config.deadlineStrategy == deadlineTruncate && remaining > config.deadlineReserve {
+		return remaining - config.deadlineReserve, nil
+	}
+
+	return 0, fmt.Errorf("%w: backoff %s, remaining %s, last error: %v", ErrWouldExceedDeadline, interval, remaining, lastErr)
+}
+
+// runAttempt runs retryFunc once. If any timeout is set, retryFunc runs in a new goroutine,
+// and it's abandoned once its context is done.
+func runAttempt(ctx context.Context, retryFunc ContextRetryFunc, config *RetryConfig) error {
+	if config.attemptTimeout <= 0 && config.totalTimeout <= 0 {
+		return retryFunc(ctx)
+	}
+
+	if config.attemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.attemptTimeout)
+		defer cancel()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- retryFunc(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func getFuncName(fn any) string {
+	funcPath := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
 	lastSlash := strings.LastIndex(funcPath, "/")
-	funcName := funcPath[lastSlash+1:]
 
-	return fmt.Errorf("function %s run failed after %d times retry", funcName, i)
+	return funcPath[lastSlash+1:]
 }
 
 // BackoffStrategy is an interface that defines a method for calculating backoff intervals.
@@ -141,3 +141,45 @@ func ExampleRetry() {
 	// Output:
 	// 3
 }
+
+func ExampleFailFastOnDeadline() {
+	number := 0
+	increaseNumber := func() error {
+		number++
+		return errors.New("error occurs")
+	}
+
+	err := Retry(increaseNumber,
+		TotalTimeout(time.Second),
+		RetryWithLinearBackoff(time.Minute),
+		FailFastOnDeadline(),
+	)
+
+	fmt.Println(number)
+	fmt.Println(errors.Is(err, ErrWouldExceedDeadline))
+
+	// Output:
+	// 1
+	// true
+}
+
+func ExampleAttemptFromContext() {
+	logAttempt := func(ctx context.Context) error {
+		attempt, _ := AttemptFromContext(ctx)
+		fmt.Println("attempt", attempt)
+		if attempt < 3 {
+			return errors.New("error occurs")
+		}
+		return nil
+	}
+
+	err := RetryWithContext(logAttempt, RetryWithLinearBackoff(time.Microsecond*50))
+
+	fmt.Println(err)
+
+	// Output:
+	// attempt 1
+	// attempt 2
+	// attempt 3
+	// <nil>
+}
@@ -3,9 +3,12 @@ package retry
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/duke-git/lancet/v2/datetime"
 	"github.com/duke-git/lancet/v2/internal"
 )
 
@@ -256,3 +259,211 @@ func TestCancelRetry(t *testing.T) {
 	assert.IsNotNil(err)
 	assert.Equal(4, number)
 }
+
+func TestRetryWithAttemptTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryWithAttemptTimeout")
+
+	var number int32
+	hangOnce := func(ctx context.Context) error {
+		if atomic.AddInt32(&number, 1) == 1 {
+			<-ctx.Done()
+			time.Sleep(time.Second)
+			return nil
+		}
+		return nil
+	}
+
+	start := time.Now()
+	err := RetryWithContext(hangOnce,
+		AttemptTimeout(time.Millisecond*20),
+		RetryWithLinearBackoff(time.Microsecond*50),
+	)
+
+	assert.IsNil(err)
+	assert.Equal(int32(2), atomic.LoadInt32(&number))
+	assert.Greater(time.Millisecond*500, time.Since(start))
+}
+
+func TestRetryWithTotalTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryWithTotalTimeout")
+
+	var number int32
+	alwaysHang := func() error {
+		atomic.AddInt32(&number, 1)
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	start := time.Now()
+	err := Retry(alwaysHang,
+		RetryTimes(100),
+		AttemptTimeout(time.Millisecond*20),
+		TotalTimeout(time.Millisecond*100),
+		RetryWithLinearBackoff(time.Microsecond*50),
+	)
+
+	assert.IsNotNil(err)
+	assert.Equal("retry is cancelled", err.Error())
+	assert.Greater(time.Millisecond*500, time.Since(start))
+	assert.Greater(int32(100), atomic.LoadInt32(&number))
+	assert.GreaterOrEqual(atomic.LoadInt32(&number), int32(2))
+}
+
+func TestRetryWithContextFailed(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryWithContextFailed")
+
+	var number int
+	increaseNumber := func(ctx context.Context) error {
+		number++
+		return errors.New("error occurs")
+	}
+
+	err := RetryWithContext(increaseNumber, RetryTimes(3), RetryWithLinearBackoff(time.Microsecond*50))
+
+	assert.IsNotNil(err)
+	assert.Equal(3, number)
+}
+
+func TestRetryWithClock(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryWithClock")
+
+	clock := datetime.NewFakeClock(time.Now())
+
+	var number int32
+	increaseNumber := func() error {
+		if atomic.AddInt32(&number, 1) == 3 {
+			return nil
+		}
+		return errors.New("error occurs")
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- Retry(increaseNumber, RetryWithLinearBackoff(time.Hour), RetryWithClock(clock))
+	}()
+
+	clock.BlockUntil(1)
+	assert.Equal(int32(1), atomic.LoadInt32(&number))
+	clock.Advance(time.Hour)
+
+	clock.BlockUntil(1)
+	assert.Equal(int32(2), atomic.LoadInt32(&number))
+	clock.Advance(time.Hour)
+
+	assert.IsNil(<-done)
+	assert.Equal(int32(3), atomic.LoadInt32(&number))
+}
+
+func TestRetryFailFastOnDeadline(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryFailFastOnDeadline")
+
+	var number int
+	increaseNumber := func() error {
+		number++
+		return errors.New("error occurs")
+	}
+
+	start := time.Now()
+	err := Retry(increaseNumber,
+		TotalTimeout(time.Second),
+		RetryWithLinearBackoff(time.Hour),
+		FailFastOnDeadline(),
+	)
+
+	assert.Equal(true, errors.Is(err, ErrWouldExceedDeadline))
+	assert.Equal(true, strings.HasSuffix(err.Error(), "last error: error occurs"))
+	assert.Equal(1, number)
+	assert.Greater(time.Millisecond*500, time.Since(start))
+
+	// the backoff interval before the deadline is not changed
+	number = 0
+	err = Retry(increaseNumber,
+		RetryTimes(3),
+		TotalTimeout(time.Second),
+		RetryWithLinearBackoff(time.Microsecond*50),
+		FailFastOnDeadline(),
+	)
+
+	assert.Equal("function retry.TestRetryFailFastOnDeadline.func1 run failed after 3 times retry", err.Error())
+	assert.Equal(3, number)
+}
+
+func TestRetryTruncateBackoffToDeadline(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRetryTruncateBackoffToDeadline")
+
+	var number int
+	succeedTwice := func() error {
+		number++
+		if number == 2 {
+			return nil
+		}
+		return errors.New("error occurs")
+	}
+
+	start := time.Now()
+	err := Retry(succeedTwice,
+		TotalTimeout(time.Millisecond*200),
+		RetryWithLinearBackoff(time.Hour),
+		TruncateBackoffToDeadline(time.Millisecond*100),
+	)
+
+	assert.IsNil(err)
+	assert.Equal(2, number)
+	assert.GreaterOrEqual(time.Since(start), time.Millisecond*50)
+	assert.Greater(time.Millisecond*200, time.Since(start))
+
+	// the remaining time is not longer than reserve
+	number = 0
+	err = Retry(succeedTwice,
+		TotalTimeout(time.Millisecond*50),
+		RetryWithLinearBackoff(time.Hour),
+		TruncateBackoffToDeadline(time.Millisecond*100),
+	)
+
+	assert.Equal(true, errors.Is(err, ErrWouldExceedDeadline))
+	assert.Equal(1, number)
+
+	// no deadline
+	number = 0
+	err = Retry(succeedTwice,
+		RetryWithLinearBackoff(time.Microsecond*50),
+		TruncateBackoffToDeadline(time.Hour),
+	)
+
+	assert.IsNil(err)
+	assert.Equal(2, number)
+}
+
+func TestAttemptFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAttemptFromContext")
+
+	var attempts []uint
+	recordAttempt := func(ctx context.Context) error {
+		attempt, ok := AttemptFromContext(ctx)
+		assert.Equal(true, ok)
+		attempts = append(attempts, attempt)
+		return errors.New("error occurs")
+	}
+
+	err := RetryWithContext(recordAttempt, RetryTimes(3), RetryWithLinearBackoff(time.Microsecond*50))
+
+	assert.IsNotNil(err)
+	assert.Equal([]uint{1, 2, 3}, attempts)
+
+	_, ok := AttemptFromContext(context.Background())
+	assert.Equal(false, ok)
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/duke-git/lancet/v2/random"
+	"github.com/duke-git/lancet/v2/tuple"
 	"golang.org/x/exp/constraints"
 )
 
@@ -92,6 +93,83 @@ func Chunk[T any](slice []T, size int) [][]T {
 	return result
 }
 
+// Windows returns the sliding windows of slice with length size, the start of each window moves by step.
+// The trailing elements which can't fill a window are dropped. Each window is a new slice.
+func Windows[T any](slice []T, size, step int) [][]T {
+	result := [][]T{}
+
+	if size <= 0 || step <= 0 {
+		return result
+	}
+
+	for i := 0; i+size <= len(slice); i += step {
+		window := make([]T, size)
+		copy(window, slice[i:i+size])
+		result = append(result, window)
+	}
+
+	return result
+}
+
+// Pairwise returns the pairs of adjacent elements of slice, e.g. [1, 2, 3] => [(1, 2), (2, 3)].
+func Pairwise[T any](slice []T) []tuple.Tuple2[T, T] {
+	if len(slice) < 2 {
+		return []tuple.Tuple2[T, T]{}
+	}
+
+	result := make([]tuple.Tuple2[T, T], len(slice)-1)
+	for i := range result {
+		result[i] = tuple.NewTuple2(slice[i], slice[i+1])
+	}
+
+	return result
+}
+
+// Zip2 creates a slice of Tuple2 whose elements are the corresponding elements of a and b.
+// Unlike tuple.Zip2, the result is as long as the shorter one of a and b.
+func Zip2[A any, B any](a []A, b []B) []tuple.Tuple2[A, B] {
+	size := len(a)
+	if len(b) < size {
+		size = len(b)
+	}
+
+	result := make([]tuple.Tuple2[A, B], size)
+	for i := range result {
+		result[i] = tuple.NewTuple2(a[i], b[i])
+	}
+
+	return result
+}
+
+// Zip3 creates a slice of Tuple3 whose elements are the corresponding elements of a, b and c.
+// Unlike tuple.Zip3, the result is as long as the shortest one of a, b and c.
+func Zip3[A any, B any, C any](a []A, b []B, c []C) []tuple.Tuple3[A, B, C] {
+	size := len(a)
+	if len(b) < size {
+		size = len(b)
+	}
+	if len(c) < size {
+		size = len(c)
+	}
+
+	result := make([]tuple.Tuple3[A, B, C], size)
+	for i := range result {
+		result[i] = tuple.NewTuple3(a[i], b[i], c[i])
+	}
+
+	return result
+}
+
+// Unzip2 splits a slice of Tuple2 into two slices, it's the reverse of Zip2.
+func Unzip2[A any, B any](tuples []tuple.Tuple2[A, B]) ([]A, []B) {
+	return tuple.Unzip2(tuples)
+}
+
+// Unzip3 splits a slice of Tuple3 into three slices, it's the reverse of Zip3.
+func Unzip3[A any, B any, C any](tuples []tuple.Tuple3[A, B, C]) ([]A, []B, []C) {
+	return tuple.Unzip3(tuples)
+}
+
 // Compact creates a slice with all falsey values removed. The values false, nil, 0, and "" are falsey.
 // Play: https://go.dev/play/p/pO5AnxEr3TK
 func Compact[T comparable](slice []T) []T {
@@ -335,6 +413,55 @@ func GroupWith[T any, U comparable](slice []T, iteratee func(item T) U) map[U][]
 	return result
 }
 
+// GroupByMap groups the values projected by mapper by their keys, the values in a group keep the order of slice,
+// so the groups needn't be mapped again after GroupWith.
+func GroupByMap[T any, K comparable, V any](slice []T, mapper func(item T) (K, V)) map[K][]V {
+	result := make(map[K][]V)
+
+	for _, item := range slice {
+		k, v := mapper(item)
+		result[k] = append(result[k], v)
+	}
+
+	return result
+}
+
+// GroupByOrdered is like GroupWith, but also returns the keys in the order they're first seen in slice.
+func GroupByOrdered[T any, K comparable](slice []T, keyFn func(item T) K) ([]K, map[K][]T) {
+	keys := make([]K, 0)
+	groups := make(map[K][]T)
+
+	for _, item := range slice {
+		k := keyFn(item)
+		group, ok := groups[k]
+		if !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(group, item)
+	}
+
+	return keys, groups
+}
+
+// IndexBy converts a slice to a map by the keys of keyFn. If keys are duplicated, the first element wins if
+// firstWins is true, otherwise the last one wins like KeyBy.
+func IndexBy[T any, K comparable](slice []T, keyFn func(item T) K, firstWins bool) map[K]T {
+	if !firstWins {
+		return KeyBy(slice, keyFn)
+	}
+
+	result := make(map[K]T, len(slice))
+
+	for _, item := range slice {
+		k := keyFn(item)
+		if _, ok := result[k]; !ok {
+			result[k] = item
+		}
+	}
+
+	return result
+}
+
 // Find iterates over elements of slice, returning the first one that passes a truth test on predicate function.
 // If return T is nil then no items matched the predicate func.
 // Play: https://go.dev/play/p/CBKeBoHVLgq
@@ -573,6 +700,10 @@ func ReplaceAll[T comparable](slice []T, old T, new T) []T {
 // Repeat creates a slice with length n whose elements are param `item`.
 // Play: https://go.dev/play/p/1CbOmtgILUU
 func Repeat[T any](item T, n int) []T {
+	if n < 0 {
+		n = 0
+	}
+
 	result := make([]T, n)
 
 	for i := range result {
@@ -582,6 +713,71 @@ func Repeat[T any](item T, n int) []T {
 	return result
 }
 
+// RepeatSlice creates a slice which repeats the elements of slice n times.
+func RepeatSlice[T any](slice []T, n int) []T {
+	if n < 0 {
+		n = 0
+	}
+
+	result := make([]T, 0, len(slice)*n)
+
+	for i := 0; i < n; i++ {
+		result = append(result, slice...)
+	}
+
+	return result
+}
+
+// Fill sets the elements of slice from index `from` to index `to` (exclude) to value, the slice is modified.
+// The indexes are clamped into the range of slice.
+func Fill[T any](slice []T, value T, from, to int) {
+	if from < 0 {
+		from = 0
+	}
+	if to > len(slice) {
+		to = len(slice)
+	}
+
+	for i := from; i < to; i++ {
+		slice[i] = value
+	}
+}
+
+// PadLeft returns a copy of slice padded on the left side with padItem until its length is `length`.
+// If the slice is not shorter than length, a copy of it is returned. Unlike LeftPadding, length is the length
+// of result rather than the count of padding.
+func PadLeft[T any](slice []T, length int, padItem T) []T {
+	if len(slice) >= length {
+		return append([]T{}, slice...)
+	}
+
+	result := make([]T, length)
+	padding := length - len(slice)
+	for i := 0; i < padding; i++ {
+		result[i] = padItem
+	}
+	copy(result[padding:], slice)
+
+	return result
+}
+
+// PadRight returns a copy of slice padded on the right side with padItem until its length is `length`.
+// If the slice is not shorter than length, a copy of it is returned. Unlike RightPadding, length is the length
+// of result rather than the count of padding.
+func PadRight[T any](slice []T, length int, padItem T) []T {
+	if len(slice) >= length {
+		return append([]T{}, slice...)
+	}
+
+	result := make([]T, length)
+	copy(result, slice)
+	for i := len(slice); i < length; i++ {
+		result[i] = padItem
+	}
+
+	return result
+}
+
 // InterfaceSlice convert param to slice of interface.
 // This function is deprecated, use generics feature of go1.18+ for replacement.
 // Play: https://go.dev/play/p/FdQXF0Vvqs-
@@ -1010,6 +1206,75 @@ func SortBy[T any](slice []T, less func(a, b T) bool) {
 	quickSortBy(slice, 0, len(slice)-1, less)
 }
 
+// TopK returns the k largest elements of slice in descending order as determined by the less function, the slice
+// is not modified. It keeps a heap of k elements, so it's O(n*log(k)) and faster than sorting the whole slice.
+func TopK[T any](slice []T, k int, less func(a, b T) bool) []T {
+	if k <= 0 {
+		return []T{}
+	}
+	if k > len(slice) {
+		k = len(slice)
+	}
+
+	// the min heap of the largest k elements
+	result := make([]T, k)
+	copy(result, slice[:k])
+	heapify(result, less)
+
+	for _, v := range slice[k:] {
+		if less(result[0], v) {
+			result[0] = v
+			siftDown(result, 0, less)
+		}
+	}
+
+	// pop the min one to the end repeatedly, so the result is in descending order
+	for i := k - 1; i > 0; i-- {
+		swap(result, 0, i)
+		siftDown(result[:i], 0, less)
+	}
+
+	return result
+}
+
+// BottomK returns the k smallest elements of slice in ascending order as determined by the less function, the
+// slice is not modified.
+func BottomK[T any](slice []T, k int, less func(a, b T) bool) []T {
+	return TopK(slice, k, func(a, b T) bool {
+		return less(b, a)
+	})
+}
+
+// PartialSortBy rearranges the slice so that the first k elements are the smallest ones in ascending order as
+// determined by the less function, and the order of the rest is not specified. It's O(n*log(k)).
+func PartialSortBy[T any](slice []T, k int, less func(a, b T) bool) {
+	if k <= 0 {
+		return
+	}
+	if k > len(slice) {
+		k = len(slice)
+	}
+
+	// the max heap of the smallest k elements
+	greater := func(a, b T) bool {
+		return less(b, a)
+	}
+	heap := slice[:k]
+	heapify(heap, greater)
+
+	for i := k; i < len(slice); i++ {
+		if less(slice[i], heap[0]) {
+			swap(slice, 0, i)
+			siftDown(heap, 0, greater)
+		}
+	}
+
+	for i := k - 1; i > 0; i-- {
+		swap(heap, 0, i)
+		siftDown(heap[:i], 0, greater)
+	}
+}
+
 // SortByField return sorted slice by field
 // slice element should be struct, field type should be int, uint, string, or bool
 // default sortType is ascending (asc), if descending order, set sortType to desc
@@ -1253,6 +1518,34 @@ func Partition[T any](slice []T, predicates ...func(item T) bool) [][]T {
 	return result
 }
 
+// PartitionBy partitions the elements of slice into buckets by the keys returned by classifier, the elements in
+// each bucket keep the order of slice. It's the same as GroupWith.
+func PartitionBy[T any, K comparable](slice []T, classifier func(item T) K) map[K][]T {
+	return GroupWith(slice, classifier)
+}
+
+// Partition3 partitions the elements of slice by the fallible predicate into three groups: the elements matched,
+// the elements unmatched, and the elements failed to evaluate with their errors, errs[i] is the error of failed[i].
+// All the groups keep the order of slice.
+func Partition3[T any](slice []T, predicate func(item T) (bool, error)) (matched []T, unmatched []T, failed []T, errs []error) {
+	matched, unmatched, failed, errs = make([]T, 0), make([]T, 0), make([]T, 0), make([]error, 0)
+
+	for _, v := range slice {
+		ok, err := predicate(v)
+		switch {
+		case err != nil:
+			failed = append(failed, v)
+			errs = append(errs, err)
+		case ok:
+			matched = append(matched, v)
+		default:
+			unmatched = append(unmatched, v)
+		}
+	}
+
+	return matched, unmatched, failed, errs
+}
+
 // Breaks a list into two parts at the point where the predicate for the first time is true.
 // Play: https://go.dev/play/p/yLYcBTyeQIz
 func Break[T any](values []T, predicate func(T) bool) ([]T, []T) {
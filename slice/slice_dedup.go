@@ -0,0 +1,56 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package slice
+
+// Deduper filters the duplicate elements incrementally (thread unsafe), it remembers the elements have been
+// added, so the elements ingested batch by batch needn't be deduplicated by Unique over the whole slice again.
+type Deduper[T comparable] struct {
+	seen map[T]struct{}
+}
+
+// NewDeduper creates a Deduper pointer instance.
+func NewDeduper[T comparable]() *Deduper[T] {
+	return &Deduper[T]{seen: make(map[T]struct{})}
+}
+
+// Add adds item to the deduper, returns true if item is not added before.
+func (d *Deduper[T]) Add(item T) bool {
+	if _, ok := d.seen[item]; ok {
+		return false
+	}
+
+	d.seen[item] = struct{}{}
+
+	return true
+}
+
+// AddAll adds the items to the deduper, returns the items not added before in order, the duplicate
+// ones in items are also filtered.
+func (d *Deduper[T]) AddAll(items ...T) []T {
+	result := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if d.Add(item) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// Contain checks if item has been added.
+func (d *Deduper[T]) Contain(item T) bool {
+	_, ok := d.seen[item]
+	return ok
+}
+
+// Len returns the count of distinct items added.
+func (d *Deduper[T]) Len() int {
+	return len(d.seen)
+}
+
+// Reset forgets all items added.
+func (d *Deduper[T]) Reset() {
+	d.seen = make(map[T]struct{})
+}
@@ -0,0 +1,46 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestDeduper(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeduper")
+
+	deduper := NewDeduper[string]()
+
+	assert.Equal(true, deduper.Add("a"))
+	assert.Equal(false, deduper.Add("a"))
+	assert.Equal(true, deduper.Contain("a"))
+	assert.Equal(false, deduper.Contain("b"))
+
+	assert.Equal([]string{"b", "c"}, deduper.AddAll("a", "b", "c", "b"))
+	assert.Equal([]string{}, deduper.AddAll("c", "a"))
+	assert.Equal(3, deduper.Len())
+
+	deduper.Reset()
+	assert.Equal(0, deduper.Len())
+	assert.Equal(true, deduper.Add("a"))
+}
+
+func TestDeduper_Batches(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDeduper_Batches")
+
+	batches := [][]int{{1, 2, 2, 3}, {3, 4}, {1, 5, 4, 6}}
+
+	deduper := NewDeduper[int]()
+	collected := []int{}
+	all := []int{}
+	for _, batch := range batches {
+		collected = append(collected, deduper.AddAll(batch...)...)
+		all = append(all, batch...)
+	}
+
+	assert.Equal(Unique(all), collected)
+}
@@ -0,0 +1,223 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package slice
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrInvalidPatch is returned by Patch if the edit operations don't match the slice.
+var ErrInvalidPatch = errors.New("slice: edit operations don't match the slice")
+
+// Change is a pair of elements with the same key but different values.
+type Change[T any] struct {
+	Old T
+	New T
+}
+
+// DiffResult is the result of Diff.
+type DiffResult[T any] struct {
+	// Added are the elements in the new slice only, in the order of new slice
+	Added []T
+	// Removed are the elements in the old slice only, in the order of old slice
+	Removed []T
+	// Changed are the elements in both slices with different values, in the order of new slice
+	Changed []Change[T]
+}
+
+// Diff compares the elements of oldSlice and newSlice by the keys extracted by keyFn, e.g. sync the rows of
+// database with the payload of API by id. The elements with the same key are compared by reflect.DeepEqual.
+// If keys are duplicated, the elements with the same key are paired in order.
+func Diff[T any, K comparable](oldSlice, newSlice []T, keyFn func(item T) K) DiffResult[T] {
+	return DiffWith(oldSlice, newSlice, keyFn, func(a, b T) bool {
+		return reflect.DeepEqual(a, b)
+	})
+}
+
+// DiffWith is like Diff, but the elements with the same key are compared by equal function.
+func DiffWith[T any, K comparable](oldSlice, newSlice []T, keyFn func(item T) K, equal func(a, b T) bool) DiffResult[T] {
+	result := DiffResult[T]{
+		Added:   make([]T, 0),
+		Removed: make([]T, 0),
+		Changed: make([]Change[T], 0),
+	}
+
+	// the indexes of old elements by key, which are not paired yet
+	indexes := make(map[K][]int, len(oldSlice))
+	for i, v := range oldSlice {
+		k := keyFn(v)
+		indexes[k] = append(indexes[k], i)
+	}
+
+	paired := make([]bool, len(oldSlice))
+	for _, v := range newSlice {
+		k := keyFn(v)
+		queue := indexes[k]
+		if len(queue) == 0 {
+			result.Added = append(result.Added, v)
+			continue
+		}
+
+		i := queue[0]
+		indexes[k] = queue[1:]
+		paired[i] = true
+		if !equal(oldSlice[i], v) {
+			result.Changed = append(result.Changed, Change[T]{Old: oldSlice[i], New: v})
+		}
+	}
+
+	for i, v := range oldSlice {
+		if !paired[i] {
+			result.Removed = append(result.Removed, v)
+		}
+	}
+
+	return result
+}
+
+// EditKind is the kind of EditOp.
+type EditKind int
+
+const (
+	// EditDelete deletes an element of the old slice
+	EditDelete EditKind = iota
+	// EditInsert inserts an element of the new slice
+	EditInsert
+)
+
+// EditOp is an operation to edit the old slice into the new one.
+type EditOp[T any] struct {
+	Kind EditKind
+	// OldIndex is the index of deleted element in old slice, or the index in old slice before which
+	// the element is inserted
+	OldIndex int
+	// NewIndex is the index of inserted element in new slice, or the index in new slice before which
+	// the element is deleted
+	NewIndex int
+	// Item is the deleted or inserted element
+	Item T
+}
+
+// EditScript returns the shortest operations to edit oldSlice into newSlice, the elements not in the operations
+// are the longest common subsequence of the two slices.
+func EditScript[T comparable](oldSlice, newSlice []T) []EditOp[T] {
+	return EditScriptWith(oldSlice, newSlice, func(a, b T) bool {
+		return a == b
+	})
+}
+
+// EditScriptWith is like EditScript, but the elements are compared by equal function. It uses Myers' algorithm,
+// which is O((n+m)*d) in time and space, d is the count of operations, so it's fast for similar slices.
+func EditScriptWith[T any](oldSlice, newSlice []T, equal func(a, b T) bool) []EditOp[T] {
+	n, m := len(oldSlice), len(newSlice)
+	max := n + m
+	if max == 0 {
+		return []EditOp[T]{}
+	}
+
+	// v[offset+k] is the furthest x on diagonal k = x - y, and trace[d] is v before step d
+	offset := max
+	v := make([]int, 2*max+2)
+	trace := make([][]int, 0)
+
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				// move down, insert newSlice[y]
+				x = v[offset+k+1]
+			} else {
+				// move right, delete oldSlice[x]
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && equal(oldSlice[x], newSlice[y]) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrackEditScript(oldSlice, newSlice, trace, offset)
+			}
+		}
+	}
+
+	// unreachable, the script is found in n+m steps at most
+	return nil
+}
+
+// backtrackEditScript walks back from (n, m) to (0, 0) by trace to find the operations.
+func backtrackEditScript[T any](oldSlice, newSlice []T, trace [][]int, offset int) []EditOp[T] {
+	result := make([]EditOp[T], 0, len(trace)-1)
+	x, y := len(oldSlice), len(newSlice)
+
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		// skip the common elements on the diagonal
+		for x > prevX && y > prevY {
+			x--
+			y--
+		}
+
+		if x == prevX {
+			result = append(result, EditOp[T]{Kind: EditInsert, OldIndex: x, NewIndex: prevY, Item: newSlice[prevY]})
+		} else {
+			result = append(result, EditOp[T]{Kind: EditDelete, OldIndex: prevX, NewIndex: y, Item: oldSlice[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+
+	return result
+}
+
+// Patch applies the operations returned by EditScript to slice, and returns the edited slice, slice is not
+// modified. It returns ErrInvalidPatch if the indexes of operations are out of order or range.
+func Patch[T any](slice []T, ops []EditOp[T]) ([]T, error) {
+	result := make([]T, 0, len(slice))
+	// pos is the index of the next element of slice to copy
+	pos := 0
+
+	for _, op := range ops {
+		switch op.Kind {
+		case EditDelete:
+			if op.OldIndex < pos || op.OldIndex >= len(slice) {
+				return nil, ErrInvalidPatch
+			}
+			result = append(result, slice[pos:op.OldIndex]...)
+			pos = op.OldIndex + 1
+		case EditInsert:
+			count := op.NewIndex - len(result)
+			if count < 0 || pos+count > len(slice) {
+				return nil, ErrInvalidPatch
+			}
+			result = append(result, slice[pos:pos+count]...)
+			pos += count
+			result = append(result, op.Item)
+		default:
+			return nil, ErrInvalidPatch
+		}
+	}
+
+	return append(result, slice[pos:]...), nil
+}
@@ -0,0 +1,148 @@
+package slice
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiff")
+
+	type row struct {
+		ID   int
+		Name string
+		Tags []string
+	}
+
+	oldRows := []row{{1, "a", nil}, {2, "b", []string{"x"}}, {3, "c", nil}, {4, "d", nil}}
+	newRows := []row{{5, "e", nil}, {2, "b", []string{"y"}}, {1, "a", nil}, {4, "D", nil}}
+
+	result := Diff(oldRows, newRows, func(r row) int { return r.ID })
+
+	assert.Equal([]row{{5, "e", nil}}, result.Added)
+	assert.Equal([]row{{3, "c", nil}}, result.Removed)
+	assert.Equal([]Change[row]{
+		{Old: row{2, "b", []string{"x"}}, New: row{2, "b", []string{"y"}}},
+		{Old: row{4, "d", nil}, New: row{4, "D", nil}},
+	}, result.Changed)
+
+	empty := Diff([]row{}, []row{}, func(r row) int { return r.ID })
+	assert.Equal(DiffResult[row]{Added: []row{}, Removed: []row{}, Changed: []Change[row]{}}, empty)
+}
+
+func TestDiffWith(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiffWith")
+
+	lower := func(s string) string { return strings.ToLower(s) }
+
+	// the duplicated keys are paired in order
+	result := DiffWith([]string{"a", "A", "b"}, []string{"a", "B", "c", "A", "a"}, lower, func(a, b string) bool {
+		return a == b
+	})
+
+	assert.Equal([]string{"c", "a"}, result.Added)
+	assert.Equal([]string{}, result.Removed)
+	assert.Equal([]Change[string]{{Old: "b", New: "B"}}, result.Changed)
+}
+
+func TestEditScript(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEditScript")
+
+	oldSlice := strings.Split("ABCABBA", "")
+	newSlice := strings.Split("CBABAC", "")
+
+	ops := EditScript(oldSlice, newSlice)
+	// the LCS of the two is 4
+	assert.Equal(5, len(ops))
+
+	patched, err := Patch(oldSlice, ops)
+	assert.IsNil(err)
+	assert.Equal(newSlice, patched)
+
+	assert.Equal([]EditOp[int]{}, EditScript([]int{}, []int{}))
+	assert.Equal([]EditOp[int]{}, EditScript([]int{1, 2}, []int{1, 2}))
+
+	assert.Equal([]EditOp[int]{
+		{Kind: EditDelete, OldIndex: 1, NewIndex: 1, Item: 2},
+		{Kind: EditInsert, OldIndex: 3, NewIndex: 2, Item: 4},
+	}, EditScript([]int{1, 2, 3}, []int{1, 3, 4}))
+}
+
+// lcsLength returns the length of longest common subsequence by dynamic programming.
+func lcsLength(a, b []int) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else if dp[i-1][j] > dp[i][j-1] {
+				dp[i][j] = dp[i-1][j]
+			} else {
+				dp[i][j] = dp[i][j-1]
+			}
+		}
+	}
+
+	return dp[len(a)][len(b)]
+}
+
+func TestEditScript_Random(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEditScript_Random")
+
+	r := rand.New(rand.NewSource(1))
+	randomSlice := func() []int {
+		s := make([]int, r.Intn(30))
+		for i := range s {
+			s[i] = r.Intn(4)
+		}
+		return s
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := randomSlice(), randomSlice()
+
+		ops := EditScript(a, b)
+		assert.Equal(len(a)+len(b)-2*lcsLength(a, b), len(ops))
+
+		patched, err := Patch(a, ops)
+		assert.IsNil(err)
+		assert.Equal(b, patched)
+	}
+}
+
+func TestPatch_Invalid(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPatch_Invalid")
+
+	_, err := Patch([]int{1, 2}, []EditOp[int]{{Kind: EditDelete, OldIndex: 2}})
+	assert.Equal(ErrInvalidPatch, err)
+
+	_, err = Patch([]int{1, 2}, []EditOp[int]{{Kind: EditInsert, NewIndex: 5}})
+	assert.Equal(ErrInvalidPatch, err)
+
+	_, err = Patch([]int{1, 2}, []EditOp[int]{
+		{Kind: EditDelete, OldIndex: 1},
+		{Kind: EditDelete, OldIndex: 0},
+	})
+	assert.Equal(ErrInvalidPatch, err)
+
+	result, err := Patch([]int{1, 2}, nil)
+	assert.IsNil(err)
+	assert.Equal([]int{1, 2}, result)
+}
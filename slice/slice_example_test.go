@@ -1,6 +1,7 @@
 package slice
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"reflect"
@@ -80,6 +81,75 @@ func ExampleChunk() {
 	// [[a b c d e]]
 }
 
+func ExampleWindows() {
+	nums := []int{1, 2, 3, 4, 5}
+
+	result1 := Windows(nums, 3, 1)
+	result2 := Windows(nums, 2, 2)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// [[1 2 3] [2 3 4] [3 4 5]]
+	// [[1 2] [3 4]]
+}
+
+func ExamplePairwise() {
+	prices := []int{10, 12, 9, 15}
+
+	for _, pair := range Pairwise(prices) {
+		fmt.Println(pair.FieldB - pair.FieldA)
+	}
+
+	// Output:
+	// 2
+	// -3
+	// 6
+}
+
+func ExampleZip2() {
+	result := Zip2([]int{1, 2, 3}, []string{"a", "b"})
+
+	fmt.Println(result)
+
+	// Output:
+	// [{1 a} {2 b}]
+}
+
+func ExampleUnzip2() {
+	nums, strs := Unzip2(Zip2([]int{1, 2}, []string{"a", "b"}))
+
+	fmt.Println(nums)
+	fmt.Println(strs)
+
+	// Output:
+	// [1 2]
+	// [a b]
+}
+
+func ExampleZip3() {
+	result := Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false})
+
+	fmt.Println(result)
+
+	// Output:
+	// [{1 a true} {2 b false}]
+}
+
+func ExampleUnzip3() {
+	nums, strs, bools := Unzip3(Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false}))
+
+	fmt.Println(nums)
+	fmt.Println(strs)
+	fmt.Println(bools)
+
+	// Output:
+	// [1 2]
+	// [a b]
+	// [true false]
+}
+
 func ExampleCompact() {
 	result1 := Compact([]int{0})
 	result2 := Compact([]int{0, 1, 2, 3})
@@ -307,6 +377,52 @@ func ExampleGroupWith() {
 	// map[4:[4.2] 6:[6.1 6.3]]
 }
 
+func ExampleGroupByMap() {
+	type order struct {
+		User   string
+		Amount int
+	}
+	orders := []order{{"a", 10}, {"b", 20}, {"a", 30}}
+
+	result := GroupByMap(orders, func(o order) (string, int) {
+		return o.User, o.Amount
+	})
+
+	fmt.Println(result)
+
+	// Output:
+	// map[a:[10 30] b:[20]]
+}
+
+func ExampleGroupByOrdered() {
+	words := []string{"banana", "apple", "blueberry", "avocado"}
+
+	keys, groups := GroupByOrdered(words, func(s string) string { return s[:1] })
+
+	for _, k := range keys {
+		fmt.Println(k, groups[k])
+	}
+
+	// Output:
+	// b [banana blueberry]
+	// a [apple avocado]
+}
+
+func ExampleIndexBy() {
+	words := []string{"ab", "cd", "efg"}
+	length := func(s string) int { return len(s) }
+
+	result1 := IndexBy(words, length, true)
+	result2 := IndexBy(words, length, false)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// map[2:ab 3:efg]
+	// map[2:cd 3:efg]
+}
+
 func ExampleFind() {
 	nums := []int{1, 2, 3, 4, 5}
 
@@ -563,6 +679,44 @@ func ExampleRepeat() {
 	// [a a a]
 }
 
+func ExampleRepeatSlice() {
+	result := RepeatSlice([]string{"a", "b"}, 2)
+
+	fmt.Println(result)
+
+	// Output:
+	// [a b a b]
+}
+
+func ExampleFill() {
+	nums := []int{1, 2, 3, 4, 5}
+
+	Fill(nums, 0, 1, 4)
+
+	fmt.Println(nums)
+
+	// Output:
+	// [1 0 0 0 5]
+}
+
+func ExamplePadLeft() {
+	result := PadLeft([]int{1, 2, 3}, 5, 0)
+
+	fmt.Println(result)
+
+	// Output:
+	// [0 0 1 2 3]
+}
+
+func ExamplePadRight() {
+	result := PadRight([]int{1, 2, 3}, 5, 0)
+
+	fmt.Println(result)
+
+	// Output:
+	// [1 2 3 0 0]
+}
+
 func ExampleInterfaceSlice() {
 	strs := []string{"a", "b", "c"}
 
@@ -1141,3 +1295,218 @@ func ExampleLeftPadding() {
 	// Output:
 	// [0 0 0 1 2 3 4 5]
 }
+
+func ExampleMapParallel() {
+	nums := []int{1, 2, 3, 4}
+
+	result := MapParallel(nums, func(_ int, item int) int {
+		return item * item
+	}, 2)
+
+	fmt.Println(result)
+
+	// Output:
+	// [1 4 9 16]
+}
+
+func ExampleReduceParallel() {
+	nums := []int{1, 2, 3, 4}
+
+	result := ReduceParallel(nums, 0, func(_ int, item int, agg int) int {
+		return agg + item
+	}, func(a, b int) int {
+		return a + b
+	}, 2)
+
+	fmt.Println(result)
+
+	// Output:
+	// 10
+}
+
+func ExampleProcessInBatches() {
+	ids := []int{1, 2, 3, 4, 5, 6, 7}
+
+	err := ProcessInBatches(context.Background(), ids, 3, 2, func(batch []int) error {
+		// e.g. insert the batch of rows into database
+		if Contain(batch, 5) {
+			return fmt.Errorf("failed to insert %v", batch)
+		}
+		return nil
+	})
+
+	fmt.Println(err)
+
+	// Output:
+	// failed to insert [4 5 6]
+}
+
+func ExampleTopK() {
+	nums := []int{5, 1, 9, 3, 7, 2, 8}
+
+	result := TopK(nums, 3, func(a, b int) bool {
+		return a < b
+	})
+
+	fmt.Println(result)
+
+	// Output:
+	// [9 8 7]
+}
+
+func ExamplePartialSortBy() {
+	nums := []int{5, 1, 9, 3, 7, 2, 8}
+
+	PartialSortBy(nums, 3, func(a, b int) bool {
+		return a < b
+	})
+
+	fmt.Println(nums[:3])
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExamplePartition3() {
+	matched, unmatched, failed, errs := Partition3([]string{"1", "2", "x"}, func(item string) (bool, error) {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return false, err
+		}
+		return n%2 == 0, nil
+	})
+
+	fmt.Println(matched)
+	fmt.Println(unmatched)
+	fmt.Println(failed)
+	fmt.Println(errs)
+
+	// Output:
+	// [2]
+	// [1]
+	// [x]
+	// [strconv.Atoi: parsing "x": invalid syntax]
+}
+
+func ExampleDiff() {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	oldUsers := []user{{1, "Alice"}, {2, "Bob"}, {3, "Carol"}}
+	newUsers := []user{{1, "Alice"}, {2, "Bobby"}, {4, "Dave"}}
+
+	result := Diff(oldUsers, newUsers, func(u user) int { return u.ID })
+
+	fmt.Println(result.Added)
+	fmt.Println(result.Removed)
+	fmt.Println(result.Changed)
+
+	// Output:
+	// [{4 Dave}]
+	// [{3 Carol}]
+	// [{{2 Bob} {2 Bobby}}]
+}
+
+func ExampleEditScript() {
+	oldSlice := []string{"a", "b", "c", "d"}
+	newSlice := []string{"a", "c", "d", "e"}
+
+	ops := EditScript(oldSlice, newSlice)
+	for _, op := range ops {
+		if op.Kind == EditDelete {
+			fmt.Printf("delete %s at %d\n", op.Item, op.OldIndex)
+		} else {
+			fmt.Printf("insert %s at %d\n", op.Item, op.NewIndex)
+		}
+	}
+
+	result, _ := Patch(oldSlice, ops)
+	fmt.Println(result)
+
+	// Output:
+	// delete b at 1
+	// insert e at 3
+	// [a c d e]
+}
+
+func ExampleDeduper() {
+	deduper := NewDeduper[int]()
+
+	fmt.Println(deduper.Add(1))
+	fmt.Println(deduper.Add(1))
+	fmt.Println(deduper.AddAll(1, 2, 3, 2))
+	fmt.Println(deduper.Len())
+
+	// Output:
+	// true
+	// false
+	// [2 3]
+	// 3
+}
+
+func ExampleSampleWeighted() {
+	result, err := SampleWeighted([]string{"a", "b", "c"}, []float64{0, 1, 2}, 3)
+
+	fmt.Println(len(result))
+	fmt.Println(Contain(result, "a"))
+	fmt.Println(err)
+
+	// Output:
+	// 2
+	// false
+	// <nil>
+}
+
+func ExampleReservoirSample() {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 0; i < 1000; i++ {
+			ch <- i
+		}
+	}()
+
+	result := ReservoirSample(ch, 5)
+
+	fmt.Println(len(result))
+
+	// Output:
+	// 5
+}
+
+func ExampleSortByKeys() {
+	type person struct {
+		Name string
+		Age  int
+	}
+
+	people := []person{{"Tom", 20}, {"Jim", 30}, {"Tom", 30}, {"Amy", 25}}
+
+	SortByKeys(people,
+		ByKey(func(p person) string { return p.Name }),
+		ByKeyDesc(func(p person) int { return p.Age }),
+	)
+
+	fmt.Println(people)
+
+	// Output:
+	// [{Amy 25} {Jim 30} {Tom 30} {Tom 20}]
+}
+
+func ExampleLessByKeys() {
+	words := []string{"bb", "a", "ccc", "ab"}
+
+	less := LessByKeys(
+		ByKey(func(s string) int { return len(s) }),
+		ByKey(func(s string) string { return s }),
+	)
+
+	SortBy(words, less)
+
+	fmt.Println(words)
+
+	// Output:
+	// [a ab bb ccc]
+}
@@ -89,3 +89,31 @@ func partitionAnySlice[T any](slice []T, lowIndex, highIndex int, less func(a, b
 func swap[T any](slice []T, i, j int) {
 	slice[i], slice[j] = slice[j], slice[i]
 }
+
+// heapify builds a heap of slice in place, the root is the min one as determined by the less function.
+func heapify[T any](slice []T, less func(a, b T) bool) {
+	for i := len(slice)/2 - 1; i >= 0; i-- {
+		siftDown(slice, i, less)
+	}
+}
+
+// siftDown moves the element at index i down to its position in the heap.
+func siftDown[T any](heap []T, i int, less func(a, b T) bool) {
+	for {
+		min := i
+		left, right := 2*i+1, 2*i+2
+
+		if left < len(heap) && less(heap[left], heap[min]) {
+			min = left
+		}
+		if right < len(heap) && less(heap[right], heap[min]) {
+			min = right
+		}
+		if min == i {
+			return
+		}
+
+		swap(heap, i, min)
+		i = min
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package slice
+
+import (
+	"context"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/internal/parallel"
+)
+
+// MapParallel is like Map, but the elements are split into numOfThreads chunks which are mapped by goroutines
+// concurrently, the result keeps the order of slice. If numOfThreads <= 0, it's runtime.GOMAXPROCS(0).
+func MapParallel[T any, U any](slice []T, iteratee func(index int, item T) U, numOfThreads int) []U {
+	result := make([]U, len(slice))
+
+	parallel.Chunks(len(slice), parallel.Workers(len(slice), numOfThreads), func(_, low, high int) {
+		for i := low; i < high; i++ {
+			result[i] = iteratee(i, slice[i])
+		}
+	})
+
+	return result
+}
+
+// FilterParallel is like Filter, but the predicate is called by numOfThreads goroutines concurrently, the result
+// keeps the order of slice. If numOfThreads <= 0, it's runtime.GOMAXPROCS(0).
+func FilterParallel[T any](slice []T, predicate func(index int, item T) bool, numOfThreads int) []T {
+	passed := make([]bool, len(slice))
+
+	parallel.Chunks(len(slice), parallel.Workers(len(slice), numOfThreads), func(_, low, high int) {
+		for i := low; i < high; i++ {
+			passed[i] = predicate(i, slice[i])
+		}
+	})
+
+	result := make([]T, 0)
+	for i, v := range slice {
+		if passed[i] {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// ForEachParallel is like ForEach, but the iteratee is called by numOfThreads goroutines concurrently, each goroutine
+// iterates over a chunk in order. If numOfThreads <= 0, it's runtime.GOMAXPROCS(0).
+func ForEachParallel[T any](slice []T, iteratee func(index int, item T), numOfThreads int) {
+	parallel.Chunks(len(slice), parallel.Workers(len(slice), numOfThreads), func(_, low, high int) {
+		for i := low; i < high; i++ {
+			iteratee(i, slice[i])
+		}
+	})
+}
+
+// ReduceParallel is like ReduceBy, but the chunks of slice are reduced from identity by numOfThreads goroutines
+// concurrently, then the partial results are merged by combiner in the order of chunks. identity should be the
+// identity value of combiner (e.g. 0 for +) and combiner should be associative, so the result is deterministic.
+// If numOfThreads <= 0, it's runtime.GOMAXPROCS(0).
+func ReduceParallel[T any, U any](slice []T, identity U, reducer func(index int, item T, agg U) U, combiner func(a, b U) U, numOfThreads int) U {
+	chunks := parallel.Workers(len(slice), numOfThreads)
+	partials := make([]U, chunks)
+
+	parallel.Chunks(len(slice), chunks, func(chunk, low, high int) {
+		agg := identity
+		for i := low; i < high; i++ {
+			agg = reducer(i, slice[i], agg)
+		}
+		partials[chunk] = agg
+	})
+
+	result := identity
+	for _, partial := range partials {
+		result = combiner(result, partial)
+	}
+
+	return result
+}
+
+// keyOccurrence is the first occurrence of key in a chunk.
+type keyOccurrence[K comparable] struct {
+	index int
+	key   K
+}
+
+// UniqueByKeyParallel removes the duplicated elements of slice by the keys extracted by keyFn, the first occurrence
+// of each key is kept in order. The keys are extracted and deduplicated in chunks by numOfThreads goroutines, and the
+// chunks are merged by key as well, so T doesn't need to be comparable. If numOfThreads <= 0, it's
+// runtime.GOMAXPROCS(0).
+func UniqueByKeyParallel[T any, K comparable](slice []T, keyFn func(item T) K, numOfThreads int) []T {
+	chunkCount := parallel.Workers(len(slice), numOfThreads)
+	chunks := make([][]keyOccurrence[K], chunkCount)
+
+	parallel.Chunks(len(slice), chunkCount, func(chunk, low, high int) {
+		seen := make(map[K]struct{}, high-low)
+		for i := low; i < high; i++ {
+			key := keyFn(slice[i])
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				chunks[chunk] = append(chunks[chunk], keyOccurrence[K]{index: i, key: key})
+			}
+		}
+	})
+
+	result := make([]T, 0)
+	seen := make(map[K]struct{})
+	for _, occurrences := range chunks {
+		for _, o := range occurrences {
+			if _, ok := seen[o.key]; !ok {
+				seen[o.key] = struct{}{}
+				result = append(result, slice[o.index])
+			}
+		}
+	}
+
+	return result
+}
+
+// ProcessInBatches splits slice into batches of batchSize elements, and calls fn for each batch by workers goroutines
+// concurrently, e.g. insert rows into database in bulk. The batches are sub-slices of slice, so only the batches
+// being processed are in memory at the same time. The error of a batch doesn't stop the others, all the errors are
+// joined in the order of batches. If ctx is done, the batches not started are skipped and ctx.Err() is joined too.
+// If batchSize <= 0, the whole slice is a batch, and if workers <= 0, it's runtime.GOMAXPROCS(0).
+func ProcessInBatches[T any](ctx context.Context, slice []T, batchSize, workers int, fn func(batch []T) error) error {
+	if err := ctx.Err(); err != nil || len(slice) == 0 {
+		return err
+	}
+	if batchSize <= 0 {
+		batchSize = len(slice)
+	}
+
+	batches := (len(slice) + batchSize - 1) / batchSize
+	workers = parallel.Workers(batches, workers)
+
+	errs := make([]error, batches)
+	started := make([]bool, batches)
+
+	parallel.Dispatch(ctx, batches, workers, func(i int) {
+		// the batch may be received after ctx is done
+		if ctx.Err() != nil {
+			return
+		}
+		started[i] = true
+
+		low, high := i*batchSize, (i+1)*batchSize
+		if high > len(slice) {
+			high = len(slice)
+		}
+		errs[i] = fn(slice[low:high:high])
+	})
+
+	for _, ok := range started {
+		if !ok {
+			errs = append(errs, ctx.Err())
+			break
+		}
+	}
+
+	return internal.JoinError(errs...)
+}
@@ -0,0 +1,262 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestMapParallel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMapParallel")
+
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = i
+	}
+
+	for _, threads := range []int{-1, 0, 1, 3, 8, 2000} {
+		result := MapParallel(nums, func(index int, item int) string {
+			return strconv.Itoa(index + item)
+		}, threads)
+
+		assert.Equal(Map(nums, func(index int, item int) string {
+			return strconv.Itoa(index + item)
+		}), result)
+	}
+
+	assert.Equal([]int{}, MapParallel([]int{}, func(_ int, item int) int { return item }, 4))
+}
+
+func TestFilterParallel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFilterParallel")
+
+	nums := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	isEven := func(_ int, item int) bool { return item%2 == 0 }
+
+	assert.Equal([]int{2, 4, 6, 8, 10}, FilterParallel(nums, isEven, 3))
+	assert.Equal([]int{2, 4, 6, 8, 10}, FilterParallel(nums, isEven, 0))
+	assert.Equal([]int{}, FilterParallel([]int{1, 3}, isEven, 2))
+}
+
+func TestForEachParallel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestForEachParallel")
+
+	nums := make([]int, 100)
+	visited := make([]int32, 100)
+
+	var sum int64
+	ForEachParallel(nums, func(index int, item int) {
+		atomic.AddInt32(&visited[index], 1)
+		atomic.AddInt64(&sum, int64(index))
+	}, 7)
+
+	assert.Equal(int64(4950), sum)
+	for _, v := range visited {
+		assert.Equal(int32(1), v)
+	}
+}
+
+func TestReduceParallel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestReduceParallel")
+
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = i + 1
+	}
+
+	sum := ReduceParallel(nums, 0, func(_ int, item int, agg int) int {
+		return agg + item
+	}, func(a, b int) int {
+		return a + b
+	}, 4)
+	assert.Equal(500500, sum)
+
+	// the combiner is associative but not commutative, the order of chunks is kept
+	letters := []string{"a", "b", "c", "d", "e", "f", "g"}
+	for _, threads := range []int{1, 2, 3, 7, 10} {
+		joined := ReduceParallel(letters, "", func(_ int, item string, agg string) string {
+			return agg + item
+		}, func(a, b string) string {
+			return a + b
+		}, threads)
+		assert.Equal("abcdefg", joined)
+	}
+
+	assert.Equal(0, ReduceParallel([]int{}, 0, func(_ int, item int, agg int) int {
+		return agg + item
+	}, func(a, b int) int { return a + b }, 4))
+}
+
+func TestParallel_Panic(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestParallel_Panic")
+
+	defer func() {
+		assert.Equal("boom", recover())
+	}()
+
+	ForEachParallel([]int{1, 2, 3, 4}, func(_ int, item int) {
+		if item == 1 {
+			panic("boom")
+		}
+	}, 4)
+}
+
+func TestUniqueByKeyParallel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestUniqueByKeyParallel")
+
+	type user struct {
+		name string
+		tags []string
+	}
+
+	// user is not comparable because of the slice field
+	users := []user{
+		{"tom", []string{"a"}},
+		{"jim", nil},
+		{"tom", []string{"b"}},
+		{"mike", nil},
+		{"jim", []string{"c"}},
+	}
+
+	for _, threads := range []int{0, 1, 2, 5, 10} {
+		result := UniqueByKeyParallel(users, func(u user) string {
+			return u.name
+		}, threads)
+		assert.Equal([]user{users[0], users[1], users[3]}, result)
+	}
+
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = i % 7
+	}
+	assert.Equal([]int{0, 1, 2, 3, 4, 5, 6}, UniqueByKeyParallel(nums, func(item int) int { return item }, 4))
+
+	assert.Equal([]int{}, UniqueByKeyParallel([]int{}, func(item int) int { return item }, 4))
+}
+
+func TestProcessInBatches(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProcessInBatches")
+
+	nums := make([]int, 105)
+	for i := range nums {
+		nums[i] = i + 1
+	}
+
+	for _, workers := range []int{-1, 1, 4, 100} {
+		var sum, batches int64
+		err := ProcessInBatches(context.Background(), nums, 10, workers, func(batch []int) error {
+			if len(batch) > 10 {
+				return errors.New("batch is too large")
+			}
+			atomic.AddInt64(&batches, 1)
+			for _, v := range batch {
+				atomic.AddInt64(&sum, int64(v))
+			}
+			return nil
+		})
+
+		assert.IsNil(err)
+		assert.Equal(int64(11), batches)
+		assert.Equal(int64(105*106/2), sum)
+	}
+
+	var batches int64
+	err := ProcessInBatches(context.Background(), nums, 0, 4, func(batch []int) error {
+		atomic.AddInt64(&batches, 1)
+		return nil
+	})
+	assert.IsNil(err)
+	assert.Equal(int64(1), batches)
+
+	err = ProcessInBatches(context.Background(), []int{}, 10, 4, func(batch []int) error {
+		return errors.New("unexpected")
+	})
+	assert.IsNil(err)
+}
+
+func TestProcessInBatches_Errors(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProcessInBatches_Errors")
+
+	errFirst, errThird := errors.New("first"), errors.New("third")
+
+	var processed int64
+	err := ProcessInBatches(context.Background(), []int{1, 2, 3, 4, 5, 6}, 2, 3, func(batch []int) error {
+		atomic.AddInt64(&processed, 1)
+		switch batch[0] {
+		case 1:
+			return errFirst
+		case 5:
+			return errThird
+		}
+		return nil
+	})
+
+	// the errors don't stop the other batches
+	assert.Equal(int64(3), processed)
+	assert.Equal("first\nthird", err.Error())
+	assert.Equal(true, errors.Is(err, errFirst))
+	assert.Equal(true, errors.Is(err, errThird))
+}
+
+func TestProcessInBatches_Cancel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProcessInBatches_Cancel")
+
+	nums := make([]int, 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var processed int64
+	err := ProcessInBatches(ctx, nums, 1, 2, func(batch []int) error {
+		if atomic.AddInt64(&processed, 1) == 5 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.Equal(true, errors.Is(err, context.Canceled))
+	assert.Equal(true, atomic.LoadInt64(&processed) < 10)
+
+	err = ProcessInBatches(ctx, nums, 10, 2, func(batch []int) error {
+		return errors.New("unexpected")
+	})
+	assert.Equal(context.Canceled, err)
+}
+
+func TestProcessInBatches_Panic(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestProcessInBatches_Panic")
+
+	defer func() {
+		assert.Equal("boom", recover())
+	}()
+
+	_ = ProcessInBatches(context.Background(), []int{1, 2, 3, 4}, 1, 2, func(batch []int) error {
+		if batch[0] == 3 {
+			panic("boom")
+		}
+		return nil
+	})
+}
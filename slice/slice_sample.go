@@ -0,0 +1,107 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package slice
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// ErrInvalidWeights is returned by SampleWeighted if the weights don't match the slice or are negative.
+var ErrInvalidWeights = errors.New("slice: weights don't match the slice or are negative")
+
+// weightedKey is the random key of the element at index for SampleWeighted.
+type weightedKey struct {
+	key   float64
+	index int
+}
+
+// SampleWeighted randomly picks n elements of slice without replacement, the chance of an element to be picked
+// is proportional to its weight in weights, which has the same length as slice. The elements with zero weight
+// are never picked, so the result may be shorter than n. It uses the algorithm of Efraimidis and Spirakis.
+func SampleWeighted[T any](slice []T, weights []float64, n int) ([]T, error) {
+	if len(weights) != len(slice) {
+		return nil, ErrInvalidWeights
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	keys := make([]weightedKey, 0, len(slice))
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, ErrInvalidWeights
+		}
+		if w == 0 {
+			continue
+		}
+		// the key is log(u^(1/w)), the elements with the largest n keys are picked
+		keys = append(keys, weightedKey{key: math.Log(1-r.Float64()) / w, index: i})
+	}
+
+	if n > len(keys) {
+		n = len(keys)
+	}
+	if n <= 0 {
+		return []T{}, nil
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].key > keys[j].key
+	})
+
+	result := make([]T, n)
+	for i := range result {
+		result[i] = slice[keys[i].index]
+	}
+
+	return result, nil
+}
+
+// ReservoirSample randomly picks n elements of the channel without replacement until it's closed, every element
+// has the same chance to be picked. It keeps n elements in memory only, so it works on the data which doesn't fit
+// in memory. The result is shorter than n if the channel has fewer elements.
+func ReservoirSample[T any](ch <-chan T, n int) []T {
+	sampler := newReservoir[T](n)
+	for item := range ch {
+		sampler.add(item)
+	}
+
+	return sampler.result
+}
+
+// reservoir is the sampler of Algorithm R.
+type reservoir[T any] struct {
+	r      *rand.Rand
+	n      int
+	count  int
+	result []T
+}
+
+func newReservoir[T any](n int) *reservoir[T] {
+	if n < 0 {
+		n = 0
+	}
+
+	return &reservoir[T]{
+		r:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		n:      n,
+		result: make([]T, 0, n),
+	}
+}
+
+func (s *reservoir[T]) add(item T) {
+	s.count++
+	if len(s.result) < s.n {
+		s.result = append(s.result, item)
+		return
+	}
+
+	// the item replaces a picked one with probability n/count
+	if i := s.r.Intn(s.count); i < s.n {
+		s.result[i] = item
+	}
+}
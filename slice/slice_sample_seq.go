@@ -0,0 +1,18 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+//go:build go1.23
+
+package slice
+
+import "iter"
+
+// ReservoirSampleSeq is like ReservoirSample, but picks the elements of an iterator.
+func ReservoirSampleSeq[T any](seq iter.Seq[T], n int) []T {
+	sampler := newReservoir[T](n)
+	for item := range seq {
+		sampler.add(item)
+	}
+
+	return sampler.result
+}
@@ -0,0 +1,25 @@
+//go:build go1.23
+
+package slice
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestReservoirSampleSeq(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestReservoirSampleSeq")
+
+	nums := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	result := ReservoirSampleSeq(slices.Values(nums), 4)
+	assert.Equal(4, len(result))
+	assert.Equal(4, len(Unique(result)))
+	assert.Equal(true, ContainSubSlice(nums, result))
+
+	assert.Equal(nums, ReservoirSampleSeq(slices.Values(nums), 10))
+}
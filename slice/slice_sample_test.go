@@ -0,0 +1,119 @@
+package slice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestSampleWeighted(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSampleWeighted")
+
+	items := []string{"a", "b", "c", "d"}
+
+	result, err := SampleWeighted(items, []float64{1, 1, 1, 1}, 2)
+	assert.IsNil(err)
+	assert.Equal(2, len(result))
+	assert.Equal(2, len(Unique(result)))
+
+	// the zero weight elements are never picked
+	result, err = SampleWeighted(items, []float64{0, 1, 0, 2}, 3)
+	assert.IsNil(err)
+	assert.Equal(2, len(result))
+	assert.Equal(true, Contain(result, "b") && Contain(result, "d"))
+
+	result, err = SampleWeighted(items, []float64{1, 1, 1, 1}, 0)
+	assert.IsNil(err)
+	assert.Equal([]string{}, result)
+
+	_, err = SampleWeighted(items, []float64{1, 1}, 1)
+	assert.Equal(ErrInvalidWeights, err)
+
+	_, err = SampleWeighted(items, []float64{1, -1, 1, 1}, 1)
+	assert.Equal(ErrInvalidWeights, err)
+
+	_, err = SampleWeighted(items, []float64{1, math.NaN(), 1, 1}, 1)
+	assert.Equal(ErrInvalidWeights, err)
+}
+
+func TestSampleWeighted_Distribution(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSampleWeighted_Distribution")
+
+	items := []int{0, 1, 2}
+	weights := []float64{1, 3, 6}
+	counts := make([]int, len(items))
+
+	trials := 20000
+	for i := 0; i < trials; i++ {
+		result, err := SampleWeighted(items, weights, 1)
+		assert.IsNil(err)
+		counts[result[0]]++
+	}
+
+	for i, w := range weights {
+		expected := float64(trials) * w / 10
+		if math.Abs(float64(counts[i])-expected) > expected*0.1 {
+			t.Errorf("item %d is picked %d times, expected about %.0f", i, counts[i], expected)
+		}
+	}
+}
+
+func TestReservoirSample(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestReservoirSample")
+
+	produce := func(n int) <-chan int {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := 0; i < n; i++ {
+				ch <- i
+			}
+		}()
+		return ch
+	}
+
+	result := ReservoirSample(produce(100), 10)
+	assert.Equal(10, len(result))
+	assert.Equal(10, len(Unique(result)))
+	for _, v := range result {
+		assert.Equal(true, v >= 0 && v < 100)
+	}
+
+	assert.Equal([]int{0, 1, 2}, ReservoirSample(produce(3), 5))
+	assert.Equal([]int{}, ReservoirSample(produce(3), 0))
+	assert.Equal([]int{}, ReservoirSample(produce(0), 3))
+}
+
+func TestReservoirSample_Distribution(t *testing.T) {
+	t.Parallel()
+
+	counts := make([]int, 10)
+
+	trials := 10000
+	for i := 0; i < trials; i++ {
+		ch := make(chan int, len(counts))
+		for j := range counts {
+			ch <- j
+		}
+		close(ch)
+
+		for _, v := range ReservoirSample(ch, 3) {
+			counts[v]++
+		}
+	}
+
+	// every element is picked with probability 3/10
+	expected := float64(trials) * 3 / 10
+	for i, count := range counts {
+		if math.Abs(float64(count)-expected) > expected*0.1 {
+			t.Errorf("item %d is picked %d times, expected about %.0f", i, count, expected)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package slice
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SortKey compares two elements by a key, it returns a negative number if a is before b, a positive number if
+// a is after b, and zero if they're tied. It's created by ByKey or ByKeyDesc, or written by hand.
+type SortKey[T any] func(a, b T) int
+
+// ByKey returns a SortKey which orders the elements by the key extracted by keyFn ascendingly.
+func ByKey[T any, K constraints.Ordered](keyFn func(item T) K) SortKey[T] {
+	return func(a, b T) int {
+		ka, kb := keyFn(a), keyFn(b)
+		switch {
+		case ka < kb:
+			return -1
+		case ka > kb:
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+// ByKeyDesc returns a SortKey which orders the elements by the key extracted by keyFn descendingly.
+func ByKeyDesc[T any, K constraints.Ordered](keyFn func(item T) K) SortKey[T] {
+	asc := ByKey(keyFn)
+	return func(a, b T) int {
+		return asc(b, a)
+	}
+}
+
+// LessByKeys returns a less function which compares the elements by keys in order, the next key is compared
+// only if the elements are tied by the previous ones. It could be used by SortBy, TopK and so on.
+func LessByKeys[T any](keys ...SortKey[T]) func(a, b T) bool {
+	return func(a, b T) bool {
+		for _, key := range keys {
+			if c := key(a, b); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	}
+}
+
+// SortByKeys sorts the slice by keys in order with a single stable sort, e.g. sort by name ascendingly and then
+// by age descendingly: SortByKeys(people, ByKey(getName), ByKeyDesc(getAge)). The elements tied by all keys
+// keep their original order.
+func SortByKeys[T any](slice []T, keys ...SortKey[T]) {
+	less := LessByKeys(keys...)
+
+	sort.SliceStable(slice, func(i, j int) bool {
+		return less(slice[i], slice[j])
+	})
+}
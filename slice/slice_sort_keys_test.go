@@ -0,0 +1,87 @@
+package slice
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type sortKeysPerson struct {
+	Name string
+	Age  int
+	ID   int
+}
+
+func TestSortByKeys(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortByKeys")
+
+	people := []sortKeysPerson{
+		{"Tom", 20, 1},
+		{"Jim", 30, 2},
+		{"Tom", 30, 3},
+		{"Jim", 30, 4},
+		{"Amy", 25, 5},
+	}
+
+	name := func(p sortKeysPerson) string { return p.Name }
+	age := func(p sortKeysPerson) int { return p.Age }
+
+	SortByKeys(people, ByKey(name), ByKeyDesc(age))
+
+	// the tied elements keep their original order
+	assert.Equal([]sortKeysPerson{
+		{"Amy", 25, 5},
+		{"Jim", 30, 2},
+		{"Jim", 30, 4},
+		{"Tom", 30, 3},
+		{"Tom", 20, 1},
+	}, people)
+
+	SortByKeys(people, ByKey(age))
+	assert.Equal([]int{1, 5, 2, 4, 3}, Map(people, func(_ int, p sortKeysPerson) int { return p.ID }))
+
+	// no keys keeps the order
+	SortByKeys(people)
+	assert.Equal([]int{1, 5, 2, 4, 3}, Map(people, func(_ int, p sortKeysPerson) int { return p.ID }))
+
+	empty := []sortKeysPerson{}
+	SortByKeys(empty, ByKey(name))
+	assert.Equal([]sortKeysPerson{}, empty)
+}
+
+func TestSortByKeys_CustomKey(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSortByKeys_CustomKey")
+
+	words := []string{"bb", "a", "ccc", "dd", "e"}
+
+	byLen := SortKey[string](func(a, b string) int { return len(a) - len(b) })
+	SortByKeys(words, byLen, ByKeyDesc(func(s string) string { return s }))
+
+	assert.Equal([]string{"e", "a", "dd", "bb", "ccc"}, words)
+}
+
+func TestLessByKeys(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLessByKeys")
+
+	less := LessByKeys(
+		ByKey(func(p [2]int) int { return p[0] }),
+		ByKeyDesc(func(p [2]int) int { return p[1] }),
+	)
+
+	assert.Equal(true, less([2]int{1, 5}, [2]int{2, 0}))
+	assert.Equal(true, less([2]int{1, 5}, [2]int{1, 3}))
+	assert.Equal(false, less([2]int{1, 3}, [2]int{1, 3}))
+
+	pairs := [][2]int{{2, 1}, {1, 1}, {1, 2}, {2, 3}}
+	sort.Slice(pairs, func(i, j int) bool { return less(pairs[i], pairs[j]) })
+	assert.Equal([][2]int{{1, 2}, {1, 1}, {2, 3}, {2, 1}}, pairs)
+
+	assert.Equal([][2]int{{2, 1}, {2, 3}}, TopK([][2]int{{2, 1}, {1, 1}, {1, 2}, {2, 3}}, 2, less))
+}
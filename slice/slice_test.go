@@ -3,7 +3,9 @@ package slice
 import (
 	"fmt"
 	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/tuple"
 	"math"
+	"math/rand"
 	"reflect"
 	"strconv"
 	"testing"
@@ -86,6 +88,63 @@ func TestChunk(t *testing.T) {
 	assert.Equal(r6, Chunk(arr, 6))
 }
 
+func TestWindows(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWindows")
+
+	nums := []int{1, 2, 3, 4, 5}
+
+	assert.Equal([][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, Windows(nums, 3, 1))
+	assert.Equal([][]int{{1, 2}, {3, 4}}, Windows(nums, 2, 2))
+	assert.Equal([][]int{{1}, {4}}, Windows(nums, 1, 3))
+	assert.Equal([][]int{{1, 2, 3, 4, 5}}, Windows(nums, 5, 1))
+	assert.Equal([][]int{}, Windows(nums, 6, 1))
+	assert.Equal([][]int{}, Windows(nums, 0, 1))
+	assert.Equal([][]int{}, Windows(nums, 2, 0))
+
+	// the windows don't share memory with slice
+	windows := Windows(nums, 2, 1)
+	windows[0][0] = 9
+	assert.Equal(1, nums[0])
+}
+
+func TestPairwise(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPairwise")
+
+	assert.Equal([]tuple.Tuple2[int, int]{tuple.NewTuple2(1, 2), tuple.NewTuple2(2, 3)}, Pairwise([]int{1, 2, 3}))
+	assert.Equal([]tuple.Tuple2[int, int]{}, Pairwise([]int{1}))
+	assert.Equal([]tuple.Tuple2[int, int]{}, Pairwise([]int{}))
+}
+
+func TestZipAndUnzip(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestZipAndUnzip")
+
+	pairs := Zip2([]int{1, 2, 3}, []string{"a", "b"})
+	assert.Equal([]tuple.Tuple2[int, string]{tuple.NewTuple2(1, "a"), tuple.NewTuple2(2, "b")}, pairs)
+
+	nums, strs := Unzip2(pairs)
+	assert.Equal([]int{1, 2}, nums)
+	assert.Equal([]string{"a", "b"}, strs)
+
+	triples := Zip3([]int{1, 2}, []string{"a", "b", "c"}, []bool{true, false})
+	assert.Equal([]tuple.Tuple3[int, string, bool]{
+		tuple.NewTuple3(1, "a", true),
+		tuple.NewTuple3(2, "b", false),
+	}, triples)
+
+	nums, strs, bools := Unzip3(triples)
+	assert.Equal([]int{1, 2}, nums)
+	assert.Equal([]string{"a", "b"}, strs)
+	assert.Equal([]bool{true, false}, bools)
+
+	assert.Equal([]tuple.Tuple2[int, int]{}, Zip2([]int{}, []int{1}))
+}
+
 func TestCompact(t *testing.T) {
 	t.Parallel()
 
@@ -260,6 +319,59 @@ func TestGroupWith(t *testing.T) {
 	assert.Equal(expected, actual)
 }
 
+func TestGroupByMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGroupByMap")
+
+	type order struct {
+		User   string
+		Amount int
+	}
+	orders := []order{{"a", 10}, {"b", 20}, {"a", 30}}
+
+	result := GroupByMap(orders, func(o order) (string, int) {
+		return o.User, o.Amount
+	})
+	assert.Equal(map[string][]int{"a": {10, 30}, "b": {20}}, result)
+
+	assert.Equal(map[string][]int{}, GroupByMap([]order{}, func(o order) (string, int) {
+		return o.User, o.Amount
+	}))
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGroupByOrdered")
+
+	words := []string{"banana", "apple", "blueberry", "cherry", "avocado"}
+
+	keys, groups := GroupByOrdered(words, func(s string) byte { return s[0] })
+	assert.Equal([]byte{'b', 'a', 'c'}, keys)
+	assert.Equal(map[byte][]string{
+		'b': {"banana", "blueberry"},
+		'a': {"apple", "avocado"},
+		'c': {"cherry"},
+	}, groups)
+
+	keys, groups = GroupByOrdered([]string{}, func(s string) byte { return s[0] })
+	assert.Equal([]byte{}, keys)
+	assert.Equal(map[byte][]string{}, groups)
+}
+
+func TestIndexBy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIndexBy")
+
+	words := []string{"ab", "cd", "efg", "h", "ij"}
+	length := func(s string) int { return len(s) }
+
+	assert.Equal(map[int]string{1: "h", 2: "ab", 3: "efg"}, IndexBy(words, length, true))
+	assert.Equal(map[int]string{1: "h", 2: "ij", 3: "efg"}, IndexBy(words, length, false))
+}
+
 func TestCount(t *testing.T) {
 	t.Parallel()
 
@@ -1181,6 +1293,53 @@ func TestRepeat(t *testing.T) {
 
 	assert.Equal([]string{}, Repeat("a", 0))
 	assert.Equal([]string{"a", "a", "a", "a", "a", "a"}, Repeat("a", 6))
+	assert.Equal([]string{}, Repeat("a", -1))
+}
+
+func TestRepeatSlice(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRepeatSlice")
+
+	assert.Equal([]int{1, 2, 1, 2, 1, 2}, RepeatSlice([]int{1, 2}, 3))
+	assert.Equal([]int{}, RepeatSlice([]int{1, 2}, 0))
+	assert.Equal([]int{}, RepeatSlice([]int{1, 2}, -1))
+	assert.Equal([]int{}, RepeatSlice([]int{}, 3))
+}
+
+func TestFill(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFill")
+
+	nums := []int{1, 2, 3, 4, 5}
+	Fill(nums, 0, 1, 3)
+	assert.Equal([]int{1, 0, 0, 4, 5}, nums)
+
+	Fill(nums, 9, -1, 10)
+	assert.Equal([]int{9, 9, 9, 9, 9}, nums)
+
+	Fill(nums, 0, 3, 2)
+	assert.Equal([]int{9, 9, 9, 9, 9}, nums)
+}
+
+func TestPadLeftAndPadRight(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPadLeftAndPadRight")
+
+	nums := []int{1, 2, 3}
+
+	assert.Equal([]int{0, 0, 1, 2, 3}, PadLeft(nums, 5, 0))
+	assert.Equal([]int{1, 2, 3, 0, 0}, PadRight(nums, 5, 0))
+	assert.Equal([]int{1, 2, 3}, PadLeft(nums, 2, 0))
+	assert.Equal([]int{1, 2, 3}, PadRight(nums, 3, 0))
+	assert.Equal([]int{0, 0}, PadLeft([]int{}, 2, 0))
+
+	// the result is a copy
+	result := PadRight(nums, 0, 0)
+	result[0] = 9
+	assert.Equal([]int{1, 2, 3}, nums)
 }
 
 func TestJoin(t *testing.T) {
@@ -1420,3 +1579,124 @@ func TestRightPaddingAndLeftPadding(t *testing.T) {
 	padded := LeftPadding(RightPadding(nums, 0, 3), 0, 3)
 	assert.Equal([]int{0, 0, 0, 1, 2, 3, 4, 5, 0, 0, 0}, padded)
 }
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTopK")
+
+	nums := []int{5, 1, 9, 3, 7, 2, 8}
+	less := func(a, b int) bool { return a < b }
+
+	assert.Equal([]int{9, 8, 7}, TopK(nums, 3, less))
+	assert.Equal([]int{1, 2, 3}, BottomK(nums, 3, less))
+	assert.Equal([]int{9, 8, 7, 5, 3, 2, 1}, TopK(nums, 10, less))
+	assert.Equal([]int{}, TopK(nums, 0, less))
+	assert.Equal([]int{}, BottomK([]int{}, 3, less))
+
+	// the slice is not modified
+	assert.Equal([]int{5, 1, 9, 3, 7, 2, 8}, nums)
+
+	r := rand.New(rand.NewSource(1))
+	large := make([]int, 10000)
+	for i := range large {
+		large[i] = r.Intn(100000)
+	}
+	sorted := make([]int, len(large))
+	copy(sorted, large)
+	SortBy(sorted, less)
+
+	bottom := BottomK(large, 50, less)
+	assert.Equal(sorted[:50], bottom)
+
+	top := TopK(large, 50, less)
+	for i, v := range top {
+		assert.Equal(sorted[len(sorted)-1-i], v)
+	}
+}
+
+func TestPartialSortBy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPartialSortBy")
+
+	less := func(a, b int) bool { return a < b }
+
+	nums := []int{5, 1, 9, 3, 7, 2, 8}
+	PartialSortBy(nums, 3, less)
+	assert.Equal([]int{1, 2, 3}, nums[:3])
+
+	rest := append([]int{}, nums[3:]...)
+	Sort(rest)
+	assert.Equal([]int{5, 7, 8, 9}, rest)
+
+	all := []int{3, 1, 2}
+	PartialSortBy(all, 5, less)
+	assert.Equal([]int{1, 2, 3}, all)
+
+	none := []int{3, 1, 2}
+	PartialSortBy(none, 0, less)
+	assert.Equal([]int{3, 1, 2}, none)
+
+	r := rand.New(rand.NewSource(2))
+	for n := 0; n < 50; n++ {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = r.Intn(20)
+		}
+		sorted := append([]int{}, data...)
+		Sort(sorted)
+
+		k := r.Intn(n + 1)
+		PartialSortBy(data, k, less)
+		assert.Equal(sorted[:k], data[:k])
+	}
+}
+
+func TestPartitionBy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPartitionBy")
+
+	words := []string{"apple", "bob", "avocado", "cat", "banana"}
+	result := PartitionBy(words, func(item string) int {
+		return len(item)
+	})
+
+	assert.Equal(map[int][]string{
+		3: {"bob", "cat"},
+		5: {"apple"},
+		6: {"banana"},
+		7: {"avocado"},
+	}, result)
+
+	assert.Equal(map[int][]string{}, PartitionBy([]string{}, func(item string) int { return len(item) }))
+}
+
+func TestPartition3(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPartition3")
+
+	isEven := func(item string) (bool, error) {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return false, err
+		}
+		return n%2 == 0, nil
+	}
+
+	matched, unmatched, failed, errs := Partition3([]string{"1", "2", "x", "4", "5", "y"}, isEven)
+
+	assert.Equal([]string{"2", "4"}, matched)
+	assert.Equal([]string{"1", "5"}, unmatched)
+	assert.Equal([]string{"x", "y"}, failed)
+	assert.Equal(2, len(errs))
+	assert.IsNotNil(errs[0])
+
+	matched, unmatched, failed, errs = Partition3([]string{}, isEven)
+	assert.Equal([]string{}, matched)
+	assert.Equal([]string{}, unmatched)
+	assert.Equal([]string{}, failed)
+	assert.Equal([]error{}, errs)
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package stream
+
+import (
+	"strings"
+
+	"github.com/duke-git/lancet/v2/maputil"
+	"golang.org/x/exp/constraints"
+)
+
+// Collector describes how to reduce the elements of stream of type T into a result of type R, like
+// Collectors of java. Collectors could be composed, e.g. GroupByWith(key, Counting[T]()).
+type Collector[T any, R any] struct {
+	// start creates a new accumulation, so a collector could be used many times
+	start func() (add func(item T), result func() R)
+}
+
+// NewCollector creates a collector, supplier creates the initial accumulation, accumulator adds an element
+// into it, and finisher converts the accumulation into the result.
+func NewCollector[T any, A any, R any](supplier func() A, accumulator func(acc A, item T) A, finisher func(acc A) R) Collector[T, R] {
+	return Collector[T, R]{
+		start: func() (func(item T), func() R) {
+			acc := supplier()
+			add := func(item T) {
+				acc = accumulator(acc, item)
+			}
+			result := func() R {
+				return finisher(acc)
+			}
+			return add, result
+		},
+	}
+}
+
+// Collect performs a reduction on the elements of stream by collector, it's always executed sequentially.
+func Collect[T any, R any](s Stream[T], collector Collector[T, R]) R {
+	add, result := collector.start()
+
+	s.each(func(item T) bool {
+		add(item)
+		return true
+	})
+
+	return result()
+}
+
+// GroupBy returns a collector which groups the values of elements by their keys, the values in a group are in
+// the order of stream.
+func GroupBy[T any, K comparable, V any](key func(item T) K, value func(item T) V) Collector[T, map[K][]V] {
+	return NewCollector(
+		func() map[K][]V { return map[K][]V{} },
+		func(acc map[K][]V, item T) map[K][]V {
+			k := key(item)
+			acc[k] = append(acc[k], value(item))
+			return acc
+		},
+		identity[map[K][]V],
+	)
+}
+
+// collectorGroup is the accumulation of a group of GroupByWith.
+type collectorGroup[T any, R any] struct {
+	add    func(item T)
+	result func() R
+}
+
+// GroupByWith returns a collector which groups the elements by their keys, and the elements of each group are
+// collected by downstream collector, e.g. count the elements of each group with Counting.
+func GroupByWith[T any, K comparable, R any](key func(item T) K, downstream Collector[T, R]) Collector[T, map[K]R] {
+	return NewCollector(
+		func() map[K]*collectorGroup[T, R] { return map[K]*collectorGroup[T, R]{} },
+		func(acc map[K]*collectorGroup[T, R], item T) map[K]*collectorGroup[T, R] {
+			k := key(item)
+			g, ok := acc[k]
+			if !ok {
+				add, result := downstream.start()
+				g = &collectorGroup[T, R]{add: add, result: result}
+				acc[k] = g
+			}
+			g.add(item)
+			return acc
+		},
+		func(acc map[K]*collectorGroup[T, R]) map[K]R {
+			result := make(map[K]R, len(acc))
+			for k, g := range acc {
+				result[k] = g.result()
+			}
+			return result
+		},
+	)
+}
+
+// ToMap returns a collector which collects the elements into a map by key and value extractors. If keys are
+// duplicated, merge is called with the existing and the new value, and nil merge keeps the last one.
+func ToMap[T any, K comparable, V any](key func(item T) K, value func(item T) V, merge func(existing, replacement V) V) Collector[T, map[K]V] {
+	return NewCollector(
+		func() map[K]V { return map[K]V{} },
+		func(acc map[K]V, item T) map[K]V {
+			k, v := key(item), value(item)
+			if existing, ok := acc[k]; ok && merge != nil {
+				v = merge(existing, v)
+			}
+			acc[k] = v
+			return acc
+		},
+		identity[map[K]V],
+	)
+}
+
+// ToEntryMap returns a collector which collects the entries into a map, e.g. the entries of FromMap. If keys are
+// duplicated, merge is called like ToMap.
+func ToEntryMap[K comparable, V any](merge func(existing, replacement V) V) Collector[maputil.Entry[K, V], map[K]V] {
+	return ToMap(
+		func(e maputil.Entry[K, V]) K { return e.Key },
+		func(e maputil.Entry[K, V]) V { return e.Value },
+		merge,
+	)
+}
+
+// PartitionBy returns a collector which partitions the elements by predicate, the elements matching it are
+// in result[true] and the others in result[false], both keys are always present.
+func PartitionBy[T any](predicate func(item T) bool) Collector[T, map[bool][]T] {
+	return NewCollector(
+		func() map[bool][]T { return map[bool][]T{true: {}, false: {}} },
+		func(acc map[bool][]T, item T) map[bool][]T {
+			k := predicate(item)
+			acc[k] = append(acc[k], item)
+			return acc
+		},
+		identity[map[bool][]T],
+	)
+}
+
+// Joining returns a collector which concatenates the strings with separator, and wraps the result with prefix
+// and suffix.
+func Joining(separator, prefix, suffix string) Collector[string, string] {
+	type joining struct {
+		builder *strings.Builder
+		empty   bool
+	}
+
+	return NewCollector(
+		func() joining {
+			b := &strings.Builder{}
+			b.WriteString(prefix)
+			return joining{builder: b, empty: true}
+		},
+		func(acc joining, item string) joining {
+			if !acc.empty {
+				acc.builder.WriteString(separator)
+			}
+			acc.builder.WriteString(item)
+			acc.empty = false
+			return acc
+		},
+		func(acc joining) string {
+			acc.builder.WriteString(suffix)
+			return acc.builder.String()
+		},
+	)
+}
+
+// Counting returns a collector which counts the elements.
+func Counting[T any]() Collector[T, int] {
+	return NewCollector(
+		func() int { return 0 },
+		func(acc int, item T) int { return acc + 1 },
+		identity[int],
+	)
+}
+
+// Summing returns a collector which sums the numbers.
+func Summing[T constraints.Integer | constraints.Float]() Collector[T, T] {
+	return SummingBy(identity[T])
+}
+
+// SummingBy returns a collector which sums the numbers extracted from elements, e.g. as downstream of GroupByWith.
+func SummingBy[T any, N constraints.Integer | constraints.Float](mapper func(item T) N) Collector[T, N] {
+	return NewCollector(
+		func() N { return 0 },
+		func(acc N, item T) N { return acc + mapper(item) },
+		identity[N],
+	)
+}
+
+type average struct {
+	sum   float64
+	count int
+}
+
+// Averaging returns a collector which calculates the average of numbers extracted from elements, it's 0 if
+// there is no element.
+func Averaging[T any, N constraints.Integer | constraints.Float](mapper func(item T) N) Collector[T, float64] {
+	return NewCollector(
+		func() average { return average{} },
+		func(acc average, item T) average {
+			acc.sum += float64(mapper(item))
+			acc.count++
+			return acc
+		},
+		func(acc average) float64 {
+			if acc.count == 0 {
+				return 0
+			}
+			return acc.sum / float64(acc.count)
+		},
+	)
+}
+
+func identity[T any](v T) T {
+	return v
+}
@@ -0,0 +1,182 @@
+package stream
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/maputil"
+)
+
+type collectorPerson struct {
+	Name string
+	City string
+	Age  int
+}
+
+var collectorPeople = []collectorPerson{
+	{"Tom", "Beijing", 10},
+	{"Jim", "Shanghai", 20},
+	{"Mike", "Beijing", 30},
+	{"Lily", "Shenzhen", 40},
+	{"Lucy", "Shanghai", 50},
+}
+
+func TestCollect_GroupBy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCollect_GroupBy")
+
+	s := FromSlice(collectorPeople)
+
+	names := Collect(s, GroupBy(
+		func(p collectorPerson) string { return p.City },
+		func(p collectorPerson) string { return p.Name },
+	))
+	assert.Equal(map[string][]string{
+		"Beijing":  {"Tom", "Mike"},
+		"Shanghai": {"Jim", "Lucy"},
+		"Shenzhen": {"Lily"},
+	}, names)
+
+	counts := Collect(s, GroupByWith(func(p collectorPerson) string { return p.City }, Counting[collectorPerson]()))
+	assert.Equal(map[string]int{"Beijing": 2, "Shanghai": 2, "Shenzhen": 1}, counts)
+
+	ages := Collect(s, GroupByWith(
+		func(p collectorPerson) bool { return p.Age >= 30 },
+		SummingBy(func(p collectorPerson) int { return p.Age }),
+	))
+	assert.Equal(map[bool]int{false: 30, true: 120}, ages)
+
+	// nested groups
+	nested := Collect(s, GroupByWith(
+		func(p collectorPerson) string { return p.City },
+		GroupByWith(func(p collectorPerson) bool { return p.Age > 25 }, Counting[collectorPerson]()),
+	))
+	assert.Equal(map[string]map[bool]int{
+		"Beijing":  {false: 1, true: 1},
+		"Shanghai": {false: 1, true: 1},
+		"Shenzhen": {true: 1},
+	}, nested)
+
+	assert.Equal(map[string][]int{}, Collect(FromSlice([]int{}), GroupBy(
+		func(n int) string { return "" },
+		func(n int) int { return n },
+	)))
+}
+
+func TestCollect_ToMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCollect_ToMap")
+
+	s := FromSlice(collectorPeople)
+
+	byName := Collect(s, ToMap(
+		func(p collectorPerson) string { return p.Name },
+		func(p collectorPerson) int { return p.Age },
+		nil,
+	))
+	assert.Equal(map[string]int{"Tom": 10, "Jim": 20, "Mike": 30, "Lily": 40, "Lucy": 50}, byName)
+
+	lastByCity := Collect(s, ToMap(
+		func(p collectorPerson) string { return p.City },
+		func(p collectorPerson) string { return p.Name },
+		nil,
+	))
+	assert.Equal(map[string]string{"Beijing": "Mike", "Shanghai": "Lucy", "Shenzhen": "Lily"}, lastByCity)
+
+	joinedByCity := Collect(s, ToMap(
+		func(p collectorPerson) string { return p.City },
+		func(p collectorPerson) string { return p.Name },
+		func(existing, replacement string) string { return existing + "," + replacement },
+	))
+	assert.Equal(map[string]string{"Beijing": "Tom,Mike", "Shanghai": "Jim,Lucy", "Shenzhen": "Lily"}, joinedByCity)
+}
+
+func TestCollect_ToEntryMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCollect_ToEntryMap")
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	doubled := Collect(FromMap(m).Map(func(e maputil.Entry[string, int]) maputil.Entry[string, int] {
+		return maputil.Entry[string, int]{Key: e.Key, Value: e.Value * 2}
+	}), ToEntryMap[string, int](nil))
+	assert.Equal(map[string]int{"a": 2, "b": 4, "c": 6}, doubled)
+
+	assert.Equal(m, Collect(FromSlice(maputil.Entries(m)), ToEntryMap[string, int](nil)))
+
+	summed := Collect(Of(
+		maputil.Entry[string, int]{Key: "a", Value: 1},
+		maputil.Entry[string, int]{Key: "a", Value: 2},
+	), ToEntryMap[string](func(existing, replacement int) int { return existing + replacement }))
+	assert.Equal(map[string]int{"a": 3}, summed)
+}
+
+func TestCollect_PartitionBy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCollect_PartitionBy")
+
+	result := Collect(FromRange(1, 6, 1), PartitionBy(func(n int) bool { return n%2 == 0 }))
+	assert.Equal(map[bool][]int{true: {2, 4, 6}, false: {1, 3, 5}}, result)
+
+	empty := Collect(Of[int](), PartitionBy(func(n int) bool { return true }))
+	assert.Equal(map[bool][]int{true: {}, false: {}}, empty)
+}
+
+func TestCollect_Joining(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCollect_Joining")
+
+	assert.Equal("[a, b, c]", Collect(Of("a", "b", "c"), Joining(", ", "[", "]")))
+	assert.Equal("abc", Collect(Of("a", "b", "c"), Joining("", "", "")))
+	assert.Equal("[]", Collect(Of[string](), Joining(", ", "[", "]")))
+	assert.Equal("<,>", Collect(Of("", ""), Joining(",", "<", ">")))
+
+	names := MapTo(FromSlice(collectorPeople), func(p collectorPerson) string { return strings.ToLower(p.Name) })
+	assert.Equal("tom|jim|mike|lily|lucy", Collect(names, Joining("|", "", "")))
+}
+
+func TestCollect_Numeric(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCollect_Numeric")
+
+	s := FromRange(1, 100, 1)
+
+	assert.Equal(100, Collect(s, Counting[int]()))
+	assert.Equal(5050, Collect(s, Summing[int]()))
+	assert.Equal(5.5, Collect(FromRange(1.0, 10.0, 1.0), Summing[float64]())/10)
+	assert.Equal(50.5, Collect(s, Averaging(func(n int) int { return n })))
+	assert.Equal(0.0, Collect(Of[int](), Averaging(func(n int) int { return n })))
+	assert.Equal(0, Collect(Of[int](), Counting[int]()))
+
+	// a collector could be used many times
+	summing := Summing[int]()
+	assert.Equal(6, Collect(Of(1, 2, 3), summing))
+	assert.Equal(6, Collect(Of(1, 2, 3), summing))
+}
+
+func TestNewCollector(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestNewCollector")
+
+	longest := NewCollector(
+		func() string { return "" },
+		func(acc string, item string) string {
+			if len(item) > len(acc) {
+				return item
+			}
+			return acc
+		},
+		strings.ToUpper,
+	)
+
+	assert.Equal("BANANA", Collect(Of("apple", "banana", "kiwi"), longest))
+	assert.Equal("", Collect(Of[string](), longest))
+}
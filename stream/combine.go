@@ -0,0 +1,283 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package stream
+
+import "github.com/duke-git/lancet/v2/tuple"
+
+// Zip returns a stream of pairs of the elements of a and b at the same position, it ends when either is exhausted.
+func Zip[A any, B any](a Stream[A], b Stream[B]) Stream[tuple.Tuple2[A, B]] {
+	zip := newStream(func(yield func(item tuple.Tuple2[A, B]) bool) {
+		next, stop := pull(b)
+		defer stop()
+
+		a.each(func(x A) bool {
+			y, ok := next()
+			if !ok {
+				return false
+			}
+			return yield(tuple.NewTuple2(x, y))
+		})
+	})
+	zip.workers = maxWorkers(a.workers, b.workers)
+
+	return zip
+}
+
+// Interleave returns a stream which takes an element from each stream in turn, e.g. a1, b1, a2, b2 and etc.
+// The exhausted streams are skipped, so it ends when all the streams are exhausted.
+func Interleave[T any](streams ...Stream[T]) Stream[T] {
+	interleave := newStream(func(yield func(item T) bool) {
+		nexts := make([]func() (T, bool), 0, len(streams))
+		for _, s := range streams {
+			next, stop := pull(s)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+
+		for len(nexts) > 0 {
+			active := nexts[:0]
+			for _, next := range nexts {
+				item, ok := next()
+				if !ok {
+					continue
+				}
+				if !yield(item) {
+					return
+				}
+				active = append(active, next)
+			}
+			nexts = active
+		}
+	})
+
+	for _, s := range streams {
+		interleave.workers = maxWorkers(interleave.workers, s.workers)
+	}
+
+	return interleave
+}
+
+// Windowed returns a stream of sliding windows of size elements, a window starts every step elements, and
+// the trailing elements which can't fill a window are dropped. Each window is a new slice. Windowed panics if
+// size or step is not positive.
+func Windowed[T any](s Stream[T], size, step int) Stream[[]T] {
+	if size <= 0 {
+		panic("stream.Windowed: param size should be positive")
+	} else if step <= 0 {
+		panic("stream.Windowed: param step should be positive")
+	}
+
+	windowed := newStream(func(yield func(item []T) bool) {
+		window := make([]T, 0, size)
+		// skip is the count of elements to drop between windows if step > size
+		skip := 0
+
+		s.each(func(item T) bool {
+			if skip > 0 {
+				skip--
+				return true
+			}
+
+			window = append(window, item)
+			if len(window) < size {
+				return true
+			}
+
+			result := make([]T, size)
+			copy(result, window)
+
+			if step < size {
+				window = append(window[:0], window[step:]...)
+			} else {
+				window = window[:0]
+				skip = step - size
+			}
+
+			return yield(result)
+		})
+	})
+	windowed.workers = s.workers
+
+	return windowed
+}
+
+// Chunked returns a stream of chunks of size elements, the last chunk may be smaller. Chunked panics if size
+// is not positive.
+func Chunked[T any](s Stream[T], size int) Stream[[]T] {
+	if size <= 0 {
+		panic("stream.Chunked: param size should be positive")
+	}
+
+	chunked := newStream(func(yield func(item []T) bool) {
+		chunk := make([]T, 0, size)
+		stopped := false
+
+		s.each(func(item T) bool {
+			chunk = append(chunk, item)
+			if len(chunk) < size {
+				return true
+			}
+
+			if !yield(chunk) {
+				stopped = true
+				return false
+			}
+			chunk = make([]T, 0, size)
+			return true
+		})
+
+		if !stopped && len(chunk) > 0 {
+			yield(chunk)
+		}
+	})
+	chunked.workers = s.workers
+
+	return chunked
+}
+
+// GroupAdjacent returns a stream of groups of consecutive elements with the same key, e.g. the elements of a
+// sorted stream are grouped by the sort key. Each group is a new slice.
+func GroupAdjacent[T any, K comparable](s Stream[T], key func(item T) K) Stream[[]T] {
+	grouped := newStream(func(yield func(item []T) bool) {
+		var group []T
+		var groupKey K
+		stopped := false
+
+		s.each(func(item T) bool {
+			k := key(item)
+			if len(group) > 0 && k != groupKey {
+				if !yield(group) {
+					stopped = true
+					return false
+				}
+				group = nil
+			}
+
+			group = append(group, item)
+			groupKey = k
+			return true
+		})
+
+		if !stopped && len(group) > 0 {
+			yield(group)
+		}
+	})
+	grouped.workers = s.workers
+
+	return grouped
+}
+
+// RunLengthEncode returns a stream of pairs of value and count of its consecutive occurrences, e.g.
+// a, a, b, a becomes (a, 2), (b, 1), (a, 1).
+func RunLengthEncode[T comparable](s Stream[T]) Stream[tuple.Tuple2[T, int]] {
+	encoded := newStream(func(yield func(item tuple.Tuple2[T, int]) bool) {
+		var value T
+		count := 0
+		stopped := false
+
+		s.each(func(item T) bool {
+			if count > 0 && item != value {
+				if !yield(tuple.NewTuple2(value, count)) {
+					stopped = true
+					return false
+				}
+				count = 0
+			}
+
+			value = item
+			count++
+			return true
+		})
+
+		if !stopped && count > 0 {
+			yield(tuple.NewTuple2(value, count))
+		}
+	})
+	encoded.workers = s.workers
+
+	return encoded
+}
+
+func maxWorkers(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// pull converts the push style pipeline of s into a pull style iterator by running it in another goroutine,
+// the pipeline only advances when next is called. stop must be called to release the goroutine, and the panic
+// in the pipeline is propagated to the caller of next or stop.
+func pull[T any](s Stream[T]) (next func() (T, bool), stop func()) {
+	items := make(chan T)
+	resume := make(chan struct{})
+	done := make(chan struct{})
+
+	var (
+		panicVal          any
+		panicked          bool
+		started, finished bool
+	)
+
+	run := func() {
+		defer close(items)
+		defer func() {
+			if r := recover(); r != nil {
+				panicVal, panicked = r, true
+			}
+		}()
+
+		s.each(func(item T) bool {
+			items <- item
+			select {
+			case <-resume:
+				return true
+			case <-done:
+				return false
+			}
+		})
+	}
+
+	next = func() (T, bool) {
+		var zero T
+		if finished {
+			return zero, false
+		}
+
+		if !started {
+			started = true
+			go run()
+		} else {
+			resume <- struct{}{}
+		}
+
+		item, ok := <-items
+		if !ok {
+			finished = true
+			if panicked {
+				panic(panicVal)
+			}
+			return zero, false
+		}
+
+		return item, true
+	}
+
+	stop = func() {
+		if !started || finished {
+			finished = true
+			return
+		}
+		finished = true
+
+		close(done)
+		for range items {
+		}
+		if panicked {
+			panic(panicVal)
+		}
+	}
+
+	return next, stop
+}
@@ -0,0 +1,183 @@
+package stream
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/tuple"
+)
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestZip")
+
+	s := Zip(Of(1, 2, 3), Of("a", "b"))
+	expected := []tuple.Tuple2[int, string]{tuple.NewTuple2(1, "a"), tuple.NewTuple2(2, "b")}
+	assert.Equal(expected, s.ToSlice())
+	// the stream could be executed again
+	assert.Equal(2, s.Count())
+
+	assert.Equal(0, Zip(Of[int](), Of(1)).Count())
+
+	// infinite streams
+	naturals := func() Stream[int] {
+		return Generate(func() func() (int, bool) {
+			i := 0
+			return func() (int, bool) {
+				i++
+				return i, true
+			}
+		})
+	}
+	sums := MapTo(Zip(naturals(), naturals()).Limit(3), func(item tuple.Tuple2[int, int]) int {
+		return item.FieldA + item.FieldB
+	})
+	assert.Equal([]int{2, 4, 6}, sums.ToSlice())
+}
+
+func TestZip_Lazy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestZip_Lazy")
+
+	var pulled []int
+	b := Of(1, 2, 3, 4, 5).Peek(func(item int) {
+		pulled = append(pulled, item)
+	})
+
+	Zip(Of("a", "b", "c", "d"), b).Limit(2).ToSlice()
+	assert.Equal([]int{1, 2}, pulled)
+}
+
+func TestZip_Panic(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestZip_Panic")
+
+	b := Of(1, 2, 3).Map(func(item int) int {
+		if item == 2 {
+			panic("boom")
+		}
+		return item
+	})
+
+	defer func() {
+		assert.Equal("boom", recover())
+	}()
+
+	Zip(Of(1, 2, 3), b).ToSlice()
+}
+
+func TestPull_Release(t *testing.T) {
+	assert := internal.NewAssert(t, "TestPull_Release")
+
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 100; i++ {
+		Zip(FromRange(1, 1000, 1), FromRange(1, 1000, 1)).Limit(3).ToSlice()
+		Interleave(FromRange(1, 1000, 1), Of(1), Of[int]()).FindFirst()
+	}
+
+	// wait for the goroutines to exit
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(true, runtime.NumGoroutine() <= before)
+}
+
+func TestInterleave(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestInterleave")
+
+	s := Interleave(Of(1, 4, 7, 9), Of(2, 5), Of(3, 6, 8))
+	assert.Equal([]int{1, 2, 3, 4, 5, 6, 7, 8, 9}, s.ToSlice())
+	assert.Equal([]int{1, 2, 3, 4}, s.Limit(4).ToSlice())
+
+	assert.Equal([]int{}, Interleave[int]().ToSlice())
+	assert.Equal([]int{1, 2}, Interleave(Of(1, 2)).ToSlice())
+}
+
+func TestWindowed(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWindowed")
+
+	s := Of(1, 2, 3, 4, 5, 6, 7)
+
+	assert.Equal([][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}, {5, 6, 7}}, Windowed(s, 3, 1).ToSlice())
+	assert.Equal([][]int{{1, 2, 3}, {3, 4, 5}, {5, 6, 7}}, Windowed(s, 3, 2).ToSlice())
+	assert.Equal([][]int{{1, 2}, {4, 5}}, Windowed(s, 2, 3).ToSlice())
+	assert.Equal([][]int{}, Windowed(s, 8, 1).ToSlice())
+
+	// windows are not shared
+	windows := Windowed(s, 2, 1).ToSlice()
+	windows[0][1] = 100
+	assert.Equal([]int{2, 3}, windows[1])
+
+	assert.Equal([][]int{{1, 2}}, Windowed(s, 2, 1).Limit(1).ToSlice())
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	Windowed(s, 0, 1)
+}
+
+func TestChunked(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestChunked")
+
+	s := Of(1, 2, 3, 4, 5, 6, 7)
+
+	assert.Equal([][]int{{1, 2, 3}, {4, 5, 6}, {7}}, Chunked(s, 3).ToSlice())
+	assert.Equal([][]int{{1, 2, 3, 4, 5, 6, 7}}, Chunked(s, 10).ToSlice())
+	assert.Equal([][]int{{1, 2}, {3, 4}}, Chunked(s, 2).Limit(2).ToSlice())
+	assert.Equal([][]int{}, Chunked(Of[int](), 2).ToSlice())
+
+	defer func() {
+		assert.IsNotNil(recover())
+	}()
+	Chunked(s, -1)
+}
+
+func TestGroupAdjacent(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestGroupAdjacent")
+
+	s := Of(1, 3, 2, 4, 6, 5, 8)
+	isEven := func(item int) bool { return item%2 == 0 }
+
+	assert.Equal([][]int{{1, 3}, {2, 4, 6}, {5}, {8}}, GroupAdjacent(s, isEven).ToSlice())
+	assert.Equal([][]int{{1, 3}, {2, 4, 6}}, GroupAdjacent(s, isEven).Limit(2).ToSlice())
+	assert.Equal([][]int{}, GroupAdjacent(Of[int](), isEven).ToSlice())
+
+	words := Of("apple", "avocado", "banana", "blueberry", "cherry")
+	groups := GroupAdjacent(words, func(item string) byte { return item[0] })
+	assert.Equal([][]string{{"apple", "avocado"}, {"banana", "blueberry"}, {"cherry"}}, groups.ToSlice())
+}
+
+func TestRunLengthEncode(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRunLengthEncode")
+
+	s := RunLengthEncode(Of("a", "a", "b", "a", "c", "c", "c"))
+	expected := []tuple.Tuple2[string, int]{
+		tuple.NewTuple2("a", 2),
+		tuple.NewTuple2("b", 1),
+		tuple.NewTuple2("a", 1),
+		tuple.NewTuple2("c", 3),
+	}
+	assert.Equal(expected, s.ToSlice())
+	assert.Equal(expected[:2], s.Limit(2).ToSlice())
+
+	assert.Equal([]tuple.Tuple2[int, int]{}, RunLengthEncode(Of[int]()).ToSlice())
+
+	// zero value is encoded as well
+	assert.Equal([]tuple.Tuple2[int, int]{tuple.NewTuple2(0, 2)}, RunLengthEncode(Of(0, 0)).ToSlice())
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package stream
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// isComparable checks if the values of T could be used as map key without panic, interface type is excluded
+// since its dynamic value may not be comparable.
+func isComparable[T any]() bool {
+	return strictlyComparable(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+func strictlyComparable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return strictlyComparable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !strictlyComparable(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return t.Comparable()
+}
+
+// hashKey encodes the content of item into a string, the items with equal content have the same key.
+func hashKey[T any](item T) string {
+	e := &keyEncoder{visited: map[uintptr]int{}}
+	e.encode(reflect.ValueOf(&item).Elem())
+
+	return e.String()
+}
+
+// keyEncoder encodes values by reflection, which works with unexported fields, unlike encoding/gob.
+type keyEncoder struct {
+	strings.Builder
+	// visited records the pointers on the path, to encode the cycles
+	visited map[uintptr]int
+}
+
+func (e *keyEncoder) writeUint(n uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	e.Write(buf[:])
+}
+
+func (e *keyEncoder) writeString(s string) {
+	e.writeUint(uint64(len(s)))
+	e.WriteString(s)
+}
+
+func (e *keyEncoder) encode(v reflect.Value) {
+	e.WriteByte(byte(v.Kind()))
+
+	switch v.Kind() {
+	case reflect.Invalid:
+	case reflect.Bool:
+		if v.Bool() {
+			e.WriteByte(1)
+		} else {
+			e.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.writeUint(floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		e.writeUint(floatBits(real(c)))
+		e.writeUint(floatBits(imag(c)))
+	case reflect.String:
+		e.writeString(v.String())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			e.encode(v.Index(i))
+		}
+	case reflect.Slice:
+		e.writeUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			e.encode(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			e.encode(v.Field(i))
+		}
+	case reflect.Map:
+		e.encodeMap(v)
+	case reflect.Pointer:
+		e.encodePointer(v)
+	case reflect.Interface:
+		if v.IsNil() {
+			e.WriteByte(0)
+			return
+		}
+		e.WriteByte(1)
+		e.writeString(v.Elem().Type().String())
+		e.encode(v.Elem())
+	default:
+		// func, chan and unsafe pointer are compared by identity
+		e.writeUint(uint64(v.Pointer()))
+	}
+}
+
+func (e *keyEncoder) encodeMap(v reflect.Value) {
+	if v.IsNil() {
+		e.WriteByte(0)
+		return
+	}
+	e.WriteByte(1)
+	e.writeUint(uint64(v.Len()))
+
+	// the entries are sorted by the encoded keys, since the order of map is random
+	entries := make([][2]string, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		entries = append(entries, [2]string{e.sub(iter.Key()), e.sub(iter.Value())})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i][0] < entries[j][0]
+	})
+
+	for _, entry := range entries {
+		e.writeString(entry[0])
+		e.writeString(entry[1])
+	}
+}
+
+func (e *keyEncoder) encodePointer(v reflect.Value) {
+	if v.IsNil() {
+		e.WriteByte(0)
+		return
+	}
+
+	// a cycle is encoded by the depth of the pointer it goes back to
+	p := v.Pointer()
+	if depth, ok := e.visited[p]; ok {
+		e.WriteByte(2)
+		e.writeUint(uint64(depth))
+		return
+	}
+
+	e.WriteByte(1)
+	e.visited[p] = len(e.visited)
+	e.encode(v.Elem())
+	delete(e.visited, p)
+}
+
+// floatBits returns the bits of f, and -0 is the same as 0.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+
+	return math.Float64bits(f)
+}
+
+// sub encodes v separately, sharing the visited pointers.
+func (e *keyEncoder) sub(v reflect.Value) string {
+	sub := &keyEncoder{visited: e.visited}
+	sub.encode(v)
+
+	return sub.String()
+}
@@ -0,0 +1,123 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestDistinctBy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDistinctBy")
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	people := FromSlice([]Person{{"Tom", 10}, {"Jim", 10}, {"Mike", 20}, {"Tom", 30}})
+
+	byAge := DistinctBy(people, func(p Person) int { return p.Age })
+	assert.Equal([]Person{{"Tom", 10}, {"Mike", 20}, {"Tom", 30}}, byAge.ToSlice())
+
+	byName := DistinctBy(people, func(p Person) string { return p.Name })
+	assert.Equal([]Person{{"Tom", 10}, {"Jim", 10}, {"Mike", 20}}, byName.ToSlice())
+
+	// lazy, works with infinite stream
+	naturals := Generate(func() func() (int, bool) {
+		n := 0
+		return func() (int, bool) {
+			n++
+			return n, true
+		}
+	})
+	assert.Equal([]int{1, 2, 3}, DistinctBy(naturals, func(n int) int { return n % 3 }).Limit(3).ToSlice())
+	assert.Equal(true, DistinctBy(naturals.Parallel(2), func(n int) int { return n }).IsParallel())
+}
+
+func TestStream_DistinctNotComparable(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_DistinctNotComparable")
+
+	slices := FromSlice([][]int{{1, 2}, {1, 2}, {2, 1}, {}, nil, {1}})
+	assert.Equal([][]int{{1, 2}, {2, 1}, {}, {1}}, slices.Distinct().ToSlice())
+
+	maps := FromSlice([]map[string]int{{"a": 1, "b": 2}, {"b": 2, "a": 1}, {"a": 1}})
+	assert.Equal(2, maps.Distinct().Count())
+
+	// unexported fields and non-comparable fields
+	type item struct {
+		name string
+		tags []string
+	}
+	items := FromSlice([]item{{"a", []string{"x"}}, {"a", []string{"x"}}, {"a", []string{"y"}}})
+	assert.Equal(2, items.Distinct().Count())
+
+	// funcs are compared by identity
+	f1 := func() {}
+	f2 := func() {}
+	funcs := FromSlice([]func(){f1, f1, f2})
+	assert.Equal(2, funcs.Distinct().Count())
+
+	// pointers are compared by the pointed values
+	a, b, c := 1, 1, 2
+	pointers := FromSlice([]*int{&a, &b, &c, nil, nil})
+	assert.Equal(3, DistinctBy(pointers, hashKey[*int]).Count())
+
+	// interface values could be mixed types
+	values := FromSlice([]any{1, "1", 1, []int{1}, []int{1}, int64(1), nil, nil})
+	assert.Equal([]any{1, "1", []int{1}, int64(1), nil}, values.Distinct().ToSlice())
+
+	floats := FromSlice([][]float64{{0}, {-0.0}, {1}})
+	assert.Equal(2, floats.Distinct().Count())
+}
+
+func TestHashKey_Cycle(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestHashKey_Cycle")
+
+	type node struct {
+		value int
+		next  *node
+	}
+
+	a := &node{value: 1}
+	a.next = a
+	b := &node{value: 1}
+	b.next = b
+	c := &node{value: 1, next: &node{value: 1}}
+
+	assert.Equal(hashKey(a), hashKey(b))
+	assert.NotEqual(hashKey(a), hashKey(c))
+
+	// the shared pointers are not cycles
+	shared := &node{value: 2}
+	d := []*node{shared, shared}
+	e := []*node{{value: 2}, {value: 2}}
+	assert.Equal(hashKey(d), hashKey(e))
+}
+
+func TestIsComparable(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIsComparable")
+
+	type withInterface struct {
+		v any
+	}
+	type plain struct {
+		a int
+		b [2]string
+	}
+
+	assert.Equal(true, isComparable[int]())
+	assert.Equal(true, isComparable[plain]())
+	assert.Equal(true, isComparable[*plain]())
+	assert.Equal(false, isComparable[any]())
+	assert.Equal(false, isComparable[withInterface]())
+	assert.Equal(false, isComparable[[]int]())
+	assert.Equal(false, isComparable[[1]func()]())
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package stream
+
+import (
+	"runtime"
+
+	"github.com/duke-git/lancet/v2/internal/parallel"
+)
+
+const (
+	// parallelBatchSize is the count of elements per worker pulled from the source at a time,
+	// so a parallel stream is still lazy and works with infinite source.
+	parallelBatchSize = 256
+
+	// minParallelSize is the min count of elements to execute in parallel, smaller batch is executed
+	// sequentially since the goroutine overhead outweighs the gain.
+	minParallelSize = 64
+)
+
+// Parallel returns a parallel stream, whose Map, Filter, ForEach and ReduceAssociative are executed by a
+// pool of workers, and the order of elements is preserved except ForEach. The following stages are parallel
+// too. If workers is not positive, runtime.GOMAXPROCS(0) is used. The functions passed to the parallel
+// operations should be safe for concurrent use, and Limit may not stop Map and Filter of the parallel
+// stage exactly, at most a batch of extra elements are consumed.
+func (s Stream[T]) Parallel(workers int) Stream[T] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	s.workers = workers
+
+	return s
+}
+
+// Sequential returns a sequential stream, the following stages are executed in the calling goroutine.
+func (s Stream[T]) Sequential() Stream[T] {
+	s.workers = 0
+
+	return s
+}
+
+// IsParallel returns whether the stream is executed in parallel.
+func (s Stream[T]) IsParallel() bool {
+	return s.workers > 1
+}
+
+// ReduceAssociative performs a reduction on the elements of this stream, identity should be the identity
+// value of accumulator (e.g. 0 for +) and accumulator should be associative, so that the elements could be
+// reduced in parallel segments and the partial results are combined in order. Unlike Reduce, the accumulator
+// doesn't need to be commutative.
+func (s Stream[T]) ReduceAssociative(identity T, accumulator func(a, b T) T) T {
+	if !s.IsParallel() {
+		return s.Reduce(identity, accumulator)
+	}
+
+	result := identity
+	partials := make([]T, s.workers)
+
+	s.batches(s.workers*parallelBatchSize, func(batch []T) bool {
+		segments := runParallel(len(batch), s.workers, func(segment, low, high int) {
+			partial := identity
+			for _, v := range batch[low:high] {
+				partial = accumulator(partial, v)
+			}
+			partials[segment] = partial
+		})
+
+		for _, partial := range partials[:segments] {
+			result = accumulator(result, partial)
+		}
+		return true
+	})
+
+	return result
+}
+
+// parallelMap maps the elements of s in parallel, the elements that mapper returns false are dropped.
+func parallelMap[T any, R any](s Stream[T], mapper func(item T) (R, bool)) Stream[R] {
+	return parallelMapIndexed(s, func(_ int, item T) (R, bool) {
+		return mapper(item)
+	})
+}
+
+// parallelMapIndexed is like parallelMap, and mapper is called with the position of element as well.
+func parallelMapIndexed[T any, R any](s Stream[T], mapper func(index int, item T) (R, bool)) Stream[R] {
+	return Stream[R]{
+		workers: s.workers,
+		iterate: func(yield func(item R) bool) {
+			size := s.workers * parallelBatchSize
+			results := make([]R, size)
+			keep := make([]bool, size)
+			offset := 0
+
+			s.batches(size, func(batch []T) bool {
+				runParallel(len(batch), s.workers, func(_, low, high int) {
+					for i := low; i < high; i++ {
+						results[i], keep[i] = mapper(offset+i, batch[i])
+					}
+				})
+				offset += len(batch)
+
+				for i := range batch {
+					if keep[i] && !yield(results[i]) {
+						return false
+					}
+				}
+				return true
+			})
+		},
+	}
+}
+
+// batches executes the pipeline and pushes the elements to yield in batches of at most size elements.
+// The batch is reused, so yield should not keep it.
+func (s Stream[T]) batches(size int, yield func(batch []T) bool) {
+	batch := make([]T, 0, size)
+	stopped := false
+
+	s.each(func(item T) bool {
+		batch = append(batch, item)
+		if len(batch) < size {
+			return true
+		}
+		if !yield(batch) {
+			stopped = true
+			return false
+		}
+		batch = batch[:0]
+		return true
+	})
+
+	if !stopped && len(batch) > 0 {
+		yield(batch)
+	}
+}
+
+// runParallel splits [0, n) into at most workers contiguous segments and calls fn for each segment in
+// goroutines, it returns the count of segments. If n is smaller than minParallelSize, fn is called once
+// in the calling goroutine. The panic in fn is propagated to the calling goroutine.
+func runParallel(n, workers int, fn func(segment, low, high int)) int {
+	if n == 0 {
+		return 0
+	}
+	if n < minParallelSize || workers <= 1 {
+		fn(0, 0, n)
+		return 1
+	}
+
+	segments := parallel.Workers(n, workers)
+	parallel.Chunks(n, segments, fn)
+
+	return segments
+}
@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestStream_Parallel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_Parallel")
+
+	s := FromRange(1, 10000, 1)
+	assert.Equal(false, s.IsParallel())
+
+	p := s.Parallel(4)
+	assert.Equal(true, p.IsParallel())
+	assert.Equal(true, p.Filter(func(n int) bool { return true }).Skip(1).IsParallel())
+	assert.Equal(false, p.Sequential().IsParallel())
+	assert.Equal(false, s.Parallel(1).IsParallel())
+	assert.Equal(true, Concat(s, p).IsParallel())
+
+	expected := s.Map(func(n int) int { return n * 2 }).Filter(func(n int) bool { return n%3 == 0 }).ToSlice()
+	actual := p.Map(func(n int) int { return n * 2 }).Filter(func(n int) bool { return n%3 == 0 }).ToSlice()
+	assert.Equal(expected, actual)
+	assert.Equal(3333, len(actual))
+
+	// the default workers
+	assert.Equal(expected, s.Parallel(0).Map(func(n int) int { return n * 2 }).Filter(func(n int) bool { return n%3 == 0 }).ToSlice())
+}
+
+func TestStream_ParallelForEach(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_ParallelForEach")
+
+	var sum, count int64
+	FromRange(1, 10000, 1).Parallel(8).ForEach(func(n int) {
+		atomic.AddInt64(&sum, int64(n))
+		atomic.AddInt64(&count, 1)
+	})
+
+	assert.Equal(int64(50005000), sum)
+	assert.Equal(int64(10000), count)
+}
+
+func TestStream_ReduceAssociative(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_ReduceAssociative")
+
+	sum := FromRange(1, 10000, 1).Parallel(4).ReduceAssociative(0, func(a, b int) int {
+		return a + b
+	})
+	assert.Equal(50005000, sum)
+
+	// string concatenation is associative but not commutative
+	words := make([]string, 2000)
+	for i := range words {
+		words[i] = strconv.Itoa(i)
+	}
+	concat := func(a, b string) string { return a + b }
+
+	assert.Equal(strings.Join(words, ""), FromSlice(words).Parallel(3).ReduceAssociative("", concat))
+	assert.Equal(strings.Join(words, ""), FromSlice(words).ReduceAssociative("", concat))
+	assert.Equal("", FromSlice([]string{}).Parallel(3).ReduceAssociative("", concat))
+}
+
+func TestStream_ParallelLazy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_ParallelLazy")
+
+	var calls int64
+	naturals := Generate(func() func() (int, bool) {
+		n := 0
+		return func() (int, bool) {
+			n++
+			return n, true
+		}
+	})
+
+	s := naturals.Parallel(2).Map(func(n int) int {
+		atomic.AddInt64(&calls, 1)
+		return n * n
+	})
+	assert.Equal(int64(0), atomic.LoadInt64(&calls))
+
+	assert.Equal([]int{1, 4, 9}, s.Limit(3).ToSlice())
+	// at most a batch is consumed
+	assert.Equal(true, atomic.LoadInt64(&calls) <= 2*parallelBatchSize)
+}
+
+func TestStream_ParallelSmallInput(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_ParallelSmallInput")
+
+	// executed sequentially, so the mapper without lock is safe
+	calls := 0
+	result := Of(1, 2, 3).Parallel(4).Map(func(n int) int {
+		calls++
+		return n + 1
+	}).ToSlice()
+
+	assert.Equal([]int{2, 3, 4}, result)
+	assert.Equal(3, calls)
+	assert.Equal(1, runParallel(minParallelSize-1, 4, func(_, _, _ int) {}))
+	assert.Equal(4, runParallel(minParallelSize, 4, func(_, _, _ int) {}))
+	assert.Equal(0, runParallel(0, 4, func(_, _, _ int) {}))
+}
+
+func TestStream_ParallelPanic(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_ParallelPanic")
+
+	defer func() {
+		assert.Equal("boom", recover())
+	}()
+
+	FromRange(1, 1000, 1).Parallel(4).Map(func(n int) int {
+		if n == 500 {
+			panic("boom")
+		}
+		return n
+	}).ToSlice()
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+//go:build go1.23
+
+package stream
+
+import "iter"
+
+// FromSeq creates stream from iterator of the standard library, e.g. maps.Keys(m). The iterator is called
+// when the stream is executed, so it should be re-iterable if the stream is consumed more than once.
+func FromSeq[T any](seq iter.Seq[T]) Stream[T] {
+	if seq == nil {
+		return newStream[T](nil)
+	}
+
+	return newStream(func(yield func(item T) bool) {
+		seq(yield)
+	})
+}
+
+// Seq returns an iterator of the elements, which executes the stream on every range and works with the
+// iterator based APIs of the standard library, e.g. slices.Collect(s.Seq()).
+func (s Stream[T]) Seq() iter.Seq[T] {
+	return func(yield func(item T) bool) {
+		s.each(yield)
+	}
+}
+
+// Seq2 returns an iterator of the index and element pairs, the index starts from 0 on every range.
+func (s Stream[T]) Seq2() iter.Seq2[int, T] {
+	return func(yield func(index int, item T) bool) {
+		index := 0
+		s.each(func(item T) bool {
+			ok := yield(index, item)
+			index++
+			return ok
+		})
+	}
+}
@@ -0,0 +1,32 @@
+//go:build go1.23
+
+package stream
+
+import (
+	"fmt"
+	"slices"
+)
+
+func ExampleFromSeq() {
+	s := FromSeq(slices.Values([]int{3, 1, 2}))
+
+	result := slices.Collect(s.Sorted(func(a, b int) bool { return a < b }).Seq())
+
+	fmt.Println(result)
+
+	// Output:
+	// [1 2 3]
+}
+
+func ExampleStream_Seq2() {
+	s := FromSlice([]string{"a", "b", "c"})
+
+	for i, v := range s.Seq2() {
+		fmt.Println(i, v)
+	}
+
+	// Output:
+	// 0 a
+	// 1 b
+	// 2 c
+}
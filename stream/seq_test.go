@@ -0,0 +1,80 @@
+//go:build go1.23
+
+package stream
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestFromSeq(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFromSeq")
+
+	s := FromSeq(slices.Values([]int{1, 2, 3, 4}))
+	assert.Equal([]int{2, 4}, s.Filter(func(item int) bool { return item%2 == 0 }).ToSlice())
+	// the iterator is called again
+	assert.Equal(4, s.Count())
+
+	keys := FromSeq(maps.Keys(map[string]int{"a": 1, "b": 2, "c": 3})).Sorted(func(a, b string) bool { return a < b })
+	assert.Equal([]string{"a", "b", "c"}, keys.ToSlice())
+
+	assert.Equal([]int{}, FromSeq[int](nil).ToSlice())
+
+	// infinite iterator
+	naturals := func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+	assert.Equal([]int{0, 1, 2}, FromSeq(naturals).Limit(3).ToSlice())
+}
+
+func TestStream_Seq(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_Seq")
+
+	s := FromSlice([]int{1, 2, 3, 4, 5}).Map(func(item int) int { return item * 10 })
+	assert.Equal([]int{10, 20, 30, 40, 50}, slices.Collect(s.Seq()))
+
+	var result []int
+	for v := range s.Seq() {
+		if v > 30 {
+			break
+		}
+		result = append(result, v)
+	}
+	assert.Equal([]int{10, 20, 30}, result)
+
+	parallel := FromRange(1, 1000, 1).Parallel(4).Map(func(item int) int { return item * 2 })
+	assert.Equal(parallel.ToSlice(), slices.Collect(parallel.Seq()))
+
+	assert.Equal([]int(nil), slices.Collect(Stream[int]{}.Seq()))
+}
+
+func TestStream_Seq2(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_Seq2")
+
+	s := FromSlice([]string{"a", "b", "c"})
+
+	var indexes []int
+	var items []string
+	for i, v := range s.Seq2() {
+		indexes = append(indexes, i)
+		items = append(items, v)
+	}
+	assert.Equal([]int{0, 1, 2}, indexes)
+	assert.Equal([]string{"a", "b", "c"}, items)
+
+	// index restarts on every range
+	for i := range s.Seq2() {
+		assert.Equal(0, i)
+		break
+	}
+}
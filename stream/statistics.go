@@ -0,0 +1,78 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package stream
+
+import "golang.org/x/exp/constraints"
+
+// Sum returns the sum of the numbers in stream, it's 0 if the stream is empty. It's executed in parallel
+// if the stream is parallel.
+func Sum[T constraints.Integer | constraints.Float](s Stream[T]) T {
+	return s.ReduceAssociative(0, func(a, b T) T {
+		return a + b
+	})
+}
+
+// Average returns the average of the numbers in stream, it returns false if the stream is empty. The numbers are
+// summed as float64, so the sum of integers doesn't overflow.
+func Average[T constraints.Integer | constraints.Float](s Stream[T]) (float64, bool) {
+	var sum float64
+	count := 0
+
+	s.each(func(item T) bool {
+		sum += float64(item)
+		count++
+		return true
+	})
+
+	if count == 0 {
+		return 0, false
+	}
+
+	return sum / float64(count), true
+}
+
+// Min returns the minimum number in stream, it returns false if the stream is empty.
+func Min[T constraints.Integer | constraints.Float](s Stream[T]) (T, bool) {
+	return s.Min(func(a, b T) bool { return a < b })
+}
+
+// Max returns the maximum number in stream, it returns false if the stream is empty.
+func Max[T constraints.Integer | constraints.Float](s Stream[T]) (T, bool) {
+	return s.Max(func(a, b T) bool { return a > b })
+}
+
+// Statistics is the summary of the numbers in stream, Min, Max and Mean are zero values if Count is 0.
+type Statistics[T constraints.Integer | constraints.Float] struct {
+	Count int
+	Sum   T
+	Min   T
+	Max   T
+	// Mean is calculated from the sum as float64, so it's accurate even if Sum overflows
+	Mean float64
+}
+
+// SummaryStatistics returns the count, sum, min, max and mean of the numbers in stream in one pass.
+func SummaryStatistics[T constraints.Integer | constraints.Float](s Stream[T]) Statistics[T] {
+	var result Statistics[T]
+	var sum float64
+
+	s.each(func(item T) bool {
+		if result.Count == 0 || item < result.Min {
+			result.Min = item
+		}
+		if result.Count == 0 || item > result.Max {
+			result.Max = item
+		}
+		result.Sum += item
+		sum += float64(item)
+		result.Count++
+		return true
+	})
+
+	if result.Count > 0 {
+		result.Mean = sum / float64(result.Count)
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package stream
+
+import (
+	"math"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestSum(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSum")
+
+	assert.Equal(15, Sum(Of(1, 2, 3, 4, 5)))
+	assert.Equal(0, Sum(Of[int]()))
+	assert.Equal(4.5, Sum(Of(1.5, 3.0)))
+	assert.Equal(500500, Sum(FromRange(1, 1000, 1).Parallel(4)))
+}
+
+func TestAverage(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAverage")
+
+	avg, ok := Average(Of(1, 2, 3, 4))
+	assert.Equal(true, ok)
+	assert.Equal(2.5, avg)
+
+	_, ok = Average(Of[float64]())
+	assert.Equal(false, ok)
+
+	// the sum of int8 overflows
+	avg, _ = Average(Of[int8](100, 100, 100))
+	assert.Equal(100.0, avg)
+}
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMinMax")
+
+	min, ok := Min(Of(3, -1, 2))
+	assert.Equal(true, ok)
+	assert.Equal(-1, min)
+
+	max, ok := Max(Of(3.5, -1, 2))
+	assert.Equal(true, ok)
+	assert.Equal(3.5, max)
+
+	_, ok = Min(Of[uint]())
+	assert.Equal(false, ok)
+	_, ok = Max(Of[uint]())
+	assert.Equal(false, ok)
+}
+
+func TestSummaryStatistics(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSummaryStatistics")
+
+	stats := SummaryStatistics(Of(4, -2, 7, 1))
+	assert.Equal(Statistics[int]{Count: 4, Sum: 10, Min: -2, Max: 7, Mean: 2.5}, stats)
+
+	assert.Equal(Statistics[float64]{}, SummaryStatistics(Of[float64]()))
+
+	stats8 := SummaryStatistics(Of[uint8](200, 200))
+	assert.Equal(uint8(144), stats8.Sum)
+	assert.Equal(200.0, stats8.Mean)
+
+	floats := SummaryStatistics(Of(0.5, math.Inf(1)))
+	assert.Equal(math.Inf(1), floats.Max)
+	assert.Equal(0.5, floats.Min)
+}
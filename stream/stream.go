@@ -3,12 +3,14 @@
 
 // Package stream implements a sequence of elements supporting sequential and operations.
 // this package is an experiment to explore if stream in go can work as the way java does. its function is very limited.
+// like java stream, the intermediate operations are lazy, they only build a pipeline which is executed when a
+// terminal operation (ForEach, ToSlice, Count, Reduce and etc) is called, so an infinite source works with Limit.
 package stream
 
 import (
-	"bytes"
-	"encoding/gob"
+	"context"
 
+	"github.com/duke-git/lancet/v2/maputil"
 	"github.com/duke-git/lancet/v2/slice"
 	"golang.org/x/exp/constraints"
 )
@@ -47,8 +49,42 @@ import (
 // 	Concat(streams ...StreamI[T]) StreamI[T]
 // }
 
+// Stream is a lazy pipeline of elements. Every terminal operation executes the pipeline from the source again,
+// so a stream from channel or generator can't be consumed twice.
 type Stream[T any] struct {
-	source []T
+	// iterate pushes the elements to yield one by one, and stops as soon as yield returns false.
+	iterate func(yield func(item T) bool)
+	// workers is the count of goroutines to execute the parallel operations, see Parallel.
+	workers int
+}
+
+// newStream creates a stream whose elements are pushed by iterate.
+func newStream[T any](iterate func(yield func(item T) bool)) Stream[T] {
+	return Stream[T]{iterate: iterate}
+}
+
+// derive creates a stream of the next stage, which is parallel if s is parallel.
+func (s Stream[T]) derive(iterate func(yield func(item T) bool)) Stream[T] {
+	return Stream[T]{iterate: iterate, workers: s.workers}
+}
+
+// each executes the pipeline, the zero value of Stream is an empty stream.
+func (s Stream[T]) each(yield func(item T) bool) {
+	if s.iterate != nil {
+		s.iterate(yield)
+	}
+}
+
+// collect executes the pipeline and returns all the elements.
+func (s Stream[T]) collect() []T {
+	result := make([]T, 0)
+
+	s.each(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+
+	return result
 }
 
 // Of creates a stream whose elements are the specified values.
@@ -57,39 +93,78 @@ func Of[T any](elems ...T) Stream[T] {
 	return FromSlice(elems)
 }
 
-// Generate stream where each element is generated by the provided generater function
+// Generate stream where each element is generated by the provided generater function, the generator is called
+// when the stream is executed, and it could be infinite if the stream is truncated by Limit.
 // Play: https://go.dev/play/p/rkOWL1yA3j9
 func Generate[T any](generator func() func() (item T, ok bool)) Stream[T] {
-	source := make([]T, 0)
-
-	var zeroValue T
-	for next, item, ok := generator(), zeroValue, true; ok; {
-
-		item, ok = next()
-		if ok {
-			source = append(source, item)
+	return newStream(func(yield func(item T) bool) {
+		next := generator()
+		for {
+			item, ok := next()
+			if !ok || !yield(item) {
+				return
+			}
 		}
-	}
-
-	return FromSlice(source)
+	})
 }
 
 // FromSlice creates stream from slice.
 // Play: https://go.dev/play/p/wywTO0XZtI4
 func FromSlice[T any](source []T) Stream[T] {
-	return Stream[T]{source: source}
+	return newStream(func(yield func(item T) bool) {
+		for _, v := range source {
+			if !yield(v) {
+				return
+			}
+		}
+	})
 }
 
-// FromChannel creates stream from channel.
+// FromMap creates stream of the entries of map, the order of entries is random like ranging over the map.
+// The entries could be collected back into a map by ToEntryMap.
+func FromMap[K comparable, V any](source map[K]V) Stream[maputil.Entry[K, V]] {
+	return newStream(func(yield func(item maputil.Entry[K, V]) bool) {
+		for k, v := range source {
+			if !yield(maputil.Entry[K, V]{Key: k, Value: v}) {
+				return
+			}
+		}
+	})
+}
+
+// FromChannel creates stream from channel. The channel is received when the stream is executed, until it's
+// closed or the stream is truncated by Limit.
 // Play: https://go.dev/play/p/9TZYugGMhXZ
 func FromChannel[T any](source <-chan T) Stream[T] {
-	s := make([]T, 0)
-
-	for v := range source {
-		s = append(s, v)
-	}
+	return newStream(func(yield func(item T) bool) {
+		for v := range source {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
 
-	return FromSlice(s)
+// FromChannelWithContext creates stream from channel like FromChannel, and the stream ends when ctx is done, so
+// the pipeline could be aborted even if the channel is never closed. Use ctx.Err() to check if it's aborted.
+func FromChannelWithContext[T any](ctx context.Context, source <-chan T) Stream[T] {
+	return newStream(func(yield func(item T) bool) {
+		for {
+			// check ctx first, since select chooses randomly if both are ready
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-source:
+				if !ok || !yield(v) {
+					return
+				}
+			}
+		}
+	})
 }
 
 // FromRange creates a number stream from start to end. both start and end are included. [start, end]
@@ -102,89 +177,207 @@ func FromRange[T constraints.Integer | constraints.Float](start, end, step T) St
 	}
 
 	l := int((end-start)/step) + 1
-	source := make([]T, l)
-
-	for i := 0; i < l; i++ {
-		source[i] = start + (T(i) * step)
-	}
 
-	return FromSlice(source)
+	return newStream(func(yield func(item T) bool) {
+		for i := 0; i < l; i++ {
+			if !yield(start + (T(i) * step)) {
+				return
+			}
+		}
+	})
 }
 
 // Concat creates a lazily concatenated stream whose elements are all the elements of the first stream followed by all the elements of the second stream.
 // Play: https://go.dev/play/p/HM4OlYk_OUC
 func Concat[T any](a, b Stream[T]) Stream[T] {
-	source := make([]T, 0)
+	concat := newStream(func(yield func(item T) bool) {
+		stopped := false
+		a.each(func(item T) bool {
+			if !yield(item) {
+				stopped = true
+				return false
+			}
+			return true
+		})
 
-	source = append(source, a.source...)
-	source = append(source, b.source...)
+		if !stopped {
+			b.each(yield)
+		}
+	})
 
-	return FromSlice(source)
+	// the concatenated stream is parallel if either is parallel
+	concat.workers = a.workers
+	if b.workers > concat.workers {
+		concat.workers = b.workers
+	}
+
+	return concat
 }
 
-// Distinct returns a stream that removes the duplicated items.
+// Distinct returns a stream that removes the duplicated items. If T is comparable, the items are compared by ==,
+// otherwise they are compared by content deeply like reflect.DeepEqual, funcs and channels are compared by identity.
 // Play: https://go.dev/play/p/eGkOSrm64cB
 func (s Stream[T]) Distinct() Stream[T] {
-	source := make([]T, 0)
-
-	distinct := map[string]bool{}
-
-	for _, v := range s.source {
-		// todo: performance issue
-		k := hashKey(v)
-		if _, ok := distinct[k]; !ok {
-			distinct[k] = true
-			source = append(source, v)
-		}
+	if !isComparable[T]() {
+		return DistinctBy(s, hashKey[T])
 	}
 
-	return FromSlice(source)
+	return s.derive(func(yield func(item T) bool) {
+		seen := map[any]struct{}{}
+
+		s.each(func(item T) bool {
+			if _, ok := seen[item]; ok {
+				return true
+			}
+			seen[item] = struct{}{}
+			return yield(item)
+		})
+	})
 }
 
-func hashKey(data any) string {
-	buffer := bytes.NewBuffer(nil)
-	encoder := gob.NewEncoder(buffer)
-	err := encoder.Encode(data)
-	if err != nil {
-		panic("stream.hashKey: get hashkey failed")
-	}
-	return buffer.String()
+// DistinctBy returns a stream that removes the items with duplicated key, the first item of each key is kept.
+// It's a function since method can't have type parameters.
+func DistinctBy[T any, K comparable](s Stream[T], key func(item T) K) Stream[T] {
+	return s.derive(func(yield func(item T) bool) {
+		seen := map[K]struct{}{}
+
+		s.each(func(item T) bool {
+			k := key(item)
+			if _, ok := seen[k]; ok {
+				return true
+			}
+			seen[k] = struct{}{}
+			return yield(item)
+		})
+	})
 }
 
 // Filter returns a stream consisting of the elements of this stream that match the given predicate.
+// If the stream is parallel, the predicate is called concurrently, see Parallel.
 // Play: https://go.dev/play/p/MFlSANo-buc
 func (s Stream[T]) Filter(predicate func(item T) bool) Stream[T] {
-	source := make([]T, 0)
-
-	for _, v := range s.source {
-		if predicate(v) {
-			source = append(source, v)
-		}
+	if s.IsParallel() {
+		return parallelMap(s, func(item T) (T, bool) {
+			return item, predicate(item)
+		})
 	}
 
-	return FromSlice(source)
+	return s.derive(func(yield func(item T) bool) {
+		s.each(func(item T) bool {
+			if !predicate(item) {
+				return true
+			}
+			return yield(item)
+		})
+	})
 }
 
 // Map returns a stream consisting of the elements of this stream that apply the given function to elements of stream.
+// If the stream is parallel, the mapper is called concurrently, see Parallel.
 // Play: https://go.dev/play/p/OtNQUImdYko
 func (s Stream[T]) Map(mapper func(item T) T) Stream[T] {
-	source := make([]T, s.Count())
+	if s.IsParallel() {
+		return parallelMap(s, func(item T) (T, bool) {
+			return mapper(item), true
+		})
+	}
 
-	for i, v := range s.source {
-		source[i] = mapper(v)
+	return s.derive(func(yield func(item T) bool) {
+		s.each(func(item T) bool {
+			return yield(mapper(item))
+		})
+	})
+}
+
+// FilterIndexed is like Filter, but the predicate is called with the position of element in stream as well.
+// If the stream is parallel, the predicate is called concurrently, see Parallel.
+func (s Stream[T]) FilterIndexed(predicate func(index int, item T) bool) Stream[T] {
+	if s.IsParallel() {
+		return parallelMapIndexed(s, func(index int, item T) (T, bool) {
+			return item, predicate(index, item)
+		})
+	}
+
+	return s.derive(func(yield func(item T) bool) {
+		index := -1
+		s.each(func(item T) bool {
+			index++
+			if !predicate(index, item) {
+				return true
+			}
+			return yield(item)
+		})
+	})
+}
+
+// MapIndexed is like Map, but the mapper is called with the position of element in stream as well.
+// If the stream is parallel, the mapper is called concurrently, see Parallel.
+func (s Stream[T]) MapIndexed(mapper func(index int, item T) T) Stream[T] {
+	if s.IsParallel() {
+		return parallelMapIndexed(s, func(index int, item T) (T, bool) {
+			return mapper(index, item), true
+		})
+	}
+
+	return s.derive(func(yield func(item T) bool) {
+		index := -1
+		s.each(func(item T) bool {
+			index++
+			return yield(mapper(index, item))
+		})
+	})
+}
+
+// MapTo returns a stream consisting of the results of applying the given function to the elements of stream, the
+// type of elements could be changed, e.g. project structs to one of their fields. It's a function since method
+// can't have type parameters. If the stream is parallel, the mapper is called concurrently, see Parallel.
+func MapTo[T any, R any](s Stream[T], mapper func(item T) R) Stream[R] {
+	if s.IsParallel() {
+		return parallelMap(s, func(item T) (R, bool) {
+			return mapper(item), true
+		})
 	}
 
-	return FromSlice(source)
+	return Stream[R]{
+		workers: s.workers,
+		iterate: func(yield func(item R) bool) {
+			s.each(func(item T) bool {
+				return yield(mapper(item))
+			})
+		},
+	}
+}
+
+// FlatMapTo returns a stream consisting of the elements of the streams produced by applying the given function to
+// each element of stream, the type of elements could be changed. The produced streams are consumed lazily in order.
+func FlatMapTo[T any, R any](s Stream[T], mapper func(item T) Stream[R]) Stream[R] {
+	return Stream[R]{
+		workers: s.workers,
+		iterate: func(yield func(item R) bool) {
+			s.each(func(item T) bool {
+				stopped := false
+				mapper(item).each(func(r R) bool {
+					if !yield(r) {
+						stopped = true
+						return false
+					}
+					return true
+				})
+				return !stopped
+			})
+		},
+	}
 }
 
 // Peek returns a stream consisting of the elements of this stream, additionally performing the provided action on each element as elements are consumed from the resulting stream.
 // Play: https://go.dev/play/p/u1VNzHs6cb2
 func (s Stream[T]) Peek(consumer func(item T)) Stream[T] {
-	for _, v := range s.source {
-		consumer(v)
-	}
-
-	return s
+	return s.derive(func(yield func(item T) bool) {
+		s.each(func(item T) bool {
+			consumer(item)
+			return yield(item)
+		})
+	})
 }
 
 // Skip returns a stream consisting of the remaining elements of this stream after discarding the first n elements of the stream.
@@ -195,62 +388,59 @@ func (s Stream[T]) Skip(n int) Stream[T] {
 		return s
 	}
 
-	source := make([]T, 0)
-	l := len(s.source)
-
-	if n > l {
-		return FromSlice(source)
-	}
-
-	for i := n; i < l; i++ {
-		source = append(source, s.source[i])
-	}
-
-	return FromSlice(source)
+	return s.derive(func(yield func(item T) bool) {
+		skipped := 0
+		s.each(func(item T) bool {
+			if skipped < n {
+				skipped++
+				return true
+			}
+			return yield(item)
+		})
+	})
 }
 
 // Limit returns a stream consisting of the elements of this stream, truncated to be no longer than maxSize in length.
+// No more elements are pulled from the source after maxSize elements, so it works with infinite stream.
 // Play: https://go.dev/play/p/qsO4aniDcGf
 func (s Stream[T]) Limit(maxSize int) Stream[T] {
-	if s.source == nil {
-		return s
-	}
-
-	if maxSize < 0 {
-		return FromSlice([]T{})
-	}
-
-	source := make([]T, 0, maxSize)
-
-	for i := 0; i < len(s.source) && i < maxSize; i++ {
-		source = append(source, s.source[i])
+	if maxSize <= 0 {
+		return s.derive(nil)
 	}
 
-	return FromSlice(source)
+	return s.derive(func(yield func(item T) bool) {
+		count := 0
+		s.each(func(item T) bool {
+			count++
+			return yield(item) && count < maxSize
+		})
+	})
 }
 
 // AllMatch returns whether all elements of this stream match the provided predicate.
 // Play: https://go.dev/play/p/V5TBpVRs-Cx
 func (s Stream[T]) AllMatch(predicate func(item T) bool) bool {
-	for _, v := range s.source {
-		if !predicate(v) {
-			return false
-		}
-	}
+	result := true
+
+	s.each(func(item T) bool {
+		result = predicate(item)
+		return result
+	})
 
-	return true
+	return result
 }
 
 // AnyMatch returns whether any elements of this stream match the provided predicate.
 // Play: https://go.dev/play/p/PTCnWn4OxSn
 func (s Stream[T]) AnyMatch(predicate func(item T) bool) bool {
-	for _, v := range s.source {
-		if predicate(v) {
-			return true
-		}
-	}
+	result := false
 
-	return false
+	s.each(func(item T) bool {
+		result = predicate(item)
+		return !result
+	})
+
+	return result
 }
 
 // NoneMatch returns whether no elements of this stream match the provided predicate.
@@ -259,20 +449,61 @@ func (s Stream[T]) NoneMatch(predicate func(item T) bool) bool {
 	return !s.AnyMatch(predicate)
 }
 
-// ForEach performs an action for each element of this stream.
+// ForEach performs an action for each element of this stream. If the stream is parallel, the action is called
+// concurrently and the order is not guaranteed.
 // Play: https://go.dev/play/p/Dsm0fPqcidk
 func (s Stream[T]) ForEach(action func(item T)) {
-	for _, v := range s.source {
-		action(v)
+	if s.IsParallel() {
+		s.batches(s.workers*parallelBatchSize, func(batch []T) bool {
+			runParallel(len(batch), s.workers, func(_, low, high int) {
+				for _, v := range batch[low:high] {
+					action(v)
+				}
+			})
+			return true
+		})
+		return
 	}
+
+	s.each(func(item T) bool {
+		action(item)
+		return true
+	})
+}
+
+// ForEachIndexed is like ForEach, but the action is called with the position of element in stream as well.
+// If the stream is parallel, the action is called concurrently and the order is not guaranteed.
+func (s Stream[T]) ForEachIndexed(action func(index int, item T)) {
+	if s.IsParallel() {
+		offset := 0
+		s.batches(s.workers*parallelBatchSize, func(batch []T) bool {
+			runParallel(len(batch), s.workers, func(_, low, high int) {
+				for i := low; i < high; i++ {
+					action(offset+i, batch[i])
+				}
+			})
+			offset += len(batch)
+			return true
+		})
+		return
+	}
+
+	index := 0
+	s.each(func(item T) bool {
+		action(index, item)
+		index++
+		return true
+	})
 }
 
 // Reduce performs a reduction on the elements of this stream, using an associative accumulation function, and returns an Optional describing the reduced value, if any.
+// It's always executed sequentially, use ReduceAssociative for parallel stream.
 // Play: https://go.dev/play/p/6uzZjq_DJLU
 func (s Stream[T]) Reduce(initial T, accumulator func(a, b T) T) T {
-	for _, v := range s.source {
-		initial = accumulator(initial, v)
-	}
+	s.each(func(item T) bool {
+		initial = accumulator(initial, item)
+		return true
+	})
 
 	return initial
 }
@@ -280,43 +511,56 @@ func (s Stream[T]) Reduce(initial T, accumulator func(a, b T) T) T {
 // Count returns the count of elements in the stream.
 // Play: https://go.dev/play/p/r3koY6y_Xo-
 func (s Stream[T]) Count() int {
-	return len(s.source)
+	count := 0
+
+	s.each(func(item T) bool {
+		count++
+		return true
+	})
+
+	return count
 }
 
 // FindFirst returns the first element of this stream and true, or zero value and false if the stream is empty.
 // Play: https://go.dev/play/p/9xEf0-6C1e3
 func (s Stream[T]) FindFirst() (T, bool) {
 	var result T
+	found := false
 
-	if s.source == nil || len(s.source) == 0 {
-		return result, false
-	}
+	s.each(func(item T) bool {
+		result, found = item, true
+		return false
+	})
 
-	return s.source[0], true
+	return result, found
 }
 
 // FindLast returns the last element of this stream and true, or zero value and false if the stream is empty.
 // Play: https://go.dev/play/p/WZD2rDAW-2h
 func (s Stream[T]) FindLast() (T, bool) {
 	var result T
+	found := false
 
-	if s.source == nil || len(s.source) == 0 {
-		return result, false
-	}
+	s.each(func(item T) bool {
+		result, found = item, true
+		return true
+	})
 
-	return s.source[len(s.source)-1], true
+	return result, found
 }
 
 // Reverse returns a stream whose elements are reverse order of given stream.
+// The elements are buffered when the stream is executed.
 // Play: https://go.dev/play/p/A8_zkJnLHm4
 func (s Stream[T]) Reverse() Stream[T] {
-	l := len(s.source)
-	source := make([]T, l)
-
-	for i := 0; i < l; i++ {
-		source[i] = s.source[l-1-i]
-	}
-	return FromSlice(source)
+	return s.derive(func(yield func(item T) bool) {
+		source := s.collect()
+		for i := len(source) - 1; i >= 0; i-- {
+			if !yield(source[i]) {
+				return
+			}
+		}
+	})
 }
 
 // Range returns a stream whose elements are in the range from start(included) to end(excluded) original stream.
@@ -329,31 +573,26 @@ func (s Stream[T]) Range(start, end int) Stream[T] {
 		end = 0
 	}
 	if start >= end {
-		return FromSlice([]T{})
-	}
-
-	source := make([]T, 0)
-
-	if end > len(s.source) {
-		end = len(s.source)
+		return s.derive(nil)
 	}
 
-	for i := start; i < end; i++ {
-		source = append(source, s.source[i])
-	}
-
-	return FromSlice(source)
+	return s.Skip(start).Limit(end - start)
 }
 
 // Sorted returns a stream consisting of the elements of this stream, sorted according to the provided less function.
+// The elements are buffered when the stream is executed.
 // Play: https://go.dev/play/p/XXtng5uonFj
 func (s Stream[T]) Sorted(less func(a, b T) bool) Stream[T] {
-	source := []T{}
-	source = append(source, s.source...)
-
-	slice.SortBy(source, less)
-
-	return FromSlice(source)
+	return s.derive(func(yield func(item T) bool) {
+		source := s.collect()
+		slice.SortBy(source, less)
+
+		for _, v := range source {
+			if !yield(v) {
+				return
+			}
+		}
+	})
 }
 
 // Max returns the maximum element of this stream according to the provided less function.
@@ -361,17 +600,17 @@ func (s Stream[T]) Sorted(less func(a, b T) bool) Stream[T] {
 // Play: https://go.dev/play/p/fm-1KOPtGzn
 func (s Stream[T]) Max(less func(a, b T) bool) (T, bool) {
 	var max T
+	found := false
 
-	if len(s.source) == 0 {
-		return max, false
-	}
-
-	for i, v := range s.source {
-		if less(v, max) || i == 0 {
-			max = v
+	s.each(func(item T) bool {
+		if !found || less(item, max) {
+			max = item
 		}
-	}
-	return max, true
+		found = true
+		return true
+	})
+
+	return max, found
 }
 
 // Min returns the minimum element of this stream according to the provided less function.
@@ -379,22 +618,21 @@ func (s Stream[T]) Max(less func(a, b T) bool) (T, bool) {
 // Play: https://go.dev/play/p/vZfIDgGNRe_0
 func (s Stream[T]) Min(less func(a, b T) bool) (T, bool) {
 	var min T
+	found := false
 
-	if len(s.source) == 0 {
-		return min, false
-	}
-
-	for i, v := range s.source {
-		if less(v, min) || i == 0 {
-			min = v
+	s.each(func(item T) bool {
+		if !found || less(item, min) {
+			min = item
 		}
-	}
+		found = true
+		return true
+	})
 
-	return min, true
+	return min, found
 }
 
 // ToSlice return the elements in the stream.
 // Play: https://go.dev/play/p/jI6_iZZuVFE
 func (s Stream[T]) ToSlice() []T {
-	return s.source
+	return s.collect()
 }
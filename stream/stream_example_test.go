@@ -1,7 +1,12 @@
 package stream
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+
+	"github.com/duke-git/lancet/v2/maputil"
+	"github.com/duke-git/lancet/v2/tuple"
 )
 
 func ExampleOf() {
@@ -76,6 +81,25 @@ func ExampleGenerate() {
 	// [1 2 3]
 }
 
+func ExampleGenerate_infinite() {
+	generator := func() func() (int, bool) {
+		n := 0
+		return func() (int, bool) {
+			n++
+			return n * n, true
+		}
+	}
+
+	s := Generate(generator).Filter(func(n int) bool {
+		return n%2 == 1
+	}).Limit(3)
+
+	fmt.Println(s.ToSlice())
+
+	// Output:
+	// [1 9 25]
+}
+
 func ExampleConcat() {
 	s1 := FromSlice([]int{1, 2, 3})
 	s2 := FromSlice([]int{4, 5, 6})
@@ -384,3 +408,269 @@ func ExampleStream_Count() {
 	// 3
 	// 0
 }
+
+func ExampleStream_Parallel() {
+	s := FromRange(1, 1000, 1).Parallel(4)
+
+	squares := s.Map(func(n int) int {
+		return n * n
+	}).Filter(func(n int) bool {
+		return n%7 == 0
+	}).Limit(5)
+
+	sum := s.ReduceAssociative(0, func(a, b int) int {
+		return a + b
+	})
+
+	fmt.Println(squares.ToSlice())
+	fmt.Println(sum)
+
+	// Output:
+	// [49 196 441 784 1225]
+	// 500500
+}
+
+func ExampleMapTo() {
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	people := FromSlice([]Person{{"Tom", 10}, {"Jim", 20}, {"Mike", 30}})
+
+	names := MapTo(people, func(p Person) string {
+		return p.Name
+	})
+
+	fmt.Println(names.ToSlice())
+
+	// Output:
+	// [Tom Jim Mike]
+}
+
+func ExampleFlatMapTo() {
+	s := FromSlice([]int{1, 2, 3})
+
+	result := FlatMapTo(s, func(n int) Stream[string] {
+		return Of(fmt.Sprint(n), fmt.Sprint(n*10))
+	})
+
+	fmt.Println(result.ToSlice())
+
+	// Output:
+	// [1 10 2 20 3 30]
+}
+
+func ExampleGroupBy() {
+	type Person struct {
+		Name string
+		City string
+	}
+
+	people := FromSlice([]Person{
+		{"Tom", "Beijing"},
+		{"Jim", "Shanghai"},
+		{"Mike", "Beijing"},
+	})
+
+	names := Collect(people, GroupBy(
+		func(p Person) string { return p.City },
+		func(p Person) string { return p.Name },
+	))
+
+	counts := Collect(people, GroupByWith(
+		func(p Person) string { return p.City },
+		Counting[Person](),
+	))
+
+	fmt.Println(names)
+	fmt.Println(counts)
+
+	// Output:
+	// map[Beijing:[Tom Mike] Shanghai:[Jim]]
+	// map[Beijing:2 Shanghai:1]
+}
+
+func ExamplePartitionBy() {
+	s := FromRange(1, 6, 1)
+
+	result := Collect(s, PartitionBy(func(n int) bool {
+		return n%2 == 0
+	}))
+
+	fmt.Println(result[true])
+	fmt.Println(result[false])
+
+	// Output:
+	// [2 4 6]
+	// [1 3 5]
+}
+
+func ExampleJoining() {
+	s := Of("a", "b", "c")
+
+	result := Collect(s, Joining(", ", "[", "]"))
+
+	fmt.Println(result)
+
+	// Output:
+	// [a, b, c]
+}
+
+func ExampleSumming() {
+	s := FromRange(1, 100, 1)
+
+	fmt.Println(Collect(s, Summing[int]()))
+	fmt.Println(Collect(s, Counting[int]()))
+
+	// Output:
+	// 5050
+	// 100
+}
+
+func ExampleDistinctBy() {
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	people := FromSlice([]Person{{"Tom", 10}, {"Jim", 10}, {"Mike", 20}})
+
+	result := DistinctBy(people, func(p Person) int {
+		return p.Age
+	})
+
+	fmt.Println(result.ToSlice())
+
+	// Output:
+	// [{Tom 10} {Mike 20}]
+}
+
+func ExampleZip() {
+	names := FromSlice([]string{"a", "b", "c"})
+	numbers := FromRange(1, 10, 1)
+
+	Zip(names, numbers).ForEach(func(item tuple.Tuple2[string, int]) {
+		fmt.Println(item.FieldA, item.FieldB)
+	})
+
+	// Output:
+	// a 1
+	// b 2
+	// c 3
+}
+
+func ExampleInterleave() {
+	s := Interleave(Of(1, 3, 5, 7), Of(2, 4))
+
+	fmt.Println(s.ToSlice())
+
+	// Output:
+	// [1 2 3 4 5 7]
+}
+
+func ExampleWindowed() {
+	s := Windowed(Of(1, 2, 3, 4, 5), 3, 1)
+
+	fmt.Println(s.ToSlice())
+
+	// Output:
+	// [[1 2 3] [2 3 4] [3 4 5]]
+}
+
+func ExampleChunked() {
+	s := Chunked(Of(1, 2, 3, 4, 5), 2)
+
+	fmt.Println(s.ToSlice())
+
+	// Output:
+	// [[1 2] [3 4] [5]]
+}
+
+func ExampleTryMapTo() {
+	s := FromSlice([]string{"1", "2", "x", "4"})
+
+	result, err := TryMapTo(s.Try(), strconv.Atoi).ToSlice()
+
+	fmt.Println(result)
+	fmt.Println(err)
+
+	// Output:
+	// []
+	// strconv.Atoi: parsing "x": invalid syntax
+}
+
+func ExampleSummaryStatistics() {
+	stats := SummaryStatistics(Of(4, -2, 7, 1))
+
+	fmt.Println(stats.Count, stats.Sum, stats.Min, stats.Max, stats.Mean)
+
+	// Output:
+	// 4 10 -2 7 2.5
+}
+
+func ExampleFromChannelWithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan int)
+	go func() {
+		// the channel is never closed
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	s := FromChannelWithContext(ctx, ch).Limit(3)
+
+	fmt.Println(s.ToSlice())
+
+	// Output:
+	// [0 1 2]
+}
+
+func ExampleFromMap() {
+	prices := map[string]int{"apple": 3, "banana": 2, "cherry": 8}
+
+	discounted := Collect(
+		FromMap(prices).Map(func(e maputil.Entry[string, int]) maputil.Entry[string, int] {
+			return maputil.Entry[string, int]{Key: e.Key, Value: e.Value - 1}
+		}),
+		ToEntryMap[string, int](nil),
+	)
+
+	fmt.Println(discounted)
+
+	// Output:
+	// map[apple:2 banana:1 cherry:7]
+}
+
+func ExampleStream_MapIndexed() {
+	s := Of("a", "b", "c")
+
+	result := s.MapIndexed(func(index int, item string) string {
+		return fmt.Sprintf("%d:%s", index, item)
+	})
+
+	fmt.Println(result.ToSlice())
+
+	// Output:
+	// [0:a 1:b 2:c]
+}
+
+func ExampleRunLengthEncode() {
+	s := RunLengthEncode(Of("a", "a", "b", "c", "c", "c"))
+
+	s.ForEach(func(item tuple.Tuple2[string, int]) {
+		fmt.Println(item.FieldA, item.FieldB)
+	})
+
+	// Output:
+	// a 2
+	// b 1
+	// c 3
+}
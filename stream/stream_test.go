@@ -1,10 +1,15 @@
 package stream
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/duke-git/lancet/v2/internal"
+	"github.com/duke-git/lancet/v2/maputil"
 )
 
 func TestOf(t *testing.T) {
@@ -63,6 +68,81 @@ func TestFromChannel(t *testing.T) {
 	assert.Equal([]int{1, 2, 3}, stream.ToSlice())
 }
 
+func TestFromChannel_Limit(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFromChannel_Limit")
+
+	ch := make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	assert.Equal([]int{0, 1, 2}, FromChannel(ch).Limit(3).ToSlice())
+	// the rest are not consumed
+	assert.Equal(7, len(ch))
+}
+
+func TestFromChannelWithContext(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFromChannelWithContext")
+
+	// the channel is never closed
+	ch := make(chan int)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	result := FromChannelWithContext(ctx, ch).Peek(func(item int) {
+		if item == 4 {
+			cancel()
+		}
+	}).ToSlice()
+
+	assert.Equal([]int{0, 1, 2, 3, 4}, result)
+	assert.Equal(context.Canceled, ctx.Err())
+
+	ch2 := make(chan int, 3)
+	ch2 <- 1
+	ch2 <- 2
+	close(ch2)
+	assert.Equal([]int{1, 2}, FromChannelWithContext(context.Background(), ch2).ToSlice())
+
+	timeout, cancelTimeout := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelTimeout()
+	assert.Equal([]int{}, FromChannelWithContext(timeout, make(chan int)).ToSlice())
+}
+
+func TestFromMap(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFromMap")
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	keys := MapTo(FromMap(m), func(e maputil.Entry[string, int]) string {
+		return e.Key
+	}).Sorted(func(a, b string) bool { return a < b }).ToSlice()
+	assert.Equal([]string{"a", "b", "c"}, keys)
+
+	assert.Equal(2, FromMap(m).Filter(func(e maputil.Entry[string, int]) bool {
+		return e.Value > 1
+	}).Count())
+
+	assert.Equal(1, FromMap(m).Limit(1).Count())
+	assert.Equal(0, FromMap(map[string]int{}).Count())
+}
+
 func TestFromRange(t *testing.T) {
 	t.Parallel()
 
@@ -102,11 +182,11 @@ func TestStream_Distinct(t *testing.T) {
 	stream := FromSlice(people)
 	distinctStream := stream.Distinct()
 
-	// {[{001 Tom 10} {001 Tom 10} {002 Jim 20} {003 Mike 30}]}
-	t.Log(stream)
+	// [{001 Tom 10} {001 Tom 10} {002 Jim 20} {003 Mike 30}]
+	t.Log(stream.ToSlice())
 
-	// {[{001 Tom 10} {002 Jim 20} {003 Mike 30}]}
-	t.Log(distinctStream)
+	// [{001 Tom 10} {002 Jim 20} {003 Mike 30}]
+	t.Log(distinctStream.ToSlice())
 }
 
 func TestStream_Filter(t *testing.T) {
@@ -381,3 +461,224 @@ func TestStream_Min(t *testing.T) {
 	assert.Equal(1, max)
 	assert.Equal(true, ok)
 }
+
+func TestStream_Lazy(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_Lazy")
+
+	calls := 0
+	s := FromSlice([]int{1, 2, 3, 4, 5, 6}).Map(func(n int) int {
+		calls++
+		return n * 10
+	}).Filter(func(n int) bool {
+		return n > 10
+	})
+
+	assert.Equal(0, calls)
+
+	// short-circuit after the limit is reached
+	assert.Equal([]int{20, 30}, s.Limit(2).ToSlice())
+	assert.Equal(3, calls)
+
+	// every terminal operation executes the pipeline again
+	calls = 0
+	assert.Equal(5, s.Count())
+	assert.Equal(6, calls)
+
+	calls = 0
+	first, ok := s.FindFirst()
+	assert.Equal(20, first)
+	assert.Equal(true, ok)
+	assert.Equal(2, calls)
+
+	calls = 0
+	assert.Equal(true, s.AnyMatch(func(n int) bool { return n == 30 }))
+	assert.Equal(3, calls)
+
+	calls = 0
+	assert.Equal(false, s.AllMatch(func(n int) bool { return n < 30 }))
+	assert.Equal(3, calls)
+}
+
+func TestStream_Infinite(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_Infinite")
+
+	naturals := Generate(func() func() (int, bool) {
+		n := 0
+		return func() (int, bool) {
+			n++
+			return n, true
+		}
+	})
+
+	even := naturals.Filter(func(n int) bool { return n%2 == 0 })
+	assert.Equal([]int{2, 4, 6}, even.Limit(3).ToSlice())
+	assert.Equal([]int{4, 6, 8}, even.Skip(1).Limit(3).ToSlice())
+	assert.Equal([]int{6, 8}, even.Range(2, 4).ToSlice())
+
+	first, ok := Concat(naturals, naturals).FindFirst()
+	assert.Equal(1, first)
+	assert.Equal(true, ok)
+
+	ch := make(chan int)
+	go func() {
+		for i := 1; ; i++ {
+			ch <- i
+		}
+	}()
+
+	s := FromChannel(ch).Limit(3)
+	assert.Equal([]int{1, 2, 3}, s.ToSlice())
+	// the channel is consumed, so the stream continues from where it stopped
+	assert.Equal([]int{4, 5, 6}, s.ToSlice())
+}
+
+func TestStream_Empty(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_Empty")
+
+	var s Stream[int]
+
+	assert.Equal([]int{}, s.ToSlice())
+	assert.Equal(0, s.Count())
+	assert.Equal([]int{}, s.Map(func(n int) int { return n }).Reverse().ToSlice())
+
+	_, ok := s.Max(func(a, b int) bool { return a > b })
+	assert.Equal(false, ok)
+
+	assert.Equal([]int{1}, Concat(s, Of(1)).ToSlice())
+}
+
+func TestMapTo(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMapTo")
+
+	type Person struct {
+		Name string
+		Age  int
+	}
+
+	people := FromSlice([]Person{{"Tom", 10}, {"Jim", 20}, {"Mike", 30}})
+
+	names := MapTo(people, func(p Person) string { return p.Name })
+	assert.Equal([]string{"Tom", "Jim", "Mike"}, names.ToSlice())
+
+	ages := MapTo(people.Filter(func(p Person) bool { return p.Age > 10 }), func(p Person) int { return p.Age })
+	assert.Equal(50, ages.Reduce(0, func(a, b int) int { return a + b }))
+
+	assert.Equal([]string{}, MapTo(FromSlice([]int{}), func(n int) string { return fmt.Sprint(n) }).ToSlice())
+
+	// parallel
+	numbers := FromRange(1, 1000, 1).Parallel(4)
+	strs := MapTo(numbers, func(n int) string { return fmt.Sprint(n) })
+	assert.Equal(true, strs.IsParallel())
+	result := strs.ToSlice()
+	assert.Equal(1000, len(result))
+	assert.Equal("1", result[0])
+	assert.Equal("1000", result[999])
+}
+
+func TestFlatMapTo(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestFlatMapTo")
+
+	words := FlatMapTo(Of("hello world", "go stream"), func(s string) Stream[string] {
+		return FromSlice(strings.Fields(s))
+	})
+	assert.Equal([]string{"hello", "world", "go", "stream"}, words.ToSlice())
+
+	repeat := FlatMapTo(Of(1, 2, 3), func(n int) Stream[string] {
+		return Generate(func() func() (string, bool) {
+			i := 0
+			return func() (string, bool) {
+				i++
+				return fmt.Sprint(n), i <= n
+			}
+		})
+	})
+	assert.Equal([]string{"1", "2", "2", "3", "3", "3"}, repeat.ToSlice())
+	assert.Equal([]string{"1", "2", "2"}, repeat.Limit(3).ToSlice())
+	assert.Equal(6, repeat.Count())
+
+	// the streams are consumed lazily, so infinite inner stream works with Limit
+	calls := 0
+	infinite := FlatMapTo(Of(1, 2), func(n int) Stream[int] {
+		calls++
+		return Generate(func() func() (int, bool) {
+			return func() (int, bool) { return n, true }
+		})
+	})
+	assert.Equal([]int{1, 1, 1}, infinite.Limit(3).ToSlice())
+	assert.Equal(1, calls)
+
+	assert.Equal([]int{}, FlatMapTo(Of(1, 2), func(n int) Stream[int] { return Of[int]() }).ToSlice())
+}
+
+func TestStream_Indexed(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_Indexed")
+
+	s := Of("a", "b", "c", "d")
+
+	mapped := s.MapIndexed(func(index int, item string) string {
+		return fmt.Sprintf("%d%s", index, item)
+	})
+	assert.Equal([]string{"0a", "1b", "2c", "3d"}, mapped.ToSlice())
+
+	// the index is the position in the stream of the operation
+	filtered := s.Skip(1).FilterIndexed(func(index int, item string) bool {
+		return index%2 == 0
+	})
+	assert.Equal([]string{"b", "d"}, filtered.ToSlice())
+
+	var indexes []int
+	var items []string
+	filtered.ForEachIndexed(func(index int, item string) {
+		indexes = append(indexes, index)
+		items = append(items, item)
+	})
+	assert.Equal([]int{0, 1}, indexes)
+	assert.Equal([]string{"b", "d"}, items)
+
+	// the index restarts on every execution
+	assert.Equal([]string{"0a", "1b"}, mapped.Limit(2).ToSlice())
+	assert.Equal([]string{"0a", "1b"}, mapped.Limit(2).ToSlice())
+}
+
+func TestStream_IndexedParallel(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestStream_IndexedParallel")
+
+	s := FromRange(0, 9999, 1).Parallel(4)
+
+	mapped := s.MapIndexed(func(index int, item int) int {
+		return index - item
+	}).ToSlice()
+	assert.Equal(10000, len(mapped))
+	for _, v := range mapped {
+		assert.Equal(0, v)
+	}
+
+	filtered := s.FilterIndexed(func(index int, item int) bool {
+		return index%1000 == 0
+	}).ToSlice()
+	assert.Equal([]int{0, 1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000}, filtered)
+
+	seen := make([]int32, 10000)
+	s.ForEachIndexed(func(index int, item int) {
+		if index == item {
+			atomic.AddInt32(&seen[index], 1)
+		}
+	})
+	for _, v := range seen {
+		assert.Equal(int32(1), v)
+	}
+}
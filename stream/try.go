@@ -0,0 +1,223 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package stream
+
+// TryStream is a lazy and sequential pipeline whose operations may fail, the first error stops the pipeline, no more
+// elements are pulled from the source, and it's returned by the terminal operation.
+type TryStream[T any] struct {
+	// iterate pushes the elements to yield until yield returns false or an error occurs, and returns the error.
+	iterate func(yield func(item T) bool) error
+}
+
+// each executes the pipeline, the zero value of TryStream is an empty stream.
+func (s TryStream[T]) each(yield func(item T) bool) error {
+	if s.iterate == nil {
+		return nil
+	}
+
+	return s.iterate(yield)
+}
+
+// Try converts the stream into a TryStream, so that fallible operations could be chained. If the stream is
+// parallel, it's executed sequentially from now on.
+func (s Stream[T]) Try() TryStream[T] {
+	return TryStream[T]{
+		iterate: func(yield func(item T) bool) error {
+			s.each(yield)
+			return nil
+		},
+	}
+}
+
+// TryMap returns a TryStream consisting of the results of applying the fallible mapper to the elements of stream.
+func (s Stream[T]) TryMap(mapper func(item T) (T, error)) TryStream[T] {
+	return s.Try().Map(mapper)
+}
+
+// TryFilter returns a TryStream consisting of the elements of stream that match the fallible predicate.
+func (s Stream[T]) TryFilter(predicate func(item T) (bool, error)) TryStream[T] {
+	return s.Try().Filter(predicate)
+}
+
+// TryForEach performs the fallible action for each element of stream sequentially, it stops at the first error
+// and returns it.
+func (s Stream[T]) TryForEach(action func(item T) error) error {
+	return s.Try().ForEach(action)
+}
+
+// TryMapTo returns a TryStream consisting of the results of applying the fallible mapper to the elements of s,
+// the type of elements could be changed.
+func TryMapTo[T any, R any](s TryStream[T], mapper func(item T) (R, error)) TryStream[R] {
+	return TryStream[R]{
+		iterate: func(yield func(item R) bool) error {
+			var err error
+			upstreamErr := s.each(func(item T) bool {
+				var v R
+				if v, err = mapper(item); err != nil {
+					return false
+				}
+				return yield(v)
+			})
+
+			if err != nil {
+				return err
+			}
+			return upstreamErr
+		},
+	}
+}
+
+// Map returns a TryStream consisting of the results of applying the fallible mapper to the elements of stream.
+func (s TryStream[T]) Map(mapper func(item T) (T, error)) TryStream[T] {
+	return TryMapTo(s, mapper)
+}
+
+// Filter returns a TryStream consisting of the elements of stream that match the fallible predicate.
+func (s TryStream[T]) Filter(predicate func(item T) (bool, error)) TryStream[T] {
+	return TryStream[T]{
+		iterate: func(yield func(item T) bool) error {
+			var err error
+			upstreamErr := s.each(func(item T) bool {
+				var ok bool
+				if ok, err = predicate(item); err != nil {
+					return false
+				}
+				return !ok || yield(item)
+			})
+
+			if err != nil {
+				return err
+			}
+			return upstreamErr
+		},
+	}
+}
+
+// Peek returns a TryStream consisting of the elements of stream, additionally performing the fallible action on
+// each element as elements are consumed.
+func (s TryStream[T]) Peek(action func(item T) error) TryStream[T] {
+	return s.Filter(func(item T) (bool, error) {
+		return true, action(item)
+	})
+}
+
+// Skip returns a TryStream consisting of the remaining elements of stream after discarding the first n elements.
+func (s TryStream[T]) Skip(n int) TryStream[T] {
+	if n <= 0 {
+		return s
+	}
+
+	return TryStream[T]{
+		iterate: func(yield func(item T) bool) error {
+			skipped := 0
+			return s.each(func(item T) bool {
+				if skipped < n {
+					skipped++
+					return true
+				}
+				return yield(item)
+			})
+		},
+	}
+}
+
+// Limit returns a TryStream consisting of the elements of stream, truncated to be no longer than maxSize in length,
+// the operations on the elements after maxSize are never executed.
+func (s TryStream[T]) Limit(maxSize int) TryStream[T] {
+	if maxSize <= 0 {
+		return TryStream[T]{}
+	}
+
+	return TryStream[T]{
+		iterate: func(yield func(item T) bool) error {
+			count := 0
+			return s.each(func(item T) bool {
+				count++
+				return yield(item) && count < maxSize
+			})
+		},
+	}
+}
+
+// ForEach performs the fallible action for each element of stream, it stops at the first error and returns it.
+func (s TryStream[T]) ForEach(action func(item T) error) error {
+	var err error
+	upstreamErr := s.each(func(item T) bool {
+		err = action(item)
+		return err == nil
+	})
+
+	if err != nil {
+		return err
+	}
+	return upstreamErr
+}
+
+// ToSlice returns the elements in stream, or nil and the first error.
+func (s TryStream[T]) ToSlice() ([]T, error) {
+	result := make([]T, 0)
+
+	err := s.each(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Count returns the count of elements in stream, or the first error.
+func (s TryStream[T]) Count() (int, error) {
+	count := 0
+
+	err := s.each(func(item T) bool {
+		count++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Reduce performs a reduction on the elements of stream with the fallible accumulator, it returns the first error
+// of the pipeline or accumulator.
+func (s TryStream[T]) Reduce(initial T, accumulator func(a, b T) (T, error)) (T, error) {
+	var err error
+	upstreamErr := s.each(func(item T) bool {
+		initial, err = accumulator(initial, item)
+		return err == nil
+	})
+
+	if err == nil {
+		err = upstreamErr
+	}
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return initial, nil
+}
+
+// FindFirst returns the first element of stream and true, or false if the stream is empty. The error occurred before
+// the first element is returned.
+func (s TryStream[T]) FindFirst() (T, bool, error) {
+	var result T
+	found := false
+
+	err := s.each(func(item T) bool {
+		result, found = item, true
+		return false
+	})
+	if err != nil {
+		var zero T
+		return zero, false, err
+	}
+
+	return result, found, nil
+}
@@ -0,0 +1,130 @@
+package stream
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestTryStream_Map(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTryStream_Map")
+
+	numbers, err := TryMapTo(Of("1", "2", "3").Try(), strconv.Atoi).ToSlice()
+	assert.IsNil(err)
+	assert.Equal([]int{1, 2, 3}, numbers)
+
+	var parsed []string
+	s := Of("1", "x", "3").Peek(func(item string) {
+		parsed = append(parsed, item)
+	})
+
+	numbers, err = TryMapTo(s.Try(), strconv.Atoi).ToSlice()
+	assert.IsNotNil(err)
+	assert.Equal([]int(nil), numbers)
+	// the pipeline stops at the first error
+	assert.Equal([]string{"1", "x"}, parsed)
+
+	doubled, err := Of(1, 2, 3).TryMap(func(item int) (int, error) {
+		return item * 2, nil
+	}).ToSlice()
+	assert.IsNil(err)
+	assert.Equal([]int{2, 4, 6}, doubled)
+}
+
+func TestTryStream_Filter(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTryStream_Filter")
+
+	errTooLarge := errors.New("too large")
+	predicate := func(item int) (bool, error) {
+		if item > 10 {
+			return false, errTooLarge
+		}
+		return item%2 == 0, nil
+	}
+
+	result, err := Of(1, 2, 3, 4).TryFilter(predicate).ToSlice()
+	assert.IsNil(err)
+	assert.Equal([]int{2, 4}, result)
+
+	_, err = Of(1, 2, 30, 4).TryFilter(predicate).ToSlice()
+	assert.Equal(errTooLarge, err)
+
+	// Limit stops before the error
+	result, err = Of(1, 2, 3, 4, 30).TryFilter(predicate).Limit(2).ToSlice()
+	assert.IsNil(err)
+	assert.Equal([]int{2, 4}, result)
+
+	result, err = Of(1, 2, 3, 4).TryFilter(predicate).Skip(1).ToSlice()
+	assert.IsNil(err)
+	assert.Equal([]int{4}, result)
+}
+
+func TestTryStream_Terminal(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestTryStream_Terminal")
+
+	errOdd := errors.New("odd")
+	failOnOdd := func(item int) (int, error) {
+		if item%2 != 0 {
+			return 0, errOdd
+		}
+		return item, nil
+	}
+
+	var visited []int
+	err := Of(2, 4, 5, 6).TryForEach(func(item int) error {
+		visited = append(visited, item)
+		_, err := failOnOdd(item)
+		return err
+	})
+	assert.Equal(errOdd, err)
+	assert.Equal([]int{2, 4, 5}, visited)
+
+	count, err := Of(2, 4, 6).TryMap(failOnOdd).Count()
+	assert.IsNil(err)
+	assert.Equal(3, count)
+
+	count, err = Of(2, 3).TryMap(failOnOdd).Count()
+	assert.Equal(errOdd, err)
+	assert.Equal(0, count)
+
+	sum, err := Of(2, 4).TryMap(failOnOdd).Reduce(0, func(a, b int) (int, error) {
+		return a + b, nil
+	})
+	assert.IsNil(err)
+	assert.Equal(6, sum)
+
+	_, err = Of(1, 2).Try().Reduce(0, func(a, b int) (int, error) {
+		return failOnOdd(a + b)
+	})
+	assert.Equal(errOdd, err)
+
+	first, ok, err := Of(2, 3).TryMap(failOnOdd).FindFirst()
+	assert.IsNil(err)
+	assert.Equal(true, ok)
+	assert.Equal(2, first)
+
+	_, ok, err = Of(3, 2).TryMap(failOnOdd).FindFirst()
+	assert.Equal(errOdd, err)
+	assert.Equal(false, ok)
+
+	var peeked []int
+	_, err = Of(2, 3, 4).Try().Peek(func(item int) error {
+		peeked = append(peeked, item)
+		_, err := failOnOdd(item)
+		return err
+	}).ToSlice()
+	assert.Equal(errOdd, err)
+	assert.Equal([]int{2, 3}, peeked)
+
+	empty, err := TryStream[int]{}.ToSlice()
+	assert.IsNil(err)
+	assert.Equal([]int{}, empty)
+}
@@ -0,0 +1,203 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package strutil
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/duke-git/lancet/v2/slice"
+)
+
+// DiffKind is the kind of DiffSpan.
+type DiffKind int
+
+const (
+	// DiffEqual is the text in both strings
+	DiffEqual DiffKind = iota
+	// DiffDelete is the text in the old string only
+	DiffDelete
+	// DiffInsert is the text in the new string only
+	DiffInsert
+)
+
+// DiffSpan is a piece of text of the diff of two strings.
+type DiffSpan struct {
+	Kind DiffKind
+	Text string
+}
+
+// DiffStyle is the markers around the deleted and inserted text when rendering the diff.
+type DiffStyle struct {
+	DeleteStart string
+	DeleteEnd   string
+	InsertStart string
+	InsertEnd   string
+}
+
+var (
+	// ANSIDiffStyle renders the deleted text in red and the inserted text in green for terminal
+	ANSIDiffStyle = DiffStyle{
+		DeleteStart: "\x1b[31m",
+		DeleteEnd:   "\x1b[0m",
+		InsertStart: "\x1b[32m",
+		InsertEnd:   "\x1b[0m",
+	}
+
+	// MarkerDiffStyle renders the deleted text like [-text-] and the inserted text like {+text+}
+	MarkerDiffStyle = DiffStyle{
+		DeleteStart: "[-",
+		DeleteEnd:   "-]",
+		InsertStart: "{+",
+		InsertEnd:   "+}",
+	}
+)
+
+// Diff compares two strings by characters, and returns the spans to edit a into b. The short equal text between
+// the changes is merged into them, e.g. Diff("hello world", "hello gopher") is [hello ][-world][+gopher] rather than
+// the fragments matching "o" and "r", so it's readable for people. In a changed region, the deleted span is before
+// the inserted one.
+func Diff(a, b string) []DiffSpan {
+	segments := diffSegments(strings.Split(a, ""), strings.Split(b, ""))
+	return toDiffSpans(mergeShortEqualities(segments))
+}
+
+// DiffLines is like Diff, but compares two strings by lines, which is faster and more readable for the documents.
+// The line breaks are kept in the spans, and the equal lines are never merged into the changes.
+func DiffLines(a, b string) []DiffSpan {
+	return toDiffSpans(diffSegments(splitLines(a), splitLines(b)))
+}
+
+// RenderDiff joins the text of spans, the deleted and inserted text are wrapped by the markers of style.
+func RenderDiff(spans []DiffSpan, style DiffStyle) string {
+	var builder strings.Builder
+
+	for _, span := range spans {
+		switch span.Kind {
+		case DiffDelete:
+			builder.WriteString(style.DeleteStart)
+			builder.WriteString(span.Text)
+			builder.WriteString(style.DeleteEnd)
+		case DiffInsert:
+			builder.WriteString(style.InsertStart)
+			builder.WriteString(span.Text)
+			builder.WriteString(style.InsertEnd)
+		default:
+			builder.WriteString(span.Text)
+		}
+	}
+
+	return builder.String()
+}
+
+// splitLines splits s into lines, the line breaks are kept.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// diffSegment is the equal text, or the change of deleted and inserted text.
+type diffSegment struct {
+	equal    bool
+	text     string
+	deleted  string
+	inserted string
+}
+
+// diffSegments merges the edit script of tokens into the alternate equal and changed segments.
+func diffSegments(a, b []string) []diffSegment {
+	segments := []diffSegment{}
+
+	var deleted, inserted strings.Builder
+	flush := func() {
+		if deleted.Len() > 0 || inserted.Len() > 0 {
+			segments = append(segments, diffSegment{deleted: deleted.String(), inserted: inserted.String()})
+			deleted.Reset()
+			inserted.Reset()
+		}
+	}
+	equal := func(tokens []string) {
+		if len(tokens) == 0 {
+			return
+		}
+		flush()
+		segments = append(segments, diffSegment{equal: true, text: strings.Join(tokens, "")})
+	}
+
+	// pos is the index of the next token of a not in segments
+	pos := 0
+	for _, op := range slice.EditScript(a, b) {
+		equal(a[pos:op.OldIndex])
+
+		if op.Kind == slice.EditDelete {
+			deleted.WriteString(op.Item)
+			pos = op.OldIndex + 1
+		} else {
+			inserted.WriteString(op.Item)
+			pos = op.OldIndex
+		}
+	}
+	equal(a[pos:])
+	flush()
+
+	return segments
+}
+
+// mergeShortEqualities merges the equal segment into the changes around it, if it's not longer than the text
+// deleted or inserted on both sides, like the semantic cleanup of diff-match-patch.
+func mergeShortEqualities(segments []diffSegment) []diffSegment {
+	changeLen := func(seg diffSegment) int {
+		d, i := utf8.RuneCountInString(seg.deleted), utf8.RuneCountInString(seg.inserted)
+		if d > i {
+			return d
+		}
+		return i
+	}
+
+	for i := 1; i+1 < len(segments); {
+		prev, cur, next := segments[i-1], segments[i], segments[i+1]
+		n := utf8.RuneCountInString(cur.text)
+
+		if !cur.equal || n > changeLen(prev) || n > changeLen(next) {
+			i++
+			continue
+		}
+
+		merged := diffSegment{
+			deleted:  prev.deleted + cur.text + next.deleted,
+			inserted: prev.inserted + cur.text + next.inserted,
+		}
+		segments = append(append(segments[:i-1], merged), segments[i+2:]...)
+
+		// the merged change is at i-1 now, and it may make the equal segment before it short enough
+		if i -= 2; i < 1 {
+			i = 1
+		}
+	}
+
+	return segments
+}
+
+func toDiffSpans(segments []diffSegment) []DiffSpan {
+	spans := make([]DiffSpan, 0, len(segments))
+
+	for _, seg := range segments {
+		if seg.equal {
+			spans = append(spans, DiffSpan{Kind: DiffEqual, Text: seg.text})
+			continue
+		}
+		if seg.deleted != "" {
+			spans = append(spans, DiffSpan{Kind: DiffDelete, Text: seg.deleted})
+		}
+		if seg.inserted != "" {
+			spans = append(spans, DiffSpan{Kind: DiffInsert, Text: seg.inserted})
+		}
+	}
+
+	return spans
+}
@@ -0,0 +1,41 @@
+package strutil
+
+import (
+	"fmt"
+)
+
+func ExampleDiff() {
+	spans := Diff("hello world", "hello gopher")
+
+	for _, span := range spans {
+		fmt.Printf("%d %q\n", span.Kind, span.Text)
+	}
+
+	// Output:
+	// 0 "hello "
+	// 1 "world"
+	// 2 "gopher"
+}
+
+func ExampleDiffLines() {
+	oldConfig := "port: 8080\ndebug: false\n"
+	newConfig := "port: 9090\ndebug: false\n"
+
+	result := RenderDiff(DiffLines(oldConfig, newConfig), MarkerDiffStyle)
+
+	fmt.Print(result)
+
+	// Output:
+	// [-port: 8080
+	// -]{+port: 9090
+	// +}debug: false
+}
+
+func ExampleRenderDiff() {
+	result := RenderDiff(Diff("The quick fox", "The slow fox"), MarkerDiffStyle)
+
+	fmt.Println(result)
+
+	// Output:
+	// The [-quick-]{+slow+} fox
+}
@@ -0,0 +1,144 @@
+package strutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+// applyDiff rebuilds the old and new strings from spans.
+func applyDiff(spans []DiffSpan) (string, string) {
+	var a, b strings.Builder
+	for _, span := range spans {
+		if span.Kind != DiffInsert {
+			a.WriteString(span.Text)
+		}
+		if span.Kind != DiffDelete {
+			b.WriteString(span.Text)
+		}
+	}
+
+	return a.String(), b.String()
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiff")
+
+	assert.Equal([]DiffSpan{
+		{Kind: DiffEqual, Text: "hello "},
+		{Kind: DiffDelete, Text: "world"},
+		{Kind: DiffInsert, Text: "gopher"},
+	}, Diff("hello world", "hello gopher"))
+
+	assert.Equal([]DiffSpan{
+		{Kind: DiffEqual, Text: "你好"},
+		{Kind: DiffInsert, Text: "，"},
+		{Kind: DiffEqual, Text: "世界"},
+	}, Diff("你好世界", "你好，世界"))
+
+	assert.Equal([]DiffSpan{{Kind: DiffEqual, Text: "same"}}, Diff("same", "same"))
+	assert.Equal([]DiffSpan{{Kind: DiffInsert, Text: "new"}}, Diff("", "new"))
+	assert.Equal([]DiffSpan{{Kind: DiffDelete, Text: "old"}}, Diff("old", ""))
+	assert.Equal([]DiffSpan{}, Diff("", ""))
+
+	cases := [][2]string{
+		{"kitten", "sitting"},
+		{"abcabba", "cbabac"},
+		{"timeout: 30s\nretry: 3", "timeout: 60s\nretry: 3\ndebug: true"},
+		{"😀 smile", "😃 smiles"},
+	}
+	for _, c := range cases {
+		a, b := applyDiff(Diff(c[0], c[1]))
+		assert.Equal(c[0], a)
+		assert.Equal(c[1], b)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiffLines")
+
+	oldConfig := "host: localhost\nport: 8080\ndebug: false\n"
+	newConfig := "host: localhost\nport: 9090\ndebug: false\nlog: info\n"
+
+	spans := DiffLines(oldConfig, newConfig)
+	assert.Equal([]DiffSpan{
+		{Kind: DiffEqual, Text: "host: localhost\n"},
+		{Kind: DiffDelete, Text: "port: 8080\n"},
+		{Kind: DiffInsert, Text: "port: 9090\n"},
+		{Kind: DiffEqual, Text: "debug: false\n"},
+		{Kind: DiffInsert, Text: "log: info\n"},
+	}, spans)
+
+	a, b := applyDiff(DiffLines("a\nb", "a\nc\n"))
+	assert.Equal("a\nb", a)
+	assert.Equal("a\nc\n", b)
+
+	assert.Equal([]DiffSpan{}, DiffLines("", ""))
+}
+
+func TestRenderDiff(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestRenderDiff")
+
+	spans := Diff("hello world", "hello gopher")
+
+	assert.Equal("hello [-world-]{+gopher+}", RenderDiff(spans, MarkerDiffStyle))
+	assert.Equal("hello \x1b[31mworld\x1b[0m\x1b[32mgopher\x1b[0m", RenderDiff(spans, ANSIDiffStyle))
+	assert.Equal("hello <del>world</del><ins>gopher</ins>", RenderDiff(spans, DiffStyle{
+		DeleteStart: "<del>",
+		DeleteEnd:   "</del>",
+		InsertStart: "<ins>",
+		InsertEnd:   "</ins>",
+	}))
+	assert.Equal("", RenderDiff(nil, MarkerDiffStyle))
+}
+
+func TestDiff_MergeShortEqualities(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiff_MergeShortEqualities")
+
+	// the short equal segments between the changes are merged, but the long one is kept
+	segments := mergeShortEqualities([]diffSegment{
+		{equal: true, text: "x"},
+		{deleted: "12"},
+		{equal: true, text: "b"},
+		{inserted: "3"},
+		{equal: true, text: "a"},
+		{deleted: "45"},
+		{equal: true, text: "long equal"},
+	})
+
+	assert.Equal([]diffSegment{
+		{equal: true, text: "x"},
+		{deleted: "12ba45", inserted: "b3a"},
+		{equal: true, text: "long equal"},
+	}, segments)
+}
+
+func TestDiff_MergeShortEqualities_Backtrack(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDiff_MergeShortEqualities_Backtrack")
+
+	// "ab" is longer than the change after it, until the "c" after that change is merged
+	segments := mergeShortEqualities([]diffSegment{
+		{equal: true, text: "x"},
+		{deleted: "123"},
+		{equal: true, text: "ab"},
+		{deleted: "4"},
+		{equal: true, text: "c"},
+		{deleted: "5678"},
+	})
+
+	assert.Equal([]diffSegment{
+		{equal: true, text: "x"},
+		{deleted: "123ab4c5678", inserted: "abc"},
+	}, segments)
+}
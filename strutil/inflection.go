@@ -0,0 +1,240 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package strutil
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// inflectionRule is a regexp based rule to convert a word between singular and plural form.
+type inflectionRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// inflector holds the rules, irregular words and uncountable words used by Pluralize and Singularize.
+type inflector struct {
+	mu            sync.RWMutex
+	plurals       []inflectionRule
+	singulars     []inflectionRule
+	irregulars    map[string]string // singular -> plural
+	irregularsRev map[string]string // plural -> singular
+	uncountables  map[string]struct{}
+}
+
+var defaultInflector = newInflector()
+
+func newInflector() *inflector {
+	inf := &inflector{
+		irregulars:    make(map[string]string),
+		irregularsRev: make(map[string]string),
+		uncountables:  make(map[string]struct{}),
+	}
+
+	pluralRules := [][2]string{
+		{`$`, "s"},
+		{`(?i)s$`, "s"},
+		{`(?i)^(ax|test)is$`, "${1}es"},
+		{`(?i)(octop|vir)us$`, "${1}i"},
+		{`(?i)(alias|status|campus)$`, "${1}es"},
+		{`(?i)(bu)s$`, "${1}ses"},
+		{`(?i)(buffal|tomat|potat|her)o$`, "${1}oes"},
+		{`(?i)([ti])um$`, "${1}a"},
+		{`(?i)sis$`, "ses"},
+		{`(?i)(?:([^f])fe|([lr])f)$`, "${1}${2}ves"},
+		{`(?i)(hive)$`, "${1}s"},
+		{`(?i)([^aeiouy]|qu)y$`, "${1}ies"},
+		{`(?i)(x|ch|ss|sh|z)$`, "${1}es"},
+		{`(?i)(matr|vert|ind)(?:ix|ex)$`, "${1}ices"},
+		{`(?i)^(m|l)ouse$`, "${1}ice"},
+		{`(?i)^(ox)$`, "${1}en"},
+		{`(?i)(quiz)$`, "${1}zes"},
+	}
+	for _, r := range pluralRules {
+		inf.plurals = append(inf.plurals, inflectionRule{regexp.MustCompile(r[0]), r[1]})
+	}
+
+	singularRules := [][2]string{
+		{`(?i)s$`, ""},
+		{`(?i)(ss)$`, "${1}"},
+		{`(?i)(n)ews$`, "${1}ews"},
+		{`(?i)([ti])a$`, "${1}um"},
+		{`(?i)((a)naly|(b)a|(d)iagno|(p)arenthe|(p)rogno|(s)ynop|(t)he)(sis|ses)$`, "${1}sis"},
+		{`(?i)(^analy)(sis|ses)$`, "${1}sis"},
+		{`(?i)([^f])ves$`, "${1}fe"},
+		{`(?i)(hive)s$`, "${1}"},
+		{`(?i)(tive)s$`, "${1}"},
+		{`(?i)([lr])ves$`, "${1}f"},
+		{`(?i)([^aeiouy]|qu)ies$`, "${1}y"},
+		{`(?i)(s)eries$`, "${1}eries"},
+		{`(?i)(m)ovies$`, "${1}ovie"},
+		{`(?i)(x|ch|ss|sh|z)es$`, "${1}"},
+		{`(?i)^(m|l)ice$`, "${1}ouse"},
+		{`(?i)(bus)(es)?$`, "${1}"},
+		{`(?i)(buffal|tomat|potat|her)oes$`, "${1}o"},
+		{`(?i)(shoe)s$`, "${1}"},
+		{`(?i)(cris|test)(is|es)$`, "${1}is"},
+		{`(?i)^(a)x[ie]s$`, "${1}xis"},
+		{`(?i)(octop|vir)(us|i)$`, "${1}us"},
+		{`(?i)(alias|status|campus)(es)?$`, "${1}"},
+		{`(?i)^(ox)en`, "${1}"},
+		{`(?i)(vert|ind)ices$`, "${1}ex"},
+		{`(?i)(matr)ices$`, "${1}ix"},
+		{`(?i)(quiz)zes$`, "${1}"},
+	}
+	for _, r := range singularRules {
+		inf.singulars = append(inf.singulars, inflectionRule{regexp.MustCompile(r[0]), r[1]})
+	}
+
+	irregulars := [][2]string{
+		{"person", "people"},
+		{"man", "men"},
+		{"woman", "women"},
+		{"child", "children"},
+		{"tooth", "teeth"},
+		{"foot", "feet"},
+		{"goose", "geese"},
+		{"move", "moves"},
+		{"zombie", "zombies"},
+	}
+	for _, v := range irregulars {
+		inf.irregulars[v[0]] = v[1]
+		inf.irregularsRev[v[1]] = v[0]
+	}
+
+	uncountables := []string{
+		"equipment", "information", "rice", "money", "species", "series",
+		"fish", "sheep", "deer", "jeans", "police", "news", "data", "feedback",
+	}
+	for _, v := range uncountables {
+		inf.uncountables[v] = struct{}{}
+	}
+
+	return inf
+}
+
+// AddPluralRule adds a regexp rule used by Pluralize, rules added later take precedence.
+// The replacement string may contain submatch references like ${1}.
+func AddPluralRule(pattern, replacement string) {
+	defaultInflector.mu.Lock()
+	defer defaultInflector.mu.Unlock()
+
+	defaultInflector.plurals = append(defaultInflector.plurals,
+		inflectionRule{regexp.MustCompile(pattern), replacement})
+}
+
+// AddSingularRule adds a regexp rule used by Singularize, rules added later take precedence.
+// The replacement string may contain submatch references like ${1}.
+func AddSingularRule(pattern, replacement string) {
+	defaultInflector.mu.Lock()
+	defer defaultInflector.mu.Unlock()
+
+	defaultInflector.singulars = append(defaultInflector.singulars,
+		inflectionRule{regexp.MustCompile(pattern), replacement})
+}
+
+// AddIrregular adds an irregular word pair, eg: AddIrregular("person", "people").
+func AddIrregular(singular, plural string) {
+	defaultInflector.mu.Lock()
+	defer defaultInflector.mu.Unlock()
+
+	singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+	defaultInflector.irregulars[singular] = plural
+	defaultInflector.irregularsRev[plural] = singular
+}
+
+// AddUncountable adds words which have the same singular and plural form, eg: "sheep".
+func AddUncountable(words ...string) {
+	defaultInflector.mu.Lock()
+	defer defaultInflector.mu.Unlock()
+
+	for _, w := range words {
+		defaultInflector.uncountables[strings.ToLower(w)] = struct{}{}
+	}
+}
+
+// Pluralize returns the plural form of an english word, eg: "person" -> "people", "box" -> "boxes".
+// The case of the first letter of word is kept.
+func Pluralize(word string) string {
+	defaultInflector.mu.RLock()
+	defer defaultInflector.mu.RUnlock()
+
+	return defaultInflector.inflect(word, defaultInflector.irregulars, defaultInflector.plurals)
+}
+
+// Singularize returns the singular form of an english word, eg: "people" -> "person", "boxes" -> "box".
+// The case of the first letter of word is kept.
+func Singularize(word string) string {
+	defaultInflector.mu.RLock()
+	defer defaultInflector.mu.RUnlock()
+
+	return defaultInflector.inflect(word, defaultInflector.irregularsRev, defaultInflector.singulars)
+}
+
+func (inf *inflector) inflect(word string, irregulars map[string]string, rules []inflectionRule) string {
+	if strings.TrimSpace(word) == "" {
+		return word
+	}
+
+	lower := strings.ToLower(word)
+
+	if _, ok := inf.uncountables[lower]; ok {
+		return word
+	}
+
+	if v, ok := irregulars[lower]; ok {
+		return matchFirstLetterCase(word, v)
+	}
+
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].pattern.MatchString(word) {
+			return rules[i].pattern.ReplaceAllString(word, rules[i].replacement)
+		}
+	}
+
+	return word
+}
+
+// matchFirstLetterCase makes the first letter of target has the same case with source.
+func matchFirstLetterCase(source, target string) string {
+	r, _ := utf8.DecodeRuneInString(source)
+	if unicode.IsUpper(r) {
+		return UpperFirst(target)
+	}
+
+	return target
+}
+
+// Ordinal returns the english ordinal string of number, eg: 1 -> "1st", 2 -> "2nd", 13 -> "13th".
+func Ordinal(number int) string {
+	return strconv.Itoa(number) + OrdinalSuffix(number)
+}
+
+// OrdinalSuffix returns the english ordinal suffix of number, eg: 1 -> "st", 2 -> "nd", 13 -> "th".
+func OrdinalSuffix(number int) string {
+	if number < 0 {
+		number = -number
+	}
+
+	switch number % 100 {
+	case 11, 12, 13:
+		return "th"
+	}
+
+	switch number % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
@@ -0,0 +1,56 @@
+package strutil
+
+import (
+	"fmt"
+)
+
+func ExamplePluralize() {
+	result1 := Pluralize("person")
+	result2 := Pluralize("box")
+	result3 := Pluralize("City")
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(result3)
+
+	// Output:
+	// people
+	// boxes
+	// Cities
+}
+
+func ExampleSingularize() {
+	result1 := Singularize("people")
+	result2 := Singularize("boxes")
+	result3 := Singularize("Cities")
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(result3)
+
+	// Output:
+	// person
+	// box
+	// City
+}
+
+func ExampleOrdinal() {
+	result1 := Ordinal(1)
+	result2 := Ordinal(2)
+	result3 := Ordinal(3)
+	result4 := Ordinal(11)
+	result5 := Ordinal(22)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(result3)
+	fmt.Println(result4)
+	fmt.Println(result5)
+
+	// Output:
+	// 1st
+	// 2nd
+	// 3rd
+	// 11th
+	// 22nd
+}
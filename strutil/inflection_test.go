@@ -0,0 +1,114 @@
+package strutil
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestPluralize(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestPluralize")
+
+	cases := map[string]string{
+		"":         "",
+		"book":     "books",
+		"box":      "boxes",
+		"church":   "churches",
+		"city":     "cities",
+		"day":      "days",
+		"knife":    "knives",
+		"wolf":     "wolves",
+		"tomato":   "tomatoes",
+		"analysis": "analyses",
+		"status":   "statuses",
+		"matrix":   "matrices",
+		"mouse":    "mice",
+		"person":   "people",
+		"Person":   "People",
+		"child":    "children",
+		"sheep":    "sheep",
+		"news":     "news",
+		"quiz":     "quizzes",
+	}
+
+	for k, v := range cases {
+		assert.Equal(v, Pluralize(k))
+	}
+}
+
+func TestSingularize(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestSingularize")
+
+	cases := map[string]string{
+		"":         "",
+		"books":    "book",
+		"boxes":    "box",
+		"churches": "church",
+		"cities":   "city",
+		"days":     "day",
+		"knives":   "knife",
+		"wolves":   "wolf",
+		"tomatoes": "tomato",
+		"analyses": "analysis",
+		"statuses": "status",
+		"matrices": "matrix",
+		"mice":     "mouse",
+		"people":   "person",
+		"People":   "Person",
+		"children": "child",
+		"sheep":    "sheep",
+		"news":     "news",
+		"quizzes":  "quiz",
+	}
+
+	for k, v := range cases {
+		assert.Equal(v, Singularize(k))
+	}
+}
+
+func TestCustomInflection(t *testing.T) {
+	assert := internal.NewAssert(t, "TestCustomInflection")
+
+	AddIrregular("cactus", "cacti")
+	assert.Equal("cacti", Pluralize("cactus"))
+	assert.Equal("cactus", Singularize("cacti"))
+
+	AddUncountable("Aircraft")
+	assert.Equal("aircraft", Pluralize("aircraft"))
+	assert.Equal("aircraft", Singularize("aircraft"))
+
+	AddPluralRule(`(?i)(gen)us$`, "${1}era")
+	AddSingularRule(`(?i)(gen)era$`, "${1}us")
+	assert.Equal("genera", Pluralize("genus"))
+	assert.Equal("genus", Singularize("genera"))
+}
+
+func TestOrdinal(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestOrdinal")
+
+	cases := map[int]string{
+		0:    "0th",
+		1:    "1st",
+		2:    "2nd",
+		3:    "3rd",
+		4:    "4th",
+		11:   "11th",
+		12:   "12th",
+		13:   "13th",
+		21:   "21st",
+		102:  "102nd",
+		111:  "111th",
+		1003: "1003rd",
+		-1:   "-1st",
+	}
+
+	for k, v := range cases {
+		assert.Equal(v, Ordinal(k))
+	}
+}
@@ -5,7 +5,9 @@ package strutil
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -638,3 +640,88 @@ func Concat(length int, str ...string) string {
 	}
 	return sb.String()
 }
+
+// PrefixLines adds prefix to the beginning of every line in the string s.
+func PrefixLines(s string, prefix string) string {
+	if s == "" || prefix == "" {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+
+	var sb strings.Builder
+	sb.Grow(len(s) + len(prefix)*len(lines))
+
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(prefix)
+		sb.WriteString(line)
+	}
+
+	return sb.String()
+}
+
+// NumberLines adds the line number (starting from 1) to the beginning of every line in the string s.
+// Line numbers are right aligned and separated from the line content by ": ".
+func NumberLines(s string) string {
+	if s == "" {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	width := len(strconv.Itoa(len(lines)))
+
+	var sb strings.Builder
+	sb.Grow(len(s) + (width+2)*len(lines))
+
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(fmt.Sprintf("%*d: ", width, i+1))
+		sb.WriteString(line)
+	}
+
+	return sb.String()
+}
+
+// TrimBlankLines removes the leading and trailing blank lines (empty or whitespace only) of the string s.
+func TrimBlankLines(s string) string {
+	lines := strings.Split(s, "\n")
+
+	start, end := 0, len(lines)
+	for start < end && IsBlank(lines[start]) {
+		start++
+	}
+	for end > start && IsBlank(lines[end-1]) {
+		end--
+	}
+
+	return strings.Join(lines[start:end], "\n")
+}
+
+// TruncateMiddle truncates the string s to length runes by replacing the middle part with omission,
+// both ends of the string are kept. eg: TruncateMiddle("verylongpath", 7, "…") => "ver…ath".
+// If the omission is not shorter than length, the first length runes of s are returned.
+func TruncateMiddle(s string, length int, omission string) string {
+	rs := []rune(s)
+	if length < 0 {
+		length = 0
+	}
+	if len(rs) <= length {
+		return s
+	}
+
+	omissionLen := utf8.RuneCountInString(omission)
+	if omissionLen >= length {
+		return string(rs[:length])
+	}
+
+	keep := length - omissionLen
+	head := (keep + 1) / 2
+	tail := keep - head
+
+	return string(rs[:head]) + omission + string(rs[len(rs)-tail:])
+}
@@ -694,3 +694,49 @@ func ExampleConcat() {
 	// Go Language
 	// An apple a day，keeps the doctor away
 }
+
+func ExamplePrefixLines() {
+	result := PrefixLines("foo\nbar", "> ")
+
+	fmt.Println(result)
+
+	// Output:
+	// > foo
+	// > bar
+}
+
+func ExampleNumberLines() {
+	result := NumberLines("foo\nbar")
+
+	fmt.Println(result)
+
+	// Output:
+	// 1: foo
+	// 2: bar
+}
+
+func ExampleTrimBlankLines() {
+	result := TrimBlankLines("\n  \nfoo\n\nbar\n\n")
+
+	fmt.Println(result)
+
+	// Output:
+	// foo
+	//
+	// bar
+}
+
+func ExampleTruncateMiddle() {
+	result1 := TruncateMiddle("verylongpath", 7, "…")
+	result2 := TruncateMiddle("verylongpath", 11, "...")
+	result3 := TruncateMiddle("short", 10, "...")
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(result3)
+
+	// Output:
+	// ver…ath
+	// very...path
+	// short
+}
@@ -620,3 +620,50 @@ func TestConcat(t *testing.T) {
 	assert.Equal("你好，世界！", Concat(0, "你好", "，", "", "世界！", ""))
 	assert.Equal("Hello World!", Concat(0, "Hello", " Wo", "r", "ld!", ""))
 }
+
+func TestPrefixLines(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestPrefixLines")
+
+	assert.Equal("", PrefixLines("", "> "))
+	assert.Equal("foo", PrefixLines("foo", ""))
+	assert.Equal("> foo", PrefixLines("foo", "> "))
+	assert.Equal("> foo\n> bar\n> ", PrefixLines("foo\nbar\n", "> "))
+	assert.Equal("  a\n  \n  b", PrefixLines("a\n\nb", "  "))
+}
+
+func TestNumberLines(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestNumberLines")
+
+	assert.Equal("", NumberLines(""))
+	assert.Equal("1: foo", NumberLines("foo"))
+	assert.Equal("1: foo\n2: bar", NumberLines("foo\nbar"))
+	assert.Equal(" 1: a\n 2: b\n 3: c\n 4: d\n 5: e\n 6: f\n 7: g\n 8: h\n 9: i\n10: j",
+		NumberLines("a\nb\nc\nd\ne\nf\ng\nh\ni\nj"))
+}
+
+func TestTrimBlankLines(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTrimBlankLines")
+
+	assert.Equal("", TrimBlankLines(""))
+	assert.Equal("", TrimBlankLines("\n \n\t\n"))
+	assert.Equal("foo", TrimBlankLines("foo"))
+	assert.Equal("foo\n\nbar", TrimBlankLines("\n  \nfoo\n\nbar\n \n"))
+	assert.Equal("  foo  ", TrimBlankLines("\n  foo  \n"))
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	t.Parallel()
+	assert := internal.NewAssert(t, "TestTruncateMiddle")
+
+	assert.Equal("", TruncateMiddle("", 5, "…"))
+	assert.Equal("short", TruncateMiddle("short", 5, "…"))
+	assert.Equal("ver…ath", TruncateMiddle("verylongpath", 7, "…"))
+	assert.Equal("very...path", TruncateMiddle("verylongpath", 11, "..."))
+	assert.Equal("ve...h", TruncateMiddle("verylongpath", 6, "..."))
+	assert.Equal("ve", TruncateMiddle("verylongpath", 2, "..."))
+	assert.Equal("", TruncateMiddle("verylongpath", 0, "…"))
+	assert.Equal("你好…界！", TruncateMiddle("你好，世界！", 5, "…"))
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package strutil
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// CJKMode is the way Words segments the CJK text, which has no spaces between words.
+type CJKMode int
+
+const (
+	// CJKPerChar segments every CJK character as a word
+	CJKPerChar CJKMode = iota
+	// CJKBigram segments the CJK text into overlapping pairs of characters, e.g. 你好世界 => 你好, 好世, 世界,
+	// which is the common way to index CJK text without a dictionary
+	CJKBigram
+)
+
+// Words splits a string into words: the words of letters and digits (which could be joined by apostrophe or
+// hyphen, e.g. don't), the CJK words segmented by mode (CJKPerChar by default), and the emoji, the emoji joined
+// by ZWJ and the flags are kept as one word. The others like spaces and punctuations are ignored.
+// Unlike SplitWords, it's not limited to alphabetic words.
+func Words(s string, mode ...CJKMode) []string {
+	bigram := len(mode) > 0 && mode[0] == CJKBigram
+
+	words := []string{}
+	// cjkRun is the characters of CJK text not segmented yet
+	cjkRun := []string{}
+
+	flushCJK := func() {
+		if bigram && len(cjkRun) > 1 {
+			for i := 0; i+1 < len(cjkRun); i++ {
+				words = append(words, cjkRun[i]+cjkRun[i+1])
+			}
+		} else {
+			words = append(words, cjkRun...)
+		}
+		cjkRun = cjkRun[:0]
+	}
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case isCJK(r):
+			cjkRun = append(cjkRun, s[i:i+size])
+			i += size
+		case isEmoji(r):
+			flushCJK()
+			end := emojiEnd(s, i)
+			words = append(words, s[i:end])
+			i = end
+		case isWordRune(r):
+			flushCJK()
+			end := wordEnd(s, i)
+			words = append(words, s[i:end])
+			i = end
+		default:
+			flushCJK()
+			i += size
+		}
+	}
+	flushCJK()
+
+	return words
+}
+
+// CountWords returns the number of words split by Words.
+func CountWords(s string, mode ...CJKMode) int {
+	return len(Words(s, mode...))
+}
+
+// isCJK checks if r is a Chinese or Japanese character. Korean is not included since it's separated by spaces.
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		// katakana-hiragana prolonged sound mark
+		r == 'ー'
+}
+
+// isWordRune checks if r could be a part of word which is not CJK.
+func isWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)) && !isCJK(r)
+}
+
+// wordEnd returns the end index of the word starts from s[start].
+func wordEnd(s string, start int) int {
+	i := start
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if isWordRune(r) {
+			i += size
+			continue
+		}
+
+		// the apostrophe or hyphen between word runes is a part of word
+		if r == '\'' || r == '-' || r == '’' {
+			if next, _ := utf8.DecodeRuneInString(s[i+size:]); isWordRune(next) {
+				i += size
+				continue
+			}
+		}
+
+		break
+	}
+
+	return i
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// isEmoji checks if r is an emoji or pictograph.
+func isEmoji(r rune) bool {
+	switch {
+	// misc symbols, pictographs, emoticons, transport, supplemental symbols and so on
+	case r >= 0x1f000 && r <= 0x1faff:
+		return true
+	// misc technical
+	case r >= 0x2300 && r <= 0x23ff:
+		return true
+	// misc symbols and dingbats
+	case r >= 0x2600 && r <= 0x27bf:
+		return true
+	// misc symbols and arrows, e.g. ⭐
+	case r >= 0x2b00 && r <= 0x2bff:
+		return true
+	}
+
+	return false
+}
+
+// isEmojiModifier checks if r changes the preceding emoji, e.g. the variation selectors and skin tones.
+func isEmojiModifier(r rune) bool {
+	switch {
+	case r == 0xfe0e || r == 0xfe0f:
+		return true
+	// skin tone modifiers
+	case r >= 0x1f3fb && r <= 0x1f3ff:
+		return true
+	// combining enclosing keycap
+	case r == 0x20e3:
+		return true
+	// tags, e.g. the subdivision flags
+	case r >= 0xe0020 && r <= 0xe007f:
+		return true
+	}
+
+	return false
+}
+
+// emojiEnd returns the end index of the emoji sequence starts from s[start].
+func emojiEnd(s string, start int) int {
+	r, size := utf8.DecodeRuneInString(s[start:])
+	i := start + size
+
+	// a flag is a pair of regional indicators
+	if isRegionalIndicator(r) {
+		if next, nextSize := utf8.DecodeRuneInString(s[i:]); isRegionalIndicator(next) {
+			return i + nextSize
+		}
+		return i
+	}
+
+	for i < len(s) {
+		r, size = utf8.DecodeRuneInString(s[i:])
+		if isEmojiModifier(r) {
+			i += size
+			continue
+		}
+
+		// zero width joiner joins the emoji into one, e.g. 👨‍👩‍👧
+		if r == 0x200d {
+			if next, nextSize := utf8.DecodeRuneInString(s[i+size:]); isEmoji(next) {
+				i += size + nextSize
+				continue
+			}
+		}
+
+		break
+	}
+
+	return i
+}
@@ -0,0 +1,29 @@
+package strutil
+
+import (
+	"fmt"
+)
+
+func ExampleWords() {
+	result1 := Words("I don't like 🌧️, 但是我喜欢雨")
+	result2 := Words("我喜欢Go语言", CJKBigram)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// [I don't like 🌧️ 但 是 我 喜 欢 雨]
+	// [我喜 喜欢 Go 语言]
+}
+
+func ExampleCountWords() {
+	result1 := CountWords("Hello 世界 🌍")
+	result2 := CountWords("Hello 世界 🌍", CJKBigram)
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+
+	// Output:
+	// 4
+	// 3
+}
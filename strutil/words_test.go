@@ -0,0 +1,66 @@
+package strutil
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestWords(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWords")
+
+	cases := map[string][]string{
+		"":                        {},
+		"  ,. ":                   {},
+		"a word":                  {"a", "word"},
+		"I don't know, well-done": {"I", "don't", "know", "well-done"},
+		"a -b- c' 'd":             {"a", "b", "c", "d"},
+		"Go 1.18 released":        {"Go", "1", "18", "released"},
+		"café naïve Привет мир":   {"café", "naïve", "Привет", "мир"},
+		"안녕하세요 세계":                {"안녕하세요", "세계"},
+		"你好，世界":                   {"你", "好", "世", "界"},
+		"Go语言":                    {"Go", "语", "言"},
+		"プログラマー":                  {"プ", "ロ", "グ", "ラ", "マ", "ー"},
+		"I ❤️ Go 👍🏽!":             {"I", "❤️", "Go", "👍🏽"},
+		"👨‍👩‍👧 family":            {"👨‍👩‍👧", "family"},
+		"🇨🇳🇺🇸":                    {"🇨🇳", "🇺🇸"},
+		"好😀":                      {"好", "😀"},
+	}
+
+	for k, v := range cases {
+		assert.Equal(v, Words(k))
+		assert.Equal(v, Words(k, CJKPerChar))
+	}
+}
+
+func TestWords_Bigram(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestWords_Bigram")
+
+	cases := map[string][]string{
+		"你好世界":        {"你好", "好世", "世界"},
+		"你好，世界":       {"你好", "世界"},
+		"我":           {"我"},
+		"学习Go语言":      {"学习", "Go", "语言"},
+		"hello world": {"hello", "world"},
+		"東京タワー 😀":     {"東京", "京タ", "タワ", "ワー", "😀"},
+	}
+
+	for k, v := range cases {
+		assert.Equal(v, Words(k, CJKBigram))
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCountWords")
+
+	assert.Equal(0, CountWords(""))
+	assert.Equal(3, CountWords("I'am a programmer"))
+	assert.Equal(7, CountWords("Hello 世界 🌍 and 你好"))
+	assert.Equal(5, CountWords("Hello 世界 🌍 and 你好", CJKBigram))
+}
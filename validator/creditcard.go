@@ -0,0 +1,130 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package validator
+
+import "errors"
+
+// CardNetwork is the payment network of credit card.
+type CardNetwork string
+
+const (
+	CardNetworkUnknown    CardNetwork = ""
+	CardNetworkVisa       CardNetwork = "Visa"
+	CardNetworkMastercard CardNetwork = "Mastercard"
+	CardNetworkAmex       CardNetwork = "American Express"
+	CardNetworkUnionPay   CardNetwork = "UnionPay"
+	CardNetworkJCB        CardNetwork = "JCB"
+	CardNetworkDiscover   CardNetwork = "Discover"
+	CardNetworkDiners     CardNetwork = "Diners Club"
+)
+
+// cardRule is the IIN range and valid lengths of a card network, ranges are compared by the
+// leading digits of the same width as low.
+type cardRule struct {
+	network CardNetwork
+	ranges  [][2]string
+	lengths []int
+}
+
+// cardRules are checked in order, so the narrower ranges come first.
+var cardRules = []cardRule{
+	{CardNetworkAmex, [][2]string{{"34", "34"}, {"37", "37"}}, []int{15}},
+	{CardNetworkJCB, [][2]string{{"3528", "3589"}}, []int{16, 17, 18, 19}},
+	{CardNetworkDiners, [][2]string{{"300", "305"}, {"3095", "3095"}, {"36", "36"}, {"38", "39"}}, []int{14, 16, 17, 18, 19}},
+	{CardNetworkVisa, [][2]string{{"4", "4"}}, []int{13, 16, 19}},
+	{CardNetworkMastercard, [][2]string{{"51", "55"}, {"2221", "2720"}}, []int{16}},
+	{CardNetworkUnionPay, [][2]string{{"62", "62"}, {"81", "81"}}, []int{16, 17, 18, 19}},
+	{CardNetworkDiscover, [][2]string{{"6011", "6011"}, {"644", "649"}, {"65", "65"}}, []int{16, 17, 18, 19}},
+}
+
+// CreditCardNetwork returns the network of credit card number by its leading digits (IIN), and
+// CardNetworkUnknown if the number is not digits, the network is unknown or the length is invalid
+// for the network. It doesn't check the Luhn checksum, use IsLuhnValid for that.
+func CreditCardNetwork(number string) CardNetwork {
+	if !isDigits(number) {
+		return CardNetworkUnknown
+	}
+
+	for _, rule := range cardRules {
+		if !matchCardRanges(number, rule.ranges) {
+			continue
+		}
+		for _, l := range rule.lengths {
+			if len(number) == l {
+				return rule.network
+			}
+		}
+		return CardNetworkUnknown
+	}
+
+	return CardNetworkUnknown
+}
+
+func matchCardRanges(number string, ranges [][2]string) bool {
+	for _, r := range ranges {
+		low, high := r[0], r[1]
+		if len(number) < len(low) {
+			continue
+		}
+		prefix := number[:len(low)]
+		if prefix >= low && prefix <= high {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsLuhnValid checks if the digits pass the Luhn (mod 10) checksum, which is used by credit card
+// numbers, IMEI and etc.
+func IsLuhnValid(number string) bool {
+	if len(number) < 2 || !isDigits(number) {
+		return false
+	}
+
+	return luhnSum(number, false)%10 == 0
+}
+
+// LuhnCheckDigit returns the Luhn check digit which should be appended to payload, e.g. to generate
+// valid test card numbers.
+func LuhnCheckDigit(payload string) (int, error) {
+	if payload == "" || !isDigits(payload) {
+		return 0, errors.New("validator: luhn payload should be digits")
+	}
+
+	return (10 - luhnSum(payload, true)%10) % 10, nil
+}
+
+// luhnSum sums the digits from right, every second digit is doubled. If doubleFirst is true,
+// the rightmost digit is doubled, i.e. the check digit is not included.
+func luhnSum(digits string, doubleFirst bool) int {
+	sum := 0
+	double := doubleFirst
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestCreditCardNetwork(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestCreditCardNetwork")
+
+	cases := map[string]CardNetwork{
+		"4111111111111111":    CardNetworkVisa,
+		"4222222222222":       CardNetworkVisa,
+		"5555555555554444":    CardNetworkMastercard,
+		"2223003122003222":    CardNetworkMastercard,
+		"378282246310005":     CardNetworkAmex,
+		"371449635398431":     CardNetworkAmex,
+		"6200000000000005":    CardNetworkUnionPay,
+		"6221263430109903":    CardNetworkUnionPay,
+		"6250941006528599":    CardNetworkUnionPay,
+		"3530111333300000":    CardNetworkJCB,
+		"3566002020360505":    CardNetworkJCB,
+		"6011111111111117":    CardNetworkDiscover,
+		"30569309025904":      CardNetworkDiners,
+		"38520000023237":      CardNetworkDiners,
+		"411111111111111":     CardNetworkUnknown,
+		"37828224631000":      CardNetworkUnknown,
+		"55555555555544441":   CardNetworkUnknown,
+		"1234567890123456":    CardNetworkUnknown,
+		"4111-1111-1111-1111": CardNetworkUnknown,
+		"":                    CardNetworkUnknown,
+	}
+
+	for number, expected := range cases {
+		assert.Equal(expected, CreditCardNetwork(number))
+		assert.Equal(expected != CardNetworkUnknown, IsCreditCard(number))
+	}
+}
+
+func TestLuhn(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestLuhn")
+
+	assert.Equal(true, IsLuhnValid("79927398713"))
+	assert.Equal(true, IsLuhnValid("4111111111111111"))
+	assert.Equal(true, IsLuhnValid("378282246310005"))
+	assert.Equal(false, IsLuhnValid("79927398710"))
+	assert.Equal(false, IsLuhnValid("4111111111111112"))
+	assert.Equal(false, IsLuhnValid("0"))
+	assert.Equal(false, IsLuhnValid("41111a1111111111"))
+
+	digit, err := LuhnCheckDigit("7992739871")
+	assert.IsNil(err)
+	assert.Equal(3, digit)
+
+	digit, err = LuhnCheckDigit("411111111111111")
+	assert.IsNil(err)
+	assert.Equal(1, digit)
+
+	for _, payload := range []string{"0", "12", "3782822463", "6011000990139"} {
+		digit, err := LuhnCheckDigit(payload)
+		assert.IsNil(err)
+		assert.Equal(true, IsLuhnValid(payload+string(rune('0'+digit))))
+	}
+
+	_, err = LuhnCheckDigit("")
+	assert.IsNotNil(err)
+	_, err = LuhnCheckDigit("12a")
+	assert.IsNotNil(err)
+}
+
+// oldCreditCardMatcher is the regexp used by IsCreditCard before CreditCardNetwork.
+var oldCreditCardMatcher = regexp.MustCompile(`^(?:4[0-9]{12}(?:[0-9]{3})?|5[1-5][0-9]{14}|(222[1-9]|22[3-9][0-9]|2[3-6][0-9]{2}|27[01][0-9]|2720)[0-9]{12}|6(?:011|5[0-9][0-9])[0-9]{12}|3[47][0-9]{13}|3(?:0[0-5]|[68][0-9])[0-9]{11}|(?:2131|1800|35\\d{3})\\d{11}|6[27][0-9]{14})$`)
+
+func TestIsCreditCardCompatible(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIsCreditCardCompatible")
+
+	// both the old regexp and the card rules only look at the first 4 digits and the length,
+	// so every 4 digits prefix padded to every length covers all numbers the old regexp accepted.
+	for prefix := 0; prefix < 10000; prefix++ {
+		for length := 4; length <= 20; length++ {
+			number := fmt.Sprintf("%04d", prefix) + strings.Repeat("0", length-4)
+			if !oldCreditCardMatcher.MatchString(number) {
+				continue
+			}
+			// 16 digits numbers starting with 67 (Maestro) were accepted by the old regexp, but not any more.
+			expected := !strings.HasPrefix(number, "67")
+			if IsCreditCard(number) != expected {
+				t.Errorf("IsCreditCard(%q) = %v, want %v", number, !expected, expected)
+			}
+		}
+	}
+
+	assert.Equal(true, oldCreditCardMatcher.MatchString("6700000000000000"))
+	assert.Equal(false, IsCreditCard("6700000000000000"))
+}
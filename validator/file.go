@@ -0,0 +1,173 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package validator
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// sniffLen is the number of bytes read to detect the mime type, same as http.DetectContentType.
+const sniffLen = 512
+
+type imageSignature struct {
+	mimeType string
+	match    func(b []byte) bool
+}
+
+var imageSignatures = []imageSignature{
+	{"image/jpeg", prefixMatcher([]byte{0xff, 0xd8, 0xff})},
+	{"image/png", prefixMatcher([]byte("\x89PNG\r\n\x1a\n"))},
+	{"image/gif", func(b []byte) bool {
+		return bytes.HasPrefix(b, []byte("GIF87a")) || bytes.HasPrefix(b, []byte("GIF89a"))
+	}},
+	{"image/webp", func(b []byte) bool {
+		return len(b) >= 12 && bytes.Equal(b[:4], []byte("RIFF")) && bytes.Equal(b[8:12], []byte("WEBP"))
+	}},
+	{"image/bmp", prefixMatcher([]byte("BM"))},
+	{"image/tiff", func(b []byte) bool {
+		return bytes.HasPrefix(b, []byte("II*\x00")) || bytes.HasPrefix(b, []byte("MM\x00*"))
+	}},
+	{"image/x-icon", prefixMatcher([]byte{0x00, 0x00, 0x01, 0x00})},
+	{"image/avif", ftypMatcher("avif", "avis")},
+	{"image/heic", ftypMatcher("heic", "heix", "heim", "heis", "mif1", "msf1")},
+}
+
+func prefixMatcher(prefix []byte) func(b []byte) bool {
+	return func(b []byte) bool {
+		return bytes.HasPrefix(b, prefix)
+	}
+}
+
+// ftypMatcher matches the major brand of ISO base media file, e.g. avif and heic.
+func ftypMatcher(brands ...string) func(b []byte) bool {
+	return func(b []byte) bool {
+		if len(b) < 12 || !bytes.Equal(b[4:8], []byte("ftyp")) {
+			return false
+		}
+		for _, brand := range brands {
+			if string(b[8:12]) == brand {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// DetectMimeType detects the mime type of content by its magic bytes, it reads at most 512 bytes
+// from r, so seek back if r will be read again. Unknown binary content is application/octet-stream.
+func DetectMimeType(r io.Reader) (string, error) {
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	buf = buf[:n]
+
+	for _, sig := range imageSignatures {
+		if sig.match(buf) {
+			return sig.mimeType, nil
+		}
+	}
+
+	return http.DetectContentType(buf), nil
+}
+
+// IsImage checks if the content read from r is an image by its magic bytes, jpeg, png, gif, webp,
+// bmp, tiff, ico, avif and heic are supported. It reads at most 512 bytes from r.
+func IsImage(r io.Reader) bool {
+	mimeType, err := DetectMimeType(r)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(mimeType, "image/")
+}
+
+// IsImageFile checks if the file is an image by its magic bytes instead of extension, see IsImage.
+func IsImageFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	return IsImage(f)
+}
+
+// MaxFileSize checks if path is a regular file and its size is not greater than maxSize bytes.
+func MaxFileSize(path string, maxSize int64) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+
+	return info.Size() <= maxSize
+}
+
+// AllowedMimeTypes checks if the mime type of content read from r is in allowlist, the mime type is
+// detected by magic bytes, see DetectMimeType. Parameters like charset are ignored, and wildcard
+// subtype like image/* is supported.
+func AllowedMimeTypes(r io.Reader, allowlist []string) bool {
+	detected, err := DetectMimeType(r)
+	if err != nil {
+		return false
+	}
+	if mediaType, _, err := mime.ParseMediaType(detected); err == nil {
+		detected = mediaType
+	}
+
+	for _, allowed := range allowlist {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == detected || allowed == "*/*" {
+			return true
+		}
+		if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(detected, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var windowsReservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+// IsSafeFilename checks if name is safe to be used as a file name on all platforms, e.g. the name of
+// uploaded file: it is valid utf8 of at most 255 bytes, it's not . or .., it contains no path separator,
+// control character or character reserved by windows (<>:"|?*), it doesn't end with space or dot,
+// and it's not a windows reserved device name like CON or COM1.txt.
+func IsSafeFilename(name string) bool {
+	if name == "" || len(name) > 255 || name == "." || name == ".." || !utf8.ValidString(name) {
+		return false
+	}
+
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(`/\<>:"|?*`, r) {
+			return false
+		}
+	}
+
+	if strings.HasSuffix(name, " ") || strings.HasSuffix(name, ".") {
+		return false
+	}
+
+	base := name
+	if i := strings.IndexByte(base, '.'); i >= 0 {
+		base = base[:i]
+	}
+	if _, ok := windowsReservedNames[strings.ToUpper(strings.TrimSpace(base))]; ok {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+func TestDetectMimeType(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestDetectMimeType")
+
+	cases := map[string][]byte{
+		"image/jpeg":                {0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10, 'J', 'F', 'I', 'F'},
+		"image/png":                 testPNG,
+		"image/gif":                 []byte("GIF89a\x01\x00\x01\x00"),
+		"image/webp":                []byte("RIFF\x24\x00\x00\x00WEBPVP8 "),
+		"image/tiff":                []byte("II*\x00\x08\x00\x00\x00"),
+		"image/avif":                []byte("\x00\x00\x00\x1cftypavif\x00\x00\x00\x00"),
+		"image/heic":                []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00"),
+		"application/pdf":           []byte("%PDF-1.7\n"),
+		"text/plain; charset=utf-8": []byte("hello world"),
+		"application/octet-stream":  {0x00, 0x01, 0x02, 0x03, 0xfe},
+	}
+
+	for expected, content := range cases {
+		actual, err := DetectMimeType(bytes.NewReader(content))
+		assert.IsNil(err)
+		assert.Equal(expected, actual)
+	}
+}
+
+func TestIsImage(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIsImage")
+
+	assert.Equal(true, IsImage(bytes.NewReader(testPNG)))
+	assert.Equal(false, IsImage(strings.NewReader("<html></html>")))
+	assert.Equal(false, IsImage(bytes.NewReader(nil)))
+
+	dir := t.TempDir()
+	image := filepath.Join(dir, "image.txt")
+	fake := filepath.Join(dir, "fake.png")
+	assert.IsNil(os.WriteFile(image, testPNG, 0644))
+	assert.IsNil(os.WriteFile(fake, []byte("not an image"), 0644))
+
+	assert.Equal(true, IsImageFile(image))
+	assert.Equal(false, IsImageFile(fake))
+	assert.Equal(false, IsImageFile(filepath.Join(dir, "missing.png")))
+}
+
+func TestMaxFileSize(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMaxFileSize")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.txt")
+	assert.IsNil(os.WriteFile(path, make([]byte, 100), 0644))
+
+	assert.Equal(true, MaxFileSize(path, 100))
+	assert.Equal(true, MaxFileSize(path, 1024))
+	assert.Equal(false, MaxFileSize(path, 99))
+	assert.Equal(false, MaxFileSize(dir, 1024))
+	assert.Equal(false, MaxFileSize(filepath.Join(dir, "missing"), 1024))
+}
+
+func TestAllowedMimeTypes(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestAllowedMimeTypes")
+
+	assert.Equal(true, AllowedMimeTypes(bytes.NewReader(testPNG), []string{"image/png"}))
+	assert.Equal(true, AllowedMimeTypes(bytes.NewReader(testPNG), []string{"application/pdf", "image/*"}))
+	assert.Equal(false, AllowedMimeTypes(bytes.NewReader(testPNG), []string{"image/jpeg"}))
+	assert.Equal(true, AllowedMimeTypes(strings.NewReader("hello"), []string{"text/plain"}))
+	assert.Equal(false, AllowedMimeTypes(strings.NewReader("hello"), []string{"image/*"}))
+	assert.Equal(false, AllowedMimeTypes(strings.NewReader("hello"), nil))
+}
+
+func TestIsSafeFilename(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestIsSafeFilename")
+
+	for _, name := range []string{"a.txt", "report 2024.pdf", "文件.doc", ".gitignore", "console.log", "COM10.txt"} {
+		assert.Equal(true, IsSafeFilename(name))
+	}
+
+	for _, name := range []string{"", ".", "..", "../a.txt", "a/b.txt", `..\a.txt`, "a:b.txt", "a?.txt",
+		"a\x00.txt", "a.txt ", "a.", "CON", "con.txt", "Lpt1.log", "nul", strings.Repeat("a", 256), "\xff.txt"} {
+		assert.Equal(false, IsSafeFilename(name))
+	}
+}
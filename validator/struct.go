@@ -0,0 +1,454 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// structTagName is the struct tag read by ValidateStruct.
+const structTagName = "validate"
+
+// FieldError is the failure of a validation rule on a struct field.
+type FieldError struct {
+	// Field is the path of field, e.g. Address.City or Items[0].Name.
+	Field string
+	// Rule is the failed rule, e.g. required_if.
+	Rule string
+	// Param is the parameter of rule, e.g. 18 of min=18.
+	Param string
+	// Value is the value of field.
+	Value any
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return defaultTranslator.Translate(e)
+}
+
+// ValidationErrors is the list of FieldError returned by ValidateStruct.
+type ValidationErrors []*FieldError
+
+// Error implements the error interface.
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, len(ve))
+	for i, e := range ve {
+		msgs[i] = e.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateStruct validates the fields of struct (or pointer to struct) by the `validate` tag, and returns
+// ValidationErrors if any rule fails. Rules are separated by comma, the validation of a field stops at its
+// first failed rule. Nested struct, pointer to struct and slice of struct fields are validated too.
+// Builtin rules:
+//
+//	required, omitempty, min=n, max=n, len=n, eq=v, ne=v, gt=n, gte=n, lt=n, lte=n, oneof=a b c,
+//	email, url, ip, alpha, numeric
+//	required_if=Field value [Field value...]: required if all the fields equal to the values.
+//	required_unless=Field value [Field value...]: required unless all the fields equal to the values.
+//	required_with=Field...: required if any of the fields is not zero.
+//	required_with_all=Field...: required if all of the fields are not zero.
+//	required_without=Field...: required if any of the fields is zero.
+//	required_without_all=Field...: required if all of the fields are zero.
+//	excluded_with=Field...: must be zero if any of the fields is not zero.
+//	eqfield, nefield, gtfield, gtefield, ltfield, ltefield=Field: compare with other field.
+//	exclusive=group: at most one field of the group is not zero, e.g. mutually exclusive options.
+//
+// For strings, slices and maps the number rules compare the length. The fields referenced by rules
+// are the sibling fields in the same struct. An error which is not ValidationErrors is returned
+// if the tag is invalid.
+func ValidateStruct(s any) error {
+	v := reflect.ValueOf(s)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return errors.New("validator: ValidateStruct of nil pointer")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("validator: ValidateStruct of non struct type %T", s)
+	}
+
+	var errs ValidationErrors
+	if err := validateStruct(v, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+type structRule struct {
+	name  string
+	param string
+}
+
+func parseRules(tag string) []structRule {
+	var rules []structRule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		rules = append(rules, structRule{name: strings.TrimSpace(name), param: strings.TrimSpace(param)})
+	}
+
+	return rules
+}
+
+func validateStruct(v reflect.Value, prefix string, errs *ValidationErrors) error {
+	t := v.Type()
+	// group name -> names of non zero fields
+	groups := map[string][]string{}
+	var groupOrder []string
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get(structTagName)
+		if tag == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		path := prefix + sf.Name
+
+		failed := false
+		for _, rule := range parseRules(tag) {
+			if rule.name == "exclusive" {
+				if _, ok := groups[rule.param]; !ok {
+					groupOrder = append(groupOrder, rule.param)
+					groups[rule.param] = nil
+				}
+				if !fv.IsZero() {
+					groups[rule.param] = append(groups[rule.param], path)
+				}
+				continue
+			}
+			if failed {
+				continue
+			}
+
+			if rule.name == "omitempty" {
+				if fv.IsZero() {
+					break
+				}
+				continue
+			}
+
+			ok, err := checkRule(v, fv, rule)
+			if err != nil {
+				return fmt.Errorf("validator: field %s: %w", path, err)
+			}
+			if !ok {
+				*errs = append(*errs, &FieldError{Field: path, Rule: rule.name, Param: rule.param, Value: fv.Interface()})
+				failed = true
+			}
+		}
+
+		if err := validateNested(fv, path, errs); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range groupOrder {
+		if fields := groups[group]; len(fields) > 1 {
+			for _, field := range fields {
+				f := v.FieldByName(field[len(prefix):])
+				*errs = append(*errs, &FieldError{Field: field, Rule: "exclusive",
+					Param: strings.Join(fields, " "), Value: f.Interface()})
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateNested(fv reflect.Value, path string, errs *ValidationErrors) error {
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+
+	switch fv.Kind() {
+	case reflect.Struct:
+		if fv.Type() == reflect.TypeOf(time.Time{}) {
+			return nil
+		}
+		return validateStruct(fv, path+".", errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fv.Len(); i++ {
+			elem := fv.Index(i)
+			for elem.Kind() == reflect.Ptr && !elem.IsNil() {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct && elem.Type() != reflect.TypeOf(time.Time{}) {
+				if err := validateStruct(elem, fmt.Sprintf("%s[%d].", path, i), errs); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkRule(parent, fv reflect.Value, rule structRule) (bool, error) {
+	switch rule.name {
+	case "required":
+		return !fv.IsZero(), nil
+	case "min", "gte":
+		return compareParam(fv, rule.param, func(c int) bool { return c >= 0 })
+	case "max", "lte":
+		return compareParam(fv, rule.param, func(c int) bool { return c <= 0 })
+	case "gt":
+		return compareParam(fv, rule.param, func(c int) bool { return c > 0 })
+	case "lt":
+		return compareParam(fv, rule.param, func(c int) bool { return c < 0 })
+	case "len":
+		return compareParam(fv, rule.param, func(c int) bool { return c == 0 })
+	case "eq":
+		return fieldString(fv) == rule.param, nil
+	case "ne":
+		return fieldString(fv) != rule.param, nil
+	case "oneof":
+		s := fieldString(fv)
+		for _, option := range strings.Fields(rule.param) {
+			if s == option {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "email":
+		return IsEmail(fieldString(fv)), nil
+	case "url":
+		return IsUrl(fieldString(fv)), nil
+	case "ip":
+		return IsIp(fieldString(fv)), nil
+	case "alpha":
+		return IsAlpha(fieldString(fv)), nil
+	case "numeric":
+		return IsNumberStr(fieldString(fv)), nil
+
+	case "required_if", "required_unless":
+		pairs := strings.Fields(rule.param)
+		if len(pairs) == 0 || len(pairs)%2 != 0 {
+			return false, fmt.Errorf("%s requires field and value pairs", rule.name)
+		}
+		matched := true
+		for i := 0; i < len(pairs); i += 2 {
+			other, err := siblingField(parent, pairs[i])
+			if err != nil {
+				return false, err
+			}
+			if fieldString(other) != pairs[i+1] {
+				matched = false
+				break
+			}
+		}
+		if matched == (rule.name == "required_if") {
+			return !fv.IsZero(), nil
+		}
+		return true, nil
+
+	case "required_with", "required_with_all", "required_without", "required_without_all", "excluded_with":
+		names := strings.Fields(rule.param)
+		if len(names) == 0 {
+			return false, fmt.Errorf("%s requires field names", rule.name)
+		}
+		present := 0
+		for _, name := range names {
+			other, err := siblingField(parent, name)
+			if err != nil {
+				return false, err
+			}
+			if !other.IsZero() {
+				present++
+			}
+		}
+
+		var required bool
+		switch rule.name {
+		case "required_with":
+			required = present > 0
+		case "required_with_all":
+			required = present == len(names)
+		case "required_without":
+			required = present < len(names)
+		case "required_without_all":
+			required = present == 0
+		case "excluded_with":
+			return present == 0 || fv.IsZero(), nil
+		}
+		return !required || !fv.IsZero(), nil
+
+	case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+		other, err := siblingField(parent, rule.param)
+		if err != nil {
+			return false, err
+		}
+		// nil pointers are checked by required rules, not compared
+		if isNilPointer(fv) || isNilPointer(other) {
+			return true, nil
+		}
+		c, err := compareValues(fv, other)
+		if err != nil {
+			return false, err
+		}
+		switch rule.name {
+		case "eqfield":
+			return c == 0, nil
+		case "nefield":
+			return c != 0, nil
+		case "gtfield":
+			return c > 0, nil
+		case "gtefield":
+			return c >= 0, nil
+		case "ltfield":
+			return c < 0, nil
+		default:
+			return c <= 0, nil
+		}
+	}
+
+	return false, fmt.Errorf("unknown rule %q", rule.name)
+}
+
+func siblingField(parent reflect.Value, name string) (reflect.Value, error) {
+	f := parent.FieldByName(name)
+	if !f.IsValid() {
+		return f, fmt.Errorf("unknown field %q", name)
+	}
+
+	return f, nil
+}
+
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func isNilPointer(v reflect.Value) bool {
+	v = indirectValue(v)
+	return (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil()
+}
+
+// fieldString returns the string form of field value to compare with rule parameters.
+func fieldString(v reflect.Value) string {
+	if isNilPointer(v) {
+		return ""
+	}
+	v = indirectValue(v)
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// measure returns the number to compare of value: the value of numbers, the length of strings,
+// slices, maps and arrays.
+func measure(v reflect.Value) (float64, bool) {
+	v = indirectValue(v)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return float64(v.Len()), true
+	}
+
+	return 0, false
+}
+
+func compareParam(fv reflect.Value, param string, pass func(c int) bool) (bool, error) {
+	if isNilPointer(fv) {
+		return false, nil
+	}
+
+	n, ok := measure(fv)
+	if !ok {
+		return false, fmt.Errorf("can't compare %s with number", fv.Type())
+	}
+
+	var p float64
+	if indirectValue(fv).Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(param)
+		if err != nil {
+			return false, fmt.Errorf("invalid duration parameter %q", param)
+		}
+		p = float64(d)
+	} else {
+		var err error
+		p, err = strconv.ParseFloat(param, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid number parameter %q", param)
+		}
+	}
+
+	return pass(compareFloat(n, p)), nil
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareValues(a, b reflect.Value) (int, error) {
+	a, b = indirectValue(a), indirectValue(b)
+
+	if ta, ok := a.Interface().(time.Time); ok {
+		tb, ok := b.Interface().(time.Time)
+		if !ok {
+			return 0, fmt.Errorf("can't compare time.Time with %s", b.Type())
+		}
+		return compareFloat(float64(ta.Sub(tb)), 0), nil
+	}
+
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), nil
+	}
+
+	na, okA := measure(a)
+	nb, okB := measure(b)
+	if !okA || !okB {
+		return 0, fmt.Errorf("can't compare %s with %s", a.Type(), b.Type())
+	}
+
+	return compareFloat(na, nb), nil
+}
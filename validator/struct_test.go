@@ -0,0 +1,171 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+func TestValidateStruct(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidateStruct")
+
+	type address struct {
+		City string `validate:"required"`
+	}
+	type user struct {
+		Name    string        `validate:"required,min=2,max=8"`
+		Age     int           `validate:"gte=18,lt=150"`
+		Email   string        `validate:"omitempty,email"`
+		Role    string        `validate:"oneof=admin user"`
+		Tags    []string      `validate:"max=2"`
+		Timeout time.Duration `validate:"omitempty,lte=1m"`
+		Address address
+		Friends []*address
+		Ignored string `validate:"-"`
+		secret  string
+	}
+
+	valid := user{Name: "abc", Age: 20, Role: "user", Address: address{City: "Beijing"}}
+	assert.IsNil(ValidateStruct(valid))
+	assert.IsNil(ValidateStruct(&valid))
+
+	invalid := user{
+		Name:    "a",
+		Age:     10,
+		Email:   "abc",
+		Role:    "guest",
+		Tags:    []string{"a", "b", "c"},
+		Timeout: time.Hour,
+		Friends: []*address{{City: "Shanghai"}, {}},
+	}
+	err := ValidateStruct(invalid)
+
+	var errs ValidationErrors
+	assert.Equal(true, errors.As(err, &errs))
+
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field + ":" + e.Rule
+	}
+	assert.Equal([]string{"Name:min", "Age:gte", "Email:email", "Role:oneof", "Tags:max", "Timeout:lte",
+		"Address.City:required", "Friends[1].City:required"}, fields)
+	assert.Equal("Name must be at least 2", errs[0].Error())
+
+	_, ok := ValidateStruct(1).(ValidationErrors)
+	assert.Equal(false, ok)
+}
+
+func TestValidateStructConditional(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidateStructConditional")
+
+	type payment struct {
+		Method   string `validate:"required,oneof=card transfer"`
+		CardNo   string `validate:"required_if=Method card"`
+		Account  string `validate:"required_unless=Method card"`
+		Phone    string `validate:"required_without=Email"`
+		Email    string `validate:"required_without=Phone"`
+		Password string
+		Confirm  string `validate:"required_with=Password,eqfield=Password"`
+		Coupon   string `validate:"excluded_with=Discount"`
+		Discount int
+	}
+
+	assert.IsNil(ValidateStruct(payment{Method: "card", CardNo: "4111", Phone: "123"}))
+	assert.IsNil(ValidateStruct(payment{Method: "transfer", Account: "abc", Email: "a@b.com",
+		Password: "pwd", Confirm: "pwd", Coupon: "c"}))
+
+	err := ValidateStruct(payment{Method: "card", Password: "pwd", Confirm: "pw", Coupon: "c", Discount: 10})
+	errs := err.(ValidationErrors)
+
+	rules := make([]string, len(errs))
+	for i, e := range errs {
+		rules[i] = e.Field + ":" + e.Rule
+	}
+	assert.Equal([]string{"CardNo:required_if", "Phone:required_without", "Email:required_without",
+		"Confirm:eqfield", "Coupon:excluded_with"}, rules)
+
+	err = ValidateStruct(payment{Method: "transfer", Phone: "1", Password: "pwd"})
+	errs = err.(ValidationErrors)
+	assert.Equal(2, len(errs))
+	assert.Equal("Account is required unless Method=card", errs[0].Error())
+	assert.Equal("Confirm is required when Password is present", errs[1].Error())
+}
+
+func TestValidateStructCrossField(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidateStructCrossField")
+
+	type booking struct {
+		Start    time.Time
+		End      time.Time  `validate:"gtfield=Start"`
+		Deadline *time.Time `validate:"omitempty,ltefield=End"`
+		Min      int
+		Max      int `validate:"gtefield=Min"`
+		Name     string
+		Nick     string `validate:"nefield=Name"`
+	}
+
+	now := time.Now()
+	later := now.Add(time.Hour)
+	assert.IsNil(ValidateStruct(booking{Start: now, End: later, Deadline: &now, Min: 1, Max: 1, Name: "a", Nick: "b"}))
+
+	err := ValidateStruct(booking{Start: later, End: now, Deadline: &later, Min: 2, Max: 1, Name: "a", Nick: "a"})
+	errs := err.(ValidationErrors)
+
+	rules := make([]string, len(errs))
+	for i, e := range errs {
+		rules[i] = e.Field + ":" + e.Rule
+	}
+	assert.Equal([]string{"End:gtfield", "Deadline:ltefield", "Max:gtefield", "Nick:nefield"}, rules)
+}
+
+func TestValidateStructExclusive(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidateStructExclusive")
+
+	type source struct {
+		File  string `validate:"exclusive=input"`
+		URL   string `validate:"exclusive=input,omitempty,url"`
+		Stdin bool   `validate:"exclusive=input"`
+	}
+
+	assert.IsNil(ValidateStruct(source{}))
+	assert.IsNil(ValidateStruct(source{URL: "https://example.com"}))
+
+	errs := ValidateStruct(source{File: "a.txt", Stdin: true}).(ValidationErrors)
+	assert.Equal(2, len(errs))
+	assert.Equal("File", errs[0].Field)
+	assert.Equal("Stdin", errs[1].Field)
+	assert.Equal("only one of File, Stdin can be set", errs[0].Error())
+}
+
+func TestValidateStructInvalidTag(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestValidateStructInvalidTag")
+
+	type unknownRule struct {
+		Name string `validate:"unknown"`
+	}
+	type unknownField struct {
+		Name string `validate:"required_with=Other"`
+	}
+	type badParam struct {
+		Age int `validate:"min=abc"`
+	}
+
+	for _, v := range []any{unknownRule{}, unknownField{}, badParam{}} {
+		err := ValidateStruct(v)
+		assert.IsNotNil(err)
+		_, ok := err.(ValidationErrors)
+		assert.Equal(false, ok)
+	}
+}
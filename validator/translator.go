@@ -0,0 +1,207 @@
+// Copyright 2024 dudaodong@gmail.com. All rights reserved.
+// Use of this source code is governed by MIT license
+
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Translator translates FieldError into message for end users.
+type Translator interface {
+	Translate(e *FieldError) string
+}
+
+// MessageTranslator is a Translator with message templates of rules and display names of fields.
+// In the templates, {field} is replaced by the field name and {param} by the rule parameter.
+// A MessageTranslator should not be modified after it's used concurrently.
+type MessageTranslator struct {
+	messages   map[string]string
+	fieldNames map[string]string
+}
+
+var defaultTranslator = NewEnglishTranslator()
+
+// NewMessageTranslator creates a MessageTranslator with message templates of rules,
+// the english message is used if the rule is missing in messages.
+func NewMessageTranslator(messages map[string]string) *MessageTranslator {
+	t := &MessageTranslator{
+		messages:   make(map[string]string, len(englishMessages)),
+		fieldNames: map[string]string{},
+	}
+	for rule, msg := range englishMessages {
+		t.messages[rule] = msg
+	}
+	for rule, msg := range messages {
+		t.messages[rule] = msg
+	}
+
+	return t
+}
+
+// NewEnglishTranslator creates a MessageTranslator with builtin english messages.
+func NewEnglishTranslator() *MessageTranslator {
+	return NewMessageTranslator(nil)
+}
+
+// NewChineseTranslator creates a MessageTranslator with builtin chinese messages.
+func NewChineseTranslator() *MessageTranslator {
+	return NewMessageTranslator(chineseMessages)
+}
+
+// SetMessage sets the message template of rule.
+func (t *MessageTranslator) SetMessage(rule, message string) *MessageTranslator {
+	t.messages[rule] = message
+	return t
+}
+
+// SetFieldName sets the display name of field in messages. The field could be the path like Address.City,
+// or the struct field name like City which applies to all the fields with that name.
+func (t *MessageTranslator) SetFieldName(field, name string) *MessageTranslator {
+	t.fieldNames[field] = name
+	return t
+}
+
+// SetFieldNames sets the display names of fields, see SetFieldName.
+func (t *MessageTranslator) SetFieldNames(names map[string]string) *MessageTranslator {
+	for field, name := range names {
+		t.fieldNames[field] = name
+	}
+	return t
+}
+
+// Translate returns the message of FieldError.
+func (t *MessageTranslator) Translate(e *FieldError) string {
+	field := t.fieldName(e.Field)
+
+	msg, ok := t.messages[e.Rule]
+	if !ok {
+		return fmt.Sprintf("%s failed on rule %s", field, e.Rule)
+	}
+
+	return strings.NewReplacer("{field}", field, "{param}", t.param(e)).Replace(msg)
+}
+
+func (t *MessageTranslator) fieldName(field string) string {
+	if name, ok := t.fieldNames[field]; ok {
+		return name
+	}
+	if i := strings.LastIndexByte(field, '.'); i >= 0 {
+		if name, ok := t.fieldNames[field[i+1:]]; ok {
+			return name
+		}
+	}
+
+	return field
+}
+
+// param formats the rule parameter, the field names in it are replaced by display names.
+func (t *MessageTranslator) param(e *FieldError) string {
+	switch e.Rule {
+	case "required_if", "required_unless":
+		pairs := strings.Fields(e.Param)
+		conditions := make([]string, 0, len(pairs)/2)
+		for i := 0; i+1 < len(pairs); i += 2 {
+			conditions = append(conditions, t.fieldName(pairs[i])+"="+pairs[i+1])
+		}
+		return strings.Join(conditions, " ")
+	case "required_with", "required_with_all", "required_without", "required_without_all", "excluded_with",
+		"eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield", "exclusive":
+		fields := strings.Fields(e.Param)
+		for i, f := range fields {
+			fields[i] = t.fieldName(f)
+		}
+		return strings.Join(fields, ", ")
+	}
+
+	return e.Param
+}
+
+// Translate returns the messages of all errors by translator.
+func (ve ValidationErrors) Translate(translator Translator) []string {
+	msgs := make([]string, len(ve))
+	for i, e := range ve {
+		msgs[i] = translator.Translate(e)
+	}
+
+	return msgs
+}
+
+// TranslateMap returns the messages of errors keyed by field path, e.g. for api response.
+func (ve ValidationErrors) TranslateMap(translator Translator) map[string]string {
+	msgs := make(map[string]string, len(ve))
+	for _, e := range ve {
+		if _, ok := msgs[e.Field]; !ok {
+			msgs[e.Field] = translator.Translate(e)
+		}
+	}
+
+	return msgs
+}
+
+var englishMessages = map[string]string{
+	"required":             "{field} is required",
+	"min":                  "{field} must be at least {param}",
+	"max":                  "{field} must be at most {param}",
+	"len":                  "{field} must be {param} in length",
+	"eq":                   "{field} must be equal to {param}",
+	"ne":                   "{field} must not be equal to {param}",
+	"gt":                   "{field} must be greater than {param}",
+	"gte":                  "{field} must be greater than or equal to {param}",
+	"lt":                   "{field} must be less than {param}",
+	"lte":                  "{field} must be less than or equal to {param}",
+	"oneof":                "{field} must be one of [{param}]",
+	"email":                "{field} must be a valid email address",
+	"url":                  "{field} must be a valid url",
+	"ip":                   "{field} must be a valid ip address",
+	"alpha":                "{field} can only contain letters",
+	"numeric":              "{field} must be numeric",
+	"required_if":          "{field} is required when {param}",
+	"required_unless":      "{field} is required unless {param}",
+	"required_with":        "{field} is required when {param} is present",
+	"required_with_all":    "{field} is required when {param} are present",
+	"required_without":     "{field} is required when {param} is not present",
+	"required_without_all": "{field} is required when none of {param} is present",
+	"excluded_with":        "{field} must be empty when {param} is present",
+	"eqfield":              "{field} must be equal to {param}",
+	"nefield":              "{field} must not be equal to {param}",
+	"gtfield":              "{field} must be greater than {param}",
+	"gtefield":             "{field} must be greater than or equal to {param}",
+	"ltfield":              "{field} must be less than {param}",
+	"ltefield":             "{field} must be less than or equal to {param}",
+	"exclusive":            "only one of {param} can be set",
+}
+
+var chineseMessages = map[string]string{
+	"required":             "{field}不能为空",
+	"min":                  "{field}不能小于{param}",
+	"max":                  "{field}不能大于{param}",
+	"len":                  "{field}长度必须为{param}",
+	"eq":                   "{field}必须等于{param}",
+	"ne":                   "{field}不能等于{param}",
+	"gt":                   "{field}必须大于{param}",
+	"gte":                  "{field}必须大于或等于{param}",
+	"lt":                   "{field}必须小于{param}",
+	"lte":                  "{field}必须小于或等于{param}",
+	"oneof":                "{field}必须是[{param}]中的一个",
+	"email":                "{field}必须是有效的邮箱地址",
+	"url":                  "{field}必须是有效的URL",
+	"ip":                   "{field}必须是有效的IP地址",
+	"alpha":                "{field}只能包含字母",
+	"numeric":              "{field}必须是数字",
+	"required_if":          "当{param}时{field}不能为空",
+	"required_unless":      "除非{param}，否则{field}不能为空",
+	"required_with":        "{param}存在时{field}不能为空",
+	"required_with_all":    "{param}都存在时{field}不能为空",
+	"required_without":     "{param}不存在时{field}不能为空",
+	"required_without_all": "{param}都不存在时{field}不能为空",
+	"excluded_with":        "{param}存在时{field}必须为空",
+	"eqfield":              "{field}必须等于{param}",
+	"nefield":              "{field}不能等于{param}",
+	"gtfield":              "{field}必须大于{param}",
+	"gtefield":             "{field}必须大于或等于{param}",
+	"ltfield":              "{field}必须小于{param}",
+	"ltefield":             "{field}必须小于或等于{param}",
+	"exclusive":            "{param}只能设置其中一个",
+}
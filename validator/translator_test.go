@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/duke-git/lancet/v2/internal"
+)
+
+type translateUser struct {
+	Name     string `validate:"required"`
+	Age      int    `validate:"gte=18"`
+	Password string
+	Confirm  string `validate:"eqfield=Password"`
+	Address  struct {
+		City string `validate:"required"`
+	}
+}
+
+func TestChineseTranslator(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestChineseTranslator")
+
+	errs := ValidateStruct(translateUser{Age: 10, Password: "a", Confirm: "b"}).(ValidationErrors)
+
+	translator := NewChineseTranslator().SetFieldNames(map[string]string{
+		"Name":     "用户名",
+		"Age":      "年龄",
+		"Password": "密码",
+		"Confirm":  "确认密码",
+		"City":     "城市",
+	})
+
+	assert.Equal([]string{"用户名不能为空", "年龄必须大于或等于18", "确认密码必须等于密码", "城市不能为空"},
+		errs.Translate(translator))
+
+	assert.Equal(map[string]string{
+		"Name":         "用户名不能为空",
+		"Age":          "年龄必须大于或等于18",
+		"Confirm":      "确认密码必须等于密码",
+		"Address.City": "城市不能为空",
+	}, errs.TranslateMap(translator))
+}
+
+func TestEnglishTranslator(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestEnglishTranslator")
+
+	errs := ValidateStruct(translateUser{Name: "abc", Age: 20, Password: "a", Confirm: "b"}).(ValidationErrors)
+	assert.Equal("Confirm must be equal to Password; Address.City is required", errs.Error())
+
+	translator := NewEnglishTranslator().
+		SetFieldName("Confirm", "password confirmation").
+		SetFieldName("Address.City", "city").
+		SetMessage("required", "please enter {field}")
+
+	assert.Equal([]string{"password confirmation must be equal to Password", "please enter city"},
+		errs.Translate(translator))
+
+	// the default messages are not changed
+	assert.Equal("Address.City is required", errs[1].Error())
+}
+
+func TestMessageTranslator(t *testing.T) {
+	t.Parallel()
+
+	assert := internal.NewAssert(t, "TestMessageTranslator")
+
+	translator := NewMessageTranslator(map[string]string{"required": "{field} est obligatoire"})
+
+	assert.Equal("Name est obligatoire", translator.Translate(&FieldError{Field: "Name", Rule: "required"}))
+	assert.Equal("Age must be at least 1", translator.Translate(&FieldError{Field: "Age", Rule: "min", Param: "1"}))
+	assert.Equal("Code failed on rule custom", translator.Translate(&FieldError{Field: "Code", Rule: "custom"}))
+	assert.Equal("当Type=card时CardNo不能为空",
+		NewChineseTranslator().Translate(&FieldError{Field: "CardNo", Rule: "required_if", Param: "Type card"}))
+}
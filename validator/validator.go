@@ -30,7 +30,6 @@ var (
 	chineseIdMatcher       *regexp.Regexp = regexp.MustCompile(`^(\d{17})([0-9]|X|x)$`)
 	chineseMatcher         *regexp.Regexp = regexp.MustCompile("[\u4e00-\u9fa5]")
 	chinesePhoneMatcher    *regexp.Regexp = regexp.MustCompile(`\d{3}-\d{8}|\d{4}-\d{7}|\d{4}-\d{8}`)
-	creditCardMatcher      *regexp.Regexp = regexp.MustCompile(`^(?:4[0-9]{12}(?:[0-9]{3})?|5[1-5][0-9]{14}|(222[1-9]|22[3-9][0-9]|2[3-6][0-9]{2}|27[01][0-9]|2720)[0-9]{12}|6(?:011|5[0-9][0-9])[0-9]{12}|3[47][0-9]{13}|3(?:0[0-5]|[68][0-9])[0-9]{11}|(?:2131|1800|35\\d{3})\\d{11}|6[27][0-9]{14})$`)
 	base64Matcher          *regexp.Regexp = regexp.MustCompile(`^(?:[A-Za-z0-9+\\/]{4})*(?:[A-Za-z0-9+\\/]{2}==|[A-Za-z0-9+\\/]{3}=|[A-Za-z0-9+\\/]{4})$`)
 	base64URLMatcher       *regexp.Regexp = regexp.MustCompile(`^([A-Za-z0-9_-]{4})*([A-Za-z0-9_-]{2}(==)?|[A-Za-z0-9_-]{3}=?)?$`)
 	binMatcher             *regexp.Regexp = regexp.MustCompile(`^(0b)?[01]+$`)
@@ -321,10 +320,14 @@ func IsChinesePhone(phone string) bool {
 	return chinesePhoneMatcher.MatchString(phone)
 }
 
-// IsCreditCard check if the string is credit card.
+// IsCreditCard check if the string is credit card, the network (Visa, Mastercard, American Express,
+// UnionPay, JCB, Discover, Diners Club) is detected by leading digits and the length is checked for it.
+// See CreditCardNetwork and IsLuhnValid.
+// Every number accepted by earlier versions is still accepted, except 16 digits numbers starting with 67
+// (Maestro), which are not a supported network. JCB numbers (3528-3589) are accepted now.
 // Play: https://go.dev/play/p/sNwwL6B0-v4
 func IsCreditCard(creditCart string) bool {
-	return creditCardMatcher.MatchString(creditCart)
+	return CreditCardNetwork(creditCart) != CardNetworkUnknown
 }
 
 // IsBase64 check if the string is base64 string.
@@ -647,3 +647,87 @@ func ExampleIsChinaUnionPay() {
 	// true
 	// false
 }
+
+func ExampleValidateStruct() {
+	type order struct {
+		Method string `validate:"required,oneof=card transfer"`
+		CardNo string `validate:"required_if=Method card"`
+		Count  int    `validate:"gte=1"`
+	}
+
+	err1 := ValidateStruct(order{Method: "transfer", Count: 1})
+	err2 := ValidateStruct(order{Method: "card"})
+
+	fmt.Println(err1)
+	fmt.Println(err2)
+
+	// Output:
+	// <nil>
+	// CardNo is required when Method=card; Count must be greater than or equal to 1
+}
+
+func ExampleNewChineseTranslator() {
+	type user struct {
+		Name string `validate:"required"`
+		Age  int    `validate:"gte=18"`
+	}
+
+	err := ValidateStruct(user{Age: 10})
+
+	translator := NewChineseTranslator().SetFieldNames(map[string]string{
+		"Name": "用户名",
+		"Age":  "年龄",
+	})
+
+	for _, msg := range err.(ValidationErrors).Translate(translator) {
+		fmt.Println(msg)
+	}
+
+	// Output:
+	// 用户名不能为空
+	// 年龄必须大于或等于18
+}
+
+func ExampleCreditCardNetwork() {
+	result1 := CreditCardNetwork("4111111111111111")
+	result2 := CreditCardNetwork("378282246310005")
+	result3 := CreditCardNetwork("123456")
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(result3 == CardNetworkUnknown)
+
+	// Output:
+	// Visa
+	// American Express
+	// true
+}
+
+func ExampleLuhnCheckDigit() {
+	payload := "411111111111111"
+	digit, _ := LuhnCheckDigit(payload)
+
+	number := fmt.Sprintf("%s%d", payload, digit)
+
+	fmt.Println(number)
+	fmt.Println(IsLuhnValid(number))
+
+	// Output:
+	// 4111111111111111
+	// true
+}
+
+func ExampleIsSafeFilename() {
+	result1 := IsSafeFilename("report.pdf")
+	result2 := IsSafeFilename("../etc/passwd")
+	result3 := IsSafeFilename("CON.txt")
+
+	fmt.Println(result1)
+	fmt.Println(result2)
+	fmt.Println(result3)
+
+	// Output:
+	// true
+	// false
+	// false
+}